@@ -35,7 +35,8 @@ func Init() *echo.Echo {
 		}),
 		echoMid.Recover(),
 		echoMid.CORSWithConfig(echoMid.CORSConfig{
-			AllowHeaders: []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization},
+			AllowHeaders:  []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization, constants.CartSessionHeader, constants.IdempotencyKeyHeader},
+			ExposeHeaders: []string{constants.CartSessionHeader, constants.IdempotencyReplayedHeader, echo.HeaderContentDisposition},
 		}),
 	)
 
@@ -51,21 +52,68 @@ func Init() *echo.Echo {
 	orderProductRepo := repo.NewOrderProductRepo(client)
 	paymentResultRepo := repo.NewPaymentResult(client)
 	reviewRepo := repo.NewReviewRepo(client)
+	slugRedirectRepo := repo.NewSlugRedirectRepo(client)
+	stockMovementRepo := repo.NewStockMovementRepo(client)
+	stockReservationRepo := repo.NewStockReservationRepo(client)
+	orderReturnRepo := repo.NewOrderReturnRepo(client)
+	refundRepo := repo.NewRefundRepo(client)
+	paymentProvider := repo.NewPaypalPaymentProvider()
+	stockAlertRepo := repo.NewStockAlertRepo(client)
+	recommendationRepo := repo.NewRecommendationRepo(client)
+	productPriceRepo := repo.NewProductPriceRepo(client)
+	attributeRepo := repo.NewAttributeRepo(client)
+	brandRepo := repo.NewBrandRepo(client)
+	taxRuleRepo := repo.NewTaxRuleRepo(client)
+	shippingRepo := repo.NewShippingRepo(client)
+	couponRepo := repo.NewCouponRepo(client)
+	wishlistRepo := repo.NewWishlistRepo(client)
+	cartRepo := repo.NewCartRepo(client)
+	idempotencyKeyRepo := repo.NewIdempotencyKeyRepo(client)
+	orderDocumentRepo := repo.NewOrderDocumentRepo(client)
 	healthCheckRepo := repo.NewHealthCheck(client)
 
 	userService := service.NewUserService(userRepo, refreshTokenStoreRepo)
-	productService := service.NewProductService(productRepo, productCategoryRepo, productProductCategoryRepo, reviewRepo)
-	productCategoryService := service.NewProductCategoryService(productCategoryRepo)
-	orderService := service.NewOrderService(orderRepo, orderProductRepo, paymentResultRepo, productRepo)
+	productService := service.NewProductService(productRepo, productCategoryRepo, productProductCategoryRepo, reviewRepo, slugRedirectRepo, stockReservationRepo, attributeRepo, brandRepo)
+	productCategoryService := service.NewProductCategoryService(productCategoryRepo, slugRedirectRepo)
+	refundService := service.NewRefundService(refundRepo, orderRepo, orderProductRepo, paymentProvider)
+	orderService := service.NewOrderService(orderRepo, orderProductRepo, paymentResultRepo, productRepo, taxRuleRepo, shippingRepo, couponRepo, stockReservationRepo, refundService)
+	orderDocumentService := service.NewOrderDocumentService(orderDocumentRepo, orderService)
+	orderReturnService := service.NewOrderReturnService(orderReturnRepo, orderRepo, orderProductRepo)
+	stockMovementService := service.NewStockMovementService(stockMovementRepo, productRepo)
+	stockAlertService := service.NewStockAlertService(stockAlertRepo, productRepo)
+	recommendationService := service.NewRecommendationService(recommendationRepo, productRepo)
+	productPriceService := service.NewProductPriceService(productPriceRepo, productRepo)
+	attributeService := service.NewAttributeService(attributeRepo, productCategoryRepo)
+	brandService := service.NewBrandService(brandRepo, slugRedirectRepo)
+	taxRuleService := service.NewTaxRuleService(taxRuleRepo)
+	shippingService := service.NewShippingService(shippingRepo)
+	couponService := service.NewCouponService(couponRepo)
+	wishlistService := service.NewWishlistService(wishlistRepo, productRepo, orderService)
+	cartService := service.NewCartService(cartRepo, productRepo, orderService)
 	uploadService := service.NewUploadService()
 	reviewService := service.NewReviewService(reviewRepo)
+	idempotencyKeyService := service.NewIdempotencyKeyService(idempotencyKeyRepo)
 	healthCheckService := service.NewHealthCheckService(healthCheckRepo)
 
-	userHandlerV1 := handlerV1.NewUserhandler(userService)
+	userHandlerV1 := handlerV1.NewUserhandler(userService, cartService)
 	productHandlerV1 := handlerV1.NewProductHandler(productService)
 	productCategoryHandlerV1 := handlerV1.NewProductCategoryHandler(productCategoryService)
 	orderHandlerV1 := handlerV1.NewOrderHandler(orderService)
+	orderReturnHandlerV1 := handlerV1.NewOrderReturnHandler(orderReturnService)
+	orderDocumentHandlerV1 := handlerV1.NewOrderDocumentHandler(orderDocumentService)
+	refundHandlerV1 := handlerV1.NewRefundHandler(refundService)
 	reviewHandlerV1 := handlerV1.NewReviewHandler(reviewService)
+	stockMovementHandlerV1 := handlerV1.NewStockMovementHandler(stockMovementService)
+	stockAlertHandlerV1 := handlerV1.NewStockAlertHandler(stockAlertService)
+	recommendationHandlerV1 := handlerV1.NewRecommendationHandler(recommendationService)
+	productPriceHandlerV1 := handlerV1.NewProductPriceHandler(productPriceService)
+	attributeHandlerV1 := handlerV1.NewAttributeHandler(attributeService)
+	brandHandlerV1 := handlerV1.NewBrandHandler(brandService)
+	taxRuleHandlerV1 := handlerV1.NewTaxRuleHandler(taxRuleService)
+	shippingHandlerV1 := handlerV1.NewShippingHandler(shippingService, orderService)
+	couponHandlerV1 := handlerV1.NewCouponHandler(couponService)
+	wishlistHandlerV1 := handlerV1.NewWishlistHandler(wishlistService)
+	cartHandlerV1 := handlerV1.NewCartHandler(cartService)
 	uploadHandlerV1 := handlerV1.NewUploadHandler(uploadService)
 	healthCheckHandlerV1 := handlerV1.NewHealthCheckHandlerHandler(healthCheckService)
 
@@ -80,6 +128,11 @@ func Init() *echo.Echo {
 	userV1Route.Use(middleware.AuthorizationHandler())
 	userV1Route.GET("", userHandlerV1.GetUserDetail)
 	userV1Route.PATCH("/profile", userHandlerV1.UpdateUser)
+	userV1Route.GET("/stock-alert", stockAlertHandlerV1.GetList)
+	userV1Route.GET("/wishlist", wishlistHandlerV1.GetList)
+	userV1Route.POST("/wishlist", wishlistHandlerV1.Add)
+	userV1Route.DELETE("/wishlist/:product_id", wishlistHandlerV1.Remove)
+	userV1Route.POST("/wishlist/order", wishlistHandlerV1.MoveToOrder, middleware.ACL(constants.CustomerPermission), middleware.Idempotency(idempotencyKeyService))
 
 	// user admin v1 routes
 	userAdminV1Route := e.Group("/api/v1/admin/user")
@@ -93,20 +146,36 @@ func Init() *echo.Echo {
 	productV1Route := e.Group("/api/v1/product")
 	productV1Route.GET("", productHandlerV1.GetProductListPaginate)
 	productV1Route.GET("/top", productHandlerV1.GetTopProduct)
+	productV1Route.GET("/slug/:slug", productHandlerV1.GetProductDetailBySlug)
 	productV1Route.GET("/:product_id", productHandlerV1.GetProductDetail)
+	productV1Route.GET("/:product_id/recommendation", recommendationHandlerV1.GetByProduct)
+	productV1Route.POST("/:product_id/back-in-stock", stockAlertHandlerV1.Subscribe, middleware.AuthorizationHandler(), middleware.ACL(constants.CustomerPermission))
+	productV1Route.DELETE("/:product_id/back-in-stock", stockAlertHandlerV1.Unsubscribe, middleware.AuthorizationHandler(), middleware.ACL(constants.CustomerPermission))
 
 	// product admin v1 routes
 	productAdminV1Route := e.Group("/api/v1/admin/product")
 	productAdminV1Route.Use(middleware.AuthorizationHandler(), middleware.ACL(constants.AdminPermission))
 	productAdminV1Route.POST("", productHandlerV1.CreateProduct)
-	productAdminV1Route.GET("", productHandlerV1.GetProductListPaginate)
+	productAdminV1Route.GET("", productHandlerV1.GetProductListPaginateAdmin)
+	productAdminV1Route.GET("/low-stock", productHandlerV1.GetLowStockProductList)
+	productAdminV1Route.GET("/stock-alert", stockAlertHandlerV1.GetListAdmin)
+	productAdminV1Route.GET("/most-wishlisted", wishlistHandlerV1.GetMostWishlisted)
 	productAdminV1Route.PUT("/:product_id", productHandlerV1.UpdateProduct)
 	productAdminV1Route.DELETE("/:product_id", productHandlerV1.Delete)
-	productAdminV1Route.GET("/:product_id", productHandlerV1.GetProductDetail)
+	productAdminV1Route.GET("/:product_id", productHandlerV1.GetProductDetailAdmin)
+	productAdminV1Route.POST("/:product_id/stock-movement", stockMovementHandlerV1.Create)
+	productAdminV1Route.GET("/:product_id/stock-movement", stockMovementHandlerV1.GetListPaginate)
+	productAdminV1Route.POST("/:product_id/price-schedule", productPriceHandlerV1.CreateSchedule)
+	productAdminV1Route.GET("/:product_id/price-schedule", productPriceHandlerV1.GetScheduleList)
+	productAdminV1Route.DELETE("/:product_id/price-schedule/:product_price_schedule_id", productPriceHandlerV1.CancelSchedule)
+	productAdminV1Route.GET("/:product_id/price-history", productPriceHandlerV1.GetHistoryList)
+	productAdminV1Route.GET("/:product_id/price", productPriceHandlerV1.GetPriceAt)
 
 	// product category v1 routes
 	productCategoryV1Route := e.Group("/api/v1/product-category")
 	productCategoryV1Route.GET("", productCategoryHandlerV1.GetProductCategoryList)
+	productCategoryV1Route.GET("/tree", productCategoryHandlerV1.GetProductCategoryTree)
+	productCategoryV1Route.GET("/slug/:slug", productCategoryHandlerV1.GetProductCategoryDetailBySlug)
 	productCategoryV1Route.GET("/:product_category_id", productCategoryHandlerV1.GetProductCategoryDetail)
 
 	// product category admin v1 routes
@@ -116,20 +185,119 @@ func Init() *echo.Echo {
 	productCategoryAdminV1Route.PUT("/:product_category_id", productCategoryHandlerV1.UpdateProductCategory)
 	productCategoryAdminV1Route.DELETE("/:product_category_id", productCategoryHandlerV1.Delete)
 
+	// brand v1 routes
+	brandV1Route := e.Group("/api/v1/brand")
+	brandV1Route.GET("", brandHandlerV1.GetList)
+	brandV1Route.GET("/slug/:slug", brandHandlerV1.GetDetailBySlug)
+	brandV1Route.GET("/:brand_id", brandHandlerV1.GetDetail)
+
+	// brand admin v1 routes
+	brandAdminV1Route := e.Group("/api/v1/admin/brand")
+	brandAdminV1Route.Use(middleware.AuthorizationHandler(), middleware.ACL(constants.AdminPermission))
+	brandAdminV1Route.POST("", brandHandlerV1.Create)
+	brandAdminV1Route.GET("", brandHandlerV1.GetList)
+	brandAdminV1Route.GET("/:brand_id", brandHandlerV1.GetDetail)
+	brandAdminV1Route.PUT("/:brand_id", brandHandlerV1.Update)
+	brandAdminV1Route.DELETE("/:brand_id", brandHandlerV1.Delete)
+
+	// tax rule admin v1 routes
+	taxRuleAdminV1Route := e.Group("/api/v1/admin/tax-rule")
+	taxRuleAdminV1Route.Use(middleware.AuthorizationHandler(), middleware.ACL(constants.AdminPermission))
+	taxRuleAdminV1Route.POST("", taxRuleHandlerV1.Create)
+	taxRuleAdminV1Route.GET("", taxRuleHandlerV1.GetList)
+	taxRuleAdminV1Route.GET("/:tax_rule_id", taxRuleHandlerV1.GetDetail)
+	taxRuleAdminV1Route.PUT("/:tax_rule_id", taxRuleHandlerV1.Update)
+	taxRuleAdminV1Route.DELETE("/:tax_rule_id", taxRuleHandlerV1.Delete)
+
+	// shipping v1 routes
+	shippingV1Route := e.Group("/api/v1/shipping")
+	shippingV1Route.POST("/quote", shippingHandlerV1.Quote)
+
+	// shipping admin v1 routes
+	shippingAdminV1Route := e.Group("/api/v1/admin/shipping-zone")
+	shippingAdminV1Route.Use(middleware.AuthorizationHandler(), middleware.ACL(constants.AdminPermission))
+	shippingAdminV1Route.POST("", shippingHandlerV1.CreateZone)
+	shippingAdminV1Route.GET("", shippingHandlerV1.GetZoneList)
+	shippingAdminV1Route.GET("/:shipping_zone_id", shippingHandlerV1.GetZoneDetail)
+	shippingAdminV1Route.PUT("/:shipping_zone_id", shippingHandlerV1.UpdateZone)
+	shippingAdminV1Route.DELETE("/:shipping_zone_id", shippingHandlerV1.DeleteZone)
+	shippingAdminV1Route.POST("/:shipping_zone_id/method", shippingHandlerV1.CreateMethod)
+	shippingAdminV1Route.PUT("/:shipping_zone_id/method/:shipping_method_id", shippingHandlerV1.UpdateMethod)
+	shippingAdminV1Route.DELETE("/:shipping_zone_id/method/:shipping_method_id", shippingHandlerV1.DeleteMethod)
+
+	// coupon admin v1 routes
+	couponAdminV1Route := e.Group("/api/v1/admin/coupon")
+	couponAdminV1Route.Use(middleware.AuthorizationHandler(), middleware.ACL(constants.AdminPermission))
+	couponAdminV1Route.POST("", couponHandlerV1.Create)
+	couponAdminV1Route.GET("", couponHandlerV1.GetList)
+	couponAdminV1Route.GET("/:coupon_id", couponHandlerV1.GetDetail)
+	couponAdminV1Route.GET("/:coupon_id/redemption", couponHandlerV1.GetRedemptionListPaginate)
+	couponAdminV1Route.PUT("/:coupon_id", couponHandlerV1.Update)
+	couponAdminV1Route.DELETE("/:coupon_id", couponHandlerV1.Delete)
+
+	// attribute v1 routes
+	attributeV1Route := e.Group("/api/v1/attribute")
+	attributeV1Route.GET("", attributeHandlerV1.GetList)
+	attributeV1Route.GET("/:attribute_definition_id", attributeHandlerV1.GetDetail)
+
+	// attribute admin v1 routes
+	attributeAdminV1Route := e.Group("/api/v1/admin/attribute")
+	attributeAdminV1Route.Use(middleware.AuthorizationHandler(), middleware.ACL(constants.AdminPermission))
+	attributeAdminV1Route.POST("", attributeHandlerV1.Create)
+	attributeAdminV1Route.GET("", attributeHandlerV1.GetList)
+	attributeAdminV1Route.GET("/:attribute_definition_id", attributeHandlerV1.GetDetail)
+	attributeAdminV1Route.PUT("/:attribute_definition_id", attributeHandlerV1.Update)
+	attributeAdminV1Route.DELETE("/:attribute_definition_id", attributeHandlerV1.Delete)
+
+	// cart v1 routes
+	cartV1Route := e.Group("/api/v1/cart")
+	cartV1Route.Use(middleware.OptionalAuthorizationHandler())
+	cartV1Route.GET("", cartHandlerV1.GetDetail)
+	cartV1Route.POST("/item", cartHandlerV1.AddItem)
+	cartV1Route.PUT("/item/:product_id", cartHandlerV1.UpdateItem)
+	cartV1Route.DELETE("/item/:product_id", cartHandlerV1.RemoveItem)
+	cartV1Route.POST("/checkout", cartHandlerV1.Checkout, middleware.AuthorizationHandler(), middleware.ACL(constants.CustomerPermission), middleware.Idempotency(idempotencyKeyService))
+
 	// order v1 routes
 	orderV1Route := e.Group("/api/v1/order")
-	orderV1Route.Use(middleware.AuthorizationHandler(), middleware.ACL(constants.CustomerPermission))
+	orderV1Route.Use(middleware.AuthorizationHandler(), middleware.ACL(constants.CustomerPermission), middleware.Idempotency(idempotencyKeyService))
 	orderV1Route.POST("", orderHandlerV1.Create)
 	orderV1Route.GET("", orderHandlerV1.GetList)
-	orderV1Route.GET("/:order_id", orderHandlerV1.GetDetail)
+	orderV1Route.GET("/:order_id", orderHandlerV1.GetDetailByUser)
+	orderV1Route.PUT("/:order_id/reserve", orderHandlerV1.RenewStockReservation)
 	orderV1Route.PUT("/:order_id/pay", orderHandlerV1.UpdatePaid)
+	orderV1Route.POST("/:order_id/cancel", orderHandlerV1.Cancel)
+	orderV1Route.GET("/:order_id/invoice", orderDocumentHandlerV1.GetInvoice)
+	orderV1Route.GET("/:order_id/packing-slip", orderDocumentHandlerV1.GetPackingSlip)
+	orderV1Route.POST("/:order_id/return", orderReturnHandlerV1.Create)
+	orderV1Route.GET("/:order_id/return", orderReturnHandlerV1.GetListByOrder)
+	orderV1Route.GET("/:order_id/return/:order_return_id", orderReturnHandlerV1.GetDetailByOrder)
 
 	// order admin v1 routes
 	orderAdminV1Route := e.Group("/api/v1/admin/order")
-	orderAdminV1Route.Use(middleware.AuthorizationHandler(), middleware.ACL(constants.AdminPermission))
-	orderV1Route.GET("", orderHandlerV1.GetListAdmin)
-	orderV1Route.GET("/:order_id", orderHandlerV1.GetDetail)
-	orderV1Route.PUT("/:order_id/deliver", orderHandlerV1.UpdateDelivered)
+	orderAdminV1Route.Use(middleware.AuthorizationHandler(), middleware.ACL(constants.AdminPermission), middleware.Idempotency(idempotencyKeyService))
+	orderAdminV1Route.GET("", orderHandlerV1.GetListAdmin)
+	orderAdminV1Route.GET("/:order_id", orderHandlerV1.GetDetail)
+	orderAdminV1Route.PUT("/:order_id/deliver", orderHandlerV1.UpdateDelivered)
+	orderAdminV1Route.PUT("/:order_id/status", orderHandlerV1.UpdateStatus)
+	orderAdminV1Route.POST("/:order_id/cancel", orderHandlerV1.CancelAdmin)
+	orderAdminV1Route.GET("/:order_id/invoice", orderDocumentHandlerV1.GetInvoiceAdmin)
+	orderAdminV1Route.GET("/:order_id/packing-slip", orderDocumentHandlerV1.GetPackingSlipAdmin)
+	orderAdminV1Route.POST("/:order_id/refund", refundHandlerV1.Create)
+	orderAdminV1Route.GET("/:order_id/refund", refundHandlerV1.GetListByOrder)
+
+	// refund admin v1 routes
+	refundAdminV1Route := e.Group("/api/v1/admin/refund")
+	refundAdminV1Route.Use(middleware.AuthorizationHandler(), middleware.ACL(constants.AdminPermission), middleware.Idempotency(idempotencyKeyService))
+	refundAdminV1Route.PUT("/:refund_id/process", refundHandlerV1.Process)
+	refundAdminV1Route.PUT("/:refund_id/reject", refundHandlerV1.Reject)
+
+	// order return admin v1 routes
+	orderReturnAdminV1Route := e.Group("/api/v1/admin/order-return")
+	orderReturnAdminV1Route.Use(middleware.AuthorizationHandler(), middleware.ACL(constants.AdminPermission), middleware.Idempotency(idempotencyKeyService))
+	orderReturnAdminV1Route.GET("", orderReturnHandlerV1.GetListPaginate)
+	orderReturnAdminV1Route.GET("/:order_return_id", orderReturnHandlerV1.GetDetail)
+	orderReturnAdminV1Route.PUT("/:order_return_id/status", orderReturnHandlerV1.UpdateStatus)
 
 	// review v1 routes
 	reviewV1Route := e.Group("/api/v1/review")
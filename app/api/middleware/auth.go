@@ -21,6 +21,23 @@ func AuthorizationHandler() echo.MiddlewareFunc {
 	}
 }
 
+// OptionalAuthorizationHandler only verifies the token when one is given, so the route also serves guests
+func OptionalAuthorizationHandler() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if c.Request().Header.Get(echo.HeaderAuthorization) == "" {
+				return next(c)
+			}
+
+			err := auth.VerifyToken(c)
+			if err != nil {
+				return c.JSON(http.StatusUnauthorized, map[string]string{"message": err.Error()})
+			}
+			return next(c)
+		}
+	}
+}
+
 func ACL(permission map[int]bool) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
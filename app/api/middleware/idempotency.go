@@ -0,0 +1,101 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/danisbagus/go-common-packages/logger"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/danisbagus/matchoshop/utils/auth"
+	"github.com/danisbagus/matchoshop/utils/constants"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Idempotency honours the Idempotency-Key header on mutating requests. The response of the first request is
+// stored with the key and replayed on a retry, a key reused with a different request is rejected. It must run
+// after the authorization middleware since keys are scoped to the user.
+func Idempotency(service port.IdempotencyKeyService) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			key := strings.TrimSpace(req.Header.Get(constants.IdempotencyKeyHeader))
+			if key == "" || req.Method == http.MethodGet || req.Method == http.MethodHead || req.Method == http.MethodOptions {
+				return next(c)
+			}
+
+			body, err := io.ReadAll(req.Body)
+			if err != nil {
+				logger.Error("Error while reading idempotent request body: " + err.Error())
+				return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+			req.Body = io.NopCloser(bytes.NewReader(body))
+
+			form := new(domain.IdempotencyKey)
+			form.Key = key
+			form.Method = req.Method
+			form.Path = req.URL.RequestURI()
+			form.RequestHash = hashRequest(form.Method, form.Path, body)
+			if userInfo := auth.GetClaimData(c); userInfo != nil {
+				form.UserID = userInfo.UserID
+			}
+
+			stored, appErr := service.Begin(form)
+			if appErr != nil {
+				return c.JSON(appErr.Code, appErr.AsMessage())
+			}
+
+			if stored != nil {
+				contentType := echo.MIMEApplicationJSONCharsetUTF8
+				if stored.ContentType != nil && *stored.ContentType != "" {
+					contentType = *stored.ContentType
+				}
+				c.Response().Header().Set(constants.IdempotencyReplayedHeader, "true")
+				return c.Blob(stored.StatusCode, contentType, stored.ResponseBody)
+			}
+
+			res := c.Response()
+			resBody := new(bytes.Buffer)
+			res.Writer = &idempotencyResponseWriter{Writer: io.MultiWriter(res.Writer, resBody), ResponseWriter: res.Writer}
+
+			// a request that failed unexpectedly gives the key up so it can be retried
+			err = next(c)
+			if err != nil || !res.Committed || res.Status >= http.StatusInternalServerError {
+				if appErr := service.Release(form); appErr != nil {
+					logger.Error("Error while release idempotency key: " + appErr.Message)
+				}
+				return err
+			}
+
+			contentType := res.Header().Get(echo.HeaderContentType)
+			form.StatusCode = res.Status
+			form.ContentType = &contentType
+			form.ResponseBody = resBody.Bytes()
+			if appErr := service.Complete(form); appErr != nil {
+				logger.Error("Error while complete idempotency key: " + appErr.Message)
+			}
+			return nil
+		}
+	}
+}
+
+func hashRequest(method, path string, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(method + " " + path + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+type idempotencyResponseWriter struct {
+	io.Writer
+	http.ResponseWriter
+}
+
+func (w *idempotencyResponseWriter) Write(b []byte) (int, error) {
+	return w.Writer.Write(b)
+}
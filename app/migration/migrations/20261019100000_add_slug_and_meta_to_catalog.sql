@@ -0,0 +1,43 @@
+-- +goose Up
+-- SQL in section 'Up' is executed when this migration is applied
+ALTER TABLE products ADD COLUMN slug VARCHAR(120) NULL;
+ALTER TABLE products ADD COLUMN meta_title VARCHAR(70) NULL;
+ALTER TABLE products ADD COLUMN meta_description VARCHAR(160) NULL;
+
+UPDATE products SET slug = CONCAT(TRIM(BOTH '-' FROM regexp_replace(lower(name), '[^a-z0-9]+', '-', 'g')), '-', product_id);
+
+ALTER TABLE products ALTER COLUMN slug SET NOT NULL;
+CREATE UNIQUE INDEX products_slug_unique ON products (slug);
+
+ALTER TABLE product_categories ADD COLUMN slug VARCHAR(120) NULL;
+ALTER TABLE product_categories ADD COLUMN meta_title VARCHAR(70) NULL;
+ALTER TABLE product_categories ADD COLUMN meta_description VARCHAR(160) NULL;
+
+UPDATE product_categories SET slug = CONCAT(TRIM(BOTH '-' FROM regexp_replace(lower(name), '[^a-z0-9]+', '-', 'g')), '-', product_category_id);
+
+ALTER TABLE product_categories ALTER COLUMN slug SET NOT NULL;
+CREATE UNIQUE INDEX product_categories_slug_unique ON product_categories (slug);
+
+CREATE TABLE slug_redirects (
+    slug_redirect_id    SERIAL NOT NULL,
+    entity_type         VARCHAR(30) NOT NULL,
+    old_slug            VARCHAR(120) NOT NULL,
+    entity_id           INT NOT NULL,
+    created_at          TIMESTAMP NOT NULL,
+    PRIMARY KEY (slug_redirect_id),
+    UNIQUE (entity_type, old_slug)
+);
+
+-- +goose Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP TABLE slug_redirects;
+
+DROP INDEX IF EXISTS product_categories_slug_unique;
+ALTER TABLE product_categories DROP COLUMN slug;
+ALTER TABLE product_categories DROP COLUMN meta_title;
+ALTER TABLE product_categories DROP COLUMN meta_description;
+
+DROP INDEX IF EXISTS products_slug_unique;
+ALTER TABLE products DROP COLUMN slug;
+ALTER TABLE products DROP COLUMN meta_title;
+ALTER TABLE products DROP COLUMN meta_description;
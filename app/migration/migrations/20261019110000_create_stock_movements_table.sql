@@ -0,0 +1,26 @@
+-- +goose Up
+-- SQL in section 'Up' is executed when this migration is applied
+CREATE TABLE stock_movements (
+    stock_movement_id   SERIAL NOT NULL,
+    product_id          INT NOT NULL,
+    movement_type       VARCHAR(20) NOT NULL,
+    quantity            INT NOT NULL,
+    stock_after         INT NOT NULL,
+    reason              VARCHAR(255) NULL,
+    actor_user_id       INT NULL,
+    order_id            INT NULL,
+    created_at          TIMESTAMP NOT NULL,
+    PRIMARY KEY (stock_movement_id)
+);
+
+CREATE INDEX stock_movements_product_id_idx ON stock_movements (product_id, created_at);
+
+-- opening balance so the ledger sums up to the current stock
+INSERT INTO stock_movements(product_id, movement_type, quantity, stock_after, reason, created_at)
+SELECT product_id, 'adjustment', stock, stock, 'Opening balance', current_timestamp
+FROM products
+WHERE stock <> 0;
+
+-- +goose Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP TABLE stock_movements;
@@ -0,0 +1,22 @@
+-- +goose Up
+-- SQL in section 'Up' is executed when this migration is applied
+ALTER TABLE orders ADD COLUMN reservation_expires_at TIMESTAMP NULL;
+
+CREATE TABLE stock_reservations (
+    stock_reservation_id    SERIAL NOT NULL,
+    order_id                INT NOT NULL,
+    product_id              INT NOT NULL,
+    quantity                INT NOT NULL,
+    status                  VARCHAR(20) NOT NULL,
+    created_at              TIMESTAMP NOT NULL,
+    updated_at              TIMESTAMP NOT NULL,
+    PRIMARY KEY (stock_reservation_id)
+);
+
+CREATE INDEX stock_reservations_order_id_idx ON stock_reservations (order_id, status);
+
+-- +goose Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP TABLE stock_reservations;
+
+ALTER TABLE orders DROP COLUMN reservation_expires_at;
@@ -0,0 +1,34 @@
+-- +goose Up
+-- SQL in section 'Up' is executed when this migration is applied
+ALTER TABLE products ADD COLUMN low_stock_threshold INT NOT NULL DEFAULT 0;
+ALTER TABLE products ADD COLUMN low_stock_notified_at TIMESTAMP NULL;
+
+CREATE TABLE back_in_stock_subscriptions (
+    back_in_stock_subscription_id   SERIAL NOT NULL,
+    product_id                      INT NOT NULL,
+    user_id                         INT NOT NULL,
+    notified_at                     TIMESTAMP NULL,
+    created_at                      TIMESTAMP NOT NULL,
+    PRIMARY KEY (back_in_stock_subscription_id),
+    UNIQUE (product_id, user_id)
+);
+
+CREATE TABLE stock_alerts (
+    stock_alert_id      SERIAL NOT NULL,
+    alert_type          VARCHAR(20) NOT NULL,
+    product_id          INT NOT NULL,
+    user_id             INT NULL,
+    stock               INT NOT NULL,
+    created_at          TIMESTAMP NOT NULL,
+    PRIMARY KEY (stock_alert_id)
+);
+
+CREATE INDEX stock_alerts_user_id_idx ON stock_alerts (user_id, stock_alert_id);
+
+-- +goose Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP TABLE stock_alerts;
+DROP TABLE back_in_stock_subscriptions;
+
+ALTER TABLE products DROP COLUMN low_stock_notified_at;
+ALTER TABLE products DROP COLUMN low_stock_threshold;
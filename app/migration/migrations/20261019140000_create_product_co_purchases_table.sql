@@ -0,0 +1,17 @@
+-- +goose Up
+-- SQL in section 'Up' is executed when this migration is applied
+ALTER TABLE products ADD COLUMN is_published BOOLEAN NOT NULL DEFAULT TRUE;
+
+CREATE TABLE product_co_purchases (
+    product_id              INT NOT NULL,
+    related_product_id      INT NOT NULL,
+    purchase_count          INT NOT NULL,
+    updated_at              TIMESTAMP NOT NULL,
+    PRIMARY KEY (product_id, related_product_id)
+);
+
+-- +goose Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP TABLE product_co_purchases;
+
+ALTER TABLE products DROP COLUMN is_published;
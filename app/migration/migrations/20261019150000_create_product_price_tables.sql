@@ -0,0 +1,42 @@
+-- +goose Up
+-- SQL in section 'Up' is executed when this migration is applied
+ALTER TABLE products ADD COLUMN compare_at_price INT NULL;
+
+CREATE TABLE product_price_histories (
+    product_price_history_id    SERIAL NOT NULL,
+    product_id                  INT NOT NULL,
+    price                       INT NOT NULL,
+    compare_at_price            INT NULL,
+    actor_user_id               INT NULL,
+    created_at                  TIMESTAMP NOT NULL,
+    PRIMARY KEY (product_price_history_id)
+);
+
+CREATE INDEX product_price_histories_product_id_idx ON product_price_histories (product_id, created_at);
+
+CREATE TABLE product_price_schedules (
+    product_price_schedule_id   SERIAL NOT NULL,
+    product_id                  INT NOT NULL,
+    price                       INT NOT NULL,
+    compare_at_price            INT NULL,
+    starts_at                   TIMESTAMP NOT NULL,
+    ends_at                     TIMESTAMP NULL,
+    actor_user_id               INT NULL,
+    cancelled_at                TIMESTAMP NULL,
+    created_at                  TIMESTAMP NOT NULL,
+    PRIMARY KEY (product_price_schedule_id)
+);
+
+CREATE INDEX product_price_schedules_product_id_idx ON product_price_schedules (product_id, starts_at);
+
+-- current prices are the first known history entry
+INSERT INTO product_price_histories(product_id, price, created_at)
+SELECT product_id, price, created_at
+FROM products;
+
+-- +goose Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP TABLE product_price_schedules;
+DROP TABLE product_price_histories;
+
+ALTER TABLE products DROP COLUMN compare_at_price;
@@ -0,0 +1,36 @@
+-- +goose Up
+-- SQL in section 'Up' is executed when this migration is applied
+CREATE TABLE attribute_definitions (
+    attribute_definition_id     SERIAL NOT NULL,
+    code                        VARCHAR(50) NOT NULL,
+    name                        VARCHAR(100) NOT NULL,
+    data_type                   VARCHAR(20) NOT NULL,
+    options                     TEXT[] NOT NULL DEFAULT '{}',
+    is_required                 BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at                  TIMESTAMP NOT NULL,
+    updated_at                  TIMESTAMP NOT NULL,
+    PRIMARY KEY (attribute_definition_id),
+    UNIQUE (code)
+);
+
+CREATE TABLE attribute_definition_categories (
+    attribute_definition_id     INT NOT NULL,
+    product_category_id         INT NOT NULL,
+    PRIMARY KEY (attribute_definition_id, product_category_id)
+);
+
+CREATE TABLE product_attribute_values (
+    product_id                  INT NOT NULL,
+    attribute_definition_id     INT NOT NULL,
+    value                       VARCHAR(255) NOT NULL,
+    value_number                NUMERIC NULL,
+    PRIMARY KEY (product_id, attribute_definition_id)
+);
+
+CREATE INDEX product_attribute_values_filter_idx ON product_attribute_values (attribute_definition_id, value);
+
+-- +goose Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP TABLE product_attribute_values;
+DROP TABLE attribute_definition_categories;
+DROP TABLE attribute_definitions;
@@ -0,0 +1,9 @@
+-- +goose Up
+-- SQL in section 'Up' is executed when this migration is applied
+ALTER TABLE product_categories ADD COLUMN parent_id INT NULL REFERENCES product_categories (product_category_id);
+CREATE INDEX product_categories_parent_id_index ON product_categories (parent_id);
+
+-- +goose Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP INDEX IF EXISTS product_categories_parent_id_index;
+ALTER TABLE product_categories DROP COLUMN parent_id;
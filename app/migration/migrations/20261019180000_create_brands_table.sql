@@ -0,0 +1,47 @@
+-- +goose Up
+-- SQL in section 'Up' is executed when this migration is applied
+CREATE TABLE brands (
+    brand_id            SERIAL NOT NULL,
+    name                VARCHAR(100) NOT NULL,
+    slug                VARCHAR(120) NOT NULL,
+    logo                VARCHAR(255) NULL,
+    description         VARCHAR(500) NULL,
+    created_at          TIMESTAMP NOT NULL,
+    updated_at          TIMESTAMP NOT NULL,
+    PRIMARY KEY (brand_id)
+);
+
+-- one brand per case and whitespace insensitive brand string, named after its most used spelling
+INSERT INTO brands (name, slug, created_at, updated_at)
+SELECT DISTINCT ON (lower(trim(brand))) trim(brand), '', NOW(), NOW()
+FROM products
+WHERE trim(COALESCE(brand, '')) != ''
+GROUP BY lower(trim(brand)), trim(brand)
+ORDER BY lower(trim(brand)), COUNT(product_id) DESC, trim(brand);
+
+UPDATE brands SET slug = CONCAT(TRIM(BOTH '-' FROM regexp_replace(lower(name), '[^a-z0-9]+', '-', 'g')), '-', brand_id);
+
+CREATE UNIQUE INDEX brands_slug_unique ON brands (slug);
+CREATE UNIQUE INDEX brands_name_unique ON brands (lower(name));
+
+ALTER TABLE products ADD COLUMN brand_id INT NULL REFERENCES brands (brand_id);
+CREATE INDEX products_brand_id_index ON products (brand_id);
+
+UPDATE products p SET brand_id = b.brand_id
+FROM brands b
+WHERE lower(trim(p.brand)) = lower(b.name);
+
+ALTER TABLE products DROP COLUMN brand;
+
+-- +goose Down
+-- SQL section 'Down' is executed when this migration is rolled back
+ALTER TABLE products ADD COLUMN brand VARCHAR(50) NULL;
+
+UPDATE products p SET brand = b.name
+FROM brands b
+WHERE b.brand_id = p.brand_id;
+
+DROP INDEX IF EXISTS products_brand_id_index;
+ALTER TABLE products DROP COLUMN brand_id;
+
+DROP TABLE brands;
@@ -0,0 +1,15 @@
+-- +goose Up
+-- SQL in section 'Up' is executed when this migration is applied
+CREATE TABLE wishlists (
+    wishlist_id         SERIAL NOT NULL,
+    user_id             INT NOT NULL,
+    product_id          INT NOT NULL,
+    created_at          TIMESTAMP NOT NULL,
+    PRIMARY KEY (wishlist_id),
+    UNIQUE (user_id, product_id)
+);
+CREATE INDEX wishlists_product_id_index ON wishlists (product_id);
+
+-- +goose Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP TABLE wishlists;
@@ -0,0 +1,29 @@
+-- +goose Up
+-- SQL in section 'Up' is executed when this migration is applied
+CREATE TABLE carts (
+    cart_id             SERIAL NOT NULL,
+    user_id             INT NULL,
+    session_id          VARCHAR(64) NULL,
+    created_at          TIMESTAMP NOT NULL,
+    updated_at          TIMESTAMP NOT NULL,
+    PRIMARY KEY (cart_id),
+    UNIQUE (user_id),
+    UNIQUE (session_id),
+    CHECK (user_id IS NOT NULL OR session_id IS NOT NULL)
+);
+
+CREATE TABLE cart_items (
+    cart_item_id        SERIAL NOT NULL,
+    cart_id             INT NOT NULL REFERENCES carts (cart_id) ON DELETE CASCADE,
+    product_id          INT NOT NULL,
+    quantity            INT NOT NULL,
+    created_at          TIMESTAMP NOT NULL,
+    updated_at          TIMESTAMP NOT NULL,
+    PRIMARY KEY (cart_item_id),
+    UNIQUE (cart_id, product_id)
+);
+
+-- +goose Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP TABLE cart_items;
+DROP TABLE carts;
@@ -0,0 +1,38 @@
+-- +goose Up
+-- SQL in section 'Up' is executed when this migration is applied
+ALTER TABLE orders ADD COLUMN discount_price INT NOT NULL DEFAULT 0;
+
+ALTER TABLE order_products
+    ADD COLUMN regular_price INT NULL,
+    ADD COLUMN price INT NULL,
+    ADD COLUMN discount_price INT NOT NULL DEFAULT 0,
+    ADD COLUMN tax_price INT NOT NULL DEFAULT 0,
+    ADD COLUMN total_price INT NULL;
+
+-- older orders did not keep their line prices, the current product price is the best estimate
+UPDATE order_products op
+SET regular_price = p.price,
+    price = p.price,
+    total_price = p.price * op.quantity
+FROM products p
+WHERE p.product_id = op.product_id;
+
+UPDATE order_products
+SET regular_price = 0, price = 0, total_price = 0
+WHERE price IS NULL;
+
+ALTER TABLE order_products
+    ALTER COLUMN regular_price SET NOT NULL,
+    ALTER COLUMN price SET NOT NULL,
+    ALTER COLUMN total_price SET NOT NULL;
+
+-- +goose Down
+-- SQL section 'Down' is executed when this migration is rolled back
+ALTER TABLE order_products
+    DROP COLUMN regular_price,
+    DROP COLUMN price,
+    DROP COLUMN discount_price,
+    DROP COLUMN tax_price,
+    DROP COLUMN total_price;
+
+ALTER TABLE orders DROP COLUMN discount_price;
@@ -0,0 +1,27 @@
+-- +goose Up
+-- SQL in section 'Up' is executed when this migration is applied
+ALTER TABLE order_products
+    ADD COLUMN name VARCHAR(255) NULL,
+    ADD COLUMN sku VARCHAR(50) NOT NULL DEFAULT '',
+    ADD COLUMN image TEXT NOT NULL DEFAULT '';
+
+UPDATE order_products op
+SET name = p.name,
+    sku = p.sku,
+    image = COALESCE(p.image, '')
+FROM products p
+WHERE p.product_id = op.product_id;
+
+-- the product of these lines is already gone
+UPDATE order_products
+SET name = 'Deleted product'
+WHERE name IS NULL;
+
+ALTER TABLE order_products ALTER COLUMN name SET NOT NULL;
+
+-- +goose Down
+-- SQL section 'Down' is executed when this migration is rolled back
+ALTER TABLE order_products
+    DROP COLUMN name,
+    DROP COLUMN sku,
+    DROP COLUMN image;
@@ -0,0 +1,37 @@
+-- +goose Up
+-- SQL in section 'Up' is executed when this migration is applied
+ALTER TABLE orders ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'pending_payment';
+
+UPDATE orders SET status = 'paid' WHERE is_paid = 1;
+UPDATE orders SET status = 'delivered' WHERE is_delivered = 1;
+
+CREATE INDEX orders_status_index ON orders (status);
+
+CREATE TABLE order_status_histories (
+    order_status_history_id     SERIAL NOT NULL,
+    order_id                    INT NOT NULL,
+    from_status                 VARCHAR(20) NULL,
+    to_status                   VARCHAR(20) NOT NULL,
+    actor_user_id               INT NULL,
+    note                        TEXT NULL,
+    created_at                  TIMESTAMP NOT NULL,
+    PRIMARY KEY (order_status_history_id)
+);
+
+CREATE INDEX order_status_histories_order_id_index ON order_status_histories (order_id, created_at);
+
+-- rebuild the timeline of existing orders from their flags
+INSERT INTO order_status_histories (order_id, from_status, to_status, actor_user_id, created_at)
+SELECT order_id, NULL, 'pending_payment', user_id, created_at FROM orders;
+
+INSERT INTO order_status_histories (order_id, from_status, to_status, actor_user_id, created_at)
+SELECT order_id, 'pending_payment', 'paid', user_id, COALESCE(paid_at, updated_at) FROM orders WHERE is_paid = 1;
+
+INSERT INTO order_status_histories (order_id, from_status, to_status, created_at)
+SELECT order_id, 'paid', 'delivered', COALESCE(delivered_at, updated_at) FROM orders WHERE is_delivered = 1;
+
+-- +goose Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP TABLE order_status_histories;
+
+ALTER TABLE orders DROP COLUMN status;
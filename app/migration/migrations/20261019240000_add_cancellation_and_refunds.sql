@@ -0,0 +1,28 @@
+-- +goose Up
+-- SQL in section 'Up' is executed when this migration is applied
+ALTER TABLE orders
+    ADD COLUMN cancellation_reason TEXT NULL,
+    ADD COLUMN cancelled_at TIMESTAMP NULL;
+
+CREATE TABLE refunds (
+    refund_id               SERIAL NOT NULL,
+    order_id                INT NOT NULL,
+    payment_result_id       VARCHAR(20) NULL,
+    amount                  INT NOT NULL,
+    reason                  TEXT NULL,
+    status                  VARCHAR(20) NOT NULL,
+    requested_by            INT NULL,
+    created_at              TIMESTAMP NOT NULL,
+    updated_at              TIMESTAMP NOT NULL,
+    PRIMARY KEY (refund_id)
+);
+
+CREATE INDEX refunds_order_id_index ON refunds (order_id);
+
+-- +goose Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP TABLE refunds;
+
+ALTER TABLE orders
+    DROP COLUMN cancellation_reason,
+    DROP COLUMN cancelled_at;
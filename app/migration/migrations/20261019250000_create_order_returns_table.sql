@@ -0,0 +1,49 @@
+-- +goose Up
+-- SQL in section 'Up' is executed when this migration is applied
+CREATE TABLE order_returns (
+    order_return_id         SERIAL NOT NULL,
+    order_id                INT NOT NULL,
+    user_id                 INT NOT NULL,
+    reason                  TEXT NOT NULL,
+    photos                  TEXT[] NOT NULL DEFAULT '{}',
+    status                  VARCHAR(20) NOT NULL,
+    refund_amount           INT NOT NULL,
+    is_restocked            BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at              TIMESTAMP NOT NULL,
+    updated_at              TIMESTAMP NOT NULL,
+    PRIMARY KEY (order_return_id)
+);
+
+CREATE INDEX order_returns_order_id_index ON order_returns (order_id);
+CREATE INDEX order_returns_status_index ON order_returns (status);
+
+CREATE TABLE order_return_items (
+    order_return_item_id    SERIAL NOT NULL,
+    order_return_id         INT NOT NULL REFERENCES order_returns (order_return_id) ON DELETE CASCADE,
+    product_id              INT NOT NULL,
+    quantity                INT NOT NULL,
+    refund_amount           INT NOT NULL,
+    PRIMARY KEY (order_return_item_id),
+    UNIQUE (order_return_id, product_id)
+);
+
+CREATE TABLE order_return_status_histories (
+    order_return_status_history_id  SERIAL NOT NULL,
+    order_return_id                 INT NOT NULL REFERENCES order_returns (order_return_id) ON DELETE CASCADE,
+    from_status                     VARCHAR(20) NULL,
+    to_status                       VARCHAR(20) NOT NULL,
+    actor_user_id                   INT NULL,
+    note                            TEXT NULL,
+    created_at                      TIMESTAMP NOT NULL,
+    PRIMARY KEY (order_return_status_history_id)
+);
+
+ALTER TABLE refunds ADD COLUMN order_return_id INT NULL;
+
+-- +goose Down
+-- SQL section 'Down' is executed when this migration is rolled back
+ALTER TABLE refunds DROP COLUMN order_return_id;
+
+DROP TABLE order_return_status_histories;
+DROP TABLE order_return_items;
+DROP TABLE order_returns;
@@ -0,0 +1,31 @@
+-- +goose Up
+-- SQL in section 'Up' is executed when this migration is applied
+ALTER TABLE refunds
+    ADD COLUMN provider_refund_id VARCHAR(64) NULL,
+    ADD COLUMN failure_reason TEXT NULL,
+    ADD COLUMN processed_by INT NULL,
+    ADD COLUMN processed_at TIMESTAMP NULL;
+
+CREATE TABLE refund_items (
+    refund_item_id  SERIAL NOT NULL,
+    refund_id       INT NOT NULL REFERENCES refunds (refund_id) ON DELETE CASCADE,
+    product_id      INT NOT NULL,
+    quantity        INT NOT NULL,
+    amount          INT NOT NULL,
+    PRIMARY KEY (refund_item_id),
+    UNIQUE (refund_id, product_id)
+);
+
+ALTER TABLE orders ADD COLUMN refunded_price INT NOT NULL DEFAULT 0;
+
+-- +goose Down
+-- SQL section 'Down' is executed when this migration is rolled back
+ALTER TABLE orders DROP COLUMN refunded_price;
+
+DROP TABLE refund_items;
+
+ALTER TABLE refunds
+    DROP COLUMN provider_refund_id,
+    DROP COLUMN failure_reason,
+    DROP COLUMN processed_by,
+    DROP COLUMN processed_at;
@@ -0,0 +1,38 @@
+-- +goose Up
+-- SQL in section 'Up' is executed when this migration is applied
+CREATE TABLE tax_rules (
+    tax_rule_id     SERIAL NOT NULL,
+    country         VARCHAR(100) NOT NULL,
+    region          VARCHAR(100) NOT NULL DEFAULT '',
+    tax_class       VARCHAR(20) NOT NULL,
+    rate            NUMERIC(6,3) NOT NULL,
+    is_inclusive    BOOLEAN NOT NULL DEFAULT FALSE,
+    rounding        VARCHAR(20) NOT NULL DEFAULT 'half_up',
+    created_at      TIMESTAMP NOT NULL,
+    updated_at      TIMESTAMP NOT NULL,
+    PRIMARY KEY (tax_rule_id)
+);
+
+CREATE UNIQUE INDEX tax_rules_location_class_unique ON tax_rules (LOWER(country), LOWER(region), tax_class);
+
+ALTER TABLE products ADD COLUMN tax_class VARCHAR(20) NOT NULL DEFAULT 'standard';
+
+ALTER TABLE shipment_address ADD COLUMN region VARCHAR(100) NULL;
+
+ALTER TABLE order_products
+    ADD COLUMN tax_class VARCHAR(20) NOT NULL DEFAULT 'standard',
+    ADD COLUMN tax_rate NUMERIC(6,3) NOT NULL DEFAULT 0,
+    ADD COLUMN is_tax_inclusive BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +goose Down
+-- SQL section 'Down' is executed when this migration is rolled back
+ALTER TABLE order_products
+    DROP COLUMN tax_class,
+    DROP COLUMN tax_rate,
+    DROP COLUMN is_tax_inclusive;
+
+ALTER TABLE shipment_address DROP COLUMN region;
+
+ALTER TABLE products DROP COLUMN tax_class;
+
+DROP TABLE tax_rules;
@@ -0,0 +1,56 @@
+-- +goose Up
+-- SQL in section 'Up' is executed when this migration is applied
+CREATE TABLE shipping_zones (
+    shipping_zone_id        SERIAL NOT NULL,
+    name                    VARCHAR(100) NOT NULL,
+    countries               TEXT[] NOT NULL,
+    postal_code_patterns    TEXT[] NOT NULL DEFAULT '{}',
+    created_at              TIMESTAMP NOT NULL,
+    updated_at              TIMESTAMP NOT NULL,
+    PRIMARY KEY (shipping_zone_id)
+);
+
+CREATE TABLE shipping_methods (
+    shipping_method_id  SERIAL NOT NULL,
+    shipping_zone_id    INT NOT NULL REFERENCES shipping_zones(shipping_zone_id) ON DELETE CASCADE,
+    name                VARCHAR(100) NOT NULL,
+    rate_type           VARCHAR(20) NOT NULL,
+    rate                INT NOT NULL DEFAULT 0,
+    weight_unit         INT NOT NULL DEFAULT 1000,
+    min_subtotal        INT NOT NULL DEFAULT 0,
+    is_active           BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at          TIMESTAMP NOT NULL,
+    updated_at          TIMESTAMP NOT NULL,
+    PRIMARY KEY (shipping_method_id)
+);
+
+CREATE TABLE shipping_rate_tiers (
+    shipping_rate_tier_id   SERIAL NOT NULL,
+    shipping_method_id      INT NOT NULL REFERENCES shipping_methods(shipping_method_id) ON DELETE CASCADE,
+    min_subtotal            INT NOT NULL,
+    price                   INT NOT NULL,
+    PRIMARY KEY (shipping_rate_tier_id),
+    UNIQUE (shipping_method_id, min_subtotal)
+);
+
+ALTER TABLE products ADD COLUMN weight INT NOT NULL DEFAULT 0;
+
+ALTER TABLE orders
+    ADD COLUMN shipping_method_id INT NULL REFERENCES shipping_methods(shipping_method_id) ON DELETE SET NULL,
+    ADD COLUMN shipping_method_name VARCHAR(100) NULL,
+    ADD COLUMN shipping_weight INT NOT NULL DEFAULT 0;
+
+-- +goose Down
+-- SQL section 'Down' is executed when this migration is rolled back
+ALTER TABLE orders
+    DROP COLUMN shipping_method_id,
+    DROP COLUMN shipping_method_name,
+    DROP COLUMN shipping_weight;
+
+ALTER TABLE products DROP COLUMN weight;
+
+DROP TABLE shipping_rate_tiers;
+
+DROP TABLE shipping_methods;
+
+DROP TABLE shipping_zones;
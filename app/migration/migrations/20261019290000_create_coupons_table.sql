@@ -0,0 +1,58 @@
+-- +goose Up
+-- SQL in section 'Up' is executed when this migration is applied
+CREATE TABLE coupons (
+    coupon_id               SERIAL NOT NULL,
+    code                    VARCHAR(50) NOT NULL,
+    name                    VARCHAR(100) NOT NULL,
+    type                    VARCHAR(20) NOT NULL,
+    value                   INT NOT NULL DEFAULT 0,
+    max_discount            INT NOT NULL DEFAULT 0,
+    min_spend               INT NOT NULL DEFAULT 0,
+    buy_quantity            INT NOT NULL DEFAULT 0,
+    get_quantity            INT NOT NULL DEFAULT 0,
+    product_ids             INT[] NOT NULL DEFAULT '{}',
+    product_category_ids    INT[] NOT NULL DEFAULT '{}',
+    usage_limit             INT NOT NULL DEFAULT 0,
+    usage_limit_per_user    INT NOT NULL DEFAULT 0,
+    starts_at               TIMESTAMP NULL,
+    ends_at                 TIMESTAMP NULL,
+    is_stackable            BOOLEAN NOT NULL DEFAULT FALSE,
+    is_active               BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at              TIMESTAMP NOT NULL,
+    updated_at              TIMESTAMP NOT NULL,
+    PRIMARY KEY (coupon_id)
+);
+
+CREATE UNIQUE INDEX coupons_code_unique ON coupons (LOWER(code));
+
+CREATE TABLE coupon_redemptions (
+    coupon_redemption_id    SERIAL NOT NULL,
+    coupon_id               INT NOT NULL REFERENCES coupons(coupon_id),
+    order_id                INT NOT NULL REFERENCES orders(order_id) ON DELETE CASCADE,
+    user_id                 INT NOT NULL,
+    code                    VARCHAR(50) NOT NULL,
+    discount_price          INT NOT NULL,
+    created_at              TIMESTAMP NOT NULL,
+    PRIMARY KEY (coupon_redemption_id),
+    UNIQUE (coupon_id, order_id)
+);
+
+CREATE INDEX coupon_redemptions_order_id_index ON coupon_redemptions (order_id);
+
+ALTER TABLE orders
+    ADD COLUMN coupon_discount_price INT NOT NULL DEFAULT 0,
+    ADD COLUMN shipping_discount_price INT NOT NULL DEFAULT 0;
+
+ALTER TABLE order_products ADD COLUMN coupon_discount_price INT NOT NULL DEFAULT 0;
+
+-- +goose Down
+-- SQL section 'Down' is executed when this migration is rolled back
+ALTER TABLE order_products DROP COLUMN coupon_discount_price;
+
+ALTER TABLE orders
+    DROP COLUMN coupon_discount_price,
+    DROP COLUMN shipping_discount_price;
+
+DROP TABLE coupon_redemptions;
+
+DROP TABLE coupons;
@@ -0,0 +1,24 @@
+-- +goose Up
+-- SQL in section 'Up' is executed when this migration is applied
+CREATE TABLE idempotency_keys (
+    idempotency_key_id      SERIAL NOT NULL,
+    user_id                 INT NOT NULL DEFAULT 0,
+    key                     VARCHAR(255) NOT NULL,
+    method                  VARCHAR(10) NOT NULL,
+    path                    VARCHAR(255) NOT NULL,
+    request_hash            VARCHAR(64) NOT NULL,
+    status_code             INT NOT NULL DEFAULT 0,
+    content_type            VARCHAR(100) NULL,
+    response_body           BYTEA NULL,
+    created_at              TIMESTAMP NOT NULL,
+    completed_at            TIMESTAMP NULL,
+    expires_at              TIMESTAMP NOT NULL,
+    PRIMARY KEY (idempotency_key_id),
+    UNIQUE (user_id, key)
+);
+
+CREATE INDEX idempotency_keys_expires_at_index ON idempotency_keys (expires_at);
+
+-- +goose Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP TABLE idempotency_keys;
@@ -0,0 +1,32 @@
+-- +goose Up
+-- SQL in section 'Up' is executed when this migration is applied
+CREATE TABLE invoice_sequences (
+    year                    INT NOT NULL,
+    last_number             INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (year)
+);
+
+CREATE TABLE invoices (
+    order_id                INT NOT NULL REFERENCES orders(order_id) ON DELETE CASCADE,
+    invoice_number          VARCHAR(30) NOT NULL,
+    issued_at               TIMESTAMP NOT NULL,
+    PRIMARY KEY (order_id),
+    UNIQUE (invoice_number)
+);
+
+CREATE TABLE order_documents (
+    order_document_id       SERIAL NOT NULL,
+    order_id                INT NOT NULL REFERENCES orders(order_id) ON DELETE CASCADE,
+    type                    VARCHAR(20) NOT NULL,
+    file_name               VARCHAR(100) NOT NULL,
+    content                 BYTEA NOT NULL,
+    created_at              TIMESTAMP NOT NULL,
+    PRIMARY KEY (order_document_id),
+    UNIQUE (order_id, type)
+);
+
+-- +goose Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP TABLE order_documents;
+DROP TABLE invoices;
+DROP TABLE invoice_sequences;
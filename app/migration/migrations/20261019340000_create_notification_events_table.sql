@@ -0,0 +1,22 @@
+-- +goose Up
+-- SQL in section 'Up' is executed when this migration is applied
+CREATE TABLE notification_events (
+    notification_event_id   SERIAL NOT NULL,
+    user_id                 INT NOT NULL,
+    template                VARCHAR(50) NOT NULL,
+    data                    JSONB NOT NULL DEFAULT '{}',
+    status                  VARCHAR(20) NOT NULL,
+    attempts                INT NOT NULL DEFAULT 0,
+    next_attempt_at         TIMESTAMP NOT NULL,
+    last_error              TEXT NULL,
+    sent_at                 TIMESTAMP NULL,
+    created_at              TIMESTAMP NOT NULL,
+    updated_at              TIMESTAMP NOT NULL,
+    PRIMARY KEY (notification_event_id)
+);
+
+CREATE INDEX notification_events_status_next_attempt_at_index ON notification_events (status, next_attempt_at);
+
+-- +goose Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP TABLE notification_events;
@@ -0,0 +1,61 @@
+package worker
+
+import (
+	"time"
+
+	"github.com/danisbagus/matchoshop/internal/core/service"
+	"github.com/danisbagus/matchoshop/internal/repo"
+	"github.com/danisbagus/matchoshop/utils/modules"
+)
+
+// Start runs the periodic background jobs. It is started by the long running
+// server only, the serverless handler has no process to keep them alive.
+func Start() {
+	client := modules.GetPostgresClient()
+
+	// wiring
+	stockReservationRepo := repo.NewStockReservationRepo(client)
+	stockAlertRepo := repo.NewStockAlertRepo(client)
+	productRepo := repo.NewProductRepo(client)
+	recommendationRepo := repo.NewRecommendationRepo(client)
+	idempotencyKeyRepo := repo.NewIdempotencyKeyRepo(client)
+	userRepo := repo.NewUserRepo(client)
+	notificationEventRepo := repo.NewNotificationEventRepo(client)
+	notifier := repo.NewNotifier()
+
+	notificationService := service.NewNotificationService(notificationEventRepo, notifier, userRepo)
+	stockReservationService := service.NewStockReservationService(stockReservationRepo)
+	stockAlertService := service.NewStockAlertService(stockAlertRepo, productRepo)
+	recommendationService := service.NewRecommendationService(recommendationRepo, productRepo)
+	idempotencyKeyService := service.NewIdempotencyKeyService(idempotencyKeyRepo)
+
+	// jobs
+	go schedule(time.Minute, func() {
+		stockReservationService.ReleaseExpired()
+	})
+	go schedule(time.Minute, func() {
+		stockAlertService.Dispatch()
+	})
+	go schedule(10*time.Second, func() {
+		notificationService.SendPending()
+	})
+	go func() {
+		// the matrix is built at boot so it does not stay empty for the first hour
+		recommendationService.RecomputeCoPurchases()
+		schedule(time.Hour, func() {
+			recommendationService.RecomputeCoPurchases()
+		})
+	}()
+	go schedule(time.Hour, func() {
+		idempotencyKeyService.DeleteExpired()
+	})
+}
+
+func schedule(interval time.Duration, job func()) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		job()
+	}
+}
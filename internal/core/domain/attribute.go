@@ -0,0 +1,32 @@
+package domain
+
+type AttributeDefinition struct {
+	AttributeDefinitionID int64
+	Code                  string
+	Name                  string
+	DataType              string
+	Options               []string
+	IsRequired            bool
+	ProductCategoryIDs    []int64
+	CreatedAt             string
+	UpdatedAt             string
+}
+
+type ProductAttributeValue struct {
+	ProductID             int64
+	AttributeDefinitionID int64
+	Code                  string
+	Name                  string
+	DataType              string
+	Value                 string
+	ValueNumber           *float64
+}
+
+// AttributeFilter narrows a product list by an enum attribute (Values) or a number attribute (Min, Max)
+type AttributeFilter struct {
+	Code                  string
+	AttributeDefinitionID int64
+	Values                []string
+	Min                   *float64
+	Max                   *float64
+}
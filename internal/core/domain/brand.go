@@ -0,0 +1,12 @@
+package domain
+
+type Brand struct {
+	BrandID      int64
+	Name         string
+	Slug         string
+	Logo         *string
+	Description  *string
+	NumbProducts int64
+	CreatedAt    string
+	UpdatedAt    string
+}
@@ -0,0 +1,52 @@
+package domain
+
+import "time"
+
+type (
+	Cart struct {
+		CartID    int64
+		UserID    *int64
+		SessionID *string
+		CreatedAt time.Time
+		UpdatedAt time.Time
+	}
+
+	// CartOwner identifies a cart either by the logged in user or by the guest session
+	CartOwner struct {
+		UserID    int64
+		SessionID string
+	}
+
+	CartItem struct {
+		CartItemID int64
+		CartID     int64
+		ProductID  int64
+		Quantity   int64
+		CreatedAt  time.Time
+		UpdatedAt  time.Time
+	}
+
+	CartLine struct {
+		CartItem
+		Product     ProductList
+		Subtotal    int64
+		IsAvailable bool
+	}
+
+	CartDetail struct {
+		Cart
+		Lines     []CartLine
+		TotalItem int64
+		Subtotal  int64
+	}
+
+	// CartCheckout converts the user cart into a new order
+	CartCheckout struct {
+		UserID           int64
+		PaymentMethodID  int64
+		ShippingMethodID int64
+		CouponCodes      []string
+		TotalPrice       int64
+		ShipmentAddress  ShipmentAddress
+	}
+)
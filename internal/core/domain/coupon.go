@@ -0,0 +1,57 @@
+package domain
+
+import "time"
+
+type (
+	// Coupon is a promotion redeemed by code. Value is the percentage or the amount off, a buy X get Y coupon
+	// gives GetQuantity units free for every BuyQuantity units bought. Products and categories scope the
+	// coupon, a category covers its subcategories, and a zero limit is no limit. An automatic coupon is not
+	// entered by the customer, it applies to every order it qualifies for and its code only names it.
+	Coupon struct {
+		CouponID           int64
+		Code               string
+		Name               string
+		Type               string
+		Value              int64
+		MaxDiscount        int64
+		MinSpend           int64
+		BuyQuantity        int64
+		GetQuantity        int64
+		ProductIDs         []int64
+		ProductCategoryIDs []int64
+		UsageLimit         int64
+		UsageLimitPerUser  int64
+		StartsAt           *time.Time
+		EndsAt             *time.Time
+		IsStackable        bool
+		IsAutomatic        bool
+		IsActive           bool
+		UsedCount          int64
+		CreatedAt          time.Time
+		UpdatedAt          time.Time
+	}
+
+	CouponRedemption struct {
+		CouponRedemptionID int64
+		CouponID           int64
+		OrderID            int64
+		UserID             int64
+		Code               string
+		Type               string
+		DiscountPrice      int64
+		CreatedAt          time.Time
+		UserName           string
+		OrderStatus        string
+	}
+
+	CouponRedemptionListCriteria struct {
+		CouponID int64
+		Page     int64
+		Limit    int64
+	}
+)
+
+// IsScoped reports whether the coupon only applies to some products
+func (c Coupon) IsScoped() bool {
+	return len(c.ProductIDs) > 0 || len(c.ProductCategoryIDs) > 0
+}
@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+type (
+	// IdempotencyKey is a client key scoped to the user, with a hash of the request it was first used for and
+	// the response once that request is done. A zero status code means the request is still running.
+	IdempotencyKey struct {
+		IdempotencyKeyID int64
+		UserID           int64
+		Key              string
+		Method           string
+		Path             string
+		RequestHash      string
+		StatusCode       int
+		ContentType      *string
+		ResponseBody     []byte
+		CreatedAt        time.Time
+		CompletedAt      *time.Time
+		ExpiresAt        time.Time
+	}
+)
@@ -0,0 +1,32 @@
+package domain
+
+import "time"
+
+type (
+	// NotificationEvent is something a user is told about, the data fills the template of the event. It is
+	// stored with the change that raised it and sent by the worker, NextAttemptAt is when it is due again.
+	NotificationEvent struct {
+		NotificationEventID int64
+		UserID              int64
+		Template            string
+		Data                map[string]interface{}
+		Status              string
+		Attempts            int64
+		NextAttemptAt       time.Time
+		LastError           *string
+		SentAt              *time.Time
+		CreatedAt           time.Time
+		UpdatedAt           time.Time
+	}
+
+	// Notification is an email rendered in the locale of the recipient, ready to be sent
+	Notification struct {
+		To       string
+		ToName   string
+		Locale   string
+		Template string
+		Subject  string
+		TextBody string
+		HTMLBody string
+	}
+)
@@ -4,19 +4,30 @@ import "time"
 
 type (
 	Order struct {
-		OrderID         int64      `db:"order_id"`
-		UserID          int64      `db:"user_id"`
-		PaymentMethodID int64      `db:"payment_method_id"`
-		ProductPrice    int64      `db:"product_price"`
-		TaxPrice        int64      `db:"tax_price"`
-		ShippingPrice   int64      `db:"shipping_price"`
-		TotalPrice      int64      `db:"total_price"`
-		IsPaid          bool       `db:"is_paid"`
-		PaidAt          *time.Time `db:"paid_at"`
-		IsDelivered     bool       `db:"is_delivered"`
-		DeliveredAt     *time.Time `db:"delivered_at"`
-		CreatedAt       time.Time  `db:"created_at"`
-		UpdatedAt       time.Time  `db:"updated_at"`
+		OrderID               int64      `db:"order_id"`
+		UserID                int64      `db:"user_id"`
+		PaymentMethodID       int64      `db:"payment_method_id"`
+		ProductPrice          int64      `db:"product_price"`
+		DiscountPrice         int64      `db:"discount_price"`
+		CouponDiscountPrice   int64      `db:"coupon_discount_price"`
+		TaxPrice              int64      `db:"tax_price"`
+		ShippingPrice         int64      `db:"shipping_price"`
+		ShippingDiscountPrice int64      `db:"shipping_discount_price"`
+		ShippingMethodID      *int64     `db:"shipping_method_id"`
+		ShippingMethodName    *string    `db:"shipping_method_name"`
+		ShippingWeight        int64      `db:"shipping_weight"`
+		TotalPrice            int64      `db:"total_price"`
+		RefundedPrice         int64      `db:"refunded_price"`
+		Status                string     `db:"status"`
+		IsPaid                bool       `db:"is_paid"`
+		PaidAt                *time.Time `db:"paid_at"`
+		IsDelivered           bool       `db:"is_delivered"`
+		DeliveredAt           *time.Time `db:"delivered_at"`
+		ReservationExpiresAt  *time.Time `db:"reservation_expires_at"`
+		CancellationReason    *string    `db:"cancellation_reason"`
+		CancelledAt           *time.Time `db:"cancelled_at"`
+		CreatedAt             time.Time  `db:"created_at"`
+		UpdatedAt             time.Time  `db:"updated_at"`
 	}
 
 	OrderDetail struct {
@@ -25,7 +36,59 @@ type (
 		UserName          string `db:"user_name"`
 		UserEmail         string `db:"user_email"`
 		ShipmentAddress
-		OrderProducts []OrderProduct
+		OrderProducts     []OrderProduct
+		StatusHistories   []OrderStatusHistory
+		CouponCodes       []string
+		CouponRedemptions []CouponRedemption
 		PaymentResult
+		// CartID is the cart checked out into the order, its lines are removed together with placing the order
+		CartID *int64
+		// IsFromWishlist removes the ordered products from the wishlist of the user together with placing the order
+		IsFromWishlist bool
+	}
+
+	// OrderListCriteria filters the admin order list, the keyword matches the order ID or the customer email
+	// or name and the dates bound the order creation day
+	OrderListCriteria struct {
+		Keyword         string
+		Status          string
+		IsPaid          *bool
+		IsDelivered     *bool
+		DateFrom        *time.Time
+		DateTo          *time.Time
+		UserID          int64
+		PaymentMethodID int64
+		MinTotalPrice   *int64
+		MaxTotalPrice   *int64
+		Sort            string
+		Order           string
+		Page            int64
+		Limit           int64
+	}
+
+	// OrderCancellation cancels an order, customers may only cancel their own order before it is shipped
+	OrderCancellation struct {
+		OrderID     int64
+		ActorUserID int64
+		IsAdmin     bool
+		Reason      string
+	}
+
+	OrderStatusHistory struct {
+		OrderStatusHistoryID int64
+		OrderID              int64
+		FromStatus           *string
+		ToStatus             string
+		ActorUserID          *int64
+		Note                 *string
+		CreatedAt            time.Time
 	}
 )
+
+// NetPaidPrice is what the customer paid for the order less what was refunded to them
+func (o Order) NetPaidPrice() int64 {
+	if !o.IsPaid {
+		return 0
+	}
+	return o.TotalPrice - o.RefundedPrice
+}
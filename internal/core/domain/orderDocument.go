@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+type (
+	// OrderDocument is a generated PDF of an order, kept so later downloads get the same document back
+	OrderDocument struct {
+		OrderDocumentID int64
+		OrderID         int64
+		Type            string
+		FileName        string
+		Content         []byte
+		CreatedAt       time.Time
+	}
+
+	// Invoice holds the number given to an order the first time its invoice is generated, numbers run
+	// sequentially within a year without gaps
+	Invoice struct {
+		OrderID       int64
+		InvoiceNumber string
+		IssuedAt      time.Time
+	}
+
+	Seller struct {
+		Name    string
+		Address string
+		Email   string
+		Phone   string
+		TaxID   string
+	}
+)
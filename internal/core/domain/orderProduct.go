@@ -1,10 +1,19 @@
 package domain
 
 type OrderProduct struct {
-	OrderID   int64
-	ProductID int64
-	Quantity  int64
-	Name      string
-	Image     string
-	Price     int64
+	OrderID             int64
+	ProductID           int64
+	Quantity            int64
+	Name                string
+	Sku                 string
+	Image               string
+	RegularPrice        int64
+	Price               int64
+	DiscountPrice       int64
+	CouponDiscountPrice int64
+	TaxClass            string
+	TaxRate             float64
+	IsTaxInclusive      bool
+	TaxPrice            int64
+	TotalPrice          int64
 }
@@ -0,0 +1,54 @@
+package domain
+
+import "time"
+
+type (
+	OrderReturn struct {
+		OrderReturnID   int64
+		OrderID         int64
+		UserID          int64
+		Reason          string
+		Photos          []string
+		Status          string
+		RefundAmount    int64
+		IsRestocked     bool
+		CreatedAt       time.Time
+		UpdatedAt       time.Time
+		Items           []OrderReturnItem
+		StatusHistories []OrderReturnStatusHistory
+	}
+
+	OrderReturnItem struct {
+		OrderReturnItemID int64
+		OrderReturnID     int64
+		ProductID         int64
+		Name              string
+		Quantity          int64
+		RefundAmount      int64
+	}
+
+	OrderReturnStatusHistory struct {
+		OrderReturnStatusHistoryID int64
+		OrderReturnID              int64
+		FromStatus                 *string
+		ToStatus                   string
+		ActorUserID                *int64
+		Note                       *string
+		CreatedAt                  time.Time
+	}
+
+	// OrderReturnStatusUpdate moves a return along its status graph, restock only applies when it is received
+	OrderReturnStatusUpdate struct {
+		OrderReturnID int64
+		ToStatus      string
+		ActorUserID   int64
+		Note          *string
+		Restock       bool
+	}
+
+	OrderReturnListCriteria struct {
+		Status string
+		Page   int64
+		Limit  int64
+	}
+)
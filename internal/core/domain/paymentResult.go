@@ -8,4 +8,5 @@ type PaymentResult struct {
 	Status          string    `db:"status"`
 	UpdateTime      time.Time `db:"update_time"`
 	Email           string    `db:"email"`
+	ActorUserID     int64     `db:"-"`
 }
@@ -1,21 +1,35 @@
 package domain
 
 type ProductModel struct {
-	ProductID   int64
-	Name        string
-	Sku         string
-	Brand       *string
-	Image       *string
-	Description *string
-	Price       int64
-	Stock       int64
-	CreatedAt   string
-	UpdatedAt   string
+	ProductID         int64
+	Name              string
+	Sku               string
+	Slug              string
+	BrandID           *int64
+	Brand             *string
+	Image             *string
+	Description       *string
+	MetaTitle         *string
+	MetaDescription   *string
+	Price             int64
+	CompareAtPrice    *int64
+	RegularPrice      int64
+	Stock             int64
+	LowStockThreshold int64
+	IsPublished       bool
+	TaxClass          string
+	Weight            int64
+	CreatedAt         string
+	UpdatedAt         string
 }
 
 type Product struct {
 	ProductModel
 	ProductCategoryIDs []int64
+	Attributes         []ProductAttributeValue
+	ActorUserID        int64
+	// Publish sets IsPublished on update, nil keeps the stored value
+	Publish *bool
 }
 
 type ProductList struct {
@@ -31,13 +45,21 @@ type ProductDetail struct {
 	Rating            float32
 	NumbReviews       int64
 	ProductCategories []ProductCategory
+	Breadcrumbs       [][]ProductCategory
+	Attributes        []ProductAttributeValue
 	Review            []Review
 }
 
 type ProductListCriteria struct {
-	Keyword string
-	Page    int64
-	Limit   int64
-	Sort    string
-	Order   string
+	Keyword            string
+	Page               int64
+	Limit              int64
+	Sort               string
+	Order              string
+	BrandID            int64
+	ProductCategoryID  int64
+	ProductCategoryIDs []int64
+	AttributeFilters   []AttributeFilter
+	// PublishedOnly hides unpublished products, set on storefront reads
+	PublishedOnly bool
 }
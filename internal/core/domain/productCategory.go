@@ -1,8 +1,13 @@
 package domain
 
 type ProductCategory struct {
-	ProductCategoryID int64  `db:"product_category_id"`
-	Name              string `db:"name"`
-	CreatedAt         string `db:"created_at"`
-	UpdatedAt         string `db:"updated_at"`
+	ProductCategoryID int64             `db:"product_category_id"`
+	ParentID          *int64            `db:"parent_id"`
+	Name              string            `db:"name"`
+	Slug              string            `db:"slug"`
+	MetaTitle         *string           `db:"meta_title"`
+	MetaDescription   *string           `db:"meta_description"`
+	CreatedAt         string            `db:"created_at"`
+	UpdatedAt         string            `db:"updated_at"`
+	Children          []ProductCategory `db:"-"`
 }
@@ -0,0 +1,34 @@
+package domain
+
+import "time"
+
+type ProductPriceSchedule struct {
+	ProductPriceScheduleID int64
+	ProductID              int64
+	Price                  int64
+	CompareAtPrice         *int64
+	StartsAt               time.Time
+	EndsAt                 *time.Time
+	ActorUserID            *int64
+	CancelledAt            *time.Time
+	CreatedAt              time.Time
+}
+
+type ProductPriceHistory struct {
+	ProductPriceHistoryID int64
+	ProductID             int64
+	Price                 int64
+	CompareAtPrice        *int64
+	ActorUserID           *int64
+	ActorName             *string
+	CreatedAt             time.Time
+}
+
+// ProductPrice is the price a product sold for at a point in time
+type ProductPrice struct {
+	ProductID              int64
+	Price                  int64
+	CompareAtPrice         *int64
+	ProductPriceScheduleID *int64
+	At                     time.Time
+}
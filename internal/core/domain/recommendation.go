@@ -0,0 +1,7 @@
+package domain
+
+type ProductRecommendation struct {
+	FrequentlyBoughtTogether []ProductModel
+	SameCategory             []ProductModel
+	SimilarPrice             []ProductModel
+}
@@ -0,0 +1,32 @@
+package domain
+
+import "time"
+
+type (
+	Refund struct {
+		RefundID         int64
+		OrderID          int64
+		OrderReturnID    *int64
+		PaymentResultID  *string
+		Amount           int64
+		Reason           *string
+		Status           string
+		RequestedBy      *int64
+		ProviderRefundID *string
+		FailureReason    *string
+		ProcessedBy      *int64
+		ProcessedAt      *time.Time
+		CreatedAt        time.Time
+		UpdatedAt        time.Time
+		Items            []RefundItem
+	}
+
+	// RefundItem ties part of a refund to an order line, a refund without items is against the order as a whole
+	RefundItem struct {
+		RefundItemID int64
+		RefundID     int64
+		ProductID    int64
+		Quantity     int64
+		Amount       int64
+	}
+)
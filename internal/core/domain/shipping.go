@@ -0,0 +1,55 @@
+package domain
+
+import "time"
+
+type (
+	// ShippingZone is a set of countries, postal code patterns narrow the zone down to part of those countries
+	ShippingZone struct {
+		ShippingZoneID     int64
+		Name               string
+		Countries          []string
+		PostalCodePatterns []string
+		CreatedAt          time.Time
+		UpdatedAt          time.Time
+		Methods            []ShippingMethod
+	}
+
+	// ShippingMethod prices shipping to its zone. Rate is the flat price or the price per weight unit in grams,
+	// a tiered rate is priced from the tiers and a free rate is only offered from the minimum subtotal
+	ShippingMethod struct {
+		ShippingMethodID int64
+		ShippingZoneID   int64
+		Name             string
+		RateType         string
+		Rate             int64
+		WeightUnit       int64
+		MinSubtotal      int64
+		IsActive         bool
+		Tiers            []ShippingRateTier
+		CreatedAt        time.Time
+		UpdatedAt        time.Time
+	}
+
+	// ShippingRateTier is the price of a tiered rate from a product subtotal
+	ShippingRateTier struct {
+		MinSubtotal int64
+		Price       int64
+	}
+
+	// ShippingQuoteCriteria is what shipping is priced on, a zero weight means the weight is unknown
+	ShippingQuoteCriteria struct {
+		Country    string
+		PostalCode string
+		Subtotal   int64
+		Weight     int64
+	}
+
+	ShippingQuote struct {
+		ShippingMethodID int64
+		ShippingZoneID   int64
+		ZoneName         string
+		Name             string
+		RateType         string
+		Price            int64
+	}
+)
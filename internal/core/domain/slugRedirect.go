@@ -0,0 +1,9 @@
+package domain
+
+type SlugRedirect struct {
+	SlugRedirectID int64  `db:"slug_redirect_id"`
+	EntityType     string `db:"entity_type"`
+	OldSlug        string `db:"old_slug"`
+	EntityID       int64  `db:"entity_id"`
+	CreatedAt      string `db:"created_at"`
+}
@@ -0,0 +1,26 @@
+package domain
+
+// StockAlert is a staff alert when UserID is nil, otherwise a customer alert
+type StockAlert struct {
+	StockAlertID int64
+	AlertType    string
+	ProductID    int64
+	ProductName  string
+	UserID       *int64
+	Stock        int64
+	CreatedAt    string
+}
+
+type StockAlertListCriteria struct {
+	UserID *int64
+	Page   int64
+	Limit  int64
+}
+
+type BackInStockSubscription struct {
+	BackInStockSubscriptionID int64
+	ProductID                 int64
+	UserID                    int64
+	NotifiedAt                *string
+	CreatedAt                 string
+}
@@ -0,0 +1,20 @@
+package domain
+
+type StockMovement struct {
+	StockMovementID int64
+	ProductID       int64
+	MovementType    string
+	Quantity        int64
+	StockAfter      int64
+	Reason          *string
+	ActorUserID     *int64
+	ActorName       *string
+	OrderID         *int64
+	CreatedAt       string
+}
+
+type StockMovementListCriteria struct {
+	ProductID int64
+	Page      int64
+	Limit     int64
+}
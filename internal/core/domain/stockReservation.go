@@ -0,0 +1,11 @@
+package domain
+
+type StockReservation struct {
+	StockReservationID int64
+	OrderID            int64
+	ProductID          int64
+	Quantity           int64
+	Status             string
+	CreatedAt          string
+	UpdatedAt          string
+}
@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+// TaxRule is the tax of a product tax class shipped to a country, an empty region covers the whole country
+type TaxRule struct {
+	TaxRuleID   int64
+	Country     string
+	Region      string
+	TaxClass    string
+	Rate        float64
+	IsInclusive bool
+	Rounding    string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
@@ -6,6 +6,7 @@ type User struct {
 	Password  string `db:"password"`
 	Name      string `db:"name"`
 	RoleID    int64  `db:"role_id"`
+	Locale    string `db:"locale"`
 	CreatedAt string `db:"created_at"`
 	UpdatedAt string `db:"updated_at"`
 }
@@ -0,0 +1,41 @@
+package domain
+
+import "time"
+
+type (
+	Wishlist struct {
+		WishlistID int64
+		UserID     int64
+		ProductID  int64
+		CreatedAt  time.Time
+	}
+
+	WishlistItem struct {
+		Wishlist
+		Product ProductList
+	}
+
+	// WishlistOrder moves wishlist products into a new order
+	WishlistOrder struct {
+		UserID           int64
+		PaymentMethodID  int64
+		ShippingMethodID int64
+		CouponCodes      []string
+		TotalPrice       int64
+		ShipmentAddress  ShipmentAddress
+		OrderProducts    []OrderProduct
+	}
+
+	WishlistReport struct {
+		ProductID     int64
+		Name          string
+		Slug          string
+		Image         *string
+		NumbWishlists int64
+	}
+
+	WishlistReportCriteria struct {
+		Page  int64
+		Limit int64
+	}
+)
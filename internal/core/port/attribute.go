@@ -0,0 +1,29 @@
+package port
+
+import (
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+)
+
+type (
+	AttributeRepo interface {
+		Insert(form *domain.AttributeDefinition) *errs.AppError
+		CheckByIDAndCode(attributeDefinitionID int64, code string) (bool, *errs.AppError)
+		GetAll() ([]domain.AttributeDefinition, *errs.AppError)
+		GetAllByProductCategoryIDs(productCategoryIDs []int64) ([]domain.AttributeDefinition, *errs.AppError)
+		GetAllByCodes(codes []string) ([]domain.AttributeDefinition, *errs.AppError)
+		GetOneByID(attributeDefinitionID int64) (*domain.AttributeDefinition, *errs.AppError)
+		Update(form *domain.AttributeDefinition) *errs.AppError
+		Delete(attributeDefinitionID int64) *errs.AppError
+		ReplaceProductValues(productID int64, values []domain.ProductAttributeValue) *errs.AppError
+		GetAllProductValues(productID int64) ([]domain.ProductAttributeValue, *errs.AppError)
+	}
+
+	AttributeService interface {
+		Create(form *domain.AttributeDefinition) *errs.AppError
+		GetList(productCategoryID int64) ([]domain.AttributeDefinition, *errs.AppError)
+		GetDetail(attributeDefinitionID int64) (*domain.AttributeDefinition, *errs.AppError)
+		Update(form *domain.AttributeDefinition) *errs.AppError
+		Delete(attributeDefinitionID int64) *errs.AppError
+	}
+)
@@ -0,0 +1,30 @@
+package port
+
+import (
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+)
+
+type (
+	BrandRepo interface {
+		Insert(form *domain.Brand) *errs.AppError
+		CheckByID(brandID int64) (bool, *errs.AppError)
+		CheckByIDAndName(brandID int64, name string) (bool, *errs.AppError)
+		CheckByIDAndSlug(brandID int64, slug string) (bool, *errs.AppError)
+		CheckProductByID(brandID int64) (bool, *errs.AppError)
+		GetAll() ([]domain.Brand, *errs.AppError)
+		GetOneByID(brandID int64) (*domain.Brand, *errs.AppError)
+		GetOneBySlug(slug string) (*domain.Brand, *errs.AppError)
+		Update(form *domain.Brand) *errs.AppError
+		Delete(brandID int64) *errs.AppError
+	}
+
+	BrandService interface {
+		Create(form *domain.Brand) *errs.AppError
+		GetList() ([]domain.Brand, *errs.AppError)
+		GetDetail(brandID int64) (*domain.Brand, *errs.AppError)
+		GetDetailBySlug(slug string) (*domain.Brand, *errs.AppError)
+		Update(form *domain.Brand) *errs.AppError
+		Delete(brandID int64) *errs.AppError
+	}
+)
@@ -0,0 +1,28 @@
+package port
+
+import (
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+)
+
+type (
+	CartRepo interface {
+		Insert(form *domain.Cart) (int64, *errs.AppError)
+		GetOneByUserID(userID int64) (*domain.Cart, *errs.AppError)
+		GetOneBySessionID(sessionID string) (*domain.Cart, *errs.AppError)
+		GetAllItemByCartID(cartID int64) ([]domain.CartItem, *errs.AppError)
+		UpsertItem(form *domain.CartItem) *errs.AppError
+		DeleteItem(cartID, productID int64) *errs.AppError
+		Merge(fromCartID, toCartID int64) *errs.AppError
+		AssignUser(cartID, userID int64) *errs.AppError
+	}
+
+	CartService interface {
+		GetDetail(owner domain.CartOwner) (*domain.CartDetail, *errs.AppError)
+		AddItem(owner domain.CartOwner, form *domain.CartItem) (*domain.CartDetail, *errs.AppError)
+		UpdateItem(owner domain.CartOwner, form *domain.CartItem) (*domain.CartDetail, *errs.AppError)
+		RemoveItem(owner domain.CartOwner, productID int64) (*domain.CartDetail, *errs.AppError)
+		Merge(sessionID string, userID int64) *errs.AppError
+		Checkout(form *domain.CartCheckout) (*domain.OrderDetail, *errs.AppError)
+	}
+)
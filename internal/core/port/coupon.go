@@ -0,0 +1,32 @@
+package port
+
+import (
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+)
+
+type (
+	CouponRepo interface {
+		Insert(form *domain.Coupon) *errs.AppError
+		CheckByIDAndCode(couponID int64, code string) (bool, *errs.AppError)
+		GetAll() ([]domain.Coupon, *errs.AppError)
+		GetAllByCodes(codes []string) ([]domain.Coupon, *errs.AppError)
+		GetAllAutomatic() ([]domain.Coupon, *errs.AppError)
+		GetOneByID(couponID int64) (*domain.Coupon, *errs.AppError)
+		GetAllScopedProductID(couponID int64, productIDs []int64) ([]int64, *errs.AppError)
+		CountRedemption(couponID, userID int64) (int64, int64, *errs.AppError)
+		GetAllRedemptionPaginate(criteria *domain.CouponRedemptionListCriteria) ([]domain.CouponRedemption, int64, *errs.AppError)
+		GetAllRedemptionByOrderID(orderID int64) ([]domain.CouponRedemption, *errs.AppError)
+		Update(form *domain.Coupon) *errs.AppError
+		Delete(couponID int64) *errs.AppError
+	}
+
+	CouponService interface {
+		Create(form *domain.Coupon) *errs.AppError
+		GetList() ([]domain.Coupon, *errs.AppError)
+		GetDetail(couponID int64) (*domain.Coupon, *errs.AppError)
+		GetRedemptionListPaginate(criteria *domain.CouponRedemptionListCriteria) ([]domain.CouponRedemption, int64, *errs.AppError)
+		Update(form *domain.Coupon) *errs.AppError
+		Delete(couponID int64) *errs.AppError
+	}
+)
@@ -0,0 +1,25 @@
+package port
+
+import (
+	"time"
+
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+)
+
+type (
+	IdempotencyKeyRepo interface {
+		Insert(form *domain.IdempotencyKey) (bool, *errs.AppError)
+		GetOneByKey(userID int64, key string) (*domain.IdempotencyKey, *errs.AppError)
+		Complete(form *domain.IdempotencyKey) *errs.AppError
+		Delete(idempotencyKeyID int64) *errs.AppError
+		DeleteExpired(now time.Time) (int64, *errs.AppError)
+	}
+
+	IdempotencyKeyService interface {
+		Begin(form *domain.IdempotencyKey) (*domain.IdempotencyKey, *errs.AppError)
+		Complete(form *domain.IdempotencyKey) *errs.AppError
+		Release(form *domain.IdempotencyKey) *errs.AppError
+		DeleteExpired() (int64, *errs.AppError)
+	}
+)
@@ -0,0 +1,27 @@
+package port
+
+import (
+	"time"
+
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+)
+
+type (
+	// Notifier delivers a rendered notification, an error below 500 means it was rejected and is not retried
+	Notifier interface {
+		Send(message *domain.Notification) *errs.AppError
+	}
+
+	// NotificationEventRepo hands the stored notification events to the worker, the events themselves are
+	// stored by the repo of the change that raised them
+	NotificationEventRepo interface {
+		ClaimDue(now, claimedUntil time.Time, limit int64) ([]domain.NotificationEvent, *errs.AppError)
+		UpdateStatus(form *domain.NotificationEvent) *errs.AppError
+	}
+
+	NotificationService interface {
+		Send(event *domain.NotificationEvent) *errs.AppError
+		SendPending() *errs.AppError
+	}
+)
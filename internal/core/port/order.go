@@ -1,26 +1,36 @@
 package port
 
 import (
+	"time"
+
 	"github.com/danisbagus/go-common-packages/errs"
 	"github.com/danisbagus/matchoshop/internal/core/domain"
 )
 
 type (
 	OrderRepo interface {
-		Insert(form *domain.OrderDetail) (int64, *errs.AppError)
-		GetAll() ([]domain.OrderDetail, *errs.AppError)
+		Insert(form *domain.OrderDetail, notification *domain.NotificationEvent) (int64, *errs.AppError)
+		GetAllPaginate(criteria *domain.OrderListCriteria) ([]domain.OrderDetail, int64, *errs.AppError)
 		GetAllByUserID(userID int64) ([]domain.OrderDetail, *errs.AppError)
 		GetOneByID(ID int64) (*domain.OrderDetail, *errs.AppError)
-		UpdatePaid(form *domain.PaymentResult) *errs.AppError
-		UpdateDelivered(ID int64) *errs.AppError
+		UpdatePaid(form *domain.PaymentResult, history *domain.OrderStatusHistory, notification *domain.NotificationEvent) *errs.AppError
+		RenewStockReservation(orderID int64, expiresAt time.Time) *errs.AppError
+		UpdateStatus(form *domain.OrderStatusHistory, notification *domain.NotificationEvent) *errs.AppError
+		Cancel(history *domain.OrderStatusHistory, refund *domain.Refund, notification *domain.NotificationEvent) *errs.AppError
+		GetAllStatusHistoryByOrderID(orderID int64) ([]domain.OrderStatusHistory, *errs.AppError)
 	}
 
 	OrderService interface {
 		Create(form *domain.OrderDetail) (*domain.OrderDetail, *errs.AppError)
-		GetList() ([]domain.OrderDetail, *errs.AppError)
+		QuoteShipping(form *domain.OrderDetail) ([]domain.ShippingQuote, *errs.AppError)
+		GetListPaginate(criteria *domain.OrderListCriteria) ([]domain.OrderDetail, int64, *errs.AppError)
 		GetListByUser(userID int64) ([]domain.OrderDetail, *errs.AppError)
 		GetDetail(ID int64) (*domain.OrderDetail, *errs.AppError)
+		GetDetailByUser(ID, userID int64) (*domain.OrderDetail, *errs.AppError)
 		UpdatePaid(form *domain.PaymentResult) *errs.AppError
-		UpdateDelivered(ID int64) *errs.AppError
+		RenewStockReservation(orderID, userID int64) (*domain.OrderDetail, *errs.AppError)
+		UpdateDelivered(ID, actorUserID int64) *errs.AppError
+		UpdateStatus(form *domain.OrderStatusHistory) *errs.AppError
+		Cancel(form *domain.OrderCancellation) *errs.AppError
 	}
 )
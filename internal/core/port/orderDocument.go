@@ -0,0 +1,20 @@
+package port
+
+import (
+	"time"
+
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+)
+
+type (
+	OrderDocumentRepo interface {
+		Insert(form *domain.OrderDocument) (bool, *errs.AppError)
+		GetOneByOrderID(orderID int64, documentType string) (*domain.OrderDocument, *errs.AppError)
+		GetOrCreateInvoice(orderID int64, issuedAt time.Time) (*domain.Invoice, *errs.AppError)
+	}
+
+	OrderDocumentService interface {
+		GetDocument(orderID, userID int64, isAdmin bool, documentType string) (*domain.OrderDocument, *errs.AppError)
+	}
+)
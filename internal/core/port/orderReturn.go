@@ -0,0 +1,26 @@
+package port
+
+import (
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+)
+
+type (
+	OrderReturnRepo interface {
+		Insert(form *domain.OrderReturn) (int64, *errs.AppError)
+		GetAllPaginate(criteria *domain.OrderReturnListCriteria) ([]domain.OrderReturn, int64, *errs.AppError)
+		GetAllByOrderID(orderID int64) ([]domain.OrderReturn, *errs.AppError)
+		GetOneByID(ID int64) (*domain.OrderReturn, *errs.AppError)
+		GetReturnedQuantityByOrderID(orderID int64) (map[int64]int64, *errs.AppError)
+		UpdateStatus(history *domain.OrderReturnStatusHistory, refund *domain.Refund, restock bool) *errs.AppError
+	}
+
+	OrderReturnService interface {
+		Create(form *domain.OrderReturn) (*domain.OrderReturn, *errs.AppError)
+		GetListPaginate(criteria *domain.OrderReturnListCriteria) ([]domain.OrderReturn, int64, *errs.AppError)
+		GetListByOrder(orderID, userID int64) ([]domain.OrderReturn, *errs.AppError)
+		GetDetail(ID int64) (*domain.OrderReturn, *errs.AppError)
+		GetDetailByUser(orderID, ID, userID int64) (*domain.OrderReturn, *errs.AppError)
+		UpdateStatus(form *domain.OrderReturnStatusUpdate) *errs.AppError
+	}
+)
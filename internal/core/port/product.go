@@ -10,11 +10,15 @@ type ProductRepo interface {
 	CheckByID(productID int64) (bool, *errs.AppError)
 	CheckBySKU(sku string) (bool, *errs.AppError)
 	CheckByIDAndSKU(productID int64, sku string) (bool, *errs.AppError)
+	CheckByIDAndSlug(productID int64, slug string) (bool, *errs.AppError)
 	GetAll(criteria *domain.ProductListCriteria) ([]domain.ProductList, *errs.AppError)
 	GetAllPaginate(criteria *domain.ProductListCriteria) ([]domain.ProductList, int64, *errs.AppError)
+	GetAllLowStockPaginate(criteria *domain.ProductListCriteria) ([]domain.ProductModel, int64, *errs.AppError)
+	GetAllByIDs(productIDs []int64) ([]domain.ProductList, *errs.AppError)
 	GetOneByID(productID int64) (*domain.ProductDetail, *errs.AppError)
+	GetOnePublishedByID(productID int64) (*domain.ProductDetail, *errs.AppError)
+	GetOneBySlug(slug string) (*domain.ProductDetail, *errs.AppError)
 	Update(productID int64, data *domain.Product) *errs.AppError
-	UpdateStock(productID, quantity int64) *errs.AppError
 	Delete(productID int64) *errs.AppError
 }
 
@@ -22,7 +26,10 @@ type ProductService interface {
 	Create(form *domain.Product) *errs.AppError
 	GetList(criteria *domain.ProductListCriteria) ([]domain.ProductDetail, *errs.AppError)
 	GetListPaginate(criteria *domain.ProductListCriteria) ([]domain.ProductDetail, int64, *errs.AppError)
+	GetLowStockListPaginate(criteria *domain.ProductListCriteria) ([]domain.ProductModel, int64, *errs.AppError)
 	GetDetail(productID int64) (*domain.ProductDetail, *errs.AppError)
+	GetPublishedDetail(productID int64) (*domain.ProductDetail, *errs.AppError)
+	GetDetailBySlug(slug string) (*domain.ProductDetail, *errs.AppError)
 	Update(productID int64, form *domain.Product) *errs.AppError
 	Delete(productID int64) *errs.AppError
 }
@@ -11,17 +11,26 @@ type ProductCategoryRepo interface {
 	CheckByIDAndName(productCategoryID int64, name string) (bool, *errs.AppError)
 	CheckByName(name string) (bool, *errs.AppError)
 	CheckByID(productCategoryID int64) (bool, *errs.AppError)
+	CheckByIDAndSlug(productCategoryID int64, slug string) (bool, *errs.AppError)
 	GetAll() ([]domain.ProductCategory, *errs.AppError)
 	GetAllByProductID(productID int64) ([]domain.ProductCategory, *errs.AppError)
+	GetAllAncestors(productCategoryID int64) ([]domain.ProductCategory, *errs.AppError)
+	GetAllDescendantIDs(productCategoryID int64) ([]int64, *errs.AppError)
+	CheckChildByID(productCategoryID int64) (bool, *errs.AppError)
+	CheckProductByID(productCategoryID int64) (bool, *errs.AppError)
 	GetOneByID(productCategoryID int64) (*domain.ProductCategory, *errs.AppError)
+	GetOneBySlug(slug string) (*domain.ProductCategory, *errs.AppError)
 	Update(productCategoryID int64, data *domain.ProductCategory) *errs.AppError
+	Reassign(productCategoryID int64, targetProductCategoryID int64) *errs.AppError
 	Delete(productCategoryID int64) *errs.AppError
 }
 
 type ProductCategoryService interface {
 	Create(data *dto.CreateProductCategoryRequest) (*dto.ResponseData, *errs.AppError)
 	GetList() ([]domain.ProductCategory, *errs.AppError)
+	GetTree() ([]domain.ProductCategory, *errs.AppError)
 	GetDetail(productCategoryID int64) (*dto.ResponseData, *errs.AppError)
+	GetDetailBySlug(slug string) (*domain.ProductCategory, *errs.AppError)
 	Update(productCategoryID int64, data *dto.CreateProductCategoryRequest) (*dto.ResponseData, *errs.AppError)
-	Delete(productCategoryID int64) (*dto.ResponseData, *errs.AppError)
+	Delete(productCategoryID int64, reassignToID int64) (*dto.ResponseData, *errs.AppError)
 }
@@ -0,0 +1,27 @@
+package port
+
+import (
+	"time"
+
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+)
+
+type (
+	ProductPriceRepo interface {
+		InsertSchedule(form *domain.ProductPriceSchedule) *errs.AppError
+		GetAllScheduleByProductID(productID int64) ([]domain.ProductPriceSchedule, *errs.AppError)
+		GetOneScheduleByID(productPriceScheduleID int64) (*domain.ProductPriceSchedule, *errs.AppError)
+		CancelSchedule(productPriceScheduleID int64, cancelledAt time.Time) *errs.AppError
+		GetAllHistoryByProductID(productID int64) ([]domain.ProductPriceHistory, *errs.AppError)
+		GetOneByProductIDAndTime(productID int64, at time.Time) (*domain.ProductPrice, *errs.AppError)
+	}
+
+	ProductPriceService interface {
+		CreateSchedule(form *domain.ProductPriceSchedule) *errs.AppError
+		GetScheduleList(productID int64) ([]domain.ProductPriceSchedule, *errs.AppError)
+		CancelSchedule(productID, productPriceScheduleID int64) *errs.AppError
+		GetHistoryList(productID int64) ([]domain.ProductPriceHistory, *errs.AppError)
+		GetPriceAt(productID int64, at time.Time) (*domain.ProductPrice, *errs.AppError)
+	}
+)
@@ -0,0 +1,22 @@
+package port
+
+import (
+	"time"
+
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+)
+
+type (
+	RecommendationRepo interface {
+		RecomputeCoPurchases(now time.Time) *errs.AppError
+		GetAllCoPurchased(productID, limit int64) ([]domain.ProductModel, *errs.AppError)
+		GetAllSameCategory(productID, limit int64) ([]domain.ProductModel, *errs.AppError)
+		GetAllByPriceRange(productID, minPrice, maxPrice, limit int64) ([]domain.ProductModel, *errs.AppError)
+	}
+
+	RecommendationService interface {
+		GetByProduct(productID int64) (*domain.ProductRecommendation, *errs.AppError)
+		RecomputeCoPurchases() *errs.AppError
+	}
+)
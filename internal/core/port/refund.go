@@ -0,0 +1,29 @@
+package port
+
+import (
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+)
+
+type (
+	RefundRepo interface {
+		Insert(form *domain.Refund) (int64, *errs.AppError)
+		GetAllByOrderID(orderID int64) ([]domain.Refund, *errs.AppError)
+		GetOneByID(ID int64) (*domain.Refund, *errs.AppError)
+		GetRefundedQuantityByOrderID(orderID int64) (map[int64]int64, *errs.AppError)
+		Complete(form *domain.Refund, history *domain.OrderStatusHistory) *errs.AppError
+		UpdateStatus(form *domain.Refund) *errs.AppError
+	}
+
+	RefundService interface {
+		Create(form *domain.Refund) (*domain.Refund, *errs.AppError)
+		GetListByOrder(orderID int64) ([]domain.Refund, *errs.AppError)
+		Process(ID, actorUserID int64) (*domain.Refund, *errs.AppError)
+		Reject(ID, actorUserID int64, note *string) *errs.AppError
+	}
+
+	// PaymentProvider sends money back through the provider the order was paid with
+	PaymentProvider interface {
+		Refund(form *domain.Refund) (string, *errs.AppError)
+	}
+)
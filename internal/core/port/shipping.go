@@ -0,0 +1,33 @@
+package port
+
+import (
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+)
+
+type (
+	ShippingRepo interface {
+		InsertZone(form *domain.ShippingZone) *errs.AppError
+		CheckAnyZone() (bool, *errs.AppError)
+		GetAllZone() ([]domain.ShippingZone, *errs.AppError)
+		GetAllZoneByCountry(country string) ([]domain.ShippingZone, *errs.AppError)
+		GetOneZoneByID(shippingZoneID int64) (*domain.ShippingZone, *errs.AppError)
+		UpdateZone(form *domain.ShippingZone) *errs.AppError
+		DeleteZone(shippingZoneID int64) *errs.AppError
+		InsertMethod(form *domain.ShippingMethod) *errs.AppError
+		GetOneMethodByID(shippingMethodID int64) (*domain.ShippingMethod, *errs.AppError)
+		UpdateMethod(form *domain.ShippingMethod) *errs.AppError
+		DeleteMethod(shippingMethodID int64) *errs.AppError
+	}
+
+	ShippingService interface {
+		CreateZone(form *domain.ShippingZone) *errs.AppError
+		GetZoneList() ([]domain.ShippingZone, *errs.AppError)
+		GetZoneDetail(shippingZoneID int64) (*domain.ShippingZone, *errs.AppError)
+		UpdateZone(form *domain.ShippingZone) *errs.AppError
+		DeleteZone(shippingZoneID int64) *errs.AppError
+		CreateMethod(form *domain.ShippingMethod) *errs.AppError
+		UpdateMethod(form *domain.ShippingMethod) *errs.AppError
+		DeleteMethod(shippingZoneID, shippingMethodID int64) *errs.AppError
+	}
+)
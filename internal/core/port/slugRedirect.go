@@ -0,0 +1,11 @@
+package port
+
+import (
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+)
+
+type SlugRedirectRepo interface {
+	Upsert(data *domain.SlugRedirect) *errs.AppError
+	GetOneBySlug(entityType, slug string) (*domain.SlugRedirect, *errs.AppError)
+}
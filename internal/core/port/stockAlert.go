@@ -0,0 +1,25 @@
+package port
+
+import (
+	"time"
+
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+)
+
+type (
+	StockAlertRepo interface {
+		InsertLowStockAlerts(now time.Time) ([]domain.StockAlert, *errs.AppError)
+		InsertBackInStockAlerts(now time.Time) ([]domain.StockAlert, *errs.AppError)
+		GetAllPaginate(criteria *domain.StockAlertListCriteria) ([]domain.StockAlert, int64, *errs.AppError)
+		UpsertSubscription(form *domain.BackInStockSubscription) *errs.AppError
+		DeleteSubscription(productID, userID int64) *errs.AppError
+	}
+
+	StockAlertService interface {
+		Dispatch() *errs.AppError
+		GetListPaginate(criteria *domain.StockAlertListCriteria) ([]domain.StockAlert, int64, *errs.AppError)
+		Subscribe(form *domain.BackInStockSubscription) *errs.AppError
+		Unsubscribe(productID, userID int64) *errs.AppError
+	}
+)
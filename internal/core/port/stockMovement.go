@@ -0,0 +1,18 @@
+package port
+
+import (
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+)
+
+type (
+	StockMovementRepo interface {
+		Insert(form *domain.StockMovement) *errs.AppError
+		GetAllPaginate(criteria *domain.StockMovementListCriteria) ([]domain.StockMovement, int64, *errs.AppError)
+	}
+
+	StockMovementService interface {
+		Create(form *domain.StockMovement) *errs.AppError
+		GetListPaginate(criteria *domain.StockMovementListCriteria) ([]domain.StockMovement, int64, *errs.AppError)
+	}
+)
@@ -0,0 +1,17 @@
+package port
+
+import (
+	"time"
+
+	"github.com/danisbagus/go-common-packages/errs"
+)
+
+type (
+	StockReservationRepo interface {
+		ReleaseExpired(now time.Time) (int64, *errs.AppError)
+	}
+
+	StockReservationService interface {
+		ReleaseExpired() (int64, *errs.AppError)
+	}
+)
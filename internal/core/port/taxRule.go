@@ -0,0 +1,26 @@
+package port
+
+import (
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+)
+
+type (
+	TaxRuleRepo interface {
+		Insert(form *domain.TaxRule) *errs.AppError
+		CheckByIDAndLocation(taxRuleID int64, country, region, taxClass string) (bool, *errs.AppError)
+		GetAll() ([]domain.TaxRule, *errs.AppError)
+		GetAllByLocation(country, region string) ([]domain.TaxRule, *errs.AppError)
+		GetOneByID(taxRuleID int64) (*domain.TaxRule, *errs.AppError)
+		Update(form *domain.TaxRule) *errs.AppError
+		Delete(taxRuleID int64) *errs.AppError
+	}
+
+	TaxRuleService interface {
+		Create(form *domain.TaxRule) *errs.AppError
+		GetList() ([]domain.TaxRule, *errs.AppError)
+		GetDetail(taxRuleID int64) (*domain.TaxRule, *errs.AppError)
+		Update(form *domain.TaxRule) *errs.AppError
+		Delete(taxRuleID int64) *errs.AppError
+	}
+)
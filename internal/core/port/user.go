@@ -10,8 +10,8 @@ type UserRepo interface {
 	GetAll() ([]domain.UserDetail, *errs.AppError)
 	FindOne(email string) (*domain.User, *errs.AppError)
 	FindOneById(userID int64) (*domain.User, *errs.AppError)
-	CreateUserCustomer(data *domain.User) (*domain.User, *errs.AppError)
-	Update(userID int64, data *domain.User) *errs.AppError
+	CreateUserCustomer(data *domain.User, notification *domain.NotificationEvent) (*domain.User, *errs.AppError)
+	Update(userID int64, data *domain.User, notification *domain.NotificationEvent) *errs.AppError
 	Delete(userID int64) *errs.AppError
 }
 
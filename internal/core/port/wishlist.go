@@ -0,0 +1,24 @@
+package port
+
+import (
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+)
+
+type (
+	WishlistRepo interface {
+		Insert(form *domain.Wishlist) *errs.AppError
+		CheckByUserIDAndProductID(userID, productID int64) (bool, *errs.AppError)
+		GetAllByUserID(userID int64) ([]domain.Wishlist, *errs.AppError)
+		GetMostWishlistedPaginate(criteria *domain.WishlistReportCriteria) ([]domain.WishlistReport, int64, *errs.AppError)
+		Delete(userID, productID int64) *errs.AppError
+	}
+
+	WishlistService interface {
+		Add(form *domain.Wishlist) *errs.AppError
+		Remove(userID, productID int64) *errs.AppError
+		GetList(userID int64) ([]domain.WishlistItem, *errs.AppError)
+		MoveToOrder(form *domain.WishlistOrder) (*domain.OrderDetail, *errs.AppError)
+		GetMostWishlisted(criteria *domain.WishlistReportCriteria) ([]domain.WishlistReport, int64, *errs.AppError)
+	}
+)
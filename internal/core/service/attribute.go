@@ -0,0 +1,228 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/danisbagus/matchoshop/utils/constants"
+)
+
+var attributeCodePattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+type AttributeService struct {
+	repo                port.AttributeRepo
+	productCategoryRepo port.ProductCategoryRepo
+}
+
+func NewAttributeService(repo port.AttributeRepo, productCategoryRepo port.ProductCategoryRepo) port.AttributeService {
+	return &AttributeService{
+		repo:                repo,
+		productCategoryRepo: productCategoryRepo,
+	}
+}
+
+func (s AttributeService) Create(form *domain.AttributeDefinition) *errs.AppError {
+	appErr := s.validate(form)
+	if appErr != nil {
+		return appErr
+	}
+
+	form.CreatedAt = time.Now().Format(dbTSLayout)
+	form.UpdatedAt = time.Now().Format(dbTSLayout)
+
+	appErr = s.repo.Insert(form)
+	if appErr != nil {
+		return appErr
+	}
+
+	return nil
+}
+
+func (s AttributeService) GetList(productCategoryID int64) ([]domain.AttributeDefinition, *errs.AppError) {
+	if productCategoryID == 0 {
+		return s.repo.GetAll()
+	}
+
+	// attributes of the parent categories apply to the category as well
+	ancestors, appErr := s.productCategoryRepo.GetAllAncestors(productCategoryID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	productCategoryIDs := make([]int64, 0, len(ancestors))
+	for _, ancestor := range ancestors {
+		productCategoryIDs = append(productCategoryIDs, ancestor.ProductCategoryID)
+	}
+
+	return s.repo.GetAllByProductCategoryIDs(productCategoryIDs)
+}
+
+func (s AttributeService) GetDetail(attributeDefinitionID int64) (*domain.AttributeDefinition, *errs.AppError) {
+	return s.repo.GetOneByID(attributeDefinitionID)
+}
+
+func (s AttributeService) Update(form *domain.AttributeDefinition) *errs.AppError {
+	attribute, appErr := s.repo.GetOneByID(form.AttributeDefinitionID)
+	if appErr != nil {
+		return appErr
+	}
+
+	// stored product values are only valid for the type they were written with
+	if attribute.DataType != form.DataType {
+		return errs.NewBadRequestError("Data type of an attribute can not be changed")
+	}
+
+	appErr = s.validate(form)
+	if appErr != nil {
+		return appErr
+	}
+
+	form.UpdatedAt = time.Now().Format(dbTSLayout)
+
+	appErr = s.repo.Update(form)
+	if appErr != nil {
+		return appErr
+	}
+
+	return nil
+}
+
+func (s AttributeService) Delete(attributeDefinitionID int64) *errs.AppError {
+	_, appErr := s.repo.GetOneByID(attributeDefinitionID)
+	if appErr != nil {
+		return appErr
+	}
+
+	return s.repo.Delete(attributeDefinitionID)
+}
+
+func (s AttributeService) validate(form *domain.AttributeDefinition) *errs.AppError {
+	form.Code = strings.ToLower(strings.TrimSpace(form.Code))
+	if !attributeCodePattern.MatchString(form.Code) {
+		return errs.NewBadRequestError("Code may only contain lowercase letters, numbers and underscores")
+	}
+
+	checkCode, appErr := s.repo.CheckByIDAndCode(form.AttributeDefinitionID, form.Code)
+	if appErr != nil {
+		return appErr
+	}
+
+	if checkCode {
+		return errs.NewBadRequestError(fmt.Sprintf("Code %s is already used", form.Code))
+	}
+
+	switch form.DataType {
+	case constants.AttributeTypeEnum:
+		options := make([]string, 0, len(form.Options))
+		seen := make(map[string]bool)
+		for _, option := range form.Options {
+			option = strings.TrimSpace(option)
+			if option == "" || seen[option] {
+				continue
+			}
+			seen[option] = true
+			options = append(options, option)
+		}
+
+		if len(options) == 0 {
+			return errs.NewBadRequestError("Enum attribute requires at least one option")
+		}
+		form.Options = options
+	case constants.AttributeTypeText, constants.AttributeTypeNumber, constants.AttributeTypeBoolean:
+		form.Options = []string{}
+	default:
+		return errs.NewBadRequestError(fmt.Sprintf("Data type %s is not supported", form.DataType))
+	}
+
+	for _, productCategoryID := range form.ProductCategoryIDs {
+		checkProductCategory, appErr := s.productCategoryRepo.CheckByID(productCategoryID)
+		if appErr != nil {
+			return appErr
+		}
+
+		if !checkProductCategory {
+			return errs.NewBadRequestError("Product category not found")
+		}
+	}
+
+	return nil
+}
+
+// validateAttributeValues checks product attribute values against the definitions scoped to
+// the product categories and returns them completed with their parsed number value.
+func validateAttributeValues(definitions []domain.AttributeDefinition, values []domain.ProductAttributeValue) ([]domain.ProductAttributeValue, *errs.AppError) {
+	definitionByID := make(map[int64]domain.AttributeDefinition)
+	for _, definition := range definitions {
+		definitionByID[definition.AttributeDefinitionID] = definition
+	}
+
+	result := make([]domain.ProductAttributeValue, 0, len(values))
+	given := make(map[int64]bool)
+	for _, value := range values {
+		definition, ok := definitionByID[value.AttributeDefinitionID]
+		if !ok {
+			return nil, errs.NewBadRequestError(fmt.Sprintf("Attribute %d does not apply to the product categories", value.AttributeDefinitionID))
+		}
+
+		if given[value.AttributeDefinitionID] {
+			return nil, errs.NewBadRequestError(fmt.Sprintf("Attribute %s is given more than once", definition.Name))
+		}
+		given[value.AttributeDefinitionID] = true
+
+		value.Value = strings.TrimSpace(value.Value)
+		if value.Value == "" {
+			if definition.IsRequired {
+				return nil, errs.NewBadRequestError(fmt.Sprintf("Attribute %s is required", definition.Name))
+			}
+			continue
+		}
+
+		switch definition.DataType {
+		case constants.AttributeTypeText:
+			if len(value.Value) > 255 {
+				return nil, errs.NewBadRequestError(fmt.Sprintf("Attribute %s maximum length is 255", definition.Name))
+			}
+		case constants.AttributeTypeNumber:
+			number, err := strconv.ParseFloat(value.Value, 64)
+			if err != nil {
+				return nil, errs.NewBadRequestError(fmt.Sprintf("Attribute %s must be a number", definition.Name))
+			}
+			value.ValueNumber = &number
+		case constants.AttributeTypeEnum:
+			if !containsString(definition.Options, value.Value) {
+				return nil, errs.NewBadRequestError(fmt.Sprintf("Attribute %s must be one of %s", definition.Name, strings.Join(definition.Options, ", ")))
+			}
+		case constants.AttributeTypeBoolean:
+			boolean, err := strconv.ParseBool(value.Value)
+			if err != nil {
+				return nil, errs.NewBadRequestError(fmt.Sprintf("Attribute %s must be true or false", definition.Name))
+			}
+			value.Value = strconv.FormatBool(boolean)
+		}
+
+		result = append(result, value)
+	}
+
+	for _, definition := range definitions {
+		if definition.IsRequired && !given[definition.AttributeDefinitionID] {
+			return nil, errs.NewBadRequestError(fmt.Sprintf("Attribute %s is required", definition.Name))
+		}
+	}
+
+	return result, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/mocks"
+	"github.com/danisbagus/matchoshop/utils/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var mockAttributeRepo = &mocks.AttributeRepo{Mock: mock.Mock{}}
+var attributeService = AttributeService{repo: mockAttributeRepo, productCategoryRepo: mockProductCategoryRepo}
+
+var attributeDefinitions = []domain.AttributeDefinition{
+	{AttributeDefinitionID: 1, Code: "material", Name: "Material", DataType: constants.AttributeTypeEnum, Options: []string{"cotton", "linen"}, IsRequired: true},
+	{AttributeDefinitionID: 2, Code: "weight", Name: "Weight", DataType: constants.AttributeTypeNumber},
+	{AttributeDefinitionID: 3, Code: "organic", Name: "Organic", DataType: constants.AttributeTypeBoolean},
+}
+
+func TestAttribute_Create_EnumWithoutOptions(t *testing.T) {
+	form := &domain.AttributeDefinition{Code: "Fit", Name: "Fit", DataType: constants.AttributeTypeEnum, ProductCategoryIDs: []int64{1}}
+
+	mockAttributeRepo.Mock.On("CheckByIDAndCode", int64(0), "fit").Return(false, nil).Once()
+
+	appErr := attributeService.Create(form)
+
+	assert.NotNil(t, appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.Code)
+}
+
+func TestAttribute_Create_Success(t *testing.T) {
+	form := &domain.AttributeDefinition{Code: "fit", Name: "Fit", DataType: constants.AttributeTypeEnum, Options: []string{"slim", " slim", "regular"}, ProductCategoryIDs: []int64{1}}
+
+	mockAttributeRepo.Mock.On("CheckByIDAndCode", int64(0), "fit").Return(false, nil).Once()
+	mockProductCategoryRepo.Mock.On("CheckByID", int64(1)).Return(true, nil).Once()
+	mockAttributeRepo.Mock.On("Insert", form).Return(nil).Once()
+
+	appErr := attributeService.Create(form)
+
+	assert.Nil(t, appErr)
+	assert.Equal(t, []string{"slim", "regular"}, form.Options)
+}
+
+func TestAttribute_ValidateValues_MissingRequired(t *testing.T) {
+	values := []domain.ProductAttributeValue{{AttributeDefinitionID: 2, Value: "1.5"}}
+
+	_, appErr := validateAttributeValues(attributeDefinitions, values)
+
+	assert.NotNil(t, appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.Code)
+}
+
+func TestAttribute_ValidateValues_InvalidEnum(t *testing.T) {
+	values := []domain.ProductAttributeValue{{AttributeDefinitionID: 1, Value: "wool"}}
+
+	_, appErr := validateAttributeValues(attributeDefinitions, values)
+
+	assert.NotNil(t, appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.Code)
+}
+
+func TestAttribute_ValidateValues_Success(t *testing.T) {
+	values := []domain.ProductAttributeValue{
+		{AttributeDefinitionID: 1, Value: "linen"},
+		{AttributeDefinitionID: 2, Value: "1.5"},
+		{AttributeDefinitionID: 3, Value: "1"},
+	}
+
+	result, appErr := validateAttributeValues(attributeDefinitions, values)
+
+	assert.Nil(t, appErr)
+	assert.Len(t, result, 3)
+	assert.Equal(t, 1.5, *result[1].ValueNumber)
+	assert.Equal(t, "true", result[2].Value)
+}
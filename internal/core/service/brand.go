@@ -0,0 +1,132 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/danisbagus/matchoshop/utils/constants"
+)
+
+type BrandService struct {
+	repo             port.BrandRepo
+	slugRedirectRepo port.SlugRedirectRepo
+}
+
+func NewBrandService(repo port.BrandRepo, slugRedirectRepo port.SlugRedirectRepo) port.BrandService {
+	return &BrandService{
+		repo:             repo,
+		slugRedirectRepo: slugRedirectRepo,
+	}
+}
+
+func (s BrandService) Create(form *domain.Brand) *errs.AppError {
+	appErr := s.checkName(0, form)
+	if appErr != nil {
+		return appErr
+	}
+
+	form.Slug, appErr = resolveSlug(form.Slug, form.Name, constants.SlugEntityBrand, func(slug string) (bool, *errs.AppError) {
+		return s.repo.CheckByIDAndSlug(0, slug)
+	})
+	if appErr != nil {
+		return appErr
+	}
+
+	form.CreatedAt = time.Now().Format(dbTSLayout)
+	form.UpdatedAt = time.Now().Format(dbTSLayout)
+
+	return s.repo.Insert(form)
+}
+
+func (s BrandService) GetList() ([]domain.Brand, *errs.AppError) {
+	return s.repo.GetAll()
+}
+
+func (s BrandService) GetDetail(brandID int64) (*domain.Brand, *errs.AppError) {
+	return s.repo.GetOneByID(brandID)
+}
+
+func (s BrandService) GetDetailBySlug(slug string) (*domain.Brand, *errs.AppError) {
+	brand, appErr := s.repo.GetOneBySlug(slug)
+	if appErr == nil {
+		return brand, nil
+	}
+
+	brandID, appErr := redirectedEntityID(s.slugRedirectRepo, constants.SlugEntityBrand, slug, appErr, "Brand not found!")
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return s.repo.GetOneByID(brandID)
+}
+
+func (s BrandService) Update(form *domain.Brand) *errs.AppError {
+	brand, appErr := s.repo.GetOneByID(form.BrandID)
+	if appErr != nil {
+		return appErr
+	}
+
+	appErr = s.checkName(form.BrandID, form)
+	if appErr != nil {
+		return appErr
+	}
+
+	form.Slug, appErr = renameSlug(form.Slug, brand.Slug, form.Name, constants.SlugEntityBrand, func(slug string) (bool, *errs.AppError) {
+		return s.repo.CheckByIDAndSlug(form.BrandID, slug)
+	})
+	if appErr != nil {
+		return appErr
+	}
+
+	form.UpdatedAt = time.Now().Format(dbTSLayout)
+
+	appErr = s.repo.Update(form)
+	if appErr != nil {
+		return appErr
+	}
+
+	appErr = redirectOldSlug(s.slugRedirectRepo, constants.SlugEntityBrand, form.BrandID, brand.Slug, form.Slug, form.UpdatedAt)
+	if appErr != nil {
+		return appErr
+	}
+
+	return nil
+}
+
+func (s BrandService) Delete(brandID int64) *errs.AppError {
+	_, appErr := s.repo.GetOneByID(brandID)
+	if appErr != nil {
+		return appErr
+	}
+
+	checkProduct, appErr := s.repo.CheckProductByID(brandID)
+	if appErr != nil {
+		return appErr
+	}
+
+	if checkProduct {
+		return errs.NewBadRequestError("Brand still has products")
+	}
+
+	return s.repo.Delete(brandID)
+}
+
+// checkName trims the brand name and refuses a name that differs from another brand only by case
+func (s BrandService) checkName(brandID int64, form *domain.Brand) *errs.AppError {
+	form.Name = strings.TrimSpace(form.Name)
+
+	checkBrand, appErr := s.repo.CheckByIDAndName(brandID, form.Name)
+	if appErr != nil {
+		return appErr
+	}
+
+	if checkBrand {
+		return errs.NewBadRequestError(fmt.Sprintf("Brand with name %s is already exits", form.Name))
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var mockBrandRepo = &mocks.BrandRepo{Mock: mock.Mock{}}
+var brandService = BrandService{repo: mockBrandRepo, slugRedirectRepo: mockSlugRedirectRepo}
+
+func TestBrand_Create_NameExists(t *testing.T) {
+	form := &domain.Brand{Name: " NIKE "}
+
+	mockBrandRepo.Mock.On("CheckByIDAndName", int64(0), "NIKE").Return(true, nil).Once()
+
+	appErr := brandService.Create(form)
+
+	assert.NotNil(t, appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.Code)
+}
+
+func TestBrand_Create_Success(t *testing.T) {
+	form := &domain.Brand{Name: "Adidas"}
+
+	mockBrandRepo.Mock.On("CheckByIDAndName", int64(0), "Adidas").Return(false, nil).Once()
+	mockBrandRepo.Mock.On("CheckByIDAndSlug", int64(0), "adidas").Return(false, nil).Once()
+	mockBrandRepo.Mock.On("Insert", form).Return(nil).Once()
+
+	appErr := brandService.Create(form)
+
+	assert.Nil(t, appErr)
+	assert.Equal(t, "adidas", form.Slug)
+}
+
+func TestBrand_Delete_HasProducts(t *testing.T) {
+	mockBrandRepo.Mock.On("GetOneByID", int64(3)).Return(&domain.Brand{BrandID: 3, Name: "Puma", Slug: "puma"}, nil).Once()
+	mockBrandRepo.Mock.On("CheckProductByID", int64(3)).Return(true, nil).Once()
+
+	appErr := brandService.Delete(3)
+
+	assert.NotNil(t, appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.Code)
+}
+
+func TestBrand_Update_RenameSlug_Success(t *testing.T) {
+	form := &domain.Brand{BrandID: 4, Name: "Reebok", Slug: "Reebok Classic"}
+
+	mockBrandRepo.Mock.On("GetOneByID", int64(4)).Return(&domain.Brand{BrandID: 4, Name: "Reebok", Slug: "reebok"}, nil).Once()
+	mockBrandRepo.Mock.On("CheckByIDAndName", int64(4), "Reebok").Return(false, nil).Once()
+	mockBrandRepo.Mock.On("CheckByIDAndSlug", int64(4), "reebok-classic").Return(false, nil).Once()
+	mockBrandRepo.Mock.On("Update", form).Return(nil).Once()
+	mockSlugRedirectRepo.Mock.On("Upsert", mock.MatchedBy(func(data *domain.SlugRedirect) bool {
+		return data.EntityType == "brand" && data.OldSlug == "reebok" && data.EntityID == 4
+	})).Return(nil).Once()
+
+	appErr := brandService.Update(form)
+
+	assert.Nil(t, appErr)
+	assert.Equal(t, "reebok-classic", form.Slug)
+}
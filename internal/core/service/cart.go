@@ -0,0 +1,324 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/go-common-packages/logger"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/core/port"
+)
+
+type CartService struct {
+	repo         port.CartRepo
+	repoProduct  port.ProductRepo
+	orderService port.OrderService
+}
+
+func NewCartService(repo port.CartRepo, repoProduct port.ProductRepo, orderService port.OrderService) port.CartService {
+	return &CartService{
+		repo:         repo,
+		repoProduct:  repoProduct,
+		orderService: orderService,
+	}
+}
+
+func (s CartService) GetDetail(owner domain.CartOwner) (*domain.CartDetail, *errs.AppError) {
+	cart, appErr := s.findCart(owner)
+	if appErr != nil {
+		if appErr.Code != http.StatusNotFound {
+			return nil, appErr
+		}
+		// nothing has been added yet
+		return &domain.CartDetail{Lines: make([]domain.CartLine, 0)}, nil
+	}
+
+	return s.getDetail(cart)
+}
+
+// AddItem adds the quantity to the cart line, the cart is created on the first item
+func (s CartService) AddItem(owner domain.CartOwner, form *domain.CartItem) (*domain.CartDetail, *errs.AppError) {
+	product, appErr := s.getProduct(form.ProductID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	cart, appErr := s.findOrCreateCart(owner)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	items, appErr := s.repo.GetAllItemByCartID(cart.CartID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	quantity := form.Quantity
+	for _, item := range items {
+		if item.ProductID == form.ProductID {
+			quantity += item.Quantity
+		}
+	}
+
+	return s.setQuantity(cart, product, quantity)
+}
+
+func (s CartService) UpdateItem(owner domain.CartOwner, form *domain.CartItem) (*domain.CartDetail, *errs.AppError) {
+	cart, appErr := s.findCartItem(owner, form.ProductID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	product, appErr := s.getProduct(form.ProductID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return s.setQuantity(cart, product, form.Quantity)
+}
+
+func (s CartService) RemoveItem(owner domain.CartOwner, productID int64) (*domain.CartDetail, *errs.AppError) {
+	cart, appErr := s.findCartItem(owner, productID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	appErr = s.repo.DeleteItem(cart.CartID, productID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return s.getDetail(cart)
+}
+
+// Merge moves the guest cart into the user cart after login, the guest cart is simply handed over when the
+// user has no cart yet
+func (s CartService) Merge(sessionID string, userID int64) *errs.AppError {
+	guestCart, appErr := s.repo.GetOneBySessionID(sessionID)
+	if appErr != nil {
+		if appErr.Code == http.StatusNotFound {
+			return nil
+		}
+		return appErr
+	}
+
+	userCart, appErr := s.repo.GetOneByUserID(userID)
+	if appErr != nil {
+		if appErr.Code != http.StatusNotFound {
+			return appErr
+		}
+		return s.repo.AssignUser(guestCart.CartID, userID)
+	}
+
+	return s.repo.Merge(guestCart.CartID, userCart.CartID)
+}
+
+// Checkout places an order for every cart line and empties the cart, the order service prices the order
+func (s CartService) Checkout(form *domain.CartCheckout) (*domain.OrderDetail, *errs.AppError) {
+	cart, appErr := s.repo.GetOneByUserID(form.UserID)
+	if appErr != nil {
+		if appErr.Code == http.StatusNotFound {
+			return nil, errs.NewBadRequestError("Cart is empty")
+		}
+		return nil, appErr
+	}
+
+	detail, appErr := s.getDetail(cart)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if len(detail.Lines) == 0 {
+		return nil, errs.NewBadRequestError("Cart is empty")
+	}
+
+	order := new(domain.OrderDetail)
+	order.UserID = form.UserID
+	order.PaymentMethodID = form.PaymentMethodID
+	order.ShippingMethodID = &form.ShippingMethodID
+	order.CouponCodes = form.CouponCodes
+	order.TotalPrice = form.TotalPrice
+	order.ShipmentAddress = form.ShipmentAddress
+
+	for _, line := range detail.Lines {
+		if !line.IsAvailable {
+			logger.Error(fmt.Sprintf("Failed while checkout cart: product %d is unavailable", line.ProductID))
+			return nil, errs.NewBadRequestError(fmt.Sprintf("Product %d is unavailable or out of stock", line.ProductID))
+		}
+		order.OrderProducts = append(order.OrderProducts, domain.OrderProduct{ProductID: line.ProductID, Quantity: line.Quantity})
+	}
+
+	// the cart lines are removed in the order transaction, so a failed order keeps the cart and a repeated
+	// checkout of the same cart finds it empty
+	order.CartID = &cart.CartID
+
+	order, appErr = s.orderService.Create(order)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return order, nil
+}
+
+func (s CartService) findCart(owner domain.CartOwner) (*domain.Cart, *errs.AppError) {
+	if owner.UserID != 0 {
+		return s.repo.GetOneByUserID(owner.UserID)
+	}
+
+	if owner.SessionID != "" {
+		return s.repo.GetOneBySessionID(owner.SessionID)
+	}
+
+	return nil, errs.NewNotFoundError("Cart not found!")
+}
+
+// findOrCreateCart returns the owner cart, guests always get a server generated session so an unknown session
+// given by the client is never adopted
+func (s CartService) findOrCreateCart(owner domain.CartOwner) (*domain.Cart, *errs.AppError) {
+	cart, appErr := s.findCart(owner)
+	if appErr == nil {
+		return cart, nil
+	}
+
+	if appErr.Code != http.StatusNotFound {
+		return nil, appErr
+	}
+
+	cart = new(domain.Cart)
+	if owner.UserID != 0 {
+		cart.UserID = &owner.UserID
+	} else {
+		sessionID, appErr := newCartSessionID()
+		if appErr != nil {
+			return nil, appErr
+		}
+		cart.SessionID = &sessionID
+	}
+	cart.CreatedAt = time.Now()
+	cart.UpdatedAt = time.Now()
+
+	cartID, appErr := s.repo.Insert(cart)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	cart.CartID = cartID
+	return cart, nil
+}
+
+func (s CartService) findCartItem(owner domain.CartOwner, productID int64) (*domain.Cart, *errs.AppError) {
+	cart, appErr := s.findCart(owner)
+	if appErr != nil {
+		if appErr.Code == http.StatusNotFound {
+			return nil, errs.NewNotFoundError("Product is not in cart")
+		}
+		return nil, appErr
+	}
+
+	items, appErr := s.repo.GetAllItemByCartID(cart.CartID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	for _, item := range items {
+		if item.ProductID == productID {
+			return cart, nil
+		}
+	}
+
+	return nil, errs.NewNotFoundError("Product is not in cart")
+}
+
+func (s CartService) getProduct(productID int64) (*domain.ProductList, *errs.AppError) {
+	products, appErr := s.repoProduct.GetAllByIDs([]int64{productID})
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if len(products) == 0 || !products[0].IsPublished {
+		return nil, errs.NewBadRequestError("Product not found")
+	}
+
+	return &products[0], nil
+}
+
+func (s CartService) setQuantity(cart *domain.Cart, product *domain.ProductList, quantity int64) (*domain.CartDetail, *errs.AppError) {
+	if product.Stock < quantity {
+		logger.Error("Failed while update cart: insufficient product stock")
+		return nil, errs.NewBadRequestError("Insufficient product stock")
+	}
+
+	item := new(domain.CartItem)
+	item.CartID = cart.CartID
+	item.ProductID = product.ProductID
+	item.Quantity = quantity
+	item.UpdatedAt = time.Now()
+
+	appErr := s.repo.UpsertItem(item)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return s.getDetail(cart)
+}
+
+// getDetail prices the cart lines with the current product price and stock
+func (s CartService) getDetail(cart *domain.Cart) (*domain.CartDetail, *errs.AppError) {
+	items, appErr := s.repo.GetAllItemByCartID(cart.CartID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	detail := new(domain.CartDetail)
+	detail.Cart = *cart
+	detail.Lines = make([]domain.CartLine, 0)
+	if len(items) == 0 {
+		return detail, nil
+	}
+
+	productIDs := make([]int64, 0, len(items))
+	for _, item := range items {
+		productIDs = append(productIDs, item.ProductID)
+	}
+
+	products, appErr := s.repoProduct.GetAllByIDs(productIDs)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	productByID := make(map[int64]domain.ProductList)
+	for _, product := range products {
+		productByID[product.ProductID] = product
+	}
+
+	for _, item := range items {
+		product, ok := productByID[item.ProductID]
+		if !ok {
+			// product was deleted after being added to the cart
+			continue
+		}
+
+		line := domain.CartLine{CartItem: item, Product: product}
+		line.Subtotal = product.Price * item.Quantity
+		line.IsAvailable = product.IsPublished && product.Stock >= item.Quantity
+
+		detail.TotalItem += item.Quantity
+		detail.Subtotal += line.Subtotal
+		detail.Lines = append(detail.Lines, line)
+	}
+
+	return detail, nil
+}
+
+func newCartSessionID() (string, *errs.AppError) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		logger.Error("Error while generate cart session: " + err.Error())
+		return "", errs.NewUnexpectedError("Unexpected error")
+	}
+	return hex.EncodeToString(buf), nil
+}
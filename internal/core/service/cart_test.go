@@ -0,0 +1,99 @@
+package service
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var mockCartRepo = &mocks.CartRepo{Mock: mock.Mock{}}
+var cartService = CartService{repo: mockCartRepo, repoProduct: mockProductRepo, orderService: mockOrderService}
+
+func TestCart_AddItem_InsufficientStock(t *testing.T) {
+	owner := domain.CartOwner{UserID: 11}
+
+	mockProductRepo.Mock.On("GetAllByIDs", []int64{40}).Return([]domain.ProductList{{ProductModel: domain.ProductModel{ProductID: 40, Price: 3000, Stock: 3, IsPublished: true}}}, nil).Once()
+	mockCartRepo.Mock.On("GetOneByUserID", int64(11)).Return(&domain.Cart{CartID: 5}, nil).Once()
+	mockCartRepo.Mock.On("GetAllItemByCartID", int64(5)).Return([]domain.CartItem{{CartID: 5, ProductID: 40, Quantity: 2}}, nil).Once()
+
+	cart, appErr := cartService.AddItem(owner, &domain.CartItem{ProductID: 40, Quantity: 2})
+
+	assert.Nil(t, cart)
+	assert.NotNil(t, appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.Code)
+}
+
+func TestCart_AddItem_NewGuestCart(t *testing.T) {
+	owner := domain.CartOwner{SessionID: "unknown-session"}
+
+	mockProductRepo.Mock.On("GetAllByIDs", []int64{41}).Return([]domain.ProductList{{ProductModel: domain.ProductModel{ProductID: 41, Price: 3000, Stock: 5, IsPublished: true}}}, nil).Times(2)
+	mockCartRepo.Mock.On("GetOneBySessionID", "unknown-session").Return(nil, errs.NewNotFoundError("Cart not found!")).Once()
+	mockCartRepo.Mock.On("Insert", mock.MatchedBy(func(cart *domain.Cart) bool {
+		return cart.UserID == nil && cart.SessionID != nil && len(*cart.SessionID) == 64
+	})).Return(int64(6), nil).Once()
+	mockCartRepo.Mock.On("GetAllItemByCartID", int64(6)).Return([]domain.CartItem{}, nil).Once()
+	mockCartRepo.Mock.On("UpsertItem", mock.MatchedBy(func(item *domain.CartItem) bool {
+		return item.CartID == 6 && item.ProductID == 41 && item.Quantity == 2
+	})).Return(nil).Once()
+	mockCartRepo.Mock.On("GetAllItemByCartID", int64(6)).Return([]domain.CartItem{{CartID: 6, ProductID: 41, Quantity: 2}}, nil).Once()
+
+	cart, appErr := cartService.AddItem(owner, &domain.CartItem{ProductID: 41, Quantity: 2})
+
+	assert.Nil(t, appErr)
+	assert.NotEqual(t, "unknown-session", *cart.SessionID)
+	assert.Equal(t, int64(6000), cart.Subtotal)
+	assert.True(t, cart.Lines[0].IsAvailable)
+}
+
+func TestCart_Merge_AssignGuestCart(t *testing.T) {
+	mockCartRepo.Mock.On("GetOneBySessionID", "guest-a").Return(&domain.Cart{CartID: 7}, nil).Once()
+	mockCartRepo.Mock.On("GetOneByUserID", int64(12)).Return(nil, errs.NewNotFoundError("Cart not found!")).Once()
+	mockCartRepo.Mock.On("AssignUser", int64(7), int64(12)).Return(nil).Once()
+
+	appErr := cartService.Merge("guest-a", 12)
+
+	assert.Nil(t, appErr)
+}
+
+func TestCart_Merge_IntoUserCart(t *testing.T) {
+	mockCartRepo.Mock.On("GetOneBySessionID", "guest-b").Return(&domain.Cart{CartID: 8}, nil).Once()
+	mockCartRepo.Mock.On("GetOneByUserID", int64(13)).Return(&domain.Cart{CartID: 9}, nil).Once()
+	mockCartRepo.Mock.On("Merge", int64(8), int64(9)).Return(nil).Once()
+
+	appErr := cartService.Merge("guest-b", 13)
+
+	assert.Nil(t, appErr)
+}
+
+func TestCart_Checkout_OutOfStock(t *testing.T) {
+	mockCartRepo.Mock.On("GetOneByUserID", int64(14)).Return(&domain.Cart{CartID: 10}, nil).Once()
+	mockCartRepo.Mock.On("GetAllItemByCartID", int64(10)).Return([]domain.CartItem{{CartID: 10, ProductID: 42, Quantity: 4}}, nil).Once()
+	mockProductRepo.Mock.On("GetAllByIDs", []int64{42}).Return([]domain.ProductList{{ProductModel: domain.ProductModel{ProductID: 42, Price: 1000, Stock: 1, IsPublished: true}}}, nil).Once()
+
+	order, appErr := cartService.Checkout(&domain.CartCheckout{UserID: 14})
+
+	assert.Nil(t, order)
+	assert.NotNil(t, appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.Code)
+}
+
+func TestCart_Checkout_Success(t *testing.T) {
+	mockCartRepo.Mock.On("GetOneByUserID", int64(15)).Return(&domain.Cart{CartID: 11}, nil).Once()
+	mockCartRepo.Mock.On("GetAllItemByCartID", int64(11)).Return([]domain.CartItem{{CartID: 11, ProductID: 43, Quantity: 2}}, nil).Once()
+	mockProductRepo.Mock.On("GetAllByIDs", []int64{43}).Return([]domain.ProductList{{ProductModel: domain.ProductModel{ProductID: 43, Name: "Sneaker", Price: 4000, Stock: 5, IsPublished: true}}}, nil).Once()
+	mockOrderService.Mock.On("Create", mock.MatchedBy(func(order *domain.OrderDetail) bool {
+		return order.UserID == 15 && order.TotalPrice == 9000 && order.OrderProducts[0].ProductID == 43 && order.OrderProducts[0].Quantity == 2 &&
+			*order.CartID == 11
+	})).Return(&domain.OrderDetail{Order: domain.Order{OrderID: 91}}, nil).Once()
+
+	order, appErr := cartService.Checkout(&domain.CartCheckout{UserID: 15, TotalPrice: 9000})
+
+	assert.Nil(t, appErr)
+	assert.Equal(t, int64(91), order.Order.OrderID)
+	mockCartRepo.AssertExpectations(t)
+}
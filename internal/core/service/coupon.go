@@ -0,0 +1,247 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/danisbagus/matchoshop/utils/constants"
+)
+
+type CouponService struct {
+	repo port.CouponRepo
+}
+
+func NewCouponService(repo port.CouponRepo) port.CouponService {
+	return &CouponService{
+		repo: repo,
+	}
+}
+
+func (s CouponService) Create(form *domain.Coupon) *errs.AppError {
+	appErr := s.checkCoupon(0, form)
+	if appErr != nil {
+		return appErr
+	}
+
+	form.CreatedAt = time.Now()
+	form.UpdatedAt = time.Now()
+
+	return s.repo.Insert(form)
+}
+
+func (s CouponService) GetList() ([]domain.Coupon, *errs.AppError) {
+	return s.repo.GetAll()
+}
+
+func (s CouponService) GetDetail(couponID int64) (*domain.Coupon, *errs.AppError) {
+	return s.repo.GetOneByID(couponID)
+}
+
+func (s CouponService) GetRedemptionListPaginate(criteria *domain.CouponRedemptionListCriteria) ([]domain.CouponRedemption, int64, *errs.AppError) {
+	_, appErr := s.repo.GetOneByID(criteria.CouponID)
+	if appErr != nil {
+		return nil, 0, appErr
+	}
+
+	return s.repo.GetAllRedemptionPaginate(criteria)
+}
+
+func (s CouponService) Update(form *domain.Coupon) *errs.AppError {
+	_, appErr := s.repo.GetOneByID(form.CouponID)
+	if appErr != nil {
+		return appErr
+	}
+
+	appErr = s.checkCoupon(form.CouponID, form)
+	if appErr != nil {
+		return appErr
+	}
+
+	form.UpdatedAt = time.Now()
+
+	return s.repo.Update(form)
+}
+
+// Delete removes a coupon that was never redeemed, a redeemed coupon is kept for its redemption records and
+// can be deactivated instead
+func (s CouponService) Delete(couponID int64) *errs.AppError {
+	_, appErr := s.repo.GetOneByID(couponID)
+	if appErr != nil {
+		return appErr
+	}
+
+	_, totalRedemption, appErr := s.repo.GetAllRedemptionPaginate(&domain.CouponRedemptionListCriteria{CouponID: couponID, Page: 1, Limit: 1})
+	if appErr != nil {
+		return appErr
+	}
+
+	if totalRedemption > 0 {
+		return errs.NewBadRequestError("Coupon has been redeemed, deactivate it instead")
+	}
+
+	return s.repo.Delete(couponID)
+}
+
+func (s CouponService) checkCoupon(couponID int64, form *domain.Coupon) *errs.AppError {
+	form.Code = normalizeCouponCode(form.Code)
+
+	switch form.Type {
+	case constants.CouponTypePercentage:
+		if form.Value < 1 || form.Value > 100 {
+			return errs.NewBadRequestError("Percentage coupon value must be between 1 and 100")
+		}
+	case constants.CouponTypeFixed:
+		if form.Value < 1 {
+			return errs.NewBadRequestError("Fixed coupon value must more than 0")
+		}
+	case constants.CouponTypeBuyXGetY:
+		if form.BuyQuantity < 1 || form.GetQuantity < 1 {
+			return errs.NewBadRequestError("Buy X get Y coupon needs a buy and a get quantity")
+		}
+	}
+
+	if form.StartsAt != nil && form.EndsAt != nil && !form.EndsAt.After(*form.StartsAt) {
+		return errs.NewBadRequestError("Coupon must end after it starts")
+	}
+
+	checkCoupon, appErr := s.repo.CheckByIDAndCode(couponID, form.Code)
+	if appErr != nil {
+		return appErr
+	}
+
+	if checkCoupon {
+		return errs.NewBadRequestError(fmt.Sprintf("Coupon code %s already exists", form.Code))
+	}
+	return nil
+}
+
+func normalizeCouponCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// checkCouponAvailable checks the coupon can be redeemed now on an order of the subtotal, usage limits are
+// checked against the redemptions separately
+func checkCouponAvailable(coupon domain.Coupon, subtotal int64, now time.Time) *errs.AppError {
+	if !coupon.IsActive {
+		return errs.NewBadRequestError(fmt.Sprintf("Coupon %s is not valid", coupon.Code))
+	}
+
+	if (coupon.StartsAt != nil && now.Before(*coupon.StartsAt)) || (coupon.EndsAt != nil && !now.Before(*coupon.EndsAt)) {
+		return errs.NewBadRequestError(fmt.Sprintf("Coupon %s is not valid at this time", coupon.Code))
+	}
+
+	if subtotal < coupon.MinSpend {
+		return errs.NewBadRequestError(fmt.Sprintf("Coupon %s needs a minimum spend of %d", coupon.Code, coupon.MinSpend))
+	}
+	return nil
+}
+
+// applyCoupon takes the coupon discount off the lines it applies to and returns the discount, a nil scope
+// means every line. Free shipping is taken off the shipping price instead and is not applied here.
+func applyCoupon(coupon domain.Coupon, lines []domain.OrderProduct, scope map[int64]bool) int64 {
+	eligible := make([]int, 0, len(lines))
+	var base int64
+	for i, line := range lines {
+		if scope != nil && !scope[line.ProductID] {
+			continue
+		}
+		if remaining := couponRemainingPrice(line); remaining > 0 {
+			eligible = append(eligible, i)
+			base += remaining
+		}
+	}
+
+	if len(eligible) == 0 {
+		return 0
+	}
+
+	switch coupon.Type {
+	case constants.CouponTypePercentage:
+		discount := base * coupon.Value / 100
+		if coupon.MaxDiscount > 0 && discount > coupon.MaxDiscount {
+			discount = coupon.MaxDiscount
+		}
+		return allocateCouponDiscount(lines, eligible, discount, base)
+	case constants.CouponTypeFixed:
+		discount := coupon.Value
+		if discount > base {
+			discount = base
+		}
+		return allocateCouponDiscount(lines, eligible, discount, base)
+	case constants.CouponTypeBuyXGetY:
+		return applyBuyXGetY(coupon, lines, eligible)
+	default:
+		return 0
+	}
+}
+
+// applyBuyXGetY makes the cheapest units free, GetQuantity for every BuyQuantity plus GetQuantity units
+func applyBuyXGetY(coupon domain.Coupon, lines []domain.OrderProduct, eligible []int) int64 {
+	type unit struct {
+		line  int
+		price int64
+	}
+
+	units := make([]unit, 0)
+	for _, i := range eligible {
+		for q := int64(0); q < lines[i].Quantity; q++ {
+			units = append(units, unit{line: i, price: lines[i].Price})
+		}
+	}
+
+	sort.SliceStable(units, func(i, j int) bool {
+		return units[i].price < units[j].price
+	})
+
+	freeUnits := int64(len(units)) / (coupon.BuyQuantity + coupon.GetQuantity) * coupon.GetQuantity
+
+	var discount int64
+	for _, u := range units[:freeUnits] {
+		price := u.price
+		if remaining := couponRemainingPrice(lines[u.line]); price > remaining {
+			price = remaining
+		}
+		lines[u.line].CouponDiscountPrice += price
+		discount += price
+	}
+	return discount
+}
+
+// allocateCouponDiscount spreads an order discount over the lines in proportion to what is left of their
+// price, the units lost to rounding go to the first lines that can still take them
+func allocateCouponDiscount(lines []domain.OrderProduct, eligible []int, discount, base int64) int64 {
+	if discount <= 0 {
+		return 0
+	}
+
+	shares := make([]int64, len(eligible))
+	var allocated int64
+	for k, i := range eligible {
+		shares[k] = discount * couponRemainingPrice(lines[i]) / base
+		allocated += shares[k]
+	}
+
+	for k, i := range eligible {
+		if allocated == discount {
+			break
+		}
+		if shares[k] < couponRemainingPrice(lines[i]) {
+			shares[k]++
+			allocated++
+		}
+	}
+
+	for k, i := range eligible {
+		lines[i].CouponDiscountPrice += shares[k]
+	}
+	return discount
+}
+
+func couponRemainingPrice(line domain.OrderProduct) int64 {
+	return line.Price*line.Quantity - line.CouponDiscountPrice
+}
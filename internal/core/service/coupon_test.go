@@ -0,0 +1,201 @@
+package service
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/mocks"
+	"github.com/danisbagus/matchoshop/utils/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var couponService = CouponService{repo: mockCouponRepo}
+
+func TestCoupon_Create_PercentageOver100(t *testing.T) {
+	form := &domain.Coupon{Code: "half", Name: "Half", Type: constants.CouponTypePercentage, Value: 150}
+
+	appErr := couponService.Create(form)
+	assert.NotNil(t, appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.Code)
+}
+
+func TestOrder_Create_PercentageCoupon(t *testing.T) {
+	form := &domain.OrderDetail{Order: domain.Order{UserID: 40}, ShipmentAddress: domain.ShipmentAddress{Country: "Malaysia"},
+		OrderProducts: []domain.OrderProduct{{ProductID: 110, Quantity: 2}}, CouponCodes: []string{" save10 "}}
+
+	mockTaxRuleRepo.Mock.On("GetAllByLocation", "Malaysia", "").Return([]domain.TaxRule{}, nil).Once()
+	mockProductRepo.Mock.On("GetOneByID", int64(110)).Return(&domain.ProductDetail{ProductModel: domain.ProductModel{ProductID: 110, Price: 30000, RegularPrice: 30000, Stock: 5, IsPublished: true}}, nil).Once()
+	mockCouponRepo.Mock.On("GetAllByCodes", []string{"SAVE10"}).Return([]domain.Coupon{
+		{CouponID: 1, Code: "SAVE10", Type: constants.CouponTypePercentage, Value: 10, MinSpend: 50000, IsActive: true},
+	}, nil).Once()
+	mockCouponRepo.Mock.On("CountRedemption", int64(1), int64(40)).Return(int64(0), int64(0), nil).Once()
+	mockShippingRepo.Mock.On("GetAllZoneByCountry", "Malaysia").Return([]domain.ShippingZone{}, nil).Once()
+	mockShippingRepo.Mock.On("CheckAnyZone").Return(false, nil).Once()
+	mockOrderRepo.Mock.On("Insert", mock.MatchedBy(func(order *domain.OrderDetail) bool {
+		return order.UserID == 40
+	}), mock.Anything).Return(int64(110), nil).Once()
+
+	order, appErr := orderService.Create(form)
+
+	assert.Nil(t, appErr)
+	assert.Equal(t, int64(6000), order.CouponDiscountPrice)
+	assert.Equal(t, int64(6000), order.OrderProducts[0].CouponDiscountPrice)
+	assert.Equal(t, int64(5400), order.TaxPrice)
+	assert.Equal(t, int64(0), order.ShippingPrice)
+	assert.Equal(t, int64(54000+5400), order.TotalPrice)
+	assert.Len(t, order.CouponRedemptions, 1)
+	assert.Equal(t, int64(6000), order.CouponRedemptions[0].DiscountPrice)
+}
+
+func TestOrder_Create_ScopedBuyXGetYCoupon(t *testing.T) {
+	form := &domain.OrderDetail{Order: domain.Order{UserID: 41}, ShipmentAddress: domain.ShipmentAddress{Country: "Thailand"},
+		OrderProducts: []domain.OrderProduct{{ProductID: 111, Quantity: 2}, {ProductID: 112, Quantity: 1}}, CouponCodes: []string{"B1G1"}}
+
+	mockTaxRuleRepo.Mock.On("GetAllByLocation", "Thailand", "").Return([]domain.TaxRule{}, nil).Once()
+	mockProductRepo.Mock.On("GetOneByID", int64(111)).Return(&domain.ProductDetail{ProductModel: domain.ProductModel{ProductID: 111, Price: 10000, RegularPrice: 10000, Stock: 5, IsPublished: true}}, nil).Once()
+	mockProductRepo.Mock.On("GetOneByID", int64(112)).Return(&domain.ProductDetail{ProductModel: domain.ProductModel{ProductID: 112, Price: 5000, RegularPrice: 5000, Stock: 5, IsPublished: true}}, nil).Once()
+	mockCouponRepo.Mock.On("GetAllByCodes", []string{"B1G1"}).Return([]domain.Coupon{
+		{CouponID: 2, Code: "B1G1", Type: constants.CouponTypeBuyXGetY, BuyQuantity: 1, GetQuantity: 1, ProductIDs: []int64{111}, IsActive: true},
+	}, nil).Once()
+	mockCouponRepo.Mock.On("CountRedemption", int64(2), int64(41)).Return(int64(0), int64(0), nil).Once()
+	mockCouponRepo.Mock.On("GetAllScopedProductID", int64(2), []int64{111, 112}).Return([]int64{111}, nil).Once()
+	mockShippingRepo.Mock.On("GetAllZoneByCountry", "Thailand").Return([]domain.ShippingZone{}, nil).Once()
+	mockShippingRepo.Mock.On("CheckAnyZone").Return(false, nil).Once()
+	mockOrderRepo.Mock.On("Insert", mock.MatchedBy(func(order *domain.OrderDetail) bool {
+		return order.UserID == 41
+	}), mock.Anything).Return(int64(111), nil).Once()
+
+	order, appErr := orderService.Create(form)
+
+	assert.Nil(t, appErr)
+	assert.Equal(t, int64(10000), order.OrderProducts[0].CouponDiscountPrice)
+	assert.Equal(t, int64(0), order.OrderProducts[1].CouponDiscountPrice)
+	assert.Equal(t, int64(2000), order.ShippingPrice)
+	assert.Equal(t, int64(15000+1500+2000), order.TotalPrice)
+}
+
+func TestOrder_Create_CouponNotStackable(t *testing.T) {
+	form := &domain.OrderDetail{Order: domain.Order{UserID: 42}, ShipmentAddress: domain.ShipmentAddress{Country: "Vietnam"},
+		OrderProducts: []domain.OrderProduct{{ProductID: 113, Quantity: 1}}, CouponCodes: []string{"SOLO", "EXTRA"}}
+
+	mockTaxRuleRepo.Mock.On("GetAllByLocation", "Vietnam", "").Return([]domain.TaxRule{}, nil).Once()
+	mockProductRepo.Mock.On("GetOneByID", int64(113)).Return(&domain.ProductDetail{ProductModel: domain.ProductModel{ProductID: 113, Price: 10000, RegularPrice: 10000, Stock: 5, IsPublished: true}}, nil).Once()
+	mockCouponRepo.Mock.On("GetAllByCodes", []string{"SOLO", "EXTRA"}).Return([]domain.Coupon{
+		{CouponID: 3, Code: "SOLO", Type: constants.CouponTypeFixed, Value: 1000, IsActive: true},
+		{CouponID: 4, Code: "EXTRA", Type: constants.CouponTypeFixed, Value: 1000, IsStackable: true, IsActive: true},
+	}, nil).Once()
+
+	order, appErr := orderService.Create(form)
+
+	assert.Nil(t, order)
+	assert.NotNil(t, appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.Code)
+}
+
+func TestOrder_Create_CouponLimitReached(t *testing.T) {
+	form := &domain.OrderDetail{Order: domain.Order{UserID: 43}, ShipmentAddress: domain.ShipmentAddress{Country: "Laos"},
+		OrderProducts: []domain.OrderProduct{{ProductID: 114, Quantity: 1}}, CouponCodes: []string{"FIRST5"}}
+
+	mockTaxRuleRepo.Mock.On("GetAllByLocation", "Laos", "").Return([]domain.TaxRule{}, nil).Once()
+	mockProductRepo.Mock.On("GetOneByID", int64(114)).Return(&domain.ProductDetail{ProductModel: domain.ProductModel{ProductID: 114, Price: 10000, RegularPrice: 10000, Stock: 5, IsPublished: true}}, nil).Once()
+	mockCouponRepo.Mock.On("GetAllByCodes", []string{"FIRST5"}).Return([]domain.Coupon{
+		{CouponID: 5, Code: "FIRST5", Type: constants.CouponTypeFixed, Value: 1000, UsageLimit: 5, IsActive: true},
+	}, nil).Once()
+	mockCouponRepo.Mock.On("CountRedemption", int64(5), int64(43)).Return(int64(5), int64(0), nil).Once()
+
+	order, appErr := orderService.Create(form)
+
+	assert.Nil(t, order)
+	assert.NotNil(t, appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.Code)
+}
+
+func TestOrder_Create_FreeShippingCoupon(t *testing.T) {
+	form := &domain.OrderDetail{Order: domain.Order{UserID: 44}, ShipmentAddress: domain.ShipmentAddress{Country: "Brunei"},
+		OrderProducts: []domain.OrderProduct{{ProductID: 115, Quantity: 1}}, CouponCodes: []string{"SHIPFREE"}}
+
+	mockTaxRuleRepo.Mock.On("GetAllByLocation", "Brunei", "").Return([]domain.TaxRule{}, nil).Once()
+	mockProductRepo.Mock.On("GetOneByID", int64(115)).Return(&domain.ProductDetail{ProductModel: domain.ProductModel{ProductID: 115, Price: 10000, RegularPrice: 10000, Stock: 5, IsPublished: true}}, nil).Once()
+	mockCouponRepo.Mock.On("GetAllByCodes", []string{"SHIPFREE"}).Return([]domain.Coupon{
+		{CouponID: 6, Code: "SHIPFREE", Type: constants.CouponTypeFreeShipping, IsActive: true},
+	}, nil).Once()
+	mockCouponRepo.Mock.On("CountRedemption", int64(6), int64(44)).Return(int64(0), int64(0), nil).Once()
+	mockShippingRepo.Mock.On("GetAllZoneByCountry", "Brunei").Return([]domain.ShippingZone{}, nil).Once()
+	mockShippingRepo.Mock.On("CheckAnyZone").Return(false, nil).Once()
+	mockOrderRepo.Mock.On("Insert", mock.MatchedBy(func(order *domain.OrderDetail) bool {
+		return order.UserID == 44
+	}), mock.Anything).Return(int64(112), nil).Once()
+
+	order, appErr := orderService.Create(form)
+
+	assert.Nil(t, appErr)
+	assert.Equal(t, int64(2000), order.ShippingPrice)
+	assert.Equal(t, int64(2000), order.ShippingDiscountPrice)
+	assert.Equal(t, int64(2000), order.CouponRedemptions[0].DiscountPrice)
+	assert.Equal(t, int64(10000+1000), order.TotalPrice)
+}
+
+func TestOrder_Create_AutomaticCoupon(t *testing.T) {
+	couponRepo := &mocks.CouponRepo{Mock: mock.Mock{}}
+	service := orderService
+	service.repoCoupon = couponRepo
+
+	form := &domain.OrderDetail{Order: domain.Order{UserID: 47}, ShipmentAddress: domain.ShipmentAddress{Country: "Cambodia"},
+		OrderProducts: []domain.OrderProduct{{ProductID: 128, Quantity: 1}}}
+
+	mockTaxRuleRepo.Mock.On("GetAllByLocation", "Cambodia", "").Return([]domain.TaxRule{}, nil).Once()
+	mockProductRepo.Mock.On("GetOneByID", int64(128)).Return(&domain.ProductDetail{ProductModel: domain.ProductModel{ProductID: 128, Price: 20000, RegularPrice: 20000, Stock: 5, IsPublished: true}}, nil).Once()
+	couponRepo.Mock.On("GetAllAutomatic").Return([]domain.Coupon{
+		{CouponID: 8, Code: "BIGSPENDER", Type: constants.CouponTypeFixed, Value: 5000, MinSpend: 100000, IsStackable: true, IsAutomatic: true, IsActive: true},
+		{CouponID: 7, Code: "WEEKEND", Type: constants.CouponTypeFixed, Value: 1000, IsStackable: true, IsAutomatic: true, IsActive: true},
+	}, nil).Once()
+	couponRepo.Mock.On("CountRedemption", int64(7), int64(47)).Return(int64(0), int64(0), nil).Once()
+	mockShippingRepo.Mock.On("GetAllZoneByCountry", "Cambodia").Return([]domain.ShippingZone{}, nil).Once()
+	mockShippingRepo.Mock.On("CheckAnyZone").Return(false, nil).Once()
+	mockOrderRepo.Mock.On("Insert", mock.MatchedBy(func(order *domain.OrderDetail) bool {
+		return order.UserID == 47
+	}), mock.Anything).Return(int64(118), nil).Once()
+
+	order, appErr := service.Create(form)
+
+	// the minimum spend of BIGSPENDER is not met, it is left out without failing the order
+	assert.Nil(t, appErr)
+	assert.Equal(t, int64(1000), order.CouponDiscountPrice)
+	assert.Len(t, order.CouponRedemptions, 1)
+	assert.Equal(t, "WEEKEND", order.CouponRedemptions[0].Code)
+	couponRepo.AssertNotCalled(t, "CountRedemption", int64(8), int64(47))
+}
+
+func TestOrder_Create_AutomaticCouponNotStacked(t *testing.T) {
+	couponRepo := &mocks.CouponRepo{Mock: mock.Mock{}}
+	service := orderService
+	service.repoCoupon = couponRepo
+
+	form := &domain.OrderDetail{Order: domain.Order{UserID: 48}, ShipmentAddress: domain.ShipmentAddress{Country: "Myanmar"},
+		OrderProducts: []domain.OrderProduct{{ProductID: 129, Quantity: 1}}, CouponCodes: []string{"ONLYME"}}
+
+	mockTaxRuleRepo.Mock.On("GetAllByLocation", "Myanmar", "").Return([]domain.TaxRule{}, nil).Once()
+	mockProductRepo.Mock.On("GetOneByID", int64(129)).Return(&domain.ProductDetail{ProductModel: domain.ProductModel{ProductID: 129, Price: 20000, RegularPrice: 20000, Stock: 5, IsPublished: true}}, nil).Once()
+	couponRepo.Mock.On("GetAllByCodes", []string{"ONLYME"}).Return([]domain.Coupon{
+		{CouponID: 9, Code: "ONLYME", Type: constants.CouponTypeFixed, Value: 3000, IsActive: true},
+	}, nil).Once()
+	couponRepo.Mock.On("GetAllAutomatic").Return([]domain.Coupon{
+		{CouponID: 10, Code: "EVERYDAY", Type: constants.CouponTypeFixed, Value: 1000, IsStackable: true, IsAutomatic: true, IsActive: true},
+	}, nil).Once()
+	couponRepo.Mock.On("CountRedemption", int64(9), int64(48)).Return(int64(0), int64(0), nil).Once()
+	mockShippingRepo.Mock.On("GetAllZoneByCountry", "Myanmar").Return([]domain.ShippingZone{}, nil).Once()
+	mockShippingRepo.Mock.On("CheckAnyZone").Return(false, nil).Once()
+	mockOrderRepo.Mock.On("Insert", mock.MatchedBy(func(order *domain.OrderDetail) bool {
+		return order.UserID == 48
+	}), mock.Anything).Return(int64(119), nil).Once()
+
+	order, appErr := service.Create(form)
+
+	// ONLYME is not stackable so EVERYDAY does not join it
+	assert.Nil(t, appErr)
+	assert.Equal(t, int64(3000), order.CouponDiscountPrice)
+	assert.Len(t, order.CouponRedemptions, 1)
+	couponRepo.AssertNotCalled(t, "CountRedemption", int64(10), int64(48))
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/go-common-packages/logger"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/danisbagus/matchoshop/utils/constants"
+)
+
+type IdempotencyKeyService struct {
+	repo port.IdempotencyKeyRepo
+}
+
+func NewIdempotencyKeyService(repo port.IdempotencyKeyRepo) port.IdempotencyKeyService {
+	return &IdempotencyKeyService{
+		repo: repo,
+	}
+}
+
+// Begin claims the key for the request. It returns nil when the request should run, or the stored key
+// whose response is to be replayed. A key reused for another request or still running is rejected.
+func (s IdempotencyKeyService) Begin(form *domain.IdempotencyKey) (*domain.IdempotencyKey, *errs.AppError) {
+	if len(form.Key) > constants.IdempotencyKeyMaxLength {
+		return nil, errs.NewBadRequestError(fmt.Sprintf("%s maximum length is %d", constants.IdempotencyKeyHeader, constants.IdempotencyKeyMaxLength))
+	}
+
+	form.CreatedAt = time.Now()
+	form.ExpiresAt = form.CreatedAt.Add(constants.IdempotencyKeyDuration)
+
+	claimed, appErr := s.repo.Insert(form)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if claimed {
+		return nil, nil
+	}
+
+	idempotencyKey, appErr := s.repo.GetOneByKey(form.UserID, form.Key)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if idempotencyKey.RequestHash != form.RequestHash {
+		return nil, errs.NewValidationError(fmt.Sprintf("%s was already used for a different request", constants.IdempotencyKeyHeader))
+	}
+
+	if idempotencyKey.CompletedAt == nil {
+		return nil, &errs.AppError{Code: http.StatusConflict, Message: "A request with this " + constants.IdempotencyKeyHeader + " is still in progress"}
+	}
+
+	return idempotencyKey, nil
+}
+
+func (s IdempotencyKeyService) Complete(form *domain.IdempotencyKey) *errs.AppError {
+	completedAt := time.Now()
+	form.CompletedAt = &completedAt
+
+	return s.repo.Complete(form)
+}
+
+// Release gives the key up after a request that failed unexpectedly, so the client can retry with it
+func (s IdempotencyKeyService) Release(form *domain.IdempotencyKey) *errs.AppError {
+	return s.repo.Delete(form.IdempotencyKeyID)
+}
+
+func (s IdempotencyKeyService) DeleteExpired() (int64, *errs.AppError) {
+	deleted, appErr := s.repo.DeleteExpired(time.Now())
+	if appErr != nil {
+		return 0, appErr
+	}
+
+	if deleted > 0 {
+		logger.Info(fmt.Sprintf("Deleted %d expired idempotency key", deleted))
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var mockIdempotencyKeyRepo = &mocks.IdempotencyKeyRepo{Mock: mock.Mock{}}
+var idempotencyKeyService = IdempotencyKeyService{repo: mockIdempotencyKeyRepo}
+
+func TestIdempotencyKey_Begin_Claimed(t *testing.T) {
+	form := &domain.IdempotencyKey{UserID: 50, Key: "order-1", RequestHash: "a"}
+
+	mockIdempotencyKeyRepo.Mock.On("Insert", form).Return(true, nil).Once()
+
+	stored, appErr := idempotencyKeyService.Begin(form)
+	assert.Nil(t, appErr)
+	assert.Nil(t, stored)
+}
+
+func TestIdempotencyKey_Begin_Replay(t *testing.T) {
+	completedAt := time.Now()
+	form := &domain.IdempotencyKey{UserID: 51, Key: "order-2", RequestHash: "a"}
+
+	mockIdempotencyKeyRepo.Mock.On("Insert", form).Return(false, nil).Once()
+	mockIdempotencyKeyRepo.Mock.On("GetOneByKey", int64(51), "order-2").Return(&domain.IdempotencyKey{UserID: 51, Key: "order-2", RequestHash: "a",
+		StatusCode: http.StatusOK, ResponseBody: []byte(`{"message":"ok"}`), CompletedAt: &completedAt}, nil).Once()
+
+	stored, appErr := idempotencyKeyService.Begin(form)
+	assert.Nil(t, appErr)
+	assert.Equal(t, http.StatusOK, stored.StatusCode)
+	assert.Equal(t, `{"message":"ok"}`, string(stored.ResponseBody))
+}
+
+func TestIdempotencyKey_Begin_DifferentRequest(t *testing.T) {
+	completedAt := time.Now()
+	form := &domain.IdempotencyKey{UserID: 52, Key: "order-3", RequestHash: "b"}
+
+	mockIdempotencyKeyRepo.Mock.On("Insert", form).Return(false, nil).Once()
+	mockIdempotencyKeyRepo.Mock.On("GetOneByKey", int64(52), "order-3").Return(&domain.IdempotencyKey{UserID: 52, Key: "order-3", RequestHash: "a",
+		StatusCode: http.StatusOK, CompletedAt: &completedAt}, nil).Once()
+
+	stored, appErr := idempotencyKeyService.Begin(form)
+	assert.Nil(t, stored)
+	assert.NotNil(t, appErr)
+	assert.Equal(t, http.StatusUnprocessableEntity, appErr.Code)
+}
+
+func TestIdempotencyKey_Begin_InProgress(t *testing.T) {
+	form := &domain.IdempotencyKey{UserID: 53, Key: "order-4", RequestHash: "a"}
+
+	mockIdempotencyKeyRepo.Mock.On("Insert", form).Return(false, nil).Once()
+	mockIdempotencyKeyRepo.Mock.On("GetOneByKey", int64(53), "order-4").Return(&domain.IdempotencyKey{UserID: 53, Key: "order-4", RequestHash: "a"}, nil).Once()
+
+	stored, appErr := idempotencyKeyService.Begin(form)
+	assert.Nil(t, stored)
+	assert.NotNil(t, appErr)
+	assert.Equal(t, http.StatusConflict, appErr.Code)
+}
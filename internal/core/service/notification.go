@@ -0,0 +1,186 @@
+package service
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/go-common-packages/logger"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/danisbagus/matchoshop/utils/constants"
+	"github.com/danisbagus/matchoshop/utils/helper"
+)
+
+const notificationTemplateDir = "templates/notification"
+
+// notification templates are laid out as <locale>/<name>.txt and <locale>/<name>.html, the text template
+// also defines the subject and the HTML one the content of the shared layout
+//
+//go:embed templates/notification
+var notificationTemplateFS embed.FS
+
+type NotificationService struct {
+	repo        port.NotificationEventRepo
+	notifier    port.Notifier
+	repoUser    port.UserRepo
+	templates   map[string]notificationTemplate
+	storeName   string
+	currency    string
+	maxAttempts int
+	retryDelay  time.Duration
+}
+
+type notificationTemplate struct {
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
+func NewNotificationService(repo port.NotificationEventRepo, notifier port.Notifier, repoUser port.UserRepo) port.NotificationService {
+	return &NotificationService{
+		repo:        repo,
+		notifier:    notifier,
+		repoUser:    repoUser,
+		templates:   parseNotificationTemplates(),
+		storeName:   helper.EnvSellerName(),
+		currency:    helper.EnvPaymentCurrency(),
+		maxAttempts: helper.EnvNotificationMaxAttempts(),
+		retryDelay:  constants.NotificationRetryDelay,
+	}
+}
+
+// SendPending sends the notification events that are due. An event the notifier failed is due again after a
+// delay that doubles on every attempt until it runs out of attempts, one that was rejected is given up.
+func (s NotificationService) SendPending() *errs.AppError {
+	now := time.Now()
+	events, appErr := s.repo.ClaimDue(now, now.Add(constants.NotificationClaimDuration), constants.NotificationBatchSize)
+	if appErr != nil {
+		return appErr
+	}
+
+	for i := range events {
+		event := &events[i]
+		sendErr := s.Send(event)
+
+		event.Attempts++
+		event.UpdatedAt = time.Now()
+		if sendErr == nil {
+			event.Status = constants.NotificationEventStatusSent
+			event.SentAt = &event.UpdatedAt
+			event.LastError = nil
+		} else {
+			logger.Error(fmt.Sprintf("Error while send notification %s to user %d, attempt %d of %d: %s", event.Template, event.UserID, event.Attempts, s.maxAttempts, sendErr.Message))
+			event.LastError = &sendErr.Message
+			event.NextAttemptAt = event.UpdatedAt.Add(s.retryDelay << (event.Attempts - 1))
+			if sendErr.Code < http.StatusInternalServerError || event.Attempts >= int64(s.maxAttempts) {
+				event.Status = constants.NotificationEventStatusFailed
+			}
+		}
+
+		appErr = s.repo.UpdateStatus(event)
+		if appErr != nil {
+			return appErr
+		}
+	}
+
+	return nil
+}
+
+// Send renders the notification in the locale of the user and sends it once, an error below 500 means the
+// notification was rejected and is not worth trying again
+func (s NotificationService) Send(event *domain.NotificationEvent) *errs.AppError {
+	user, appErr := s.repoUser.FindOneById(event.UserID)
+	if appErr != nil {
+		return appErr
+	}
+
+	if user.UserID == 0 {
+		return errs.NewNotFoundError("User not found!")
+	}
+
+	message, err := s.render(user, event)
+	if err != nil {
+		logger.Error("Error while render notification " + event.Template + ": " + err.Error())
+		return errs.NewUnexpectedError("Unexpected notification template error")
+	}
+
+	return s.notifier.Send(message)
+}
+
+// render falls back to the default locale when the template has no variant in the user locale
+func (s NotificationService) render(user *domain.User, event *domain.NotificationEvent) (*domain.Notification, error) {
+	locale := user.Locale
+	template, ok := s.templates[locale+"/"+event.Template]
+	if !ok {
+		locale = constants.DefaultLocale
+		template, ok = s.templates[locale+"/"+event.Template]
+	}
+	if !ok {
+		return nil, fmt.Errorf("template %s not found", event.Template)
+	}
+
+	data := map[string]interface{}{
+		"Name":      user.Name,
+		"Locale":    locale,
+		"StoreName": s.storeName,
+		"Currency":  s.currency,
+	}
+	for key, value := range event.Data {
+		data[key] = value
+	}
+
+	var subject, text, html bytes.Buffer
+	if err := template.text.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return nil, err
+	}
+	if err := template.text.Execute(&text, data); err != nil {
+		return nil, err
+	}
+	if err := template.html.ExecuteTemplate(&html, "layout", data); err != nil {
+		return nil, err
+	}
+
+	return &domain.Notification{
+		To:       user.Email,
+		ToName:   user.Name,
+		Locale:   locale,
+		Template: event.Template,
+		Subject:  strings.TrimSpace(subject.String()),
+		TextBody: text.String(),
+		HTMLBody: html.String(),
+	}, nil
+}
+
+// parseNotificationTemplates panics on a broken template since they are embedded in the binary
+func parseNotificationTemplates() map[string]notificationTemplate {
+	textFuncs := texttemplate.FuncMap{"price": formatThousand}
+	htmlFuncs := htmltemplate.FuncMap{"price": formatThousand}
+	layout := path.Join(notificationTemplateDir, "layout.html")
+
+	templates := make(map[string]notificationTemplate)
+	textFiles, err := fs.Glob(notificationTemplateFS, path.Join(notificationTemplateDir, "*", "*.txt"))
+	if err != nil {
+		panic(err)
+	}
+
+	for _, textFile := range textFiles {
+		locale := path.Base(path.Dir(textFile))
+		name := strings.TrimSuffix(path.Base(textFile), ".txt")
+		htmlFile := path.Join(notificationTemplateDir, locale, name+".html")
+
+		templates[locale+"/"+name] = notificationTemplate{
+			text: texttemplate.Must(texttemplate.New(path.Base(textFile)).Funcs(textFuncs).Option("missingkey=error").ParseFS(notificationTemplateFS, textFile)),
+			html: htmltemplate.Must(htmltemplate.New(path.Base(layout)).Funcs(htmlFuncs).Option("missingkey=error").ParseFS(notificationTemplateFS, layout, htmlFile)),
+		}
+	}
+
+	return templates
+}
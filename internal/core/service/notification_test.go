@@ -0,0 +1,158 @@
+package service
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/mocks"
+	"github.com/danisbagus/matchoshop/utils/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var mockNotifier = &mocks.Notifier{Mock: mock.Mock{}}
+var mockNotificationEventRepo = &mocks.NotificationEventRepo{Mock: mock.Mock{}}
+var notificationService = NotificationService{repo: mockNotificationEventRepo, notifier: mockNotifier, repoUser: mockUserRepo, templates: parseNotificationTemplates(),
+	storeName: "Matchoshop", currency: "USD", maxAttempts: 3, retryDelay: time.Minute}
+
+func TestNotification_Templates(t *testing.T) {
+	templates := []string{constants.NotificationUserRegistered, constants.NotificationUserUpdated, constants.NotificationOrderCreated,
+		constants.NotificationOrderPaid, constants.NotificationOrderStatusUpdated, constants.NotificationOrderCancelled, constants.NotificationBackInStock,
+		constants.NotificationLowStock}
+	data := map[string]interface{}{"OrderID": int64(1), "TotalPrice": int64(1000), "PayBefore": "2026-10-19 10:00:00", "Status": constants.OrderStatusShipped,
+		"Reason": "Out of stock", "RefundPrice": int64(1000), "ProductID": int64(1), "ProductName": "Polo", "Stock": int64(2)}
+
+	for _, locale := range []string{constants.LocaleEnglish, constants.LocaleIndonesian} {
+		for _, template := range templates {
+			message, err := notificationService.render(&domain.User{Name: "Budi", Email: "budi@mail.com", Locale: locale}, &domain.NotificationEvent{Template: template, Data: data})
+			assert.Nil(t, err, locale+"/"+template)
+			assert.Equal(t, locale, message.Locale)
+			assert.NotEmpty(t, message.Subject)
+			assert.Contains(t, message.TextBody, "Budi")
+			assert.Contains(t, message.HTMLBody, "Budi")
+		}
+	}
+}
+
+func TestNotification_Send_UserLocale(t *testing.T) {
+	mockUserRepo.Mock.On("FindOneById", int64(70)).Return(&domain.User{UserID: 70, Name: "Siti", Email: "siti@mail.com", Locale: constants.LocaleIndonesian}, nil).Once()
+	mockNotifier.Mock.On("Send", mock.MatchedBy(func(message *domain.Notification) bool {
+		return message.To == "siti@mail.com"
+	})).Return(nil).Once()
+
+	appErr := notificationService.Send(&domain.NotificationEvent{UserID: 70, Template: constants.NotificationOrderPaid, Data: map[string]interface{}{
+		"OrderID": int64(700), "TotalPrice": int64(1250000)}})
+
+	assert.Nil(t, appErr)
+	mockNotifier.AssertCalled(t, "Send", mock.MatchedBy(func(message *domain.Notification) bool {
+		return message.To == "siti@mail.com" && message.Subject == "Pembayaran pesanan #700 diterima" && strings.Contains(message.HTMLBody, "USD 1,250,000")
+	}))
+}
+
+func TestNotification_Send_FallbackLocale(t *testing.T) {
+	mockUserRepo.Mock.On("FindOneById", int64(71)).Return(&domain.User{UserID: 71, Name: "Jean", Email: "jean@mail.com", Locale: "fr"}, nil).Once()
+	mockNotifier.Mock.On("Send", mock.MatchedBy(func(message *domain.Notification) bool {
+		return message.To == "jean@mail.com"
+	})).Return(nil).Once()
+
+	appErr := notificationService.Send(&domain.NotificationEvent{UserID: 71, Template: constants.NotificationUserRegistered})
+
+	assert.Nil(t, appErr)
+	mockNotifier.AssertCalled(t, "Send", mock.MatchedBy(func(message *domain.Notification) bool {
+		return message.To == "jean@mail.com" && message.Locale == constants.LocaleEnglish && message.Subject == "Welcome to Matchoshop"
+	}))
+}
+
+func TestNotification_SendPending_Sent(t *testing.T) {
+	mockNotificationEventRepo.Mock.On("ClaimDue", mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time"), int64(constants.NotificationBatchSize)).
+		Return([]domain.NotificationEvent{{NotificationEventID: 1, UserID: 72, Template: constants.NotificationUserUpdated, Status: constants.NotificationEventStatusPending}}, nil).Once()
+	mockUserRepo.Mock.On("FindOneById", int64(72)).Return(&domain.User{UserID: 72, Name: "Andi", Email: "sent@mail.com"}, nil).Once()
+	mockNotifier.Mock.On("Send", mock.MatchedBy(func(message *domain.Notification) bool {
+		return message.To == "sent@mail.com"
+	})).Return(nil).Once()
+	mockNotificationEventRepo.Mock.On("UpdateStatus", mock.MatchedBy(func(event *domain.NotificationEvent) bool {
+		return event.NotificationEventID == 1
+	})).Return(nil).Once()
+
+	appErr := notificationService.SendPending()
+
+	assert.Nil(t, appErr)
+	mockNotificationEventRepo.AssertCalled(t, "UpdateStatus", mock.MatchedBy(func(event *domain.NotificationEvent) bool {
+		return event.NotificationEventID == 1 && event.Status == constants.NotificationEventStatusSent && event.Attempts == 1 && event.SentAt != nil
+	}))
+}
+
+func TestNotification_SendPending_Retry(t *testing.T) {
+	mockNotificationEventRepo.Mock.On("ClaimDue", mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time"), int64(constants.NotificationBatchSize)).
+		Return([]domain.NotificationEvent{{NotificationEventID: 2, UserID: 74, Template: constants.NotificationUserUpdated, Status: constants.NotificationEventStatusPending, Attempts: 1}}, nil).Once()
+	mockUserRepo.Mock.On("FindOneById", int64(74)).Return(&domain.User{UserID: 74, Name: "Andi", Email: "retry@mail.com"}, nil).Once()
+	mockNotifier.Mock.On("Send", mock.MatchedBy(func(message *domain.Notification) bool {
+		return message.To == "retry@mail.com"
+	})).Return(errs.NewUnexpectedError("Unexpected email error")).Once()
+	mockNotificationEventRepo.Mock.On("UpdateStatus", mock.MatchedBy(func(event *domain.NotificationEvent) bool {
+		return event.NotificationEventID == 2
+	})).Return(nil).Once()
+
+	appErr := notificationService.SendPending()
+
+	// the second attempt failed, the third is due after twice the retry delay
+	assert.Nil(t, appErr)
+	mockNotificationEventRepo.AssertCalled(t, "UpdateStatus", mock.MatchedBy(func(event *domain.NotificationEvent) bool {
+		return event.NotificationEventID == 2 && event.Status == constants.NotificationEventStatusPending && event.Attempts == 2 &&
+			event.NextAttemptAt.Sub(event.UpdatedAt) == 2*time.Minute && *event.LastError == "Unexpected email error"
+	}))
+}
+
+func TestNotification_SendPending_OutOfAttempts(t *testing.T) {
+	mockNotificationEventRepo.Mock.On("ClaimDue", mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time"), int64(constants.NotificationBatchSize)).
+		Return([]domain.NotificationEvent{{NotificationEventID: 3, UserID: 75, Template: constants.NotificationUserUpdated, Status: constants.NotificationEventStatusPending, Attempts: 2}}, nil).Once()
+	mockUserRepo.Mock.On("FindOneById", int64(75)).Return(&domain.User{UserID: 75, Name: "Andi", Email: "failed@mail.com"}, nil).Once()
+	mockNotifier.Mock.On("Send", mock.MatchedBy(func(message *domain.Notification) bool {
+		return message.To == "failed@mail.com"
+	})).Return(errs.NewUnexpectedError("Unexpected email error")).Once()
+	mockNotificationEventRepo.Mock.On("UpdateStatus", mock.MatchedBy(func(event *domain.NotificationEvent) bool {
+		return event.NotificationEventID == 3
+	})).Return(nil).Once()
+
+	appErr := notificationService.SendPending()
+
+	assert.Nil(t, appErr)
+	mockNotificationEventRepo.AssertCalled(t, "UpdateStatus", mock.MatchedBy(func(event *domain.NotificationEvent) bool {
+		return event.NotificationEventID == 3 && event.Status == constants.NotificationEventStatusFailed && event.Attempts == 3
+	}))
+}
+
+func TestNotification_SendPending_Rejected(t *testing.T) {
+	mockNotificationEventRepo.Mock.On("ClaimDue", mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time"), int64(constants.NotificationBatchSize)).
+		Return([]domain.NotificationEvent{{NotificationEventID: 4, UserID: 76, Template: constants.NotificationUserUpdated, Status: constants.NotificationEventStatusPending}}, nil).Once()
+	mockUserRepo.Mock.On("FindOneById", int64(76)).Return(&domain.User{UserID: 76, Name: "Dewi", Email: "bounced@mail.com"}, nil).Once()
+	mockNotifier.Mock.On("Send", mock.MatchedBy(func(message *domain.Notification) bool {
+		return message.To == "bounced@mail.com"
+	})).Return(errs.NewBadRequestError("Email was rejected: mailbox unavailable")).Once()
+	mockNotificationEventRepo.Mock.On("UpdateStatus", mock.MatchedBy(func(event *domain.NotificationEvent) bool {
+		return event.NotificationEventID == 4
+	})).Return(nil).Once()
+
+	appErr := notificationService.SendPending()
+
+	assert.Nil(t, appErr)
+	mockNotificationEventRepo.AssertCalled(t, "UpdateStatus", mock.MatchedBy(func(event *domain.NotificationEvent) bool {
+		return event.NotificationEventID == 4 && event.Status == constants.NotificationEventStatusFailed && event.Attempts == 1
+	}))
+}
+
+func TestNotification_Send_Rejected(t *testing.T) {
+	mockUserRepo.Mock.On("FindOneById", int64(73)).Return(&domain.User{UserID: 73, Name: "Dewi", Email: "rejected@mail.com"}, nil).Once()
+	mockNotifier.Mock.On("Send", mock.MatchedBy(func(message *domain.Notification) bool {
+		return message.To == "rejected@mail.com"
+	})).Return(errs.NewBadRequestError("Email was rejected: mailbox unavailable")).Once()
+
+	appErr := notificationService.Send(&domain.NotificationEvent{UserID: 73, Template: constants.NotificationUserUpdated})
+
+	assert.NotNil(t, appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.Code)
+}
@@ -1,6 +1,9 @@
 package service
 
 import (
+	"fmt"
+	"net/http"
+	"strings"
 	"sync"
 	"time"
 
@@ -8,45 +11,81 @@ import (
 	"github.com/danisbagus/go-common-packages/logger"
 	"github.com/danisbagus/matchoshop/internal/core/domain"
 	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/danisbagus/matchoshop/utils/constants"
+	"github.com/danisbagus/matchoshop/utils/helper"
 )
 
 type (
 	OrderService struct {
-		repo              port.OrderRepo
-		repoOrderProduct  port.OrderProductRepo
-		repoPaymentResult port.PaymentResultRepo
-		repoProduct       port.ProductRepo
+		repo                 port.OrderRepo
+		repoOrderProduct     port.OrderProductRepo
+		repoPaymentResult    port.PaymentResultRepo
+		repoProduct          port.ProductRepo
+		repoTaxRule          port.TaxRuleRepo
+		repoShipping         port.ShippingRepo
+		repoCoupon           port.CouponRepo
+		repoStockReservation port.StockReservationRepo
+		refundService        port.RefundService
+		taxRate              float64
+		reducedTaxRate       float64
+		shippingPrice        int64
+		freeShippingMinimum  int64
 	}
 )
 
-func NewOrderService(repo port.OrderRepo, repoOrderProduct port.OrderProductRepo, repoPaymentResult port.PaymentResultRepo, repoProduct port.ProductRepo) port.OrderService {
+func NewOrderService(repo port.OrderRepo, repoOrderProduct port.OrderProductRepo, repoPaymentResult port.PaymentResultRepo, repoProduct port.ProductRepo, repoTaxRule port.TaxRuleRepo, repoShipping port.ShippingRepo, repoCoupon port.CouponRepo, repoStockReservation port.StockReservationRepo, refundService port.RefundService) port.OrderService {
 	return &OrderService{
-		repo:              repo,
-		repoOrderProduct:  repoOrderProduct,
-		repoPaymentResult: repoPaymentResult,
-		repoProduct:       repoProduct,
+		repo:                 repo,
+		repoOrderProduct:     repoOrderProduct,
+		repoPaymentResult:    repoPaymentResult,
+		repoProduct:          repoProduct,
+		repoTaxRule:          repoTaxRule,
+		repoShipping:         repoShipping,
+		repoCoupon:           repoCoupon,
+		repoStockReservation: repoStockReservation,
+		refundService:        refundService,
+		taxRate:              helper.EnvOrderTaxRate(),
+		reducedTaxRate:       helper.EnvOrderReducedTaxRate(),
+		shippingPrice:        helper.EnvOrderShippingPrice(),
+		freeShippingMinimum:  helper.EnvOrderFreeShippingMinimum(),
 	}
 }
 
+// Create prices the order on the server, a total sent by the client is only compared against the computed
+// total so a stale or tampered checkout is rejected instead of stored
 func (s OrderService) Create(form *domain.OrderDetail) (*domain.OrderDetail, *errs.AppError) {
+	expectedTotalPrice := form.TotalPrice
 
-	// validate stock
-	for _, orderProduct := range form.OrderProducts {
-		product, appErr := s.repoProduct.GetOneByID(orderProduct.ProductID)
-		if appErr != nil {
-			return nil, appErr
-		}
+	appErr := s.calculatePrice(form)
+	if appErr != nil {
+		return nil, appErr
+	}
 
-		if product.Stock < orderProduct.Quantity {
-			logger.Error("Failed while create order: insufficient product stock")
-			return nil, errs.NewBadRequestError("Insufficient product stock")
-		}
+	if expectedTotalPrice != 0 && expectedTotalPrice != form.TotalPrice {
+		logger.Error(fmt.Sprintf("Failed while create order: total price %d does not match %d", expectedTotalPrice, form.TotalPrice))
+		return nil, errs.NewBadRequestError(fmt.Sprintf("Order total has changed to %d, please review the order", form.TotalPrice))
 	}
 
+	form.Order.Status = constants.OrderStatusPendingPayment
 	form.CreatedAt = time.Now()
 	form.UpdatedAt = time.Now()
 
-	orderID, appErr := s.repo.Insert(form)
+	// stock is reserved together with the order and released once unpaid past the deadline, the serverless
+	// handler has no sweeper running so stock held by expired orders is released before reserving
+	reservationExpiresAt := form.CreatedAt.Add(constants.StockReservationDuration)
+	form.ReservationExpiresAt = &reservationExpiresAt
+
+	_, appErr = s.repoStockReservation.ReleaseExpired(form.CreatedAt)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	notification := &domain.NotificationEvent{UserID: form.UserID, Template: constants.NotificationOrderCreated, CreatedAt: form.CreatedAt, Data: map[string]interface{}{
+		"TotalPrice": form.TotalPrice,
+		"PayBefore":  reservationExpiresAt.Format(constants.DATE_TIME_FORMAT),
+	}}
+
+	orderID, appErr := s.repo.Insert(form, notification)
 	if appErr != nil {
 		return nil, appErr
 	}
@@ -55,13 +94,19 @@ func (s OrderService) Create(form *domain.OrderDetail) (*domain.OrderDetail, *er
 	return form, nil
 }
 
-func (s OrderService) GetList() ([]domain.OrderDetail, *errs.AppError) {
-	orders, appErr := s.repo.GetAll()
+// QuoteShipping prices the lines like Create does and returns the shipping methods the order can be shipped
+// with, the storefront shows them before checkout so one can be chosen
+func (s OrderService) QuoteShipping(form *domain.OrderDetail) ([]domain.ShippingQuote, *errs.AppError) {
+	appErr := s.calculateProductPrice(form)
 	if appErr != nil {
 		return nil, appErr
 	}
 
-	return orders, nil
+	return s.getShippingQuotes(form)
+}
+
+func (s OrderService) GetListPaginate(criteria *domain.OrderListCriteria) ([]domain.OrderDetail, int64, *errs.AppError) {
+	return s.repo.GetAllPaginate(criteria)
 }
 
 func (s OrderService) GetListByUser(userID int64) ([]domain.OrderDetail, *errs.AppError) {
@@ -122,17 +167,50 @@ func (s OrderService) GetDetail(ID int64) (*domain.OrderDetail, *errs.AppError)
 		orderProducts = dataChan
 	}
 
+	statusHistories, appErr := s.repo.GetAllStatusHistoryByOrderID(ID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	couponRedemptions, appErr := s.repoCoupon.GetAllRedemptionByOrderID(ID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
 	order.OrderProducts = orderProducts
+	order.StatusHistories = statusHistories
+	order.CouponRedemptions = couponRedemptions
 	return order, nil
 }
 
+// GetDetailByUser returns the order to its owner only, the detail carries the status history and the
+// customer email
+func (s OrderService) GetDetailByUser(ID, userID int64) (*domain.OrderDetail, *errs.AppError) {
+	order, appErr := s.GetDetail(ID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if order.UserID != userID {
+		return nil, errs.NewNotFoundError("Order not found!")
+	}
+
+	return order, nil
+}
+
+// UpdatePaid moves the order to paid and settles the stock reserved at order creation, a payment that was
+// not completed moves the order to payment failed instead
 func (s OrderService) UpdatePaid(form *domain.PaymentResult) *errs.AppError {
 
-	_, appErr := s.repo.GetOneByID(form.OrderID)
+	order, appErr := s.repo.GetOneByID(form.OrderID)
 	if appErr != nil {
 		return appErr
 	}
 
+	if order.UserID != form.ActorUserID {
+		return errs.NewNotFoundError("Order not found!")
+	}
+
 	// check payment result by id
 	checkPaymentResult, appErr := s.repoPaymentResult.CheckByID(form.PaymentResultID)
 	if appErr != nil {
@@ -144,7 +222,7 @@ func (s OrderService) UpdatePaid(form *domain.PaymentResult) *errs.AppError {
 	}
 
 	// check order payment result
-	checkPaymentResult, appErr = s.repoPaymentResult.CheckByOrderIDAndStatus(form.OrderID, "COMPLETED")
+	checkPaymentResult, appErr = s.repoPaymentResult.CheckByOrderIDAndStatus(form.OrderID, constants.PaymentStatusCompleted)
 	if appErr != nil {
 		return appErr
 	}
@@ -154,48 +232,569 @@ func (s OrderService) UpdatePaid(form *domain.PaymentResult) *errs.AppError {
 		return errs.NewBadRequestError("order already paid")
 	}
 
-	appErr = s.repo.UpdatePaid(form)
+	history := newOrderStatusHistory(order, constants.OrderStatusPaid, form.ActorUserID, nil)
+	if form.Status != constants.PaymentStatusCompleted {
+		note := fmt.Sprintf("Payment %s is %s", form.PaymentResultID, form.Status)
+		history = newOrderStatusHistory(order, constants.OrderStatusPaymentFailed, form.ActorUserID, &note)
+		if canTransitOrderStatus(order.Order.Status, history.ToStatus) {
+			appErr = s.repo.UpdateStatus(history, newStatusUpdatedNotification(order, history))
+			if appErr != nil {
+				return appErr
+			}
+		}
+
+		logger.Error("Failed while update order paid: payment is not completed")
+		return errs.NewBadRequestError("payment is not completed")
+	}
+
+	if !canTransitOrderStatus(order.Order.Status, history.ToStatus) {
+		logger.Error("Failed while update order paid: order is " + order.Order.Status)
+		return errs.NewBadRequestError(fmt.Sprintf("Order is %s and cannot be paid", order.Order.Status))
+	}
+
+	notification := &domain.NotificationEvent{UserID: order.UserID, Template: constants.NotificationOrderPaid, CreatedAt: history.CreatedAt, Data: map[string]interface{}{
+		"OrderID":    order.Order.OrderID,
+		"TotalPrice": order.TotalPrice,
+	}}
+	return s.repo.UpdatePaid(form, history, notification)
+}
+
+// RenewStockReservation is called by the storefront right before the payment is captured, it reserves the
+// stock of an order past its payment deadline again so a captured payment never finds the stock gone
+func (s OrderService) RenewStockReservation(orderID, userID int64) (*domain.OrderDetail, *errs.AppError) {
+	order, appErr := s.repo.GetOneByID(orderID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if order.UserID != userID {
+		return nil, errs.NewNotFoundError("Order not found!")
+	}
+
+	if !canTransitOrderStatus(order.Order.Status, constants.OrderStatusPaid) {
+		logger.Error("Failed while renew stock reservation: order is " + order.Order.Status)
+		return nil, errs.NewBadRequestError(fmt.Sprintf("Order is %s and cannot be paid", order.Order.Status))
+	}
+
+	reservationExpiresAt := time.Now().Add(constants.StockReservationDuration)
+	appErr = s.repo.RenewStockReservation(order.Order.OrderID, reservationExpiresAt)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	order.ReservationExpiresAt = &reservationExpiresAt
+	return order, nil
+}
+
+func (s OrderService) UpdateDelivered(ID, actorUserID int64) *errs.AppError {
+	return s.UpdateStatus(&domain.OrderStatusHistory{OrderID: ID, ToStatus: constants.OrderStatusDelivered, ActorUserID: &actorUserID})
+}
+
+// UpdateStatus moves the order along the status graph, payment has its own transition since it also stores the
+// payment result
+func (s OrderService) UpdateStatus(form *domain.OrderStatusHistory) *errs.AppError {
+	order, appErr := s.repo.GetOneByID(form.OrderID)
+	if appErr != nil {
+		return appErr
+	}
+
+	if form.ToStatus == constants.OrderStatusPaid {
+		return errs.NewBadRequestError("Order is paid through the payment endpoint")
+	}
+
+	if form.ToStatus == constants.OrderStatusCancelled {
+		return errs.NewBadRequestError("Order is cancelled through the cancel endpoint")
+	}
+
+	if form.ToStatus == constants.OrderStatusRefunded {
+		return errs.NewBadRequestError("Order is refunded once its refunds are processed")
+	}
+
+	if !canTransitOrderStatus(order.Order.Status, form.ToStatus) {
+		logger.Error(fmt.Sprintf("Failed while update order status: %s to %s is not allowed", order.Order.Status, form.ToStatus))
+		return errs.NewBadRequestError(fmt.Sprintf("Order cannot move from %s to %s", order.Order.Status, form.ToStatus))
+	}
+
+	var actorUserID int64
+	if form.ActorUserID != nil {
+		actorUserID = *form.ActorUserID
+	}
+
+	history := newOrderStatusHistory(order, form.ToStatus, actorUserID, form.Note)
+	return s.repo.UpdateStatus(history, newStatusUpdatedNotification(order, history))
+}
+
+func newStatusUpdatedNotification(order *domain.OrderDetail, history *domain.OrderStatusHistory) *domain.NotificationEvent {
+	return &domain.NotificationEvent{UserID: order.UserID, Template: constants.NotificationOrderStatusUpdated, CreatedAt: history.CreatedAt, Data: map[string]interface{}{
+		"OrderID": order.Order.OrderID,
+		"Status":  history.ToStatus,
+	}}
+}
+
+func (s OrderService) calculatePrice(form *domain.OrderDetail) *errs.AppError {
+	appErr := s.calculateProductPrice(form)
+	if appErr != nil {
+		return appErr
+	}
+
+	appErr = s.calculateShipping(form)
+	if appErr != nil {
+		return appErr
+	}
+
+	applyShippingCoupon(form)
+
+	form.TotalPrice += form.ShippingPrice - form.ShippingDiscountPrice
+	return nil
+}
+
+// calculateProductPrice recomputes every line from the current product price, a running sale is kept as the
+// line discount so the regular price, discount and tax stay visible per line. The product name, SKU and image
+// are captured with the line so later product changes do not rewrite the order.
+func (s OrderService) calculateProductPrice(form *domain.OrderDetail) *errs.AppError {
+	form.ProductPrice = 0
+	form.DiscountPrice = 0
+	form.TaxPrice = 0
+	form.TotalPrice = 0
+	form.ShippingWeight = 0
+
+	var region string
+	if form.Region != nil {
+		region = *form.Region
+	}
+
+	taxRules, appErr := s.repoTaxRule.GetAllByLocation(form.Country, region)
+	if appErr != nil {
+		return appErr
+	}
+
+	ordered := make(map[int64]bool)
+	weightKnown := true
+	lineTaxRules := make([]domain.TaxRule, len(form.OrderProducts))
+	for i := range form.OrderProducts {
+		orderProduct := &form.OrderProducts[i]
+		if ordered[orderProduct.ProductID] {
+			return errs.NewBadRequestError(fmt.Sprintf("Product %d is given more than once", orderProduct.ProductID))
+		}
+		ordered[orderProduct.ProductID] = true
+
+		if orderProduct.Quantity < 1 {
+			return errs.NewBadRequestError(fmt.Sprintf("Quantity of product %d must more than equal 1", orderProduct.ProductID))
+		}
+
+		product, appErr := s.repoProduct.GetOneByID(orderProduct.ProductID)
+		if appErr != nil {
+			return appErr
+		}
+
+		if !product.IsPublished {
+			return errs.NewBadRequestError(fmt.Sprintf("Product %d is not available", orderProduct.ProductID))
+		}
+
+		if product.Stock < orderProduct.Quantity {
+			logger.Error("Failed while create order: insufficient product stock")
+			return errs.NewBadRequestError("Insufficient product stock")
+		}
+
+		orderProduct.Name = product.Name
+		orderProduct.Sku = product.Sku
+		if product.Image != nil {
+			orderProduct.Image = *product.Image
+		}
+
+		orderProduct.Price = product.Price
+		orderProduct.RegularPrice = product.Price
+		if product.RegularPrice > product.Price {
+			orderProduct.RegularPrice = product.RegularPrice
+		}
+		orderProduct.DiscountPrice = (orderProduct.RegularPrice - orderProduct.Price) * orderProduct.Quantity
+
+		lineTaxRules[i], appErr = s.resolveTaxRule(taxRules, product.TaxClass)
+		if appErr != nil {
+			return appErr
+		}
+
+		form.ProductPrice += orderProduct.RegularPrice * orderProduct.Quantity
+		form.DiscountPrice += orderProduct.DiscountPrice
+
+		if product.Weight <= 0 {
+			weightKnown = false
+		}
+		form.ShippingWeight += product.Weight * orderProduct.Quantity
+	}
+
+	// a product without weight leaves the order weight unknown so weight rates are not offered
+	if !weightKnown {
+		form.ShippingWeight = 0
+	}
+
+	appErr = s.applyCoupons(form)
 	if appErr != nil {
 		return appErr
 	}
 
-	// update stock
-	orderProducts, appErr := s.repoOrderProduct.GetAllByOrderID(form.OrderID)
+	// tax is charged on what is left of the line after coupons
+	for i := range form.OrderProducts {
+		orderProduct := &form.OrderProducts[i]
+		taxRule := lineTaxRules[i]
+		orderProduct.TaxClass = taxRule.TaxClass
+		orderProduct.TaxRate = taxRule.Rate
+		orderProduct.IsTaxInclusive = taxRule.IsInclusive
+
+		linePrice := orderProduct.Price*orderProduct.Quantity - orderProduct.CouponDiscountPrice
+		orderProduct.TaxPrice = calculateTax(linePrice, taxRule)
+		orderProduct.TotalPrice = linePrice
+		if !taxRule.IsInclusive {
+			orderProduct.TotalPrice += orderProduct.TaxPrice
+		}
+
+		form.TaxPrice += orderProduct.TaxPrice
+		form.TotalPrice += orderProduct.TotalPrice
+	}
+	return nil
+}
+
+// applyCoupons validates the coupon codes of the order and takes their discount off the lines, then adds the
+// automatic coupons the order qualifies for. Free shipping is only recorded here and taken off once shipping
+// is priced.
+func (s OrderService) applyCoupons(form *domain.OrderDetail) *errs.AppError {
+	form.CouponDiscountPrice = 0
+	form.ShippingDiscountPrice = 0
+	form.CouponRedemptions = nil
+	for i := range form.OrderProducts {
+		form.OrderProducts[i].CouponDiscountPrice = 0
+	}
+
+	codes := make([]string, 0, len(form.CouponCodes))
+	given := make(map[string]bool)
+	for _, code := range form.CouponCodes {
+		code = normalizeCouponCode(code)
+		if given[code] {
+			return errs.NewBadRequestError(fmt.Sprintf("Coupon %s is given more than once", code))
+		}
+		given[code] = true
+		codes = append(codes, code)
+	}
+
+	couponByCode := make(map[string]domain.Coupon)
+	if len(codes) > 0 {
+		coupons, appErr := s.repoCoupon.GetAllByCodes(codes)
+		if appErr != nil {
+			return appErr
+		}
+
+		for _, coupon := range coupons {
+			couponByCode[normalizeCouponCode(coupon.Code)] = coupon
+		}
+	}
+
+	automaticCoupons, appErr := s.repoCoupon.GetAllAutomatic()
 	if appErr != nil {
 		return appErr
 	}
 
-	for _, orderProduct := range orderProducts {
-		appErr := s.repoProduct.UpdateStock(orderProduct.ProductID, orderProduct.Quantity)
+	productIDs := make([]int64, 0, len(form.OrderProducts))
+	for _, orderProduct := range form.OrderProducts {
+		productIDs = append(productIDs, orderProduct.ProductID)
+	}
+
+	subtotal := form.ProductPrice - form.DiscountPrice
+	now := time.Now()
+	stackable := true
+	for _, code := range codes {
+		coupon, ok := couponByCode[code]
+		if !ok {
+			return errs.NewBadRequestError(fmt.Sprintf("Coupon %s is not valid", code))
+		}
+
+		if len(codes) > 1 && !coupon.IsStackable {
+			return errs.NewBadRequestError(fmt.Sprintf("Coupon %s cannot be combined with other coupons", code))
+		}
+
+		appErr = s.redeemCoupon(form, coupon, productIDs, subtotal, now)
 		if appErr != nil {
 			return appErr
 		}
+		stackable = stackable && coupon.IsStackable
+	}
+
+	// an automatic coupon the order does not qualify for is left out instead of failing the order, like a
+	// coupon given by code it is only combined when every coupon of the order is stackable
+	for _, coupon := range automaticCoupons {
+		if len(form.CouponRedemptions) > 0 && (!stackable || !coupon.IsStackable) {
+			continue
+		}
 
+		appErr = s.redeemCoupon(form, coupon, productIDs, subtotal, now)
+		if appErr != nil {
+			if appErr.Code != http.StatusBadRequest {
+				return appErr
+			}
+			continue
+		}
+		stackable = stackable && coupon.IsStackable
+	}
+	return nil
+}
+
+// redeemCoupon checks the coupon is available and within its usage limits, takes its discount off the lines
+// it applies to and records the redemption
+func (s OrderService) redeemCoupon(form *domain.OrderDetail, coupon domain.Coupon, productIDs []int64, subtotal int64, now time.Time) *errs.AppError {
+	appErr := checkCouponAvailable(coupon, subtotal, now)
+	if appErr != nil {
+		return appErr
 	}
 
+	total, totalByUser, appErr := s.repoCoupon.CountRedemption(coupon.CouponID, form.UserID)
+	if appErr != nil {
+		return appErr
+	}
+
+	if (coupon.UsageLimit > 0 && total >= coupon.UsageLimit) || (coupon.UsageLimitPerUser > 0 && totalByUser >= coupon.UsageLimitPerUser) {
+		return errs.NewBadRequestError(fmt.Sprintf("Coupon %s has reached its usage limit", coupon.Code))
+	}
+
+	var scope map[int64]bool
+	if coupon.IsScoped() {
+		scopedProductIDs, appErr := s.repoCoupon.GetAllScopedProductID(coupon.CouponID, productIDs)
+		if appErr != nil {
+			return appErr
+		}
+
+		scope = make(map[int64]bool)
+		for _, productID := range scopedProductIDs {
+			scope[productID] = true
+		}
+
+		if len(scope) == 0 {
+			return errs.NewBadRequestError(fmt.Sprintf("Coupon %s does not apply to any product in the order", coupon.Code))
+		}
+	}
+
+	var discount int64
+	if coupon.Type != constants.CouponTypeFreeShipping {
+		discount = applyCoupon(coupon, form.OrderProducts, scope)
+		if discount == 0 {
+			return errs.NewBadRequestError(fmt.Sprintf("Coupon %s does not give any discount on the order", coupon.Code))
+		}
+		form.CouponDiscountPrice += discount
+	}
+
+	form.CouponRedemptions = append(form.CouponRedemptions, domain.CouponRedemption{
+		CouponID:      coupon.CouponID,
+		UserID:        form.UserID,
+		Code:          coupon.Code,
+		Type:          coupon.Type,
+		DiscountPrice: discount,
+		CreatedAt:     now,
+	})
 	return nil
 }
 
-func (s OrderService) UpdateDelivered(ID int64) *errs.AppError {
-	order, appErr := s.repo.GetOneByID(ID)
+// applyShippingCoupon takes the shipping price off the order for a free shipping coupon
+func applyShippingCoupon(form *domain.OrderDetail) {
+	for i := range form.CouponRedemptions {
+		redemption := &form.CouponRedemptions[i]
+		if redemption.Type != constants.CouponTypeFreeShipping {
+			continue
+		}
+
+		redemption.DiscountPrice = form.ShippingPrice - form.ShippingDiscountPrice
+		form.ShippingDiscountPrice += redemption.DiscountPrice
+		form.CouponDiscountPrice += redemption.DiscountPrice
+	}
+}
+
+// calculateShipping prices the shipping method chosen for the order, the order may leave it out only when
+// shipping falls back to the configured default
+func (s OrderService) calculateShipping(form *domain.OrderDetail) *errs.AppError {
+	quotes, appErr := s.getShippingQuotes(form)
 	if appErr != nil {
 		return appErr
 	}
 
-	if !order.IsPaid {
-		logger.Error("Failed while update order delivered: order has not been paid")
-		return errs.NewBadRequestError("order has not been paid")
+	var shippingMethodID int64
+	if form.ShippingMethodID != nil {
+		shippingMethodID = *form.ShippingMethodID
 	}
 
-	if order.IsDelivered {
-		logger.Error("Failed while update order delivered: order already deliverd")
-		return errs.NewBadRequestError("order already deliverd")
+	if shippingMethodID == 0 && quotes[0].ShippingMethodID != 0 {
+		return errs.NewBadRequestError("Shipping method is required")
 	}
 
-	appErr = s.repo.UpdateDelivered(ID)
+	for _, quote := range quotes {
+		if quote.ShippingMethodID != shippingMethodID {
+			continue
+		}
+
+		name := quote.Name
+		form.ShippingPrice = quote.Price
+		form.ShippingMethodName = &name
+		form.ShippingMethodID = nil
+		if quote.ShippingMethodID != 0 {
+			form.ShippingMethodID = &quote.ShippingMethodID
+		}
+		return nil
+	}
+
+	return errs.NewBadRequestError(fmt.Sprintf("Shipping method %d is not available for this order", shippingMethodID))
+}
+
+// getShippingQuotes quotes the shipping zones of the address. Without any shipping zone set up, shipping is
+// the configured price, free from the configured minimum.
+func (s OrderService) getShippingQuotes(form *domain.OrderDetail) ([]domain.ShippingQuote, *errs.AppError) {
+	criteria := &domain.ShippingQuoteCriteria{
+		Country:    strings.TrimSpace(form.Country),
+		PostalCode: form.PostalCode,
+		Subtotal:   form.ProductPrice - form.DiscountPrice - form.CouponDiscountPrice,
+		Weight:     form.ShippingWeight,
+	}
+
+	zones, appErr := s.repoShipping.GetAllZoneByCountry(criteria.Country)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	quotes := quoteShipping(zones, criteria)
+	if len(quotes) > 0 {
+		return quotes, nil
+	}
+
+	hasZone := len(zones) > 0
+	if !hasZone {
+		hasZone, appErr = s.repoShipping.CheckAnyZone()
+		if appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	if hasZone {
+		logger.Error(fmt.Sprintf("Failed while quote shipping: no shipping method for %s %s", criteria.Country, criteria.PostalCode))
+		return nil, errs.NewBadRequestError("No shipping method is available for this address")
+	}
+
+	price := s.shippingPrice
+	if s.freeShippingMinimum > 0 && criteria.Subtotal >= s.freeShippingMinimum {
+		price = 0
+	}
+
+	return []domain.ShippingQuote{{Name: constants.ShippingMethodDefaultName, Price: price}}, nil
+}
+
+// resolveTaxRule returns the tax rule of a product tax class at the shipping location, the standard and
+// reduced classes without a rule are charged their default tax rate on top of the price
+func (s OrderService) resolveTaxRule(taxRules []domain.TaxRule, taxClass string) (domain.TaxRule, *errs.AppError) {
+	if taxClass == "" {
+		taxClass = constants.TaxClassStandard
+	}
+
+	if taxClass == constants.TaxClassExempt {
+		return domain.TaxRule{TaxClass: taxClass}, nil
+	}
+
+	taxRule, ok := findTaxRule(taxRules, taxClass)
+	if ok {
+		return taxRule, nil
+	}
+
+	switch taxClass {
+	case constants.TaxClassStandard:
+		return domain.TaxRule{TaxClass: taxClass, Rate: s.taxRate, Rounding: constants.TaxRoundingHalfUp}, nil
+	case constants.TaxClassReduced:
+		return domain.TaxRule{TaxClass: taxClass, Rate: s.reducedTaxRate, Rounding: constants.TaxRoundingHalfUp}, nil
+	}
+
+	logger.Error("Failed while resolve tax rule: no tax rule for tax class " + taxClass)
+	return domain.TaxRule{}, errs.NewBadRequestError(fmt.Sprintf("No tax rule is set up for tax class %s", taxClass))
+}
+
+// Cancel cancels the order and restores its stock, a shipped order gets its stock back once the parcel is
+// received through its return. A paid order is refunded right away, a refund the provider fails is kept as
+// failed so an admin can process it again
+func (s OrderService) Cancel(form *domain.OrderCancellation) *errs.AppError {
+	order, appErr := s.repo.GetOneByID(form.OrderID)
 	if appErr != nil {
 		return appErr
 	}
 
+	if !form.IsAdmin && order.UserID != form.ActorUserID {
+		return errs.NewNotFoundError("Order not found!")
+	}
+
+	cancellable := canTransitOrderStatus(order.Order.Status, constants.OrderStatusCancelled)
+	if !form.IsAdmin {
+		cancellable = containsString(customerCancellableStatuses, order.Order.Status)
+	}
+
+	if !cancellable {
+		logger.Error("Failed while cancel order: order is " + order.Order.Status)
+		return errs.NewBadRequestError(fmt.Sprintf("Order is %s and cannot be cancelled", order.Order.Status))
+	}
+
+	reason := form.Reason
+	history := newOrderStatusHistory(order, constants.OrderStatusCancelled, form.ActorUserID, &reason)
+
+	var refund *domain.Refund
+	if order.IsPaid {
+		refund = &domain.Refund{
+			OrderID:     order.Order.OrderID,
+			Amount:      order.TotalPrice,
+			Reason:      &reason,
+			Status:      constants.RefundStatusRequested,
+			RequestedBy: &form.ActorUserID,
+			CreatedAt:   history.CreatedAt,
+		}
+	}
+
+	notification := &domain.NotificationEvent{UserID: order.UserID, Template: constants.NotificationOrderCancelled, CreatedAt: history.CreatedAt, Data: map[string]interface{}{
+		"OrderID":     order.Order.OrderID,
+		"Reason":      reason,
+		"RefundPrice": int64(0),
+	}}
+
+	appErr = s.repo.Cancel(history, refund, notification)
+	if appErr != nil {
+		return appErr
+	}
+
+	if refund != nil && refund.RefundID != 0 {
+		_, appErr = s.refundService.Process(refund.RefundID, form.ActorUserID)
+		if appErr != nil {
+			logger.Error(fmt.Sprintf("Failed while process refund of cancelled order %d: %s", order.Order.OrderID, appErr.Message))
+		}
+	}
 	return nil
 }
+
+// orderStatusTransitions is the order status graph, statuses missing as a key are final
+var orderStatusTransitions = map[string][]string{
+	constants.OrderStatusPendingPayment: {constants.OrderStatusPaid, constants.OrderStatusPaymentFailed, constants.OrderStatusCancelled},
+	constants.OrderStatusPaymentFailed:  {constants.OrderStatusPaid, constants.OrderStatusCancelled},
+	constants.OrderStatusPaid:           {constants.OrderStatusProcessing, constants.OrderStatusShipped, constants.OrderStatusDelivered, constants.OrderStatusCancelled, constants.OrderStatusRefunded},
+	constants.OrderStatusProcessing:     {constants.OrderStatusShipped, constants.OrderStatusDelivered, constants.OrderStatusCancelled},
+	constants.OrderStatusShipped:        {constants.OrderStatusDelivered, constants.OrderStatusCancelled},
+	constants.OrderStatusDelivered:      {constants.OrderStatusRefunded},
+	constants.OrderStatusCancelled:      {constants.OrderStatusRefunded},
+}
+
+// customerCancellableStatuses are the statuses a customer may still cancel from, once shipped only an admin can
+var customerCancellableStatuses = []string{constants.OrderStatusPendingPayment, constants.OrderStatusPaymentFailed, constants.OrderStatusPaid, constants.OrderStatusProcessing}
+
+func canTransitOrderStatus(from, to string) bool {
+	return containsString(orderStatusTransitions[from], to)
+}
+
+func newOrderStatusHistory(order *domain.OrderDetail, toStatus string, actorUserID int64, note *string) *domain.OrderStatusHistory {
+	fromStatus := order.Order.Status
+	history := &domain.OrderStatusHistory{
+		OrderID:    order.Order.OrderID,
+		FromStatus: &fromStatus,
+		ToStatus:   toStatus,
+		Note:       note,
+		CreatedAt:  time.Now(),
+	}
+	if actorUserID != 0 {
+		history.ActorUserID = &actorUserID
+	}
+	return history
+}
@@ -0,0 +1,365 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/danisbagus/matchoshop/utils/constants"
+	"github.com/danisbagus/matchoshop/utils/helper"
+	"github.com/danisbagus/matchoshop/utils/pdf"
+)
+
+type OrderDocumentService struct {
+	repo         port.OrderDocumentRepo
+	orderService port.OrderService
+	seller       domain.Seller
+	currency     string
+}
+
+func NewOrderDocumentService(repo port.OrderDocumentRepo, orderService port.OrderService) port.OrderDocumentService {
+	return &OrderDocumentService{
+		repo:         repo,
+		orderService: orderService,
+		seller: domain.Seller{
+			Name:    helper.EnvSellerName(),
+			Address: helper.EnvSellerAddress(),
+			Email:   helper.EnvSellerEmail(),
+			Phone:   helper.EnvSellerPhone(),
+			TaxID:   helper.EnvSellerTaxID(),
+		},
+		currency: helper.EnvPaymentCurrency(),
+	}
+}
+
+// invoiceTax is a line of the invoice tax breakdown, the taxable price excludes the tax
+type invoiceTax struct {
+	TaxClass     string
+	TaxRate      float64
+	IsInclusive  bool
+	TaxablePrice int64
+	TaxPrice     int64
+}
+
+// GetDocument returns the invoice or packing slip of the order, customers only get those of their own order.
+// A document is generated once the order is paid and stored, later downloads get the stored document back
+// so an invoice does not change after it was issued.
+func (s OrderDocumentService) GetDocument(orderID, userID int64, isAdmin bool, documentType string) (*domain.OrderDocument, *errs.AppError) {
+	order, appErr := s.orderService.GetDetail(orderID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if !isAdmin && order.UserID != userID {
+		return nil, errs.NewNotFoundError("Order not found!")
+	}
+
+	// a packing slip stored before the order was cancelled must not be handed to the warehouse either
+	if documentType == constants.OrderDocumentTypePackingSlip && order.Order.Status == constants.OrderStatusCancelled {
+		return nil, errs.NewBadRequestError(fmt.Sprintf("Order is %s and is not packed", order.Order.Status))
+	}
+
+	document, appErr := s.repo.GetOneByOrderID(orderID, documentType)
+	if appErr == nil {
+		return document, nil
+	}
+
+	if appErr.Code != http.StatusNotFound {
+		return nil, appErr
+	}
+
+	if !order.IsPaid {
+		return nil, errs.NewBadRequestError("Order is not paid yet")
+	}
+
+	document = new(domain.OrderDocument)
+	document.OrderID = orderID
+	document.Type = documentType
+	document.CreatedAt = time.Now()
+
+	switch documentType {
+	case constants.OrderDocumentTypeInvoice:
+		invoice, appErr := s.repo.GetOrCreateInvoice(orderID, document.CreatedAt)
+		if appErr != nil {
+			return nil, appErr
+		}
+
+		document.FileName = fmt.Sprintf("invoice-%s.pdf", invoice.InvoiceNumber)
+		document.Content = s.renderInvoice(order, invoice)
+	case constants.OrderDocumentTypePackingSlip:
+		document.FileName = fmt.Sprintf("packing-slip-order-%d.pdf", orderID)
+		document.Content = s.renderPackingSlip(order)
+	default:
+		return nil, errs.NewBadRequestError(fmt.Sprintf("Unknown order document %s", documentType))
+	}
+
+	stored, appErr := s.repo.Insert(document)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if !stored {
+		return s.repo.GetOneByOrderID(orderID, documentType)
+	}
+
+	return document, nil
+}
+
+func (s OrderDocumentService) renderInvoice(order *domain.OrderDetail, invoice *domain.Invoice) []byte {
+	doc := pdf.New()
+	right := pdf.PageWidth - pdf.Margin
+
+	doc.Text(pdf.Margin, 20, true, "INVOICE")
+	doc.Ln(30)
+
+	sellerLines := []string{s.seller.Name, s.seller.Address, s.seller.Email, s.seller.Phone}
+	if s.seller.TaxID != "" {
+		sellerLines = append(sellerLines, "Tax ID: "+s.seller.TaxID)
+	}
+	invoiceLines := [][2]string{
+		{"Invoice number", invoice.InvoiceNumber},
+		{"Invoice date", invoice.IssuedAt.Format(constants.DATE_FORMAT)},
+		{"Order", fmt.Sprintf("#%d", order.Order.OrderID)},
+		{"Payment method", order.PaymentMethodName},
+	}
+	if order.PaidAt != nil {
+		invoiceLines = append(invoiceLines, [2]string{"Paid at", order.PaidAt.Format(constants.DATE_TIME_FORMAT)})
+	}
+
+	// seller details on the left, invoice details on the right
+	for i := 0; i < len(invoiceLines) || i < len(sellerLines); i++ {
+		if i == 0 {
+			doc.Text(pdf.Margin, 11, true, sellerLines[i])
+		} else if i < len(sellerLines) {
+			doc.Text(pdf.Margin, 9, false, sellerLines[i])
+		}
+		if i < len(invoiceLines) {
+			doc.Text(330, 9, true, invoiceLines[i][0])
+			doc.TextRight(right, 9, false, invoiceLines[i][1])
+		}
+		doc.Ln(13)
+	}
+	doc.Ln(12)
+
+	s.renderAddress(doc, "Bill and ship to", order)
+	doc.Ln(12)
+
+	doc.Text(pdf.Margin, 9, true, "Item")
+	doc.Text(230, 9, true, "SKU")
+	doc.TextRight(330, 9, true, "Qty")
+	doc.TextRight(395, 9, true, "Unit price")
+	doc.TextRight(450, 9, true, "Coupon")
+	doc.TextRight(495, 9, true, "Tax")
+	doc.TextRight(right, 9, true, "Total")
+	doc.HLine()
+	doc.Ln(16)
+
+	var exclusiveTaxPrice, inclusiveTaxPrice int64
+	for _, orderProduct := range order.OrderProducts {
+		doc.Text(pdf.Margin, 9, false, truncate(orderProduct.Name, 36))
+		doc.Text(230, 9, false, truncate(orderProduct.Sku, 16))
+		doc.TextRight(330, 9, false, strconv.FormatInt(orderProduct.Quantity, 10))
+		doc.TextRight(395, 9, false, formatThousand(orderProduct.Price))
+		doc.TextRight(450, 9, false, formatThousand(-orderProduct.CouponDiscountPrice))
+		doc.TextRight(495, 9, false, formatThousand(orderProduct.TaxPrice))
+		doc.TextRight(right, 9, false, formatThousand(orderProduct.TotalPrice))
+		doc.Ln(14)
+
+		if orderProduct.IsTaxInclusive {
+			inclusiveTaxPrice += orderProduct.TaxPrice
+		} else {
+			exclusiveTaxPrice += orderProduct.TaxPrice
+		}
+	}
+	doc.HLine()
+	doc.Ln(18)
+
+	shippingLabel := "Shipping"
+	if order.ShippingMethodName != nil {
+		shippingLabel = fmt.Sprintf("Shipping (%s)", *order.ShippingMethodName)
+	}
+	summaries := [][2]string{
+		{"Subtotal", s.formatPrice(order.ProductPrice)},
+		{"Discount", s.formatPrice(-order.DiscountPrice)},
+		{"Coupon discount", s.formatPrice(-order.CouponDiscountPrice)},
+		{"Tax", s.formatPrice(exclusiveTaxPrice)},
+		{shippingLabel, s.formatPrice(order.ShippingPrice)},
+		{"Shipping discount", s.formatPrice(-order.ShippingDiscountPrice)},
+	}
+	for _, summary := range summaries {
+		doc.Text(330, 9, false, summary[0])
+		doc.TextRight(right, 9, false, summary[1])
+		doc.Ln(13)
+	}
+	doc.Text(330, 10, true, "Total")
+	doc.TextRight(right, 10, true, s.formatPrice(order.TotalPrice))
+	doc.Ln(13)
+	if inclusiveTaxPrice > 0 {
+		doc.Text(330, 9, false, "Tax included in total")
+		doc.TextRight(right, 9, false, s.formatPrice(inclusiveTaxPrice))
+		doc.Ln(13)
+	}
+	doc.Ln(12)
+
+	doc.Text(pdf.Margin, 10, true, "Tax breakdown")
+	doc.Ln(16)
+	doc.Text(pdf.Margin, 9, true, "Tax class")
+	doc.TextRight(250, 9, true, "Rate")
+	doc.Text(280, 9, true, "Price")
+	doc.TextRight(450, 9, true, "Taxable amount")
+	doc.TextRight(right, 9, true, "Tax")
+	doc.HLine()
+	doc.Ln(16)
+	for _, tax := range invoiceTaxes(order.OrderProducts) {
+		taxClass := tax.TaxClass
+		if taxClass == "" {
+			taxClass = "standard"
+		}
+		priceLabel := "tax excluded"
+		if tax.IsInclusive {
+			priceLabel = "tax included"
+		}
+
+		doc.Text(pdf.Margin, 9, false, taxClass)
+		doc.TextRight(250, 9, false, strconv.FormatFloat(tax.TaxRate, 'f', -1, 64)+"%")
+		doc.Text(280, 9, false, priceLabel)
+		doc.TextRight(450, 9, false, s.formatPrice(tax.TaxablePrice))
+		doc.TextRight(right, 9, false, s.formatPrice(tax.TaxPrice))
+		doc.Ln(14)
+	}
+
+	return doc.Bytes()
+}
+
+func (s OrderDocumentService) renderPackingSlip(order *domain.OrderDetail) []byte {
+	doc := pdf.New()
+	right := pdf.PageWidth - pdf.Margin
+
+	doc.Text(pdf.Margin, 20, true, "PACKING SLIP")
+	doc.Ln(30)
+
+	doc.Text(pdf.Margin, 11, true, s.seller.Name)
+	doc.Text(330, 9, true, "Order")
+	doc.TextRight(right, 9, false, fmt.Sprintf("#%d", order.Order.OrderID))
+	doc.Ln(13)
+	doc.Text(330, 9, true, "Order date")
+	doc.TextRight(right, 9, false, order.CreatedAt.Format(constants.DATE_FORMAT))
+	doc.Ln(13)
+	if order.ShippingMethodName != nil {
+		doc.Text(330, 9, true, "Shipping method")
+		doc.TextRight(right, 9, false, *order.ShippingMethodName)
+		doc.Ln(13)
+	}
+	doc.Ln(12)
+
+	s.renderAddress(doc, "Ship to", order)
+	doc.Ln(12)
+
+	doc.Text(pdf.Margin, 9, true, "SKU")
+	doc.Text(180, 9, true, "Item")
+	doc.TextRight(right, 9, true, "Qty")
+	doc.HLine()
+	doc.Ln(16)
+
+	var quantity int64
+	for _, orderProduct := range order.OrderProducts {
+		doc.Text(pdf.Margin, 9, false, truncate(orderProduct.Sku, 22))
+		doc.Text(180, 9, false, truncate(orderProduct.Name, 60))
+		doc.TextRight(right, 9, false, strconv.FormatInt(orderProduct.Quantity, 10))
+		doc.Ln(14)
+		quantity += orderProduct.Quantity
+	}
+	doc.HLine()
+	doc.Ln(18)
+	doc.Text(330, 10, true, "Total items")
+	doc.TextRight(right, 10, true, strconv.FormatInt(quantity, 10))
+
+	return doc.Bytes()
+}
+
+func (s OrderDocumentService) renderAddress(doc *pdf.Document, title string, order *domain.OrderDetail) {
+	cityLine := order.City
+	if order.Region != nil && *order.Region != "" {
+		cityLine += ", " + *order.Region
+	}
+	cityLine = strings.TrimSpace(cityLine + " " + order.PostalCode)
+
+	doc.Text(pdf.Margin, 10, true, title)
+	doc.Ln(14)
+	for _, line := range []string{order.UserName, order.UserEmail, order.Address, cityLine, order.Country} {
+		if line == "" {
+			continue
+		}
+		doc.Text(pdf.Margin, 9, false, line)
+		doc.Ln(13)
+	}
+}
+
+func (s OrderDocumentService) formatPrice(price int64) string {
+	return s.currency + " " + formatThousand(price)
+}
+
+// invoiceTaxes groups the order lines by the tax they were charged, ordered by tax class and rate
+func invoiceTaxes(orderProducts []domain.OrderProduct) []invoiceTax {
+	taxes := []invoiceTax{}
+	for _, orderProduct := range orderProducts {
+		linePrice := orderProduct.Price*orderProduct.Quantity - orderProduct.CouponDiscountPrice
+		if orderProduct.IsTaxInclusive {
+			linePrice -= orderProduct.TaxPrice
+		}
+
+		found := false
+		for i := range taxes {
+			if taxes[i].TaxClass == orderProduct.TaxClass && taxes[i].TaxRate == orderProduct.TaxRate && taxes[i].IsInclusive == orderProduct.IsTaxInclusive {
+				taxes[i].TaxablePrice += linePrice
+				taxes[i].TaxPrice += orderProduct.TaxPrice
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			taxes = append(taxes, invoiceTax{TaxClass: orderProduct.TaxClass, TaxRate: orderProduct.TaxRate, IsInclusive: orderProduct.IsTaxInclusive,
+				TaxablePrice: linePrice, TaxPrice: orderProduct.TaxPrice})
+		}
+	}
+
+	sort.SliceStable(taxes, func(i, j int) bool {
+		if taxes[i].TaxClass != taxes[j].TaxClass {
+			return taxes[i].TaxClass < taxes[j].TaxClass
+		}
+		return taxes[i].TaxRate < taxes[j].TaxRate
+	})
+
+	return taxes
+}
+
+func formatThousand(number int64) string {
+	sign := ""
+	if number < 0 {
+		sign = "-"
+		number = -number
+	}
+
+	digits := strconv.FormatInt(number, 10)
+	for i := len(digits) - 3; i > 0; i -= 3 {
+		digits = digits[:i] + "," + digits[i:]
+	}
+
+	return sign + digits
+}
+
+func truncate(s string, length int) string {
+	runes := []rune(s)
+	if len(runes) <= length {
+		return s
+	}
+
+	return string(runes[:length-3]) + "..."
+}
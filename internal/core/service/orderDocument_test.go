@@ -0,0 +1,94 @@
+package service
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/mocks"
+	"github.com/danisbagus/matchoshop/utils/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var mockOrderDocumentRepo = &mocks.OrderDocumentRepo{Mock: mock.Mock{}}
+var orderDocumentService = OrderDocumentService{repo: mockOrderDocumentRepo, orderService: mockOrderService,
+	seller: domain.Seller{Name: "Matchoshop", TaxID: "TAX-1"}, currency: "USD"}
+
+func TestOrderDocument_GetDocument_NotOwner(t *testing.T) {
+	mockOrderService.Mock.On("GetDetail", int64(600)).Return(&domain.OrderDetail{Order: domain.Order{OrderID: 600, UserID: 60, IsPaid: true}}, nil).Once()
+
+	document, appErr := orderDocumentService.GetDocument(600, 61, false, constants.OrderDocumentTypeInvoice)
+	assert.Nil(t, document)
+	assert.NotNil(t, appErr)
+	assert.Equal(t, http.StatusNotFound, appErr.Code)
+}
+
+func TestOrderDocument_GetDocument_Stored(t *testing.T) {
+	stored := &domain.OrderDocument{OrderDocumentID: 1, OrderID: 601, Type: constants.OrderDocumentTypeInvoice, FileName: "invoice-INV-2026-000001.pdf", Content: []byte("%PDF-1.4")}
+
+	mockOrderService.Mock.On("GetDetail", int64(601)).Return(&domain.OrderDetail{Order: domain.Order{OrderID: 601, UserID: 60, IsPaid: true}}, nil).Once()
+	mockOrderDocumentRepo.Mock.On("GetOneByOrderID", int64(601), constants.OrderDocumentTypeInvoice).Return(stored, nil).Once()
+
+	document, appErr := orderDocumentService.GetDocument(601, 0, true, constants.OrderDocumentTypeInvoice)
+	assert.Nil(t, appErr)
+	assert.Equal(t, stored, document)
+	mockOrderDocumentRepo.AssertNotCalled(t, "GetOrCreateInvoice", int64(601), mock.Anything)
+}
+
+func TestOrderDocument_GetDocument_Invoice(t *testing.T) {
+	order := &domain.OrderDetail{
+		Order:         domain.Order{OrderID: 602, UserID: 60, ProductPrice: 20000, TaxPrice: 2000, TotalPrice: 22000, IsPaid: true},
+		UserName:      "Jane",
+		OrderProducts: []domain.OrderProduct{{ProductID: 1, Name: "Shirt", Quantity: 2, Price: 10000, TaxClass: "standard", TaxRate: 10, TaxPrice: 2000, TotalPrice: 22000}},
+	}
+
+	mockOrderService.Mock.On("GetDetail", int64(602)).Return(order, nil).Once()
+	mockOrderDocumentRepo.Mock.On("GetOneByOrderID", int64(602), constants.OrderDocumentTypeInvoice).Return(nil, errs.NewNotFoundError("Order document not found!")).Once()
+	mockOrderDocumentRepo.Mock.On("GetOrCreateInvoice", int64(602), mock.Anything).Return(&domain.Invoice{OrderID: 602, InvoiceNumber: "INV-2026-000007", IssuedAt: time.Now()}, nil).Once()
+	mockOrderDocumentRepo.Mock.On("Insert", mock.MatchedBy(func(document *domain.OrderDocument) bool {
+		return document.OrderID == 602
+	})).Return(true, nil).Once()
+
+	document, appErr := orderDocumentService.GetDocument(602, 60, false, constants.OrderDocumentTypeInvoice)
+	assert.Nil(t, appErr)
+	assert.Equal(t, "invoice-INV-2026-000007.pdf", document.FileName)
+	assert.True(t, bytes.HasPrefix(document.Content, []byte("%PDF-")))
+	assert.True(t, bytes.Contains(document.Content, []byte("(INV-2026-000007)")))
+	assert.True(t, bytes.Contains(document.Content, []byte("(USD 20,000)")))
+}
+
+func TestOrderDocument_GetDocument_NotPaid(t *testing.T) {
+	mockOrderService.Mock.On("GetDetail", int64(603)).Return(&domain.OrderDetail{Order: domain.Order{OrderID: 603, UserID: 60}}, nil).Once()
+	mockOrderDocumentRepo.Mock.On("GetOneByOrderID", int64(603), constants.OrderDocumentTypePackingSlip).Return(nil, errs.NewNotFoundError("Order document not found!")).Once()
+
+	document, appErr := orderDocumentService.GetDocument(603, 60, false, constants.OrderDocumentTypePackingSlip)
+	assert.Nil(t, document)
+	assert.NotNil(t, appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.Code)
+}
+
+func TestOrderDocument_GetDocument_StoredPackingSlipCancelled(t *testing.T) {
+	mockOrderService.Mock.On("GetDetail", int64(604)).Return(&domain.OrderDetail{Order: domain.Order{OrderID: 604, UserID: 60, IsPaid: true, Status: constants.OrderStatusCancelled}}, nil).Once()
+
+	document, appErr := orderDocumentService.GetDocument(604, 0, true, constants.OrderDocumentTypePackingSlip)
+	assert.Nil(t, document)
+	assert.NotNil(t, appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.Code)
+	mockOrderDocumentRepo.AssertNotCalled(t, "GetOneByOrderID", int64(604), constants.OrderDocumentTypePackingSlip)
+}
+
+func TestOrderDocument_InvoiceTaxes(t *testing.T) {
+	taxes := invoiceTaxes([]domain.OrderProduct{
+		{Quantity: 1, Price: 11000, TaxClass: "standard", TaxRate: 10, IsTaxInclusive: true, TaxPrice: 1000},
+		{Quantity: 2, Price: 5000, CouponDiscountPrice: 1000, TaxClass: "reduced", TaxRate: 5, TaxPrice: 450},
+		{Quantity: 1, Price: 22000, TaxClass: "standard", TaxRate: 10, IsTaxInclusive: true, TaxPrice: 2000},
+	})
+
+	assert.Len(t, taxes, 2)
+	assert.Equal(t, invoiceTax{TaxClass: "reduced", TaxRate: 5, TaxablePrice: 9000, TaxPrice: 450}, taxes[0])
+	assert.Equal(t, invoiceTax{TaxClass: "standard", TaxRate: 10, IsInclusive: true, TaxablePrice: 30000, TaxPrice: 3000}, taxes[1])
+}
@@ -0,0 +1,195 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/go-common-packages/logger"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/danisbagus/matchoshop/utils/constants"
+	"github.com/danisbagus/matchoshop/utils/helper"
+)
+
+type OrderReturnService struct {
+	repo             port.OrderReturnRepo
+	repoOrder        port.OrderRepo
+	repoOrderProduct port.OrderProductRepo
+	returnWindowDays int64
+}
+
+func NewOrderReturnService(repo port.OrderReturnRepo, repoOrder port.OrderRepo, repoOrderProduct port.OrderProductRepo) port.OrderReturnService {
+	return &OrderReturnService{
+		repo:             repo,
+		repoOrder:        repoOrder,
+		repoOrderProduct: repoOrderProduct,
+		returnWindowDays: helper.EnvOrderReturnWindowDays(),
+	}
+}
+
+// Create opens a return for lines of a delivered order within the return window, each line is refunded at
+// the price the customer paid for it, tax included
+func (s OrderReturnService) Create(form *domain.OrderReturn) (*domain.OrderReturn, *errs.AppError) {
+	order, appErr := s.repoOrder.GetOneByID(form.OrderID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if order.UserID != form.UserID {
+		return nil, errs.NewNotFoundError("Order not found!")
+	}
+
+	if order.Order.Status != constants.OrderStatusDelivered || order.DeliveredAt == nil {
+		return nil, errs.NewBadRequestError(fmt.Sprintf("Order is %s and cannot be returned", order.Order.Status))
+	}
+
+	now := time.Now()
+	if now.After(order.DeliveredAt.AddDate(0, 0, int(s.returnWindowDays))) {
+		return nil, errs.NewBadRequestError(fmt.Sprintf("Return window of %d days has passed", s.returnWindowDays))
+	}
+
+	orderProducts, appErr := s.repoOrderProduct.GetAllByOrderID(form.OrderID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	// an early answer for the customer, the repo checks the quantity again once the order is locked
+	returnedQuantities, appErr := s.repo.GetReturnedQuantityByOrderID(form.OrderID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	orderProductMap := make(map[int64]domain.OrderProduct)
+	for _, orderProduct := range orderProducts {
+		orderProductMap[orderProduct.ProductID] = orderProduct
+	}
+
+	form.RefundAmount = 0
+	seen := make(map[int64]bool)
+	for i, item := range form.Items {
+		if seen[item.ProductID] {
+			return nil, errs.NewBadRequestError(fmt.Sprintf("Product %d is returned more than once", item.ProductID))
+		}
+		seen[item.ProductID] = true
+
+		orderProduct, ok := orderProductMap[item.ProductID]
+		if !ok {
+			return nil, errs.NewBadRequestError(fmt.Sprintf("Product %d is not part of the order", item.ProductID))
+		}
+
+		returnable := orderProduct.Quantity - returnedQuantities[item.ProductID]
+		if item.Quantity > returnable {
+			return nil, errs.NewBadRequestError(fmt.Sprintf("Only %d of %s can be returned", returnable, orderProduct.Name))
+		}
+
+		form.Items[i].Name = orderProduct.Name
+		form.Items[i].RefundAmount = orderProduct.TotalPrice * item.Quantity / orderProduct.Quantity
+		form.RefundAmount += form.Items[i].RefundAmount
+	}
+
+	form.Status = constants.OrderReturnStatusRequested
+	form.CreatedAt = now
+	form.UpdatedAt = now
+
+	_, appErr = s.repo.Insert(form)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return form, nil
+}
+
+func (s OrderReturnService) GetListPaginate(criteria *domain.OrderReturnListCriteria) ([]domain.OrderReturn, int64, *errs.AppError) {
+	return s.repo.GetAllPaginate(criteria)
+}
+
+func (s OrderReturnService) GetListByOrder(orderID, userID int64) ([]domain.OrderReturn, *errs.AppError) {
+	order, appErr := s.repoOrder.GetOneByID(orderID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if order.UserID != userID {
+		return nil, errs.NewNotFoundError("Order not found!")
+	}
+
+	return s.repo.GetAllByOrderID(orderID)
+}
+
+func (s OrderReturnService) GetDetail(ID int64) (*domain.OrderReturn, *errs.AppError) {
+	return s.repo.GetOneByID(ID)
+}
+
+func (s OrderReturnService) GetDetailByUser(orderID, ID, userID int64) (*domain.OrderReturn, *errs.AppError) {
+	orderReturn, appErr := s.repo.GetOneByID(ID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if orderReturn.OrderID != orderID || orderReturn.UserID != userID {
+		return nil, errs.NewNotFoundError("Order return not found!")
+	}
+
+	return orderReturn, nil
+}
+
+// UpdateStatus approves, rejects or receives a return. Approving requests the refund of the return, receiving
+// puts the items back to stock when asked to
+func (s OrderReturnService) UpdateStatus(form *domain.OrderReturnStatusUpdate) *errs.AppError {
+	orderReturn, appErr := s.repo.GetOneByID(form.OrderReturnID)
+	if appErr != nil {
+		return appErr
+	}
+
+	if !containsString(orderReturnStatusTransitions[orderReturn.Status], form.ToStatus) {
+		logger.Error(fmt.Sprintf("Failed while update order return status: %s to %s is not allowed", orderReturn.Status, form.ToStatus))
+		return errs.NewBadRequestError(fmt.Sprintf("Order return cannot move from %s to %s", orderReturn.Status, form.ToStatus))
+	}
+
+	if form.Restock && form.ToStatus != constants.OrderReturnStatusReceived {
+		return errs.NewBadRequestError("Only received items can be restocked")
+	}
+
+	fromStatus := orderReturn.Status
+	history := &domain.OrderReturnStatusHistory{
+		OrderReturnID: orderReturn.OrderReturnID,
+		FromStatus:    &fromStatus,
+		ToStatus:      form.ToStatus,
+		ActorUserID:   &form.ActorUserID,
+		Note:          form.Note,
+		CreatedAt:     time.Now(),
+	}
+
+	var refund *domain.Refund
+	if form.ToStatus == constants.OrderReturnStatusApproved && orderReturn.RefundAmount > 0 {
+		order, appErr := s.repoOrder.GetOneByID(orderReturn.OrderID)
+		if appErr != nil {
+			return appErr
+		}
+
+		if order.IsPaid {
+			reason := orderReturn.Reason
+			refund = &domain.Refund{
+				OrderID:       orderReturn.OrderID,
+				OrderReturnID: &orderReturn.OrderReturnID,
+				Amount:        orderReturn.RefundAmount,
+				Reason:        &reason,
+				Status:        constants.RefundStatusRequested,
+				RequestedBy:   &form.ActorUserID,
+				CreatedAt:     history.CreatedAt,
+			}
+			for _, item := range orderReturn.Items {
+				refund.Items = append(refund.Items, domain.RefundItem{ProductID: item.ProductID, Quantity: item.Quantity, Amount: item.RefundAmount})
+			}
+		}
+	}
+
+	return s.repo.UpdateStatus(history, refund, form.Restock)
+}
+
+// orderReturnStatusTransitions is the return status graph, statuses missing as a key are final
+var orderReturnStatusTransitions = map[string][]string{
+	constants.OrderReturnStatusRequested: {constants.OrderReturnStatusApproved, constants.OrderReturnStatusRejected},
+	constants.OrderReturnStatusApproved:  {constants.OrderReturnStatusReceived},
+}
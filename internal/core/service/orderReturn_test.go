@@ -0,0 +1,85 @@
+package service
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/mocks"
+	"github.com/danisbagus/matchoshop/utils/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var mockOrderReturnRepo = &mocks.OrderReturnRepo{Mock: mock.Mock{}}
+var mockOrderProductRepo = &mocks.OrderProductRepo{Mock: mock.Mock{}}
+var orderReturnService = OrderReturnService{repo: mockOrderReturnRepo, repoOrder: mockOrderRepo, repoOrderProduct: mockOrderProductRepo, returnWindowDays: 14}
+
+func deliveredOrder(orderID, userID int64, deliveredAt time.Time) *domain.OrderDetail {
+	return &domain.OrderDetail{Order: domain.Order{OrderID: orderID, UserID: userID, Status: constants.OrderStatusDelivered, IsPaid: true, DeliveredAt: &deliveredAt}}
+}
+
+func TestOrderReturn_Create_Success(t *testing.T) {
+	form := &domain.OrderReturn{OrderID: 300, UserID: 31, Reason: "Wrong size", Items: []domain.OrderReturnItem{{ProductID: 70, Quantity: 1}}}
+
+	mockOrderRepo.Mock.On("GetOneByID", int64(300)).Return(deliveredOrder(300, 31, time.Now().AddDate(0, 0, -3)), nil).Once()
+	mockOrderProductRepo.Mock.On("GetAllByOrderID", int64(300)).Return([]domain.OrderProduct{{OrderID: 300, ProductID: 70, Name: "Polo", Quantity: 3, TotalPrice: 33000}}, nil).Once()
+	mockOrderReturnRepo.Mock.On("GetReturnedQuantityByOrderID", int64(300)).Return(map[int64]int64{70: 1}, nil).Once()
+	mockOrderReturnRepo.Mock.On("Insert", mock.MatchedBy(func(orderReturn *domain.OrderReturn) bool {
+		return orderReturn.OrderID == 300
+	})).Return(int64(1), nil).Once()
+
+	orderReturn, appErr := orderReturnService.Create(form)
+	assert.Nil(t, appErr)
+	assert.Equal(t, constants.OrderReturnStatusRequested, orderReturn.Status)
+	assert.Equal(t, int64(11000), orderReturn.RefundAmount)
+	assert.Equal(t, int64(11000), orderReturn.Items[0].RefundAmount)
+}
+
+func TestOrderReturn_Create_WindowPassed(t *testing.T) {
+	form := &domain.OrderReturn{OrderID: 301, UserID: 31, Reason: "Wrong size", Items: []domain.OrderReturnItem{{ProductID: 70, Quantity: 1}}}
+
+	mockOrderRepo.Mock.On("GetOneByID", int64(301)).Return(deliveredOrder(301, 31, time.Now().AddDate(0, 0, -15)), nil).Once()
+
+	_, appErr := orderReturnService.Create(form)
+	assert.NotNil(t, appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.Code)
+}
+
+func TestOrderReturn_Create_QuantityExceeded(t *testing.T) {
+	form := &domain.OrderReturn{OrderID: 302, UserID: 31, Reason: "Wrong size", Items: []domain.OrderReturnItem{{ProductID: 71, Quantity: 2}}}
+
+	mockOrderRepo.Mock.On("GetOneByID", int64(302)).Return(deliveredOrder(302, 31, time.Now()), nil).Once()
+	mockOrderProductRepo.Mock.On("GetAllByOrderID", int64(302)).Return([]domain.OrderProduct{{OrderID: 302, ProductID: 71, Name: "Belt", Quantity: 2, TotalPrice: 6000}}, nil).Once()
+	mockOrderReturnRepo.Mock.On("GetReturnedQuantityByOrderID", int64(302)).Return(map[int64]int64{71: 1}, nil).Once()
+
+	_, appErr := orderReturnService.Create(form)
+	assert.NotNil(t, appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.Code)
+}
+
+func TestOrderReturn_UpdateStatus_ApproveRequestsRefund(t *testing.T) {
+	form := &domain.OrderReturnStatusUpdate{OrderReturnID: 40, ToStatus: constants.OrderReturnStatusApproved, ActorUserID: 1}
+
+	mockOrderReturnRepo.Mock.On("GetOneByID", int64(40)).Return(&domain.OrderReturn{OrderReturnID: 40, OrderID: 303, Status: constants.OrderReturnStatusRequested, RefundAmount: 11000}, nil).Once()
+	mockOrderRepo.Mock.On("GetOneByID", int64(303)).Return(deliveredOrder(303, 31, time.Now()), nil).Once()
+	mockOrderReturnRepo.Mock.On("UpdateStatus", mock.MatchedBy(func(history *domain.OrderReturnStatusHistory) bool {
+		return history.OrderReturnID == 40
+	}), mock.MatchedBy(func(refund *domain.Refund) bool {
+		return refund != nil && refund.Amount == 11000 && *refund.OrderReturnID == 40
+	}), false).Return(nil).Once()
+
+	appErr := orderReturnService.UpdateStatus(form)
+	assert.Nil(t, appErr)
+}
+
+func TestOrderReturn_UpdateStatus_ReceiveBeforeApprove(t *testing.T) {
+	form := &domain.OrderReturnStatusUpdate{OrderReturnID: 41, ToStatus: constants.OrderReturnStatusReceived, ActorUserID: 1, Restock: true}
+
+	mockOrderReturnRepo.Mock.On("GetOneByID", int64(41)).Return(&domain.OrderReturn{OrderReturnID: 41, OrderID: 304, Status: constants.OrderReturnStatusRequested}, nil).Once()
+
+	appErr := orderReturnService.UpdateStatus(form)
+	assert.NotNil(t, appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.Code)
+}
@@ -0,0 +1,329 @@
+package service
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/mocks"
+	"github.com/danisbagus/matchoshop/utils/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var mockOrderRepo = &mocks.OrderRepo{Mock: mock.Mock{}}
+var mockPaymentResultRepo = &mocks.PaymentResultRepo{Mock: mock.Mock{}}
+var mockTaxRuleRepo = &mocks.TaxRuleRepo{Mock: mock.Mock{}}
+var mockShippingRepo = &mocks.ShippingRepo{Mock: mock.Mock{}}
+var mockCouponRepo = &mocks.CouponRepo{Mock: mock.Mock{}}
+var mockStockReservationRepo = &mocks.StockReservationRepo{Mock: mock.Mock{}}
+var mockRefundService = &mocks.RefundService{Mock: mock.Mock{}}
+var orderService = OrderService{repo: mockOrderRepo, repoOrderProduct: mockOrderProductRepo, repoPaymentResult: mockPaymentResultRepo, repoProduct: mockProductRepo, repoTaxRule: mockTaxRuleRepo, repoShipping: mockShippingRepo, repoCoupon: mockCouponRepo, repoStockReservation: mockStockReservationRepo, refundService: mockRefundService, taxRate: 10, reducedTaxRate: 5, shippingPrice: 2000, freeShippingMinimum: 50000}
+
+func init() {
+	// expired reservations are released on the request path, tests that care assert the call afterwards
+	mockStockReservationRepo.Mock.On("ReleaseExpired", mock.Anything).Return(int64(0), nil)
+	// no automatic coupon runs unless a test sets up its own coupon repo
+	mockCouponRepo.Mock.On("GetAllAutomatic").Return([]domain.Coupon{}, nil)
+}
+
+func TestOrder_Create_ServerPricing(t *testing.T) {
+	form := &domain.OrderDetail{Order: domain.Order{UserID: 21}, OrderProducts: []domain.OrderProduct{
+		{ProductID: 50, Quantity: 2},
+		{ProductID: 51, Quantity: 1},
+	}}
+
+	mockProductRepo.Mock.On("GetOneByID", int64(50)).Return(&domain.ProductDetail{ProductModel: domain.ProductModel{ProductID: 50, Name: "Polo", Sku: "PL-01", Price: 8000, RegularPrice: 10000, Stock: 5, IsPublished: true}}, nil).Once()
+	mockProductRepo.Mock.On("GetOneByID", int64(51)).Return(&domain.ProductDetail{ProductModel: domain.ProductModel{ProductID: 51, Name: "Belt", Price: 3005, RegularPrice: 3005, Stock: 5, IsPublished: true}}, nil).Once()
+	mockOrderRepo.Mock.On("Insert", mock.MatchedBy(func(order *domain.OrderDetail) bool {
+		return order.UserID == 21
+	}), mock.Anything).Return(int64(100), nil).Once()
+
+	mockTaxRuleRepo.Mock.On("GetAllByLocation", "", "").Return([]domain.TaxRule{}, nil).Once()
+	mockShippingRepo.Mock.On("GetAllZoneByCountry", "").Return([]domain.ShippingZone{}, nil).Once()
+	mockShippingRepo.Mock.On("CheckAnyZone").Return(false, nil).Once()
+
+	order, appErr := orderService.Create(form)
+
+	assert.Nil(t, appErr)
+	assert.Equal(t, int64(100), order.Order.OrderID)
+	assert.Equal(t, int64(23005), order.ProductPrice)
+	assert.Equal(t, int64(4000), order.DiscountPrice)
+	assert.Equal(t, int64(1901), order.TaxPrice)
+	assert.Equal(t, int64(2000), order.ShippingPrice)
+	assert.Equal(t, int64(22906), order.TotalPrice)
+	assert.Equal(t, int64(4000), order.OrderProducts[0].DiscountPrice)
+	assert.Equal(t, int64(17600), order.OrderProducts[0].TotalPrice)
+	assert.Equal(t, int64(301), order.OrderProducts[1].TaxPrice)
+	assert.Equal(t, "Polo", order.OrderProducts[0].Name)
+	assert.Equal(t, "PL-01", order.OrderProducts[0].Sku)
+	mockStockReservationRepo.AssertCalled(t, "ReleaseExpired", order.CreatedAt)
+}
+
+func TestOrder_Create_TotalMismatch(t *testing.T) {
+	form := &domain.OrderDetail{Order: domain.Order{UserID: 22, TotalPrice: 100}, OrderProducts: []domain.OrderProduct{{ProductID: 52, Quantity: 1}}}
+
+	mockProductRepo.Mock.On("GetOneByID", int64(52)).Return(&domain.ProductDetail{ProductModel: domain.ProductModel{ProductID: 52, Price: 60000, RegularPrice: 60000, Stock: 5, IsPublished: true}}, nil).Once()
+
+	mockTaxRuleRepo.Mock.On("GetAllByLocation", "", "").Return([]domain.TaxRule{}, nil).Once()
+	mockShippingRepo.Mock.On("GetAllZoneByCountry", "").Return([]domain.ShippingZone{}, nil).Once()
+	mockShippingRepo.Mock.On("CheckAnyZone").Return(false, nil).Once()
+
+	order, appErr := orderService.Create(form)
+
+	assert.Nil(t, order)
+	assert.NotNil(t, appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.Code)
+}
+
+func TestOrder_Create_FreeShipping(t *testing.T) {
+	form := &domain.OrderDetail{Order: domain.Order{UserID: 23, TotalPrice: 66000}, OrderProducts: []domain.OrderProduct{{ProductID: 53, Quantity: 1}}}
+
+	mockProductRepo.Mock.On("GetOneByID", int64(53)).Return(&domain.ProductDetail{ProductModel: domain.ProductModel{ProductID: 53, Price: 60000, RegularPrice: 60000, Stock: 5, IsPublished: true}}, nil).Once()
+	mockOrderRepo.Mock.On("Insert", mock.MatchedBy(func(order *domain.OrderDetail) bool {
+		return order.UserID == 23
+	}), mock.Anything).Return(int64(101), nil).Once()
+
+	mockTaxRuleRepo.Mock.On("GetAllByLocation", "", "").Return([]domain.TaxRule{}, nil).Once()
+	mockShippingRepo.Mock.On("GetAllZoneByCountry", "").Return([]domain.ShippingZone{}, nil).Once()
+	mockShippingRepo.Mock.On("CheckAnyZone").Return(false, nil).Once()
+
+	order, appErr := orderService.Create(form)
+
+	assert.Nil(t, appErr)
+	assert.Equal(t, int64(0), order.ShippingPrice)
+	assert.Equal(t, int64(66000), order.TotalPrice)
+}
+
+func TestOrder_Create_ReducedTaxDefault(t *testing.T) {
+	form := &domain.OrderDetail{Order: domain.Order{UserID: 33}, OrderProducts: []domain.OrderProduct{{ProductID: 126, Quantity: 1}}}
+
+	mockProductRepo.Mock.On("GetOneByID", int64(126)).Return(&domain.ProductDetail{ProductModel: domain.ProductModel{ProductID: 126, Price: 10000, RegularPrice: 10000, Stock: 5, TaxClass: constants.TaxClassReduced, IsPublished: true}}, nil).Once()
+	mockOrderRepo.Mock.On("Insert", mock.MatchedBy(func(order *domain.OrderDetail) bool {
+		return order.UserID == 33
+	}), mock.Anything).Return(int64(117), nil).Once()
+
+	mockTaxRuleRepo.Mock.On("GetAllByLocation", "", "").Return([]domain.TaxRule{}, nil).Once()
+	mockShippingRepo.Mock.On("GetAllZoneByCountry", "").Return([]domain.ShippingZone{}, nil).Once()
+	mockShippingRepo.Mock.On("CheckAnyZone").Return(false, nil).Once()
+
+	order, appErr := orderService.Create(form)
+
+	assert.Nil(t, appErr)
+	assert.Equal(t, int64(500), order.TaxPrice)
+}
+
+func TestOrder_Create_TaxClassWithoutRule(t *testing.T) {
+	form := &domain.OrderDetail{Order: domain.Order{UserID: 34}, OrderProducts: []domain.OrderProduct{{ProductID: 127, Quantity: 1}}}
+
+	mockProductRepo.Mock.On("GetOneByID", int64(127)).Return(&domain.ProductDetail{ProductModel: domain.ProductModel{ProductID: 127, Price: 10000, RegularPrice: 10000, Stock: 5, TaxClass: "luxury", IsPublished: true}}, nil).Once()
+
+	mockTaxRuleRepo.Mock.On("GetAllByLocation", "", "").Return([]domain.TaxRule{}, nil).Once()
+
+	order, appErr := orderService.Create(form)
+
+	assert.Nil(t, order)
+	assert.NotNil(t, appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.Code)
+	mockOrderRepo.AssertNotCalled(t, "Insert", mock.MatchedBy(func(order *domain.OrderDetail) bool {
+		return order.UserID == 34
+	}), mock.Anything)
+}
+
+func TestOrder_Create_ZeroQuantity(t *testing.T) {
+	form := &domain.OrderDetail{Order: domain.Order{UserID: 35}, OrderProducts: []domain.OrderProduct{{ProductID: 131, Quantity: 0}}}
+
+	mockTaxRuleRepo.Mock.On("GetAllByLocation", "", "").Return([]domain.TaxRule{}, nil).Once()
+
+	order, appErr := orderService.Create(form)
+
+	assert.Nil(t, order)
+	assert.NotNil(t, appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.Code)
+	mockProductRepo.AssertNotCalled(t, "GetOneByID", int64(131))
+}
+
+func TestOrder_UpdateStatus_NotAllowed(t *testing.T) {
+	mockOrderRepo.Mock.On("GetOneByID", int64(102)).Return(&domain.OrderDetail{Order: domain.Order{OrderID: 102, Status: constants.OrderStatusShipped}}, nil).Once()
+
+	appErr := orderService.UpdateStatus(&domain.OrderStatusHistory{OrderID: 102, ToStatus: constants.OrderStatusProcessing})
+
+	assert.NotNil(t, appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.Code)
+}
+
+func TestOrder_UpdateStatus_Refunded(t *testing.T) {
+	mockOrderRepo.Mock.On("GetOneByID", int64(116)).Return(&domain.OrderDetail{Order: domain.Order{OrderID: 116, Status: constants.OrderStatusDelivered, IsPaid: true}}, nil).Once()
+
+	appErr := orderService.UpdateStatus(&domain.OrderStatusHistory{OrderID: 116, ToStatus: constants.OrderStatusRefunded})
+
+	assert.NotNil(t, appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.Code)
+	mockOrderRepo.AssertNotCalled(t, "UpdateStatus", mock.MatchedBy(func(history *domain.OrderStatusHistory) bool {
+		return history.OrderID == 116
+	}), mock.Anything)
+}
+
+func TestOrder_UpdateDelivered_Success(t *testing.T) {
+	mockOrderRepo.Mock.On("GetOneByID", int64(103)).Return(&domain.OrderDetail{Order: domain.Order{OrderID: 103, Status: constants.OrderStatusShipped}}, nil).Once()
+	mockOrderRepo.Mock.On("UpdateStatus", mock.MatchedBy(func(history *domain.OrderStatusHistory) bool {
+		return history.OrderID == 103 && *history.FromStatus == constants.OrderStatusShipped && history.ToStatus == constants.OrderStatusDelivered && *history.ActorUserID == 1
+	}), mock.Anything).Return(nil).Once()
+
+	appErr := orderService.UpdateDelivered(103, 1)
+
+	assert.Nil(t, appErr)
+}
+
+func TestOrder_UpdatePaid_Success(t *testing.T) {
+	form := &domain.PaymentResult{PaymentResultID: "PAY-104", OrderID: 104, Status: constants.PaymentStatusCompleted, ActorUserID: 24}
+
+	mockOrderRepo.Mock.On("GetOneByID", int64(104)).Return(&domain.OrderDetail{Order: domain.Order{OrderID: 104, UserID: 24, Status: constants.OrderStatusPendingPayment}}, nil).Once()
+	mockPaymentResultRepo.Mock.On("CheckByID", "PAY-104").Return(false, nil).Once()
+	mockPaymentResultRepo.Mock.On("CheckByOrderIDAndStatus", int64(104), constants.PaymentStatusCompleted).Return(false, nil).Once()
+	mockOrderRepo.Mock.On("UpdatePaid", form, mock.MatchedBy(func(history *domain.OrderStatusHistory) bool {
+		return history.ToStatus == constants.OrderStatusPaid && *history.ActorUserID == 24
+	}), mock.Anything).Return(nil).Once()
+
+	appErr := orderService.UpdatePaid(form)
+
+	assert.Nil(t, appErr)
+}
+
+func TestOrder_UpdatePaid_NotOwner(t *testing.T) {
+	form := &domain.PaymentResult{PaymentResultID: "PAY-120", OrderID: 120, Status: "FAILED", ActorUserID: 37}
+
+	mockOrderRepo.Mock.On("GetOneByID", int64(120)).Return(&domain.OrderDetail{Order: domain.Order{OrderID: 120, UserID: 36, Status: constants.OrderStatusPendingPayment}}, nil).Once()
+
+	appErr := orderService.UpdatePaid(form)
+
+	assert.NotNil(t, appErr)
+	assert.Equal(t, http.StatusNotFound, appErr.Code)
+	mockOrderRepo.AssertNotCalled(t, "UpdateStatus", mock.MatchedBy(func(history *domain.OrderStatusHistory) bool {
+		return history.OrderID == 120
+	}), mock.Anything)
+}
+
+func TestOrder_UpdatePaid_Cancelled(t *testing.T) {
+	form := &domain.PaymentResult{PaymentResultID: "PAY-105", OrderID: 105, Status: constants.PaymentStatusCompleted}
+
+	mockOrderRepo.Mock.On("GetOneByID", int64(105)).Return(&domain.OrderDetail{Order: domain.Order{OrderID: 105, Status: constants.OrderStatusCancelled}}, nil).Once()
+	mockPaymentResultRepo.Mock.On("CheckByID", "PAY-105").Return(false, nil).Once()
+	mockPaymentResultRepo.Mock.On("CheckByOrderIDAndStatus", int64(105), constants.PaymentStatusCompleted).Return(false, nil).Once()
+
+	appErr := orderService.UpdatePaid(form)
+
+	assert.NotNil(t, appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.Code)
+}
+
+func TestOrder_RenewStockReservation_Success(t *testing.T) {
+	mockOrderRepo.Mock.On("GetOneByID", int64(113)).Return(&domain.OrderDetail{Order: domain.Order{OrderID: 113, UserID: 29, Status: constants.OrderStatusPaymentFailed}}, nil).Once()
+	mockOrderRepo.Mock.On("RenewStockReservation", int64(113), mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+	order, appErr := orderService.RenewStockReservation(113, 29)
+
+	assert.Nil(t, appErr)
+	assert.NotNil(t, order.ReservationExpiresAt)
+	assert.True(t, order.ReservationExpiresAt.After(time.Now()))
+}
+
+func TestOrder_RenewStockReservation_Cancelled(t *testing.T) {
+	mockOrderRepo.Mock.On("GetOneByID", int64(114)).Return(&domain.OrderDetail{Order: domain.Order{OrderID: 114, UserID: 30, Status: constants.OrderStatusCancelled}}, nil).Once()
+
+	_, appErr := orderService.RenewStockReservation(114, 30)
+
+	assert.NotNil(t, appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.Code)
+	mockOrderRepo.AssertNotCalled(t, "RenewStockReservation", int64(114), mock.Anything)
+}
+
+func TestOrder_RenewStockReservation_NotOwner(t *testing.T) {
+	mockOrderRepo.Mock.On("GetOneByID", int64(115)).Return(&domain.OrderDetail{Order: domain.Order{OrderID: 115, UserID: 31, Status: constants.OrderStatusPendingPayment}}, nil).Once()
+
+	_, appErr := orderService.RenewStockReservation(115, 32)
+
+	assert.NotNil(t, appErr)
+	assert.Equal(t, http.StatusNotFound, appErr.Code)
+}
+
+func TestOrder_Cancel_CustomerShipped(t *testing.T) {
+	mockOrderRepo.Mock.On("GetOneByID", int64(106)).Return(&domain.OrderDetail{Order: domain.Order{OrderID: 106, UserID: 25, Status: constants.OrderStatusShipped}}, nil).Once()
+
+	appErr := orderService.Cancel(&domain.OrderCancellation{OrderID: 106, ActorUserID: 25, Reason: "Changed my mind"})
+
+	assert.NotNil(t, appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.Code)
+}
+
+func TestOrder_Cancel_NotOwner(t *testing.T) {
+	mockOrderRepo.Mock.On("GetOneByID", int64(107)).Return(&domain.OrderDetail{Order: domain.Order{OrderID: 107, UserID: 26, Status: constants.OrderStatusPendingPayment}}, nil).Once()
+
+	appErr := orderService.Cancel(&domain.OrderCancellation{OrderID: 107, ActorUserID: 27, Reason: "Changed my mind"})
+
+	assert.NotNil(t, appErr)
+	assert.Equal(t, http.StatusNotFound, appErr.Code)
+}
+
+func TestOrder_Cancel_AdminPaidProcessesRefund(t *testing.T) {
+	mockOrderRepo.Mock.On("GetOneByID", int64(108)).Return(&domain.OrderDetail{Order: domain.Order{OrderID: 108, UserID: 28, TotalPrice: 15000, IsPaid: true, Status: constants.OrderStatusShipped}}, nil).Once()
+	mockOrderRepo.Mock.On("Cancel", mock.MatchedBy(func(history *domain.OrderStatusHistory) bool {
+		return history.ToStatus == constants.OrderStatusCancelled && *history.Note == "Lost parcel"
+	}), mock.MatchedBy(func(refund *domain.Refund) bool {
+		return refund != nil && refund.Amount == 15000 && refund.Status == constants.RefundStatusRequested
+	}), mock.MatchedBy(func(event *domain.NotificationEvent) bool {
+		return event.UserID == 28 && event.Template == constants.NotificationOrderCancelled
+	})).Run(func(args mock.Arguments) {
+		args.Get(1).(*domain.Refund).RefundID = 90
+	}).Return(nil).Once()
+	mockRefundService.Mock.On("Process", int64(90), int64(1)).Return(&domain.Refund{RefundID: 90, Status: constants.RefundStatusCompleted}, nil).Once()
+
+	appErr := orderService.Cancel(&domain.OrderCancellation{OrderID: 108, ActorUserID: 1, IsAdmin: true, Reason: "Lost parcel"})
+
+	assert.Nil(t, appErr)
+	mockRefundService.AssertCalled(t, "Process", int64(90), int64(1))
+}
+
+func TestOrder_Cancel_RefundFailedKeepsCancel(t *testing.T) {
+	// the provider failure leaves the refund failed for an admin to process again, the order stays cancelled
+	mockOrderRepo.Mock.On("GetOneByID", int64(109)).Return(&domain.OrderDetail{Order: domain.Order{OrderID: 109, UserID: 29, TotalPrice: 8000, IsPaid: true, Status: constants.OrderStatusPaid}}, nil).Once()
+	mockOrderRepo.Mock.On("Cancel", mock.MatchedBy(func(history *domain.OrderStatusHistory) bool {
+		return history.OrderID == 109
+	}), mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(1).(*domain.Refund).RefundID = 91
+	}).Return(nil).Once()
+	mockRefundService.Mock.On("Process", int64(91), int64(29)).Return(nil, errs.NewUnexpectedError("Refund failed")).Once()
+
+	appErr := orderService.Cancel(&domain.OrderCancellation{OrderID: 109, ActorUserID: 29, Reason: "Ordered by mistake"})
+
+	assert.Nil(t, appErr)
+	mockRefundService.AssertCalled(t, "Process", int64(91), int64(29))
+}
+
+func TestOrder_GetDetailByUser_NotOwner(t *testing.T) {
+	mockOrderRepo.Mock.On("GetOneByID", int64(121)).Return(&domain.OrderDetail{Order: domain.Order{OrderID: 121, UserID: 36}}, nil).Once()
+	mockOrderProductRepo.Mock.On("GetAllByOrderID", int64(121)).Return([]domain.OrderProduct{}, nil).Once()
+	mockOrderRepo.Mock.On("GetAllStatusHistoryByOrderID", int64(121)).Return([]domain.OrderStatusHistory{}, nil).Once()
+	mockCouponRepo.Mock.On("GetAllRedemptionByOrderID", int64(121)).Return([]domain.CouponRedemption{}, nil).Once()
+
+	order, appErr := orderService.GetDetailByUser(121, 37)
+
+	assert.Nil(t, order)
+	assert.NotNil(t, appErr)
+	assert.Equal(t, http.StatusNotFound, appErr.Code)
+}
+
+func TestOrder_GetListPaginate(t *testing.T) {
+	isPaid := true
+	criteria := &domain.OrderListCriteria{Keyword: "budi", IsPaid: &isPaid, Page: 1, Limit: 10}
+
+	mockOrderRepo.Mock.On("GetAllPaginate", criteria).Return([]domain.OrderDetail{{Order: domain.Order{OrderID: 500}, UserName: "Budi"}}, int64(1), nil).Once()
+
+	orders, total, appErr := orderService.GetListPaginate(criteria)
+
+	assert.Nil(t, appErr)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, int64(500), orders[0].Order.OrderID)
+}
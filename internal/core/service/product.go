@@ -5,8 +5,10 @@ import (
 	"time"
 
 	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/go-common-packages/logger"
 	"github.com/danisbagus/matchoshop/internal/core/domain"
 	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/danisbagus/matchoshop/utils/constants"
 )
 
 type ProductService struct {
@@ -14,14 +16,22 @@ type ProductService struct {
 	productCategoryRepo        port.ProductCategoryRepo
 	productProductCategoryRepo port.ProductProductCategoryRepo
 	reviewRepo                 port.ReviewRepo
+	slugRedirectRepo           port.SlugRedirectRepo
+	stockReservationRepo       port.StockReservationRepo
+	attributeRepo              port.AttributeRepo
+	brandRepo                  port.BrandRepo
 }
 
-func NewProductService(repo port.ProductRepo, productCategoryRepo port.ProductCategoryRepo, productProductCategoryRepo port.ProductProductCategoryRepo, reviewRepo port.ReviewRepo) port.ProductService {
+func NewProductService(repo port.ProductRepo, productCategoryRepo port.ProductCategoryRepo, productProductCategoryRepo port.ProductProductCategoryRepo, reviewRepo port.ReviewRepo, slugRedirectRepo port.SlugRedirectRepo, stockReservationRepo port.StockReservationRepo, attributeRepo port.AttributeRepo, brandRepo port.BrandRepo) port.ProductService {
 	return &ProductService{
 		repo:                       repo,
 		productCategoryRepo:        productCategoryRepo,
 		productProductCategoryRepo: productProductCategoryRepo,
 		reviewRepo:                 reviewRepo,
+		slugRedirectRepo:           slugRedirectRepo,
+		stockReservationRepo:       stockReservationRepo,
+		attributeRepo:              attributeRepo,
+		brandRepo:                  brandRepo,
 	}
 }
 
@@ -48,6 +58,27 @@ func (r ProductService) Create(form *domain.Product) *errs.AppError {
 		}
 	}
 
+	form.Attributes, appErr = r.validateAttributes(form)
+	if appErr != nil {
+		return appErr
+	}
+
+	appErr = r.checkBrand(form.BrandID)
+	if appErr != nil {
+		return appErr
+	}
+
+	if form.TaxClass == "" {
+		form.TaxClass = constants.TaxClassStandard
+	}
+
+	form.Slug, appErr = resolveSlug(form.Slug, form.Name, constants.SlugEntityProduct, func(slug string) (bool, *errs.AppError) {
+		return r.repo.CheckByIDAndSlug(0, slug)
+	})
+	if appErr != nil {
+		return appErr
+	}
+
 	form.CreatedAt = time.Now().Format(dbTSLayout)
 	form.UpdatedAt = time.Now().Format(dbTSLayout)
 
@@ -70,6 +101,11 @@ func (r ProductService) Create(form *domain.Product) *errs.AppError {
 		return appErr
 	}
 
+	appErr = r.attributeRepo.ReplaceProductValues(newProductData.ProductID, form.Attributes)
+	if appErr != nil {
+		return appErr
+	}
+
 	return nil
 }
 
@@ -85,9 +121,12 @@ func (r ProductService) GetList(criteria *domain.ProductListCriteria) ([]domain.
 		product.ProductID = value.ProductID
 		product.Name = value.Name
 		product.Sku = value.Sku
+		product.Slug = value.Slug
 		product.Image = value.Image
+		product.BrandID = value.BrandID
 		product.Brand = value.Brand
 		product.Price = value.Price
+		product.CompareAtPrice = value.CompareAtPrice
 		product.NumbReviews = value.NumbReviews
 		product.Rating = value.Rating
 		result = append(result, product)
@@ -98,6 +137,20 @@ func (r ProductService) GetList(criteria *domain.ProductListCriteria) ([]domain.
 
 func (r ProductService) GetListPaginate(criteria *domain.ProductListCriteria) ([]domain.ProductDetail, int64, *errs.AppError) {
 
+	// a parent category includes the products of all its descendant categories
+	if criteria.ProductCategoryID != 0 {
+		productCategoryIDs, appErr := r.productCategoryRepo.GetAllDescendantIDs(criteria.ProductCategoryID)
+		if appErr != nil {
+			return nil, 0, appErr
+		}
+		criteria.ProductCategoryIDs = productCategoryIDs
+	}
+
+	appErr := r.resolveAttributeFilters(criteria)
+	if appErr != nil {
+		return nil, 0, appErr
+	}
+
 	products, total, appErr := r.repo.GetAllPaginate(criteria)
 	if appErr != nil {
 		return nil, 0, appErr
@@ -109,9 +162,12 @@ func (r ProductService) GetListPaginate(criteria *domain.ProductListCriteria) ([
 		product.ProductID = value.ProductID
 		product.Name = value.Name
 		product.Sku = value.Sku
+		product.Slug = value.Slug
 		product.Image = value.Image
+		product.BrandID = value.BrandID
 		product.Brand = value.Brand
 		product.Price = value.Price
+		product.CompareAtPrice = value.CompareAtPrice
 		product.NumbReviews = value.NumbReviews
 		product.Rating = value.Rating
 
@@ -127,13 +183,115 @@ func (r ProductService) GetListPaginate(criteria *domain.ProductListCriteria) ([
 	return result, total, nil
 }
 
+// resolveAttributeFilters maps the filter codes to their definitions, only enum and number attributes are filterable
+func (r ProductService) resolveAttributeFilters(criteria *domain.ProductListCriteria) *errs.AppError {
+	if len(criteria.AttributeFilters) == 0 {
+		return nil
+	}
+
+	codes := make([]string, 0, len(criteria.AttributeFilters))
+	for _, filter := range criteria.AttributeFilters {
+		codes = append(codes, filter.Code)
+	}
+
+	definitions, appErr := r.attributeRepo.GetAllByCodes(codes)
+	if appErr != nil {
+		return appErr
+	}
+
+	definitionByCode := make(map[string]domain.AttributeDefinition)
+	for _, definition := range definitions {
+		definitionByCode[definition.Code] = definition
+	}
+
+	for i, filter := range criteria.AttributeFilters {
+		definition, ok := definitionByCode[filter.Code]
+		if !ok {
+			return errs.NewBadRequestError(fmt.Sprintf("Attribute %s not found", filter.Code))
+		}
+
+		switch definition.DataType {
+		case constants.AttributeTypeEnum:
+			if len(filter.Values) == 0 {
+				return errs.NewBadRequestError(fmt.Sprintf("Attribute %s filter requires values", filter.Code))
+			}
+		case constants.AttributeTypeNumber:
+			if filter.Min == nil && filter.Max == nil {
+				return errs.NewBadRequestError(fmt.Sprintf("Attribute %s filter requires min or max", filter.Code))
+			}
+		default:
+			return errs.NewBadRequestError(fmt.Sprintf("Attribute %s is not filterable", filter.Code))
+		}
+
+		criteria.AttributeFilters[i].AttributeDefinitionID = definition.AttributeDefinitionID
+	}
+
+	return nil
+}
+
+func (r ProductService) GetLowStockListPaginate(criteria *domain.ProductListCriteria) ([]domain.ProductModel, int64, *errs.AppError) {
+	r.releaseExpiredStock()
+
+	products, total, appErr := r.repo.GetAllLowStockPaginate(criteria)
+	if appErr != nil {
+		return nil, 0, appErr
+	}
+
+	return products, total, nil
+}
+
 func (r ProductService) GetDetail(productID int64) (*domain.ProductDetail, *errs.AppError) {
+	r.releaseExpiredStock()
+
 	// fetch product
 	product, err := r.repo.GetOneByID(productID)
 	if err != nil {
 		return nil, err
 	}
 
+	return r.completeDetail(product)
+}
+
+// GetPublishedDetail is the storefront detail, an unpublished product is not found
+func (r ProductService) GetPublishedDetail(productID int64) (*domain.ProductDetail, *errs.AppError) {
+	r.releaseExpiredStock()
+
+	product, err := r.repo.GetOnePublishedByID(productID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.completeDetail(product)
+}
+
+func (r ProductService) GetDetailBySlug(slug string) (*domain.ProductDetail, *errs.AppError) {
+	r.releaseExpiredStock()
+
+	product, appErr := r.repo.GetOneBySlug(slug)
+	if appErr == nil {
+		return r.completeDetail(product)
+	}
+
+	productID, appErr := redirectedEntityID(r.slugRedirectRepo, constants.SlugEntityProduct, slug, appErr, "Product not found!")
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return r.GetPublishedDetail(productID)
+}
+
+// releaseExpiredStock gives stock held by orders past their payment deadline back before stock is shown,
+// the serverless handler has no sweeper running. A failure is logged and the stock shown as stored.
+func (r ProductService) releaseExpiredStock() {
+	_, appErr := r.stockReservationRepo.ReleaseExpired(time.Now())
+	if appErr != nil {
+		logger.Error("Error while release expired stock reservation: " + appErr.Message)
+	}
+}
+
+func (r ProductService) completeDetail(product *domain.ProductDetail) (*domain.ProductDetail, *errs.AppError) {
+	productID := product.ProductID
+
 	// fetch product categories
 	productCategories, err := r.productCategoryRepo.GetAllByProductID(productID)
 	if err != nil {
@@ -141,6 +299,24 @@ func (r ProductService) GetDetail(productID int64) (*domain.ProductDetail, *errs
 	}
 	product.ProductCategories = productCategories
 
+	// breadcrumb from the root category for each product category
+	breadcrumbs := make([][]domain.ProductCategory, 0)
+	for _, productCategory := range productCategories {
+		ancestors, err := r.productCategoryRepo.GetAllAncestors(productCategory.ProductCategoryID)
+		if err != nil {
+			return nil, err
+		}
+		breadcrumbs = append(breadcrumbs, ancestors)
+	}
+	product.Breadcrumbs = breadcrumbs
+
+	// fetch product attributes
+	productAttributes, err := r.attributeRepo.GetAllProductValues(productID)
+	if err != nil {
+		return nil, err
+	}
+	product.Attributes = productAttributes
+
 	// fetch product reviews
 	productReviews, err := r.reviewRepo.GetAllByProductID(productID)
 	if err != nil {
@@ -173,6 +349,11 @@ func (r ProductService) Update(productID int64, form *domain.Product) *errs.AppE
 		return errs.NewBadRequestError("Product not found")
 	}
 
+	product, appErr := r.repo.GetOneByID(productID)
+	if appErr != nil {
+		return appErr
+	}
+
 	checkProductSKU, appErr := r.repo.CheckByIDAndSKU(productID, form.Sku)
 	if appErr != nil {
 		return appErr
@@ -194,12 +375,43 @@ func (r ProductService) Update(productID int64, form *domain.Product) *errs.AppE
 		}
 	}
 
+	form.Attributes, appErr = r.validateAttributes(form)
+	if appErr != nil {
+		return appErr
+	}
+
+	appErr = r.checkBrand(form.BrandID)
+	if appErr != nil {
+		return appErr
+	}
+
+	if form.TaxClass == "" {
+		form.TaxClass = product.TaxClass
+	}
+
+	form.IsPublished = product.IsPublished
+	if form.Publish != nil {
+		form.IsPublished = *form.Publish
+	}
+
+	form.Slug, appErr = renameSlug(form.Slug, product.Slug, form.Name, constants.SlugEntityProduct, func(slug string) (bool, *errs.AppError) {
+		return r.repo.CheckByIDAndSlug(productID, slug)
+	})
+	if appErr != nil {
+		return appErr
+	}
+
 	form.UpdatedAt = time.Now().Format(dbTSLayout)
 	appErr = r.repo.Update(productID, form)
 	if appErr != nil {
 		return appErr
 	}
 
+	appErr = redirectOldSlug(r.slugRedirectRepo, constants.SlugEntityProduct, productID, product.Slug, form.Slug, form.UpdatedAt)
+	if appErr != nil {
+		return appErr
+	}
+
 	formProductProductCategory := make([]domain.ProductProductCategory, 0)
 
 	for _, productCategoryID := range form.ProductCategoryIDs {
@@ -219,9 +431,53 @@ func (r ProductService) Update(productID int64, form *domain.Product) *errs.AppE
 		return appErr
 	}
 
+	appErr = r.attributeRepo.ReplaceProductValues(productID, form.Attributes)
+	if appErr != nil {
+		return appErr
+	}
+
+	return nil
+}
+
+func (r ProductService) checkBrand(brandID *int64) *errs.AppError {
+	if brandID == nil {
+		return nil
+	}
+
+	checkBrand, appErr := r.brandRepo.CheckByID(*brandID)
+	if appErr != nil {
+		return appErr
+	}
+
+	if !checkBrand {
+		return errs.NewBadRequestError("Brand not found")
+	}
+
 	return nil
 }
 
+// validateAttributes checks the attribute values against the definitions of the product categories
+func (r ProductService) validateAttributes(form *domain.Product) ([]domain.ProductAttributeValue, *errs.AppError) {
+	// attributes scoped to a parent category apply to its descendants as well
+	productCategoryIDs := make([]int64, 0)
+	for _, productCategoryID := range form.ProductCategoryIDs {
+		ancestors, appErr := r.productCategoryRepo.GetAllAncestors(productCategoryID)
+		if appErr != nil {
+			return nil, appErr
+		}
+		for _, ancestor := range ancestors {
+			productCategoryIDs = append(productCategoryIDs, ancestor.ProductCategoryID)
+		}
+	}
+
+	definitions, appErr := r.attributeRepo.GetAllByProductCategoryIDs(productCategoryIDs)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return validateAttributeValues(definitions, form.Attributes)
+}
+
 func (r ProductService) Delete(productID int64) *errs.AppError {
 
 	checkProduct, appErr := r.repo.CheckByID(productID)
@@ -242,5 +498,10 @@ func (r ProductService) Delete(productID int64) *errs.AppError {
 		return appErr
 	}
 
+	appErr = r.attributeRepo.ReplaceProductValues(productID, nil)
+	if appErr != nil {
+		return appErr
+	}
+
 	return nil
 }
@@ -8,15 +8,18 @@ import (
 	"github.com/danisbagus/matchoshop/internal/core/domain"
 	"github.com/danisbagus/matchoshop/internal/core/port"
 	"github.com/danisbagus/matchoshop/internal/dto"
+	"github.com/danisbagus/matchoshop/utils/constants"
 )
 
 type ProductCategoryService struct {
-	repo port.ProductCategoryRepo
+	repo             port.ProductCategoryRepo
+	slugRedirectRepo port.SlugRedirectRepo
 }
 
-func NewProductCategoryService(repo port.ProductCategoryRepo) port.ProductCategoryService {
+func NewProductCategoryService(repo port.ProductCategoryRepo, slugRedirectRepo port.SlugRedirectRepo) port.ProductCategoryService {
 	return &ProductCategoryService{
-		repo: repo,
+		repo:             repo,
+		slugRedirectRepo: slugRedirectRepo,
 	}
 }
 
@@ -37,10 +40,26 @@ func (r ProductCategoryService) Create(req *dto.CreateProductCategoryRequest) (*
 		return nil, errs.NewBadRequestError(errorMessage)
 	}
 
+	appErr = r.validateParent(0, req.ParentID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	slug, appErr := resolveSlug(req.Slug, req.Name, constants.SlugEntityProductCategory, func(slug string) (bool, *errs.AppError) {
+		return r.repo.CheckByIDAndSlug(0, slug)
+	})
+	if appErr != nil {
+		return nil, appErr
+	}
+
 	formProductCategory := domain.ProductCategory{
-		Name:      req.Name,
-		CreatedAt: time.Now().Format(dbTSLayout),
-		UpdatedAt: time.Now().Format(dbTSLayout),
+		ParentID:        parentID(req.ParentID),
+		Name:            req.Name,
+		Slug:            slug,
+		MetaTitle:       req.MetaTitle,
+		MetaDescription: req.MetaDescription,
+		CreatedAt:       time.Now().Format(dbTSLayout),
+		UpdatedAt:       time.Now().Format(dbTSLayout),
 	}
 
 	newProductCategoryData, err := r.repo.Insert(&formProductCategory)
@@ -61,6 +80,33 @@ func (r ProductCategoryService) GetList() ([]domain.ProductCategory, *errs.AppEr
 	return productCategories, nil
 }
 
+func (r ProductCategoryService) GetTree() ([]domain.ProductCategory, *errs.AppError) {
+	productCategories, appErr := r.repo.GetAll()
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	childrenByParentID := make(map[int64][]domain.ProductCategory)
+	for _, productCategory := range productCategories {
+		var parentID int64
+		if productCategory.ParentID != nil {
+			parentID = *productCategory.ParentID
+		}
+		childrenByParentID[parentID] = append(childrenByParentID[parentID], productCategory)
+	}
+
+	return buildProductCategoryTree(childrenByParentID, 0), nil
+}
+
+func buildProductCategoryTree(childrenByParentID map[int64][]domain.ProductCategory, parentID int64) []domain.ProductCategory {
+	productCategories := make([]domain.ProductCategory, 0)
+	for _, productCategory := range childrenByParentID[parentID] {
+		productCategory.Children = buildProductCategoryTree(childrenByParentID, productCategory.ProductCategoryID)
+		productCategories = append(productCategories, productCategory)
+	}
+	return productCategories
+}
+
 func (r ProductCategoryService) GetDetail(productCategoryID int64) (*dto.ResponseData, *errs.AppError) {
 
 	productCategory, appErr := r.repo.GetOneByID(productCategoryID)
@@ -73,6 +119,20 @@ func (r ProductCategoryService) GetDetail(productCategoryID int64) (*dto.Respons
 	return response, nil
 }
 
+func (r ProductCategoryService) GetDetailBySlug(slug string) (*domain.ProductCategory, *errs.AppError) {
+	productCategory, appErr := r.repo.GetOneBySlug(slug)
+	if appErr == nil {
+		return productCategory, nil
+	}
+
+	productCategoryID, appErr := redirectedEntityID(r.slugRedirectRepo, constants.SlugEntityProductCategory, slug, appErr, "Product category not found!")
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return r.repo.GetOneByID(productCategoryID)
+}
+
 func (r ProductCategoryService) Update(productCategoryID int64, req *dto.CreateProductCategoryRequest) (*dto.ResponseData, *errs.AppError) {
 
 	appErr := req.Validate()
@@ -99,10 +159,31 @@ func (r ProductCategoryService) Update(productCategoryID int64, req *dto.CreateP
 		return nil, errs.NewBadRequestError(errorMessage)
 	}
 
+	appErr = r.validateParent(productCategoryID, req.ParentID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	productCategory, appErr := r.repo.GetOneByID(productCategoryID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	slug, appErr := renameSlug(req.Slug, productCategory.Slug, req.Name, constants.SlugEntityProductCategory, func(slug string) (bool, *errs.AppError) {
+		return r.repo.CheckByIDAndSlug(productCategoryID, slug)
+	})
+	if appErr != nil {
+		return nil, appErr
+	}
+
 	formProductCategory := domain.ProductCategory{
-		Name:      req.Name,
-		CreatedAt: time.Now().Format(dbTSLayout),
-		UpdatedAt: time.Now().Format(dbTSLayout),
+		ParentID:        parentID(req.ParentID),
+		Name:            req.Name,
+		Slug:            slug,
+		MetaTitle:       req.MetaTitle,
+		MetaDescription: req.MetaDescription,
+		CreatedAt:       time.Now().Format(dbTSLayout),
+		UpdatedAt:       time.Now().Format(dbTSLayout),
 	}
 
 	appErr = r.repo.Update(productCategoryID, &formProductCategory)
@@ -110,12 +191,17 @@ func (r ProductCategoryService) Update(productCategoryID int64, req *dto.CreateP
 		return nil, appErr
 	}
 
+	appErr = redirectOldSlug(r.slugRedirectRepo, constants.SlugEntityProductCategory, productCategoryID, productCategory.Slug, slug, formProductCategory.UpdatedAt)
+	if appErr != nil {
+		return nil, appErr
+	}
+
 	response := dto.GenerateResponseData("Successfully update data", map[string]string{})
 
 	return response, nil
 }
 
-func (r ProductCategoryService) Delete(productCategoryID int64) (*dto.ResponseData, *errs.AppError) {
+func (r ProductCategoryService) Delete(productCategoryID int64, reassignToID int64) (*dto.ResponseData, *errs.AppError) {
 
 	checkProductCategory, appErr := r.repo.CheckByID(productCategoryID)
 	if appErr != nil {
@@ -126,6 +212,36 @@ func (r ProductCategoryService) Delete(productCategoryID int64) (*dto.ResponseDa
 		return nil, errs.NewBadRequestError("Product category not found")
 	}
 
+	if reassignToID != 0 {
+		appErr = r.validateParent(productCategoryID, &reassignToID)
+		if appErr != nil {
+			return nil, appErr
+		}
+
+		appErr = r.repo.Reassign(productCategoryID, reassignToID)
+		if appErr != nil {
+			return nil, appErr
+		}
+	} else {
+		checkChild, appErr := r.repo.CheckChildByID(productCategoryID)
+		if appErr != nil {
+			return nil, appErr
+		}
+
+		if checkChild {
+			return nil, errs.NewBadRequestError("Product category still has child categories, reassign them first")
+		}
+
+		checkProduct, appErr := r.repo.CheckProductByID(productCategoryID)
+		if appErr != nil {
+			return nil, appErr
+		}
+
+		if checkProduct {
+			return nil, errs.NewBadRequestError("Product category still has products, reassign them first")
+		}
+	}
+
 	appErr = r.repo.Delete(productCategoryID)
 	if appErr != nil {
 		return nil, appErr
@@ -135,3 +251,43 @@ func (r ProductCategoryService) Delete(productCategoryID int64) (*dto.ResponseDa
 
 	return response, nil
 }
+
+// validateParent makes sure the parent exists and is not the category itself or one of its descendants
+func (r ProductCategoryService) validateParent(productCategoryID int64, parentID *int64) *errs.AppError {
+	if parentID == nil || *parentID == 0 {
+		return nil
+	}
+
+	checkProductCategory, appErr := r.repo.CheckByID(*parentID)
+	if appErr != nil {
+		return appErr
+	}
+
+	if !checkProductCategory {
+		return errs.NewBadRequestError("Parent product category not found")
+	}
+
+	if productCategoryID == 0 {
+		return nil
+	}
+
+	descendantIDs, appErr := r.repo.GetAllDescendantIDs(productCategoryID)
+	if appErr != nil {
+		return appErr
+	}
+
+	for _, descendantID := range descendantIDs {
+		if descendantID == *parentID {
+			return errs.NewBadRequestError("Product category can not be placed under itself or its descendants")
+		}
+	}
+
+	return nil
+}
+
+func parentID(id *int64) *int64 {
+	if id == nil || *id == 0 {
+		return nil
+	}
+	return id
+}
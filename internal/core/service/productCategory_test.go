@@ -13,7 +13,8 @@ import (
 )
 
 var mockProductCategoryRepo = &mocks.ProductCategoryRepo{Mock: mock.Mock{}}
-var productCategoryService = ProductCategoryService{repo: mockProductCategoryRepo}
+var mockSlugRedirectRepo = &mocks.SlugRedirectRepo{Mock: mock.Mock{}}
+var productCategoryService = ProductCategoryService{repo: mockProductCategoryRepo, slugRedirectRepo: mockSlugRedirectRepo}
 
 func TestProductCategory_Create_NotValidated(t *testing.T) {
 
@@ -46,9 +47,11 @@ func TestProductCategory_Create_Success(t *testing.T) {
 	}
 
 	mockProductCategoryRepo.Mock.On("CheckByName", req.Name).Return(false, nil)
+	mockProductCategoryRepo.Mock.On("CheckByIDAndSlug", int64(0), "sport").Return(false, nil).Once()
 
 	formProductCategory := domain.ProductCategory{
 		Name:      req.Name,
+		Slug:      "sport",
 		CreatedAt: time.Now().Format(dbTSLayout),
 		UpdatedAt: time.Now().Format(dbTSLayout),
 	}
@@ -56,6 +59,7 @@ func TestProductCategory_Create_Success(t *testing.T) {
 	resultProductCategory := domain.ProductCategory{
 		ProductCategoryID: 1,
 		Name:              formProductCategory.Name,
+		Slug:              formProductCategory.Slug,
 		CreatedAt:         formProductCategory.CreatedAt,
 		UpdatedAt:         formProductCategory.UpdatedAt,
 	}
@@ -147,8 +151,17 @@ func TestProductCategory_Updated_Success(t *testing.T) {
 
 	mockProductCategoryRepo.Mock.On("CheckByIDAndName", int64(productCategoryID), req.Name).Return(false, nil)
 
+	currentProductCategory := domain.ProductCategory{
+		ProductCategoryID: int64(productCategoryID),
+		Name:              "Sports",
+		Slug:              "sports",
+	}
+
+	mockProductCategoryRepo.Mock.On("GetOneByID", int64(productCategoryID)).Return(&currentProductCategory, nil)
+
 	formProductCategory := domain.ProductCategory{
 		Name:      req.Name,
+		Slug:      currentProductCategory.Slug,
 		CreatedAt: time.Now().Format(dbTSLayout),
 		UpdatedAt: time.Now().Format(dbTSLayout),
 	}
@@ -167,7 +180,7 @@ func TestProductCategory_Delete_NotFound(t *testing.T) {
 
 	mockProductCategoryRepo.Mock.On("CheckByID", int64(productCategoryID)).Return(false, nil)
 
-	productCategory, appErr := productCategoryService.Delete(int64(productCategoryID))
+	productCategory, appErr := productCategoryService.Delete(int64(productCategoryID), 0)
 
 	assert.Nil(t, productCategory)
 	assert.NotNil(t, appErr)
@@ -179,10 +192,147 @@ func TestProductCategory_Delete_Success(t *testing.T) {
 
 	mockProductCategoryRepo.Mock.On("CheckByID", int64(productCategoryID)).Return(true, nil)
 
+	mockProductCategoryRepo.Mock.On("CheckChildByID", int64(productCategoryID)).Return(false, nil).Once()
+
+	mockProductCategoryRepo.Mock.On("CheckProductByID", int64(productCategoryID)).Return(false, nil).Once()
+
+	mockProductCategoryRepo.Mock.On("Delete", int64(productCategoryID)).Return(nil)
+
+	productCategory, appErr := productCategoryService.Delete(int64(productCategoryID), 0)
+
+	assert.NotNil(t, productCategory)
+	assert.Nil(t, appErr)
+}
+
+func TestProductCategory_Update_RenameSlug_Success(t *testing.T) {
+
+	productCategoryID := 3
+	req := dto.CreateProductCategoryRequest{
+		Name: "Outdoor",
+		Slug: "Outdoor Gear",
+	}
+
+	mockProductCategoryRepo.Mock.On("CheckByID", int64(productCategoryID)).Return(true, nil)
+
+	mockProductCategoryRepo.Mock.On("CheckByIDAndName", int64(productCategoryID), req.Name).Return(false, nil)
+
+	currentProductCategory := domain.ProductCategory{
+		ProductCategoryID: int64(productCategoryID),
+		Name:              "Outdoor",
+		Slug:              "outdoor",
+	}
+
+	mockProductCategoryRepo.Mock.On("GetOneByID", int64(productCategoryID)).Return(&currentProductCategory, nil)
+
+	mockProductCategoryRepo.Mock.On("CheckByIDAndSlug", int64(productCategoryID), "outdoor-gear").Return(false, nil).Once()
+
+	mockProductCategoryRepo.Mock.On("Update", int64(productCategoryID), mock.Anything).Return(nil)
+
+	mockSlugRedirectRepo.Mock.On("Upsert", mock.MatchedBy(func(data *domain.SlugRedirect) bool {
+		return data.OldSlug == "outdoor" && data.EntityID == int64(productCategoryID)
+	})).Return(nil).Once()
+
+	productCategory, appErr := productCategoryService.Update(int64(productCategoryID), &req)
+
+	assert.NotNil(t, productCategory)
+	assert.Nil(t, appErr)
+	mockSlugRedirectRepo.AssertExpectations(t)
+}
+
+func TestProductCategory_GetDetailBySlug_Redirect(t *testing.T) {
+
+	productCategoryID := 5
+
+	mockProductCategoryRepo.Mock.On("GetOneBySlug", "old-shoes").Return(nil, errs.NewNotFoundError("Product category not found!")).Once()
+
+	mockSlugRedirectRepo.Mock.On("GetOneBySlug", "product_category", "old-shoes").Return(&domain.SlugRedirect{EntityID: int64(productCategoryID)}, nil).Once()
+
+	mockProductCategoryRepo.Mock.On("GetOneByID", int64(productCategoryID)).Return(&domain.ProductCategory{
+		ProductCategoryID: int64(productCategoryID),
+		Name:              "Running shoes",
+		Slug:              "running-shoes",
+	}, nil).Once()
+
+	productCategory, appErr := productCategoryService.GetDetailBySlug("old-shoes")
+
+	assert.Nil(t, appErr)
+	assert.Equal(t, int64(productCategoryID), productCategory.ProductCategoryID)
+	assert.Equal(t, "running-shoes", productCategory.Slug)
+}
+
+func TestProductCategory_Delete_HasChildren(t *testing.T) {
+
+	productCategoryID := 2
+
+	mockProductCategoryRepo.Mock.On("CheckByID", int64(productCategoryID)).Return(true, nil)
+
+	mockProductCategoryRepo.Mock.On("CheckChildByID", int64(productCategoryID)).Return(true, nil).Once()
+
+	productCategory, appErr := productCategoryService.Delete(int64(productCategoryID), 0)
+
+	assert.Nil(t, productCategory)
+	assert.NotNil(t, appErr)
+}
+
+func TestProductCategory_Delete_Reassign_Success(t *testing.T) {
+
+	productCategoryID := 2
+	reassignToID := 4
+
+	mockProductCategoryRepo.Mock.On("CheckByID", int64(productCategoryID)).Return(true, nil)
+
+	mockProductCategoryRepo.Mock.On("CheckByID", int64(reassignToID)).Return(true, nil).Once()
+
+	mockProductCategoryRepo.Mock.On("GetAllDescendantIDs", int64(productCategoryID)).Return([]int64{2, 5}, nil).Once()
+
+	mockProductCategoryRepo.Mock.On("Reassign", int64(productCategoryID), int64(reassignToID)).Return(nil).Once()
+
 	mockProductCategoryRepo.Mock.On("Delete", int64(productCategoryID)).Return(nil)
 
-	productCategory, appErr := productCategoryService.Delete(int64(productCategoryID))
+	productCategory, appErr := productCategoryService.Delete(int64(productCategoryID), int64(reassignToID))
 
 	assert.NotNil(t, productCategory)
 	assert.Nil(t, appErr)
 }
+
+func TestProductCategory_Update_ParentIsDescendant(t *testing.T) {
+
+	productCategoryID := 6
+	parentID := int64(7)
+	req := dto.CreateProductCategoryRequest{
+		Name:     "Running",
+		ParentID: &parentID,
+	}
+
+	mockProductCategoryRepo.Mock.On("CheckByID", int64(productCategoryID)).Return(true, nil).Once()
+
+	mockProductCategoryRepo.Mock.On("CheckByIDAndName", int64(productCategoryID), req.Name).Return(false, nil).Once()
+
+	mockProductCategoryRepo.Mock.On("CheckByID", parentID).Return(true, nil).Once()
+
+	mockProductCategoryRepo.Mock.On("GetAllDescendantIDs", int64(productCategoryID)).Return([]int64{6, 7}, nil).Once()
+
+	productCategory, appErr := productCategoryService.Update(int64(productCategoryID), &req)
+
+	assert.Nil(t, productCategory)
+	assert.NotNil(t, appErr)
+}
+
+func TestProductCategory_GetTree_Success(t *testing.T) {
+
+	parentID := int64(1)
+	productCategories := []domain.ProductCategory{
+		{ProductCategoryID: 1, Name: "Apparel", Slug: "apparel"},
+		{ProductCategoryID: 2, ParentID: &parentID, Name: "Shirts", Slug: "shirts"},
+		{ProductCategoryID: 3, Name: "Shoes", Slug: "shoes"},
+	}
+
+	mockProductCategoryRepo.Mock.On("GetAll").Return(productCategories, nil).Once()
+
+	tree, appErr := productCategoryService.GetTree()
+
+	assert.Nil(t, appErr)
+	assert.Len(t, tree, 2)
+	assert.Len(t, tree[0].Children, 1)
+	assert.Equal(t, "Shirts", tree[0].Children[0].Name)
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"time"
+
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/core/port"
+)
+
+type ProductPriceService struct {
+	repo        port.ProductPriceRepo
+	repoProduct port.ProductRepo
+}
+
+func NewProductPriceService(repo port.ProductPriceRepo, repoProduct port.ProductRepo) port.ProductPriceService {
+	return &ProductPriceService{
+		repo:        repo,
+		repoProduct: repoProduct,
+	}
+}
+
+func (s ProductPriceService) CreateSchedule(form *domain.ProductPriceSchedule) *errs.AppError {
+	appErr := s.checkProduct(form.ProductID)
+	if appErr != nil {
+		return appErr
+	}
+
+	now := time.Now()
+	if form.EndsAt != nil {
+		if !form.EndsAt.After(form.StartsAt) {
+			return errs.NewBadRequestError("Schedule end time must be after its start time")
+		}
+
+		if !form.EndsAt.After(now) {
+			return errs.NewBadRequestError("Schedule end time must be in the future")
+		}
+	}
+
+	if form.CompareAtPrice != nil && *form.CompareAtPrice <= form.Price {
+		return errs.NewBadRequestError("Compare at price must be higher than the price")
+	}
+
+	form.CreatedAt = now
+
+	appErr = s.repo.InsertSchedule(form)
+	if appErr != nil {
+		return appErr
+	}
+
+	return nil
+}
+
+func (s ProductPriceService) GetScheduleList(productID int64) ([]domain.ProductPriceSchedule, *errs.AppError) {
+	appErr := s.checkProduct(productID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	schedules, appErr := s.repo.GetAllScheduleByProductID(productID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return schedules, nil
+}
+
+func (s ProductPriceService) CancelSchedule(productID, productPriceScheduleID int64) *errs.AppError {
+	schedule, appErr := s.repo.GetOneScheduleByID(productPriceScheduleID)
+	if appErr != nil {
+		return appErr
+	}
+
+	if schedule.ProductID != productID {
+		return errs.NewNotFoundError("Product price schedule not found!")
+	}
+
+	if schedule.CancelledAt != nil {
+		return errs.NewBadRequestError("Product price schedule already cancelled")
+	}
+
+	now := time.Now()
+	if schedule.EndsAt != nil && !schedule.EndsAt.After(now) {
+		return errs.NewBadRequestError("Product price schedule already ended")
+	}
+
+	appErr = s.repo.CancelSchedule(productPriceScheduleID, now)
+	if appErr != nil {
+		return appErr
+	}
+
+	return nil
+}
+
+func (s ProductPriceService) GetHistoryList(productID int64) ([]domain.ProductPriceHistory, *errs.AppError) {
+	appErr := s.checkProduct(productID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	histories, appErr := s.repo.GetAllHistoryByProductID(productID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return histories, nil
+}
+
+func (s ProductPriceService) GetPriceAt(productID int64, at time.Time) (*domain.ProductPrice, *errs.AppError) {
+	appErr := s.checkProduct(productID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	price, appErr := s.repo.GetOneByProductIDAndTime(productID, at)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return price, nil
+}
+
+func (s ProductPriceService) checkProduct(productID int64) *errs.AppError {
+	checkProduct, appErr := s.repoProduct.CheckByID(productID)
+	if appErr != nil {
+		return appErr
+	}
+
+	if !checkProduct {
+		return errs.NewNotFoundError("Product not found")
+	}
+
+	return nil
+}
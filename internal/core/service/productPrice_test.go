@@ -0,0 +1,51 @@
+package service
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var mockProductPriceRepo = &mocks.ProductPriceRepo{Mock: mock.Mock{}}
+var productPriceService = ProductPriceService{repo: mockProductPriceRepo, repoProduct: mockProductRepo}
+
+func TestProductPrice_CreateSchedule_EndBeforeStart(t *testing.T) {
+	startsAt := time.Now().Add(48 * time.Hour)
+	endsAt := startsAt.Add(-time.Hour)
+	form := &domain.ProductPriceSchedule{ProductID: 20, Price: 8000, StartsAt: startsAt, EndsAt: &endsAt}
+
+	mockProductRepo.Mock.On("CheckByID", int64(20)).Return(true, nil).Once()
+
+	appErr := productPriceService.CreateSchedule(form)
+
+	assert.NotNil(t, appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.Code)
+}
+
+func TestProductPrice_CreateSchedule_Success(t *testing.T) {
+	startsAt := time.Now().Add(24 * time.Hour)
+	endsAt := startsAt.Add(72 * time.Hour)
+	form := &domain.ProductPriceSchedule{ProductID: 20, Price: 8000, StartsAt: startsAt, EndsAt: &endsAt}
+
+	mockProductRepo.Mock.On("CheckByID", int64(20)).Return(true, nil).Once()
+	mockProductPriceRepo.Mock.On("InsertSchedule", form).Return(nil).Once()
+
+	appErr := productPriceService.CreateSchedule(form)
+
+	assert.Nil(t, appErr)
+	assert.False(t, form.CreatedAt.IsZero())
+}
+
+func TestProductPrice_CancelSchedule_OtherProduct(t *testing.T) {
+	mockProductPriceRepo.Mock.On("GetOneScheduleByID", int64(5)).Return(&domain.ProductPriceSchedule{ProductPriceScheduleID: 5, ProductID: 21}, nil).Once()
+
+	appErr := productPriceService.CancelSchedule(20, 5)
+
+	assert.NotNil(t, appErr)
+	assert.Equal(t, http.StatusNotFound, appErr.Code)
+}
@@ -11,7 +11,7 @@ import (
 
 var mockProductRepo = &mocks.ProductRepo{Mock: mock.Mock{}}
 var mockProductProductRepo = &mocks.ProductProductCategoryRepo{Mock: mock.Mock{}}
-var productService = ProductService{repo: mockProductRepo, productCategoryRepo: mockProductCategoryRepo, productProductCategoryRepo: mockProductProductRepo}
+var productService = ProductService{repo: mockProductRepo, productCategoryRepo: mockProductCategoryRepo, productProductCategoryRepo: mockProductProductRepo, stockReservationRepo: mockStockReservationRepo}
 
 var (
 	description = "The modern TB"
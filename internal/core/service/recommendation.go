@@ -0,0 +1,79 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/danisbagus/matchoshop/utils/constants"
+)
+
+type RecommendationService struct {
+	repo        port.RecommendationRepo
+	repoProduct port.ProductRepo
+}
+
+func NewRecommendationService(repo port.RecommendationRepo, repoProduct port.ProductRepo) port.RecommendationService {
+	return &RecommendationService{
+		repo:        repo,
+		repoProduct: repoProduct,
+	}
+}
+
+func (s RecommendationService) GetByProduct(productID int64) (*domain.ProductRecommendation, *errs.AppError) {
+	product, appErr := s.repoProduct.GetOnePublishedByID(productID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	priceBand := product.Price * constants.RecommendationPriceBand / 100
+	recommendation := new(domain.ProductRecommendation)
+
+	var wg sync.WaitGroup
+	errorChan := make(chan *errs.AppError, 3)
+	wg.Add(3)
+
+	go func() {
+		defer wg.Done()
+		products, appErr := s.repo.GetAllCoPurchased(productID, constants.RecommendationLimit)
+		if appErr != nil {
+			errorChan <- appErr
+		}
+		recommendation.FrequentlyBoughtTogether = products
+	}()
+
+	go func() {
+		defer wg.Done()
+		products, appErr := s.repo.GetAllSameCategory(productID, constants.RecommendationLimit)
+		if appErr != nil {
+			errorChan <- appErr
+		}
+		recommendation.SameCategory = products
+	}()
+
+	go func() {
+		defer wg.Done()
+		products, appErr := s.repo.GetAllByPriceRange(productID, product.Price-priceBand, product.Price+priceBand, constants.RecommendationLimit)
+		if appErr != nil {
+			errorChan <- appErr
+		}
+		recommendation.SimilarPrice = products
+	}()
+
+	wg.Wait()
+	close(errorChan)
+
+	for appErr := range errorChan {
+		if appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	return recommendation, nil
+}
+
+func (s RecommendationService) RecomputeCoPurchases() *errs.AppError {
+	return s.repo.RecomputeCoPurchases(time.Now())
+}
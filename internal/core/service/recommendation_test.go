@@ -0,0 +1,59 @@
+package service
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/mocks"
+	"github.com/danisbagus/matchoshop/utils/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var mockRecommendationRepo = &mocks.RecommendationRepo{Mock: mock.Mock{}}
+var recommendationService = RecommendationService{repo: mockRecommendationRepo, repoProduct: mockProductRepo}
+
+func TestRecommendation_GetByProduct_NotFound(t *testing.T) {
+	// an unpublished product is not found on the storefront
+	mockProductRepo.Mock.On("GetOnePublishedByID", int64(120)).Return(nil, errs.NewNotFoundError("Product not found!")).Once()
+
+	recommendation, appErr := recommendationService.GetByProduct(120)
+
+	assert.Nil(t, recommendation)
+	assert.NotNil(t, appErr)
+	assert.Equal(t, http.StatusNotFound, appErr.Code)
+	mockRecommendationRepo.AssertNotCalled(t, "GetAllCoPurchased", int64(120), mock.Anything)
+}
+
+func TestRecommendation_GetByProduct_Success(t *testing.T) {
+	coPurchased := []domain.ProductModel{{ProductID: 122, Name: "Running socks"}}
+	sameCategory := []domain.ProductModel{{ProductID: 123, Name: "Trail shoes"}}
+	similarPrice := []domain.ProductModel{{ProductID: 124, Name: "Canvas sneakers"}}
+
+	mockProductRepo.Mock.On("GetOnePublishedByID", int64(121)).Return(&domain.ProductDetail{ProductModel: domain.ProductModel{ProductID: 121, Price: 50000, IsPublished: true}}, nil).Once()
+	mockRecommendationRepo.Mock.On("GetAllCoPurchased", int64(121), int64(constants.RecommendationLimit)).Return(coPurchased, nil).Once()
+	mockRecommendationRepo.Mock.On("GetAllSameCategory", int64(121), int64(constants.RecommendationLimit)).Return(sameCategory, nil).Once()
+	mockRecommendationRepo.Mock.On("GetAllByPriceRange", int64(121), int64(40000), int64(60000), int64(constants.RecommendationLimit)).Return(similarPrice, nil).Once()
+
+	recommendation, appErr := recommendationService.GetByProduct(121)
+
+	assert.Nil(t, appErr)
+	assert.Equal(t, coPurchased, recommendation.FrequentlyBoughtTogether)
+	assert.Equal(t, sameCategory, recommendation.SameCategory)
+	assert.Equal(t, similarPrice, recommendation.SimilarPrice)
+}
+
+func TestRecommendation_GetByProduct_Error(t *testing.T) {
+	mockProductRepo.Mock.On("GetOnePublishedByID", int64(125)).Return(&domain.ProductDetail{ProductModel: domain.ProductModel{ProductID: 125, Price: 10000, IsPublished: true}}, nil).Once()
+	mockRecommendationRepo.Mock.On("GetAllCoPurchased", int64(125), int64(constants.RecommendationLimit)).Return([]domain.ProductModel{}, nil).Once()
+	mockRecommendationRepo.Mock.On("GetAllSameCategory", int64(125), int64(constants.RecommendationLimit)).Return(nil, errs.NewUnexpectedError("Unexpected database error")).Once()
+	mockRecommendationRepo.Mock.On("GetAllByPriceRange", int64(125), int64(8000), int64(12000), int64(constants.RecommendationLimit)).Return([]domain.ProductModel{}, nil).Once()
+
+	recommendation, appErr := recommendationService.GetByProduct(125)
+
+	assert.Nil(t, recommendation)
+	assert.NotNil(t, appErr)
+	assert.Equal(t, http.StatusInternalServerError, appErr.Code)
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/go-common-packages/logger"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/danisbagus/matchoshop/utils/constants"
+)
+
+type RefundService struct {
+	repo             port.RefundRepo
+	repoOrder        port.OrderRepo
+	repoOrderProduct port.OrderProductRepo
+	provider         port.PaymentProvider
+}
+
+func NewRefundService(repo port.RefundRepo, repoOrder port.OrderRepo, repoOrderProduct port.OrderProductRepo, provider port.PaymentProvider) port.RefundService {
+	return &RefundService{
+		repo:             repo,
+		repoOrder:        repoOrder,
+		repoOrderProduct: repoOrderProduct,
+		provider:         provider,
+	}
+}
+
+// Create refunds part of a paid order right away, either an amount of the order or quantities of its lines
+// at the price paid for them
+func (s RefundService) Create(form *domain.Refund) (*domain.Refund, *errs.AppError) {
+	order, appErr := s.repoOrder.GetOneByID(form.OrderID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if !order.IsPaid {
+		return nil, errs.NewBadRequestError("Order is not paid and cannot be refunded")
+	}
+
+	if len(form.Items) > 0 {
+		appErr = s.calculateItemAmount(form)
+		if appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	if form.Amount <= 0 {
+		return nil, errs.NewBadRequestError("Refund amount must more than 0")
+	}
+
+	refundable := order.TotalPrice - order.RefundedPrice
+	if form.Amount > refundable {
+		return nil, errs.NewBadRequestError(fmt.Sprintf("Refund amount exceeds the refundable amount of %d", refundable))
+	}
+
+	form.Status = constants.RefundStatusRequested
+	form.CreatedAt = time.Now()
+
+	_, appErr = s.repo.Insert(form)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return s.Process(form.RefundID, *form.RequestedBy)
+}
+
+func (s RefundService) GetListByOrder(orderID int64) ([]domain.Refund, *errs.AppError) {
+	_, appErr := s.repoOrder.GetOneByID(orderID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return s.repo.GetAllByOrderID(orderID)
+}
+
+// Process sends a requested or failed refund to the payment provider. A refund the provider fails is kept
+// as failed with the reason so it can be processed again
+func (s RefundService) Process(ID, actorUserID int64) (*domain.Refund, *errs.AppError) {
+	refund, appErr := s.repo.GetOneByID(ID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if refund.Status != constants.RefundStatusRequested && refund.Status != constants.RefundStatusFailed {
+		return nil, errs.NewBadRequestError(fmt.Sprintf("Refund is %s and cannot be processed", refund.Status))
+	}
+
+	order, appErr := s.repoOrder.GetOneByID(refund.OrderID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	providerRefundID, providerErr := s.provider.Refund(refund)
+
+	now := time.Now()
+	refund.ProcessedBy = &actorUserID
+	refund.ProcessedAt = &now
+
+	if providerErr != nil {
+		logger.Error(fmt.Sprintf("Failed while process refund %d: %s", refund.RefundID, providerErr.Message))
+		refund.Status = constants.RefundStatusFailed
+		refund.FailureReason = &providerErr.Message
+		appErr = s.repo.UpdateStatus(refund)
+		if appErr != nil {
+			return nil, appErr
+		}
+		return nil, providerErr
+	}
+
+	refund.Status = constants.RefundStatusCompleted
+	refund.ProviderRefundID = &providerRefundID
+	refund.FailureReason = nil
+
+	var history *domain.OrderStatusHistory
+	if order.RefundedPrice+refund.Amount >= order.TotalPrice && canTransitOrderStatus(order.Order.Status, constants.OrderStatusRefunded) {
+		history = newOrderStatusHistory(order, constants.OrderStatusRefunded, actorUserID, refund.Reason)
+	}
+
+	appErr = s.repo.Complete(refund, history)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return refund, nil
+}
+
+func (s RefundService) Reject(ID, actorUserID int64, note *string) *errs.AppError {
+	refund, appErr := s.repo.GetOneByID(ID)
+	if appErr != nil {
+		return appErr
+	}
+
+	if refund.Status != constants.RefundStatusRequested && refund.Status != constants.RefundStatusFailed {
+		return errs.NewBadRequestError(fmt.Sprintf("Refund is %s and cannot be rejected", refund.Status))
+	}
+
+	now := time.Now()
+	refund.Status = constants.RefundStatusRejected
+	refund.FailureReason = note
+	refund.ProcessedBy = &actorUserID
+	refund.ProcessedAt = &now
+
+	return s.repo.UpdateStatus(refund)
+}
+
+// calculateItemAmount prices the refunded lines at what was paid for them, tax included, and checks the
+// quantity is not refunded already
+func (s RefundService) calculateItemAmount(form *domain.Refund) *errs.AppError {
+	orderProducts, appErr := s.repoOrderProduct.GetAllByOrderID(form.OrderID)
+	if appErr != nil {
+		return appErr
+	}
+
+	refundedQuantities, appErr := s.repo.GetRefundedQuantityByOrderID(form.OrderID)
+	if appErr != nil {
+		return appErr
+	}
+
+	orderProductMap := make(map[int64]domain.OrderProduct)
+	for _, orderProduct := range orderProducts {
+		orderProductMap[orderProduct.ProductID] = orderProduct
+	}
+
+	form.Amount = 0
+	seen := make(map[int64]bool)
+	for i, item := range form.Items {
+		if seen[item.ProductID] {
+			return errs.NewBadRequestError(fmt.Sprintf("Product %d is refunded more than once", item.ProductID))
+		}
+		seen[item.ProductID] = true
+
+		orderProduct, ok := orderProductMap[item.ProductID]
+		if !ok {
+			return errs.NewBadRequestError(fmt.Sprintf("Product %d is not part of the order", item.ProductID))
+		}
+
+		refundable := orderProduct.Quantity - refundedQuantities[item.ProductID]
+		if item.Quantity > refundable {
+			return errs.NewBadRequestError(fmt.Sprintf("Only %d of %s can be refunded", refundable, orderProduct.Name))
+		}
+
+		form.Items[i].Amount = orderProduct.TotalPrice * item.Quantity / orderProduct.Quantity
+		form.Amount += form.Items[i].Amount
+	}
+	return nil
+}
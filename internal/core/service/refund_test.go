@@ -0,0 +1,79 @@
+package service
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/mocks"
+	"github.com/danisbagus/matchoshop/utils/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var mockRefundRepo = &mocks.RefundRepo{Mock: mock.Mock{}}
+var mockPaymentProvider = &mocks.PaymentProvider{Mock: mock.Mock{}}
+var refundService = RefundService{repo: mockRefundRepo, repoOrder: mockOrderRepo, repoOrderProduct: mockOrderProductRepo, provider: mockPaymentProvider}
+
+func paidOrder(orderID, totalPrice, refundedPrice int64, status string) *domain.OrderDetail {
+	return &domain.OrderDetail{Order: domain.Order{OrderID: orderID, UserID: 31, Status: status, IsPaid: true, TotalPrice: totalPrice, RefundedPrice: refundedPrice}}
+}
+
+func TestRefund_Create_ExceedsPaid(t *testing.T) {
+	actorUserID := int64(1)
+	form := &domain.Refund{OrderID: 400, Amount: 6000, RequestedBy: &actorUserID}
+
+	mockOrderRepo.Mock.On("GetOneByID", int64(400)).Return(paidOrder(400, 10000, 5000, constants.OrderStatusDelivered), nil).Once()
+
+	_, appErr := refundService.Create(form)
+	assert.NotNil(t, appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.Code)
+}
+
+func TestRefund_Create_LineAlreadyRefunded(t *testing.T) {
+	actorUserID := int64(1)
+	form := &domain.Refund{OrderID: 401, RequestedBy: &actorUserID, Items: []domain.RefundItem{{ProductID: 80, Quantity: 2}}}
+
+	mockOrderRepo.Mock.On("GetOneByID", int64(401)).Return(paidOrder(401, 30000, 0, constants.OrderStatusDelivered), nil).Once()
+	mockOrderProductRepo.Mock.On("GetAllByOrderID", int64(401)).Return([]domain.OrderProduct{{OrderID: 401, ProductID: 80, Name: "Polo", Quantity: 2, TotalPrice: 22000}}, nil).Once()
+	mockRefundRepo.Mock.On("GetRefundedQuantityByOrderID", int64(401)).Return(map[int64]int64{80: 1}, nil).Once()
+
+	_, appErr := refundService.Create(form)
+	assert.NotNil(t, appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.Code)
+}
+
+func TestRefund_Process_FullRefundMovesOrderToRefunded(t *testing.T) {
+	paymentResultID := "PAY-402"
+	refund := &domain.Refund{RefundID: 50, OrderID: 402, PaymentResultID: &paymentResultID, Amount: 4000, Status: constants.RefundStatusRequested}
+
+	mockRefundRepo.Mock.On("GetOneByID", int64(50)).Return(refund, nil).Once()
+	mockOrderRepo.Mock.On("GetOneByID", int64(402)).Return(paidOrder(402, 10000, 6000, constants.OrderStatusDelivered), nil).Once()
+	mockPaymentProvider.Mock.On("Refund", refund).Return("RF-50", nil).Once()
+	mockRefundRepo.Mock.On("Complete", mock.MatchedBy(func(refund *domain.Refund) bool {
+		return refund.RefundID == 50 && refund.Status == constants.RefundStatusCompleted && *refund.ProviderRefundID == "RF-50"
+	}), mock.MatchedBy(func(history *domain.OrderStatusHistory) bool {
+		return history != nil && history.ToStatus == constants.OrderStatusRefunded
+	})).Return(nil).Once()
+
+	result, appErr := refundService.Process(50, 1)
+	assert.Nil(t, appErr)
+	assert.Equal(t, constants.RefundStatusCompleted, result.Status)
+}
+
+func TestRefund_Process_ProviderFailureKeepsFailed(t *testing.T) {
+	paymentResultID := "PAY-403"
+	refund := &domain.Refund{RefundID: 51, OrderID: 403, PaymentResultID: &paymentResultID, Amount: 4000, Status: constants.RefundStatusRequested}
+
+	mockRefundRepo.Mock.On("GetOneByID", int64(51)).Return(refund, nil).Once()
+	mockOrderRepo.Mock.On("GetOneByID", int64(403)).Return(paidOrder(403, 10000, 0, constants.OrderStatusDelivered), nil).Once()
+	mockPaymentProvider.Mock.On("Refund", refund).Return("", errs.NewUnexpectedError("Unexpected payment provider error")).Once()
+	mockRefundRepo.Mock.On("UpdateStatus", mock.MatchedBy(func(refund *domain.Refund) bool {
+		return refund.RefundID == 51 && refund.Status == constants.RefundStatusFailed && refund.FailureReason != nil
+	})).Return(nil).Once()
+
+	_, appErr := refundService.Process(51, 1)
+	assert.NotNil(t, appErr)
+	assert.Equal(t, http.StatusInternalServerError, appErr.Code)
+}
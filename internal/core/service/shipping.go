@@ -0,0 +1,268 @@
+package service
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/danisbagus/matchoshop/utils/constants"
+)
+
+type ShippingService struct {
+	repo port.ShippingRepo
+}
+
+func NewShippingService(repo port.ShippingRepo) port.ShippingService {
+	return &ShippingService{
+		repo: repo,
+	}
+}
+
+func (s ShippingService) CreateZone(form *domain.ShippingZone) *errs.AppError {
+	appErr := normalizeShippingZone(form)
+	if appErr != nil {
+		return appErr
+	}
+
+	form.CreatedAt = time.Now()
+	form.UpdatedAt = time.Now()
+
+	return s.repo.InsertZone(form)
+}
+
+func (s ShippingService) GetZoneList() ([]domain.ShippingZone, *errs.AppError) {
+	return s.repo.GetAllZone()
+}
+
+func (s ShippingService) GetZoneDetail(shippingZoneID int64) (*domain.ShippingZone, *errs.AppError) {
+	return s.repo.GetOneZoneByID(shippingZoneID)
+}
+
+func (s ShippingService) UpdateZone(form *domain.ShippingZone) *errs.AppError {
+	_, appErr := s.repo.GetOneZoneByID(form.ShippingZoneID)
+	if appErr != nil {
+		return appErr
+	}
+
+	appErr = normalizeShippingZone(form)
+	if appErr != nil {
+		return appErr
+	}
+
+	form.UpdatedAt = time.Now()
+
+	return s.repo.UpdateZone(form)
+}
+
+func (s ShippingService) DeleteZone(shippingZoneID int64) *errs.AppError {
+	_, appErr := s.repo.GetOneZoneByID(shippingZoneID)
+	if appErr != nil {
+		return appErr
+	}
+
+	return s.repo.DeleteZone(shippingZoneID)
+}
+
+func (s ShippingService) CreateMethod(form *domain.ShippingMethod) *errs.AppError {
+	_, appErr := s.repo.GetOneZoneByID(form.ShippingZoneID)
+	if appErr != nil {
+		return appErr
+	}
+
+	appErr = checkShippingMethod(form)
+	if appErr != nil {
+		return appErr
+	}
+
+	form.CreatedAt = time.Now()
+	form.UpdatedAt = time.Now()
+
+	return s.repo.InsertMethod(form)
+}
+
+func (s ShippingService) UpdateMethod(form *domain.ShippingMethod) *errs.AppError {
+	method, appErr := s.repo.GetOneMethodByID(form.ShippingMethodID)
+	if appErr != nil {
+		return appErr
+	}
+
+	if method.ShippingZoneID != form.ShippingZoneID {
+		return errs.NewNotFoundError("Shipping method not found!")
+	}
+
+	appErr = checkShippingMethod(form)
+	if appErr != nil {
+		return appErr
+	}
+
+	form.UpdatedAt = time.Now()
+
+	return s.repo.UpdateMethod(form)
+}
+
+func (s ShippingService) DeleteMethod(shippingZoneID, shippingMethodID int64) *errs.AppError {
+	method, appErr := s.repo.GetOneMethodByID(shippingMethodID)
+	if appErr != nil {
+		return appErr
+	}
+
+	if method.ShippingZoneID != shippingZoneID {
+		return errs.NewNotFoundError("Shipping method not found!")
+	}
+
+	return s.repo.DeleteMethod(shippingMethodID)
+}
+
+// normalizeShippingZone trims the countries and keeps postal code patterns in the form postal codes are
+// matched in, a pattern may use * for any characters and ? for a single one
+func normalizeShippingZone(form *domain.ShippingZone) *errs.AppError {
+	countries := make([]string, 0, len(form.Countries))
+	for _, country := range form.Countries {
+		country = strings.TrimSpace(country)
+		if country != "" {
+			countries = append(countries, country)
+		}
+	}
+
+	if len(countries) == 0 {
+		return errs.NewBadRequestError("Shipping zone needs at least one country")
+	}
+
+	patterns := make([]string, 0, len(form.PostalCodePatterns))
+	for _, pattern := range form.PostalCodePatterns {
+		pattern = normalizePostalCode(pattern)
+		if pattern == "" {
+			continue
+		}
+
+		if _, err := path.Match(pattern, ""); err != nil {
+			return errs.NewBadRequestError(fmt.Sprintf("Postal code pattern %s is not valid", pattern))
+		}
+		patterns = append(patterns, pattern)
+	}
+
+	form.Countries = countries
+	form.PostalCodePatterns = patterns
+	return nil
+}
+
+func checkShippingMethod(form *domain.ShippingMethod) *errs.AppError {
+	if form.RateType == constants.ShippingRateTypeWeight && form.WeightUnit <= 0 {
+		form.WeightUnit = 1000
+	}
+
+	if form.RateType == constants.ShippingRateTypeTiered {
+		if len(form.Tiers) == 0 {
+			return errs.NewBadRequestError("Tiered shipping method needs at least one tier")
+		}
+
+		seen := make(map[int64]bool)
+		for _, tier := range form.Tiers {
+			if seen[tier.MinSubtotal] {
+				return errs.NewBadRequestError(fmt.Sprintf("Tier from %d is given more than once", tier.MinSubtotal))
+			}
+			seen[tier.MinSubtotal] = true
+		}
+	} else {
+		form.Tiers = nil
+	}
+
+	return nil
+}
+
+func normalizePostalCode(postalCode string) string {
+	return strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(postalCode), " ", ""))
+}
+
+// quoteShipping prices the active methods of the zones matching the address, cheapest first. Zones whose
+// postal code patterns match win over zones covering the whole country.
+func quoteShipping(zones []domain.ShippingZone, criteria *domain.ShippingQuoteCriteria) []domain.ShippingQuote {
+	postalCode := normalizePostalCode(criteria.PostalCode)
+
+	var postalZones, countryZones []domain.ShippingZone
+	for _, zone := range zones {
+		if len(zone.PostalCodePatterns) == 0 {
+			countryZones = append(countryZones, zone)
+			continue
+		}
+
+		for _, pattern := range zone.PostalCodePatterns {
+			if matched, _ := path.Match(pattern, postalCode); matched {
+				postalZones = append(postalZones, zone)
+				break
+			}
+		}
+	}
+
+	if len(postalZones) > 0 {
+		countryZones = postalZones
+	}
+
+	quotes := make([]domain.ShippingQuote, 0)
+	for _, zone := range countryZones {
+		for _, method := range zone.Methods {
+			if !method.IsActive {
+				continue
+			}
+
+			price, ok := priceShippingMethod(method, criteria)
+			if !ok {
+				continue
+			}
+
+			quotes = append(quotes, domain.ShippingQuote{
+				ShippingMethodID: method.ShippingMethodID,
+				ShippingZoneID:   zone.ShippingZoneID,
+				ZoneName:         zone.Name,
+				Name:             method.Name,
+				RateType:         method.RateType,
+				Price:            price,
+			})
+		}
+	}
+
+	sort.SliceStable(quotes, func(i, j int) bool {
+		return quotes[i].Price < quotes[j].Price
+	})
+	return quotes
+}
+
+// priceShippingMethod returns the price of the method for the criteria, a method is not offered when a
+// weight rate has no weight to go on, no tier covers the subtotal or the subtotal is below the free minimum
+func priceShippingMethod(method domain.ShippingMethod, criteria *domain.ShippingQuoteCriteria) (int64, bool) {
+	switch method.RateType {
+	case constants.ShippingRateTypeFlat:
+		return method.Rate, true
+	case constants.ShippingRateTypeWeight:
+		if criteria.Weight <= 0 {
+			return 0, false
+		}
+
+		weightUnit := method.WeightUnit
+		if weightUnit <= 0 {
+			weightUnit = 1000
+		}
+		return method.Rate * ((criteria.Weight + weightUnit - 1) / weightUnit), true
+	case constants.ShippingRateTypeTiered:
+		var price int64
+		var ok bool
+		var minSubtotal int64
+		for _, tier := range method.Tiers {
+			if tier.MinSubtotal <= criteria.Subtotal && (!ok || tier.MinSubtotal >= minSubtotal) {
+				price = tier.Price
+				minSubtotal = tier.MinSubtotal
+				ok = true
+			}
+		}
+		return price, ok
+	case constants.ShippingRateTypeFree:
+		return 0, criteria.Subtotal >= method.MinSubtotal
+	default:
+		return 0, false
+	}
+}
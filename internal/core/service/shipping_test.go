@@ -0,0 +1,92 @@
+package service
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/utils/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var shippingService = ShippingService{repo: mockShippingRepo}
+
+func TestShipping_CreateMethod_TieredWithoutTiers(t *testing.T) {
+	form := &domain.ShippingMethod{ShippingZoneID: 90, Name: "Regular", RateType: constants.ShippingRateTypeTiered}
+
+	mockShippingRepo.Mock.On("GetOneZoneByID", int64(90)).Return(&domain.ShippingZone{ShippingZoneID: 90}, nil).Once()
+
+	appErr := shippingService.CreateMethod(form)
+	assert.NotNil(t, appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.Code)
+}
+
+func TestShipping_Quote_PostalZoneWins(t *testing.T) {
+	zones := []domain.ShippingZone{
+		{ShippingZoneID: 91, Name: "Indonesia", Countries: []string{"Indonesia"}, Methods: []domain.ShippingMethod{
+			{ShippingMethodID: 910, Name: "Regular", RateType: constants.ShippingRateTypeFlat, Rate: 5000, IsActive: true},
+		}},
+		{ShippingZoneID: 92, Name: "Bali", Countries: []string{"Indonesia"}, PostalCodePatterns: []string{"80*"}, Methods: []domain.ShippingMethod{
+			{ShippingMethodID: 920, Name: "Express", RateType: constants.ShippingRateTypeFlat, Rate: 9000, IsActive: true},
+			{ShippingMethodID: 921, Name: "Cargo", RateType: constants.ShippingRateTypeWeight, Rate: 2000, WeightUnit: 1000, IsActive: true},
+			{ShippingMethodID: 922, Name: "Saver", RateType: constants.ShippingRateTypeTiered, IsActive: true, Tiers: []domain.ShippingRateTier{{MinSubtotal: 0, Price: 7000}, {MinSubtotal: 50000, Price: 3000}}},
+			{ShippingMethodID: 923, Name: "Free", RateType: constants.ShippingRateTypeFree, MinSubtotal: 100000, IsActive: true},
+			{ShippingMethodID: 924, Name: "Same day", RateType: constants.ShippingRateTypeFlat, Rate: 1000},
+		}},
+	}
+
+	quotes := quoteShipping(zones, &domain.ShippingQuoteCriteria{Country: "Indonesia", PostalCode: "80 361", Subtotal: 60000, Weight: 2500})
+
+	assert.Len(t, quotes, 3)
+	assert.Equal(t, int64(922), quotes[0].ShippingMethodID)
+	assert.Equal(t, int64(3000), quotes[0].Price)
+	assert.Equal(t, int64(921), quotes[1].ShippingMethodID)
+	assert.Equal(t, int64(6000), quotes[1].Price)
+	assert.Equal(t, int64(920), quotes[2].ShippingMethodID)
+}
+
+func TestOrder_Create_ShippingMethodRequired(t *testing.T) {
+	form := &domain.OrderDetail{Order: domain.Order{UserID: 25}, ShipmentAddress: domain.ShipmentAddress{Country: "Japan", PostalCode: "100-0001"}, OrderProducts: []domain.OrderProduct{{ProductID: 70, Quantity: 1}}}
+
+	mockTaxRuleRepo.Mock.On("GetAllByLocation", "Japan", "").Return([]domain.TaxRule{}, nil).Once()
+	mockProductRepo.Mock.On("GetOneByID", int64(70)).Return(&domain.ProductDetail{ProductModel: domain.ProductModel{ProductID: 70, Price: 10000, RegularPrice: 10000, Stock: 5, IsPublished: true}}, nil).Once()
+	mockShippingRepo.Mock.On("GetAllZoneByCountry", "Japan").Return([]domain.ShippingZone{
+		{ShippingZoneID: 93, Name: "Japan", Countries: []string{"Japan"}, Methods: []domain.ShippingMethod{
+			{ShippingMethodID: 930, Name: "Regular", RateType: constants.ShippingRateTypeFlat, Rate: 5000, IsActive: true},
+		}},
+	}, nil).Once()
+
+	order, appErr := orderService.Create(form)
+
+	assert.Nil(t, order)
+	assert.NotNil(t, appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.Code)
+}
+
+func TestOrder_Create_ShippingMethodStored(t *testing.T) {
+	shippingMethodID := int64(940)
+	form := &domain.OrderDetail{Order: domain.Order{UserID: 26, ShippingMethodID: &shippingMethodID}, ShipmentAddress: domain.ShipmentAddress{Country: "Singapore", PostalCode: "018956"},
+		OrderProducts: []domain.OrderProduct{{ProductID: 71, Quantity: 2}}}
+
+	mockTaxRuleRepo.Mock.On("GetAllByLocation", "Singapore", "").Return([]domain.TaxRule{}, nil).Once()
+	mockProductRepo.Mock.On("GetOneByID", int64(71)).Return(&domain.ProductDetail{ProductModel: domain.ProductModel{ProductID: 71, Price: 10000, RegularPrice: 10000, Stock: 5, IsPublished: true, Weight: 1200}}, nil).Once()
+	mockShippingRepo.Mock.On("GetAllZoneByCountry", "Singapore").Return([]domain.ShippingZone{
+		{ShippingZoneID: 94, Name: "Singapore", Countries: []string{"Singapore"}, Methods: []domain.ShippingMethod{
+			{ShippingMethodID: 940, Name: "Cargo", RateType: constants.ShippingRateTypeWeight, Rate: 1500, WeightUnit: 1000, IsActive: true},
+			{ShippingMethodID: 941, Name: "Express", RateType: constants.ShippingRateTypeFlat, Rate: 9000, IsActive: true},
+		}},
+	}, nil).Once()
+	mockOrderRepo.Mock.On("Insert", mock.MatchedBy(func(order *domain.OrderDetail) bool {
+		return order.UserID == 26
+	}), mock.Anything).Return(int64(102), nil).Once()
+
+	order, appErr := orderService.Create(form)
+
+	assert.Nil(t, appErr)
+	assert.Equal(t, int64(2400), order.ShippingWeight)
+	assert.Equal(t, int64(4500), order.ShippingPrice)
+	assert.Equal(t, "Cargo", *order.ShippingMethodName)
+	assert.Equal(t, int64(940), *order.ShippingMethodID)
+	assert.Equal(t, int64(20000+2000+4500), order.TotalPrice)
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/danisbagus/matchoshop/utils/helper"
+)
+
+// resolveSlug validates an explicitly requested slug, or generates one from the
+// name with a numeric suffix until checkSlug reports it is free.
+func resolveSlug(slug, name, fallback string, checkSlug func(slug string) (bool, *errs.AppError)) (string, *errs.AppError) {
+	if slug != "" {
+		slug = helper.Slugify(slug)
+		if slug == "" {
+			return "", errs.NewBadRequestError("Invalid slug")
+		}
+
+		used, appErr := checkSlug(slug)
+		if appErr != nil {
+			return "", appErr
+		}
+
+		if used {
+			errorMessage := fmt.Sprintf("Slug %s is already used", slug)
+			return "", errs.NewBadRequestError(errorMessage)
+		}
+		return slug, nil
+	}
+
+	baseSlug := helper.Slugify(name)
+	if baseSlug == "" {
+		baseSlug = fallback
+	}
+
+	for suffix := 1; ; suffix++ {
+		candidate := helper.SlugWithSuffix(baseSlug, suffix)
+		used, appErr := checkSlug(candidate)
+		if appErr != nil {
+			return "", appErr
+		}
+
+		if !used {
+			return candidate, nil
+		}
+	}
+}
+
+// renameSlug returns the slug of an entity being updated, a renamed entity keeps its current slug unless a
+// new one is given explicitly
+func renameSlug(slug, currentSlug, name, fallback string, checkSlug func(slug string) (bool, *errs.AppError)) (string, *errs.AppError) {
+	if slug == "" {
+		return currentSlug, nil
+	}
+
+	return resolveSlug(slug, name, fallback, checkSlug)
+}
+
+// redirectOldSlug records the redirect from the old slug once the entity is stored with its new slug, so a
+// lookup by the old slug still finds the entity and the client is pointed to the current slug
+func redirectOldSlug(slugRedirectRepo port.SlugRedirectRepo, entityType string, entityID int64, oldSlug, newSlug, createdAt string) *errs.AppError {
+	if newSlug == oldSlug {
+		return nil
+	}
+
+	return slugRedirectRepo.Upsert(&domain.SlugRedirect{
+		EntityType: entityType,
+		OldSlug:    oldSlug,
+		EntityID:   entityID,
+		CreatedAt:  createdAt,
+	})
+}
+
+// redirectedEntityID resolves the slug a lookup by current slug did not find, lookupErr, through its
+// redirect record
+func redirectedEntityID(slugRedirectRepo port.SlugRedirectRepo, entityType, slug string, lookupErr *errs.AppError, notFoundMessage string) (int64, *errs.AppError) {
+	if lookupErr.Code != http.StatusNotFound {
+		return 0, lookupErr
+	}
+
+	slugRedirect, appErr := slugRedirectRepo.GetOneBySlug(entityType, slug)
+	if appErr != nil {
+		return 0, appErr
+	}
+
+	if slugRedirect.EntityID == 0 {
+		return 0, errs.NewNotFoundError(notFoundMessage)
+	}
+
+	return slugRedirect.EntityID, nil
+}
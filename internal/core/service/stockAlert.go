@@ -0,0 +1,89 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/go-common-packages/logger"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/core/port"
+)
+
+type StockAlertService struct {
+	repo        port.StockAlertRepo
+	repoProduct port.ProductRepo
+}
+
+func NewStockAlertService(repo port.StockAlertRepo, repoProduct port.ProductRepo) port.StockAlertService {
+	return &StockAlertService{
+		repo:        repo,
+		repoProduct: repoProduct,
+	}
+}
+
+// Dispatch records the stock alerts of products that crossed their threshold or came back in stock, the
+// repo stores the email of every staff user or back in stock subscriber with its alert. The repo claims
+// every alert once, so a product or subscriber is not alerted again until it is re-armed.
+func (s StockAlertService) Dispatch() *errs.AppError {
+	now := time.Now()
+
+	lowStockAlerts, appErr := s.repo.InsertLowStockAlerts(now)
+	if appErr != nil {
+		return appErr
+	}
+
+	for _, stockAlert := range lowStockAlerts {
+		logger.Info(fmt.Sprintf("Low stock alert: product %d %s has %d left", stockAlert.ProductID, stockAlert.ProductName, stockAlert.Stock))
+	}
+
+	backInStockAlerts, appErr := s.repo.InsertBackInStockAlerts(now)
+	if appErr != nil {
+		return appErr
+	}
+
+	if len(backInStockAlerts) > 0 {
+		logger.Info(fmt.Sprintf("Queued %d back in stock alert", len(backInStockAlerts)))
+	}
+
+	return nil
+}
+
+func (s StockAlertService) GetListPaginate(criteria *domain.StockAlertListCriteria) ([]domain.StockAlert, int64, *errs.AppError) {
+	stockAlerts, total, appErr := s.repo.GetAllPaginate(criteria)
+	if appErr != nil {
+		return nil, 0, appErr
+	}
+
+	return stockAlerts, total, nil
+}
+
+func (s StockAlertService) Subscribe(form *domain.BackInStockSubscription) *errs.AppError {
+	product, appErr := s.repoProduct.GetOnePublishedByID(form.ProductID)
+	if appErr != nil {
+		return appErr
+	}
+
+	// the alert fires when stock goes from zero to positive
+	if product.Stock > 0 {
+		return errs.NewBadRequestError("Product is still in stock")
+	}
+
+	form.CreatedAt = time.Now().Format(dbTSLayout)
+
+	appErr = s.repo.UpsertSubscription(form)
+	if appErr != nil {
+		return appErr
+	}
+
+	return nil
+}
+
+func (s StockAlertService) Unsubscribe(productID, userID int64) *errs.AppError {
+	appErr := s.repo.DeleteSubscription(productID, userID)
+	if appErr != nil {
+		return appErr
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/mocks"
+	"github.com/danisbagus/matchoshop/utils/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var mockStockAlertRepo = &mocks.StockAlertRepo{Mock: mock.Mock{}}
+var stockAlertService = StockAlertService{repo: mockStockAlertRepo, repoProduct: mockProductRepo}
+
+func TestStockAlert_Subscribe_InStock(t *testing.T) {
+	form := &domain.BackInStockSubscription{ProductID: 10, UserID: 3}
+
+	mockProductRepo.Mock.On("GetOnePublishedByID", int64(10)).Return(&domain.ProductDetail{ProductModel: domain.ProductModel{ProductID: 10, Stock: 4}}, nil).Once()
+
+	appErr := stockAlertService.Subscribe(form)
+
+	assert.NotNil(t, appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.Code)
+}
+
+func TestStockAlert_Subscribe_Success(t *testing.T) {
+	form := &domain.BackInStockSubscription{ProductID: 11, UserID: 3}
+
+	mockProductRepo.Mock.On("GetOnePublishedByID", int64(11)).Return(&domain.ProductDetail{ProductModel: domain.ProductModel{ProductID: 11, Stock: 0}}, nil).Once()
+	mockStockAlertRepo.Mock.On("UpsertSubscription", form).Return(nil).Once()
+
+	appErr := stockAlertService.Subscribe(form)
+
+	assert.Nil(t, appErr)
+	assert.NotEmpty(t, form.CreatedAt)
+}
+
+func TestStockAlert_Dispatch_LowStock(t *testing.T) {
+	lowStockAlerts := []domain.StockAlert{{AlertType: constants.StockAlertLowStock, ProductID: 12, ProductName: "Canvas tote", Stock: 2}}
+
+	mockStockAlertRepo.Mock.On("InsertLowStockAlerts", mock.AnythingOfType("time.Time")).Return(lowStockAlerts, nil).Once()
+	mockStockAlertRepo.Mock.On("InsertBackInStockAlerts", mock.AnythingOfType("time.Time")).Return([]domain.StockAlert{}, nil).Once()
+
+	appErr := stockAlertService.Dispatch()
+
+	assert.Nil(t, appErr)
+	mockStockAlertRepo.AssertCalled(t, "InsertLowStockAlerts", mock.AnythingOfType("time.Time"))
+}
+
+func TestStockAlert_Dispatch_QueueSubscribers(t *testing.T) {
+	// the repo queues the back in stock notifications with the alerts it claims
+	firstUserID, secondUserID := int64(45), int64(46)
+	backInStockAlerts := []domain.StockAlert{
+		{AlertType: constants.StockAlertBackInStock, ProductID: 13, ProductName: "Wool beanie", UserID: &firstUserID, Stock: 8},
+		{AlertType: constants.StockAlertBackInStock, ProductID: 13, ProductName: "Wool beanie", UserID: &secondUserID, Stock: 8},
+	}
+
+	mockStockAlertRepo.Mock.On("InsertLowStockAlerts", mock.AnythingOfType("time.Time")).Return([]domain.StockAlert{}, nil).Once()
+	mockStockAlertRepo.Mock.On("InsertBackInStockAlerts", mock.AnythingOfType("time.Time")).Return(backInStockAlerts, nil).Once()
+
+	appErr := stockAlertService.Dispatch()
+
+	assert.Nil(t, appErr)
+	mockStockAlertRepo.AssertCalled(t, "InsertBackInStockAlerts", mock.AnythingOfType("time.Time"))
+}
+
+func TestStockAlert_Dispatch_AlreadyAlerted(t *testing.T) {
+	// a second run finds the product and its subscribers already claimed
+	mockStockAlertRepo.Mock.On("InsertLowStockAlerts", mock.AnythingOfType("time.Time")).Return([]domain.StockAlert{}, nil).Once()
+	mockStockAlertRepo.Mock.On("InsertBackInStockAlerts", mock.AnythingOfType("time.Time")).Return([]domain.StockAlert{}, nil).Once()
+
+	appErr := stockAlertService.Dispatch()
+
+	assert.Nil(t, appErr)
+}
+
+func TestStockAlert_Dispatch_Error(t *testing.T) {
+	mockStockAlertRepo.Mock.On("InsertLowStockAlerts", mock.AnythingOfType("time.Time")).Return(nil, errs.NewUnexpectedError("Unexpected database error")).Once()
+
+	appErr := stockAlertService.Dispatch()
+
+	assert.NotNil(t, appErr)
+	assert.Equal(t, http.StatusInternalServerError, appErr.Code)
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/danisbagus/matchoshop/utils/constants"
+)
+
+type StockMovementService struct {
+	repo        port.StockMovementRepo
+	repoProduct port.ProductRepo
+}
+
+func NewStockMovementService(repo port.StockMovementRepo, repoProduct port.ProductRepo) port.StockMovementService {
+	return &StockMovementService{
+		repo:        repo,
+		repoProduct: repoProduct,
+	}
+}
+
+func (s StockMovementService) Create(form *domain.StockMovement) *errs.AppError {
+	// sale and cancellation movements are written by the order flow only
+	switch form.MovementType {
+	case constants.StockMovementRestock, constants.StockMovementReturn:
+		if form.Quantity <= 0 {
+			return errs.NewBadRequestError(fmt.Sprintf("Quantity of %s movement must be positive", form.MovementType))
+		}
+	case constants.StockMovementAdjustment:
+		if form.Quantity == 0 {
+			return errs.NewBadRequestError("Quantity of adjustment movement must not be zero")
+		}
+	default:
+		return errs.NewBadRequestError(fmt.Sprintf("Movement type %s can not be created manually", form.MovementType))
+	}
+
+	checkProduct, appErr := s.repoProduct.CheckByID(form.ProductID)
+	if appErr != nil {
+		return appErr
+	}
+
+	if !checkProduct {
+		return errs.NewNotFoundError("Product not found")
+	}
+
+	form.CreatedAt = time.Now().Format(dbTSLayout)
+
+	appErr = s.repo.Insert(form)
+	if appErr != nil {
+		return appErr
+	}
+
+	return nil
+}
+
+func (s StockMovementService) GetListPaginate(criteria *domain.StockMovementListCriteria) ([]domain.StockMovement, int64, *errs.AppError) {
+	checkProduct, appErr := s.repoProduct.CheckByID(criteria.ProductID)
+	if appErr != nil {
+		return nil, 0, appErr
+	}
+
+	if !checkProduct {
+		return nil, 0, errs.NewNotFoundError("Product not found")
+	}
+
+	stockMovements, total, appErr := s.repo.GetAllPaginate(criteria)
+	if appErr != nil {
+		return nil, 0, appErr
+	}
+
+	return stockMovements, total, nil
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/mocks"
+	"github.com/danisbagus/matchoshop/utils/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var mockStockMovementRepo = &mocks.StockMovementRepo{Mock: mock.Mock{}}
+var stockMovementService = StockMovementService{repo: mockStockMovementRepo, repoProduct: mockProductRepo}
+
+func TestStockMovement_Create_SaleNotAllowed(t *testing.T) {
+	form := &domain.StockMovement{ProductID: 1, MovementType: constants.StockMovementSale, Quantity: -1}
+
+	appErr := stockMovementService.Create(form)
+
+	assert.NotNil(t, appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.Code)
+}
+
+func TestStockMovement_Create_NegativeRestock(t *testing.T) {
+	form := &domain.StockMovement{ProductID: 1, MovementType: constants.StockMovementRestock, Quantity: -5}
+
+	appErr := stockMovementService.Create(form)
+
+	assert.NotNil(t, appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.Code)
+}
+
+func TestStockMovement_Create_ProductNotFound(t *testing.T) {
+	form := &domain.StockMovement{ProductID: 99, MovementType: constants.StockMovementAdjustment, Quantity: -2}
+
+	mockProductRepo.Mock.On("CheckByID", int64(99)).Return(false, nil).Once()
+
+	appErr := stockMovementService.Create(form)
+
+	assert.NotNil(t, appErr)
+	assert.Equal(t, http.StatusNotFound, appErr.Code)
+}
+
+func TestStockMovement_Create_Success(t *testing.T) {
+	form := &domain.StockMovement{ProductID: 1, MovementType: constants.StockMovementAdjustment, Quantity: -2}
+
+	mockProductRepo.Mock.On("CheckByID", int64(1)).Return(true, nil).Once()
+	mockStockMovementRepo.Mock.On("Insert", form).Return(nil).Once()
+
+	appErr := stockMovementService.Create(form)
+
+	assert.Nil(t, appErr)
+	assert.NotEmpty(t, form.CreatedAt)
+}
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/go-common-packages/logger"
+	"github.com/danisbagus/matchoshop/internal/core/port"
+)
+
+type StockReservationService struct {
+	repo port.StockReservationRepo
+}
+
+func NewStockReservationService(repo port.StockReservationRepo) port.StockReservationService {
+	return &StockReservationService{
+		repo: repo,
+	}
+}
+
+func (s StockReservationService) ReleaseExpired() (int64, *errs.AppError) {
+	released, appErr := s.repo.ReleaseExpired(time.Now())
+	if appErr != nil {
+		return 0, appErr
+	}
+
+	if released > 0 {
+		logger.Info(fmt.Sprintf("Released %d expired stock reservation", released))
+	}
+
+	return released, nil
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/danisbagus/matchoshop/utils/constants"
+)
+
+type TaxRuleService struct {
+	repo port.TaxRuleRepo
+}
+
+func NewTaxRuleService(repo port.TaxRuleRepo) port.TaxRuleService {
+	return &TaxRuleService{
+		repo: repo,
+	}
+}
+
+func (s TaxRuleService) Create(form *domain.TaxRule) *errs.AppError {
+	appErr := s.checkLocation(0, form)
+	if appErr != nil {
+		return appErr
+	}
+
+	form.CreatedAt = time.Now()
+	form.UpdatedAt = time.Now()
+
+	return s.repo.Insert(form)
+}
+
+func (s TaxRuleService) GetList() ([]domain.TaxRule, *errs.AppError) {
+	return s.repo.GetAll()
+}
+
+func (s TaxRuleService) GetDetail(taxRuleID int64) (*domain.TaxRule, *errs.AppError) {
+	return s.repo.GetOneByID(taxRuleID)
+}
+
+func (s TaxRuleService) Update(form *domain.TaxRule) *errs.AppError {
+	_, appErr := s.repo.GetOneByID(form.TaxRuleID)
+	if appErr != nil {
+		return appErr
+	}
+
+	appErr = s.checkLocation(form.TaxRuleID, form)
+	if appErr != nil {
+		return appErr
+	}
+
+	form.UpdatedAt = time.Now()
+
+	return s.repo.Update(form)
+}
+
+func (s TaxRuleService) Delete(taxRuleID int64) *errs.AppError {
+	_, appErr := s.repo.GetOneByID(taxRuleID)
+	if appErr != nil {
+		return appErr
+	}
+
+	return s.repo.Delete(taxRuleID)
+}
+
+func (s TaxRuleService) checkLocation(taxRuleID int64, form *domain.TaxRule) *errs.AppError {
+	form.Country = strings.TrimSpace(form.Country)
+	form.Region = strings.TrimSpace(form.Region)
+
+	checkTaxRule, appErr := s.repo.CheckByIDAndLocation(taxRuleID, form.Country, form.Region, form.TaxClass)
+	if appErr != nil {
+		return appErr
+	}
+
+	if checkTaxRule {
+		return errs.NewBadRequestError(fmt.Sprintf("Tax rule of %s tax class in this location already exists", form.TaxClass))
+	}
+	return nil
+}
+
+// findTaxRule picks the rule of the tax class, a rule of the region wins over one covering the whole country
+func findTaxRule(taxRules []domain.TaxRule, taxClass string) (domain.TaxRule, bool) {
+	var found domain.TaxRule
+	var ok bool
+	for _, taxRule := range taxRules {
+		if taxRule.TaxClass != taxClass {
+			continue
+		}
+		if !ok || taxRule.Region != "" {
+			found = taxRule
+			ok = true
+		}
+	}
+	return found, ok
+}
+
+// calculateTax returns the tax of a line price under the rule, an inclusive rule takes the tax out of the
+// price instead of adding it on top
+func calculateTax(price int64, taxRule domain.TaxRule) int64 {
+	tax := float64(price) * taxRule.Rate / 100
+	if taxRule.IsInclusive {
+		tax = float64(price) - float64(price)*100/(100+taxRule.Rate)
+	}
+	// drop float noise so an exact amount is not rounded up or down a whole unit
+	tax = math.Round(tax*1e6) / 1e6
+
+	switch taxRule.Rounding {
+	case constants.TaxRoundingUp:
+		return int64(math.Ceil(tax))
+	case constants.TaxRoundingDown:
+		return int64(math.Floor(tax))
+	default:
+		return int64(math.Round(tax))
+	}
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/utils/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var taxRuleService = TaxRuleService{repo: mockTaxRuleRepo}
+
+func TestTaxRule_Create_Duplicate(t *testing.T) {
+	form := &domain.TaxRule{Country: " Indonesia ", TaxClass: constants.TaxClassStandard, Rate: 11}
+
+	mockTaxRuleRepo.Mock.On("CheckByIDAndLocation", int64(0), "Indonesia", "", constants.TaxClassStandard).Return(true, nil).Once()
+
+	appErr := taxRuleService.Create(form)
+	assert.NotNil(t, appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.Code)
+}
+
+func TestTaxRule_CalculateTax_Rounding(t *testing.T) {
+	assert.Equal(t, int64(301), calculateTax(3005, domain.TaxRule{Rate: 10, Rounding: constants.TaxRoundingHalfUp}))
+	assert.Equal(t, int64(300), calculateTax(3005, domain.TaxRule{Rate: 10, Rounding: constants.TaxRoundingDown}))
+	assert.Equal(t, int64(301), calculateTax(3001, domain.TaxRule{Rate: 10, Rounding: constants.TaxRoundingUp}))
+	assert.Equal(t, int64(100), calculateTax(1000, domain.TaxRule{Rate: 10, Rounding: constants.TaxRoundingUp}))
+	assert.Equal(t, int64(1000), calculateTax(11000, domain.TaxRule{Rate: 10, IsInclusive: true, Rounding: constants.TaxRoundingHalfUp}))
+}
+
+func TestOrder_Create_TaxRules(t *testing.T) {
+	region := "Bali"
+	form := &domain.OrderDetail{Order: domain.Order{UserID: 24}, ShipmentAddress: domain.ShipmentAddress{Country: "Indonesia", Region: &region}, OrderProducts: []domain.OrderProduct{
+		{ProductID: 60, Quantity: 1},
+		{ProductID: 61, Quantity: 1},
+		{ProductID: 62, Quantity: 1},
+	}}
+
+	mockTaxRuleRepo.Mock.On("GetAllByLocation", "Indonesia", "Bali").Return([]domain.TaxRule{
+		{TaxClass: constants.TaxClassStandard, Country: "Indonesia", Rate: 11, Rounding: constants.TaxRoundingHalfUp},
+		{TaxClass: constants.TaxClassStandard, Country: "Indonesia", Region: "Bali", Rate: 12, Rounding: constants.TaxRoundingHalfUp},
+		{TaxClass: constants.TaxClassReduced, Country: "Indonesia", Rate: 5, IsInclusive: true, Rounding: constants.TaxRoundingDown},
+	}, nil).Once()
+	mockShippingRepo.Mock.On("GetAllZoneByCountry", "Indonesia").Return([]domain.ShippingZone{}, nil).Once()
+	mockShippingRepo.Mock.On("CheckAnyZone").Return(false, nil).Once()
+	mockProductRepo.Mock.On("GetOneByID", int64(60)).Return(&domain.ProductDetail{ProductModel: domain.ProductModel{ProductID: 60, Price: 10000, RegularPrice: 10000, Stock: 5, IsPublished: true, TaxClass: constants.TaxClassStandard}}, nil).Once()
+	mockProductRepo.Mock.On("GetOneByID", int64(61)).Return(&domain.ProductDetail{ProductModel: domain.ProductModel{ProductID: 61, Price: 10500, RegularPrice: 10500, Stock: 5, IsPublished: true, TaxClass: constants.TaxClassReduced}}, nil).Once()
+	mockProductRepo.Mock.On("GetOneByID", int64(62)).Return(&domain.ProductDetail{ProductModel: domain.ProductModel{ProductID: 62, Price: 4000, RegularPrice: 4000, Stock: 5, IsPublished: true, TaxClass: constants.TaxClassExempt}}, nil).Once()
+	mockOrderRepo.Mock.On("Insert", mock.MatchedBy(func(order *domain.OrderDetail) bool {
+		return order.UserID == 24
+	}), mock.Anything).Return(int64(101), nil).Once()
+
+	order, appErr := orderService.Create(form)
+
+	assert.Nil(t, appErr)
+	assert.Equal(t, int64(1200), order.OrderProducts[0].TaxPrice)
+	assert.Equal(t, float64(12), order.OrderProducts[0].TaxRate)
+	assert.Equal(t, int64(11200), order.OrderProducts[0].TotalPrice)
+	assert.Equal(t, int64(500), order.OrderProducts[1].TaxPrice)
+	assert.True(t, order.OrderProducts[1].IsTaxInclusive)
+	assert.Equal(t, int64(10500), order.OrderProducts[1].TotalPrice)
+	assert.Equal(t, int64(0), order.OrderProducts[2].TaxPrice)
+	assert.Equal(t, int64(1700), order.TaxPrice)
+	assert.Equal(t, int64(11200+10500+4000+2000), order.TotalPrice)
+}
@@ -125,16 +125,21 @@ func (r UserService) RegisterCustomer(req *dto.RegisterCustomerRequest) (*dto.Re
 		return nil, errs.NewAuthenticationError("Email already used")
 	}
 
+	if req.Locale == "" {
+		req.Locale = constants.DefaultLocale
+	}
+
 	form := domain.User{
 		Name:      req.Name,
 		Email:     req.Email,
 		Password:  hashPassword,
 		RoleID:    3,
+		Locale:    req.Locale,
 		CreatedAt: time.Now().Format(dbTSLayout),
 		UpdatedAt: time.Now().Format(dbTSLayout),
 	}
 
-	newData, appErr := r.repo.CreateUserCustomer(&form)
+	newData, appErr := r.repo.CreateUserCustomer(&form, &domain.NotificationEvent{Template: constants.NotificationUserRegistered, CreatedAt: time.Now()})
 	if appErr != nil {
 		return nil, appErr
 	}
@@ -184,8 +189,12 @@ func (r UserService) Update(form *domain.User) *errs.AppError {
 		return errs.NewBadRequestError("User not found")
 	}
 
+	if form.Locale == "" {
+		form.Locale = user.Locale
+	}
+
 	form.UpdatedAt = time.Now().Format(dbTSLayout)
-	appErr = r.repo.Update(form.UserID, form)
+	appErr = r.repo.Update(form.UserID, form, &domain.NotificationEvent{UserID: form.UserID, Template: constants.NotificationUserUpdated, CreatedAt: time.Now()})
 	if appErr != nil {
 		return appErr
 	}
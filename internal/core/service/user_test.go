@@ -142,7 +142,7 @@ func TestUser_Update_Unexpected_Error_Update(t *testing.T) {
 		Name: form.Name,
 	}
 
-	mockUserRepo.Mock.On("Update", form.UserID, &formUpdate).Return(errs.NewUnexpectedError("Unexpected database error"))
+	mockUserRepo.Mock.On("Update", form.UserID, &formUpdate, mock.Anything).Return(errs.NewUnexpectedError("Unexpected database error"))
 	appErr := userService.Update(form)
 
 	assert.NotNil(t, appErr)
@@ -164,7 +164,7 @@ func TestUser_Update_Success(t *testing.T) {
 		Name: form.Name,
 	}
 
-	mockUserRepo.Mock.On("Update", form.UserID, &formUpdate).Return(nil)
+	mockUserRepo.Mock.On("Update", form.UserID, &formUpdate, mock.Anything).Return(nil)
 	appErr := userService.Update(form)
 
 	assert.Nil(t, appErr)
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/core/port"
+)
+
+type WishlistService struct {
+	repo         port.WishlistRepo
+	repoProduct  port.ProductRepo
+	orderService port.OrderService
+}
+
+func NewWishlistService(repo port.WishlistRepo, repoProduct port.ProductRepo, orderService port.OrderService) port.WishlistService {
+	return &WishlistService{
+		repo:         repo,
+		repoProduct:  repoProduct,
+		orderService: orderService,
+	}
+}
+
+func (s WishlistService) Add(form *domain.Wishlist) *errs.AppError {
+	checkProduct, appErr := s.repoProduct.CheckByID(form.ProductID)
+	if appErr != nil {
+		return appErr
+	}
+
+	if !checkProduct {
+		return errs.NewBadRequestError("Product not found")
+	}
+
+	form.CreatedAt = time.Now()
+
+	return s.repo.Insert(form)
+}
+
+func (s WishlistService) Remove(userID, productID int64) *errs.AppError {
+	checkWishlist, appErr := s.repo.CheckByUserIDAndProductID(userID, productID)
+	if appErr != nil {
+		return appErr
+	}
+
+	if !checkWishlist {
+		return errs.NewNotFoundError("Product is not in wishlist")
+	}
+
+	return s.repo.Delete(userID, productID)
+}
+
+func (s WishlistService) GetList(userID int64) ([]domain.WishlistItem, *errs.AppError) {
+	wishlists, appErr := s.repo.GetAllByUserID(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	productByID, appErr := s.getProducts(wishlists)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	items := make([]domain.WishlistItem, 0)
+	for _, wishlist := range wishlists {
+		product, ok := productByID[wishlist.ProductID]
+		if !ok {
+			// product was deleted after being wishlisted
+			continue
+		}
+		items = append(items, domain.WishlistItem{Wishlist: wishlist, Product: product})
+	}
+
+	return items, nil
+}
+
+// MoveToOrder places an order for wishlist products and removes them from the wishlist, all wishlist
+// products with quantity one are ordered when no product is given. The order service prices the order.
+func (s WishlistService) MoveToOrder(form *domain.WishlistOrder) (*domain.OrderDetail, *errs.AppError) {
+	wishlists, appErr := s.repo.GetAllByUserID(form.UserID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if len(form.OrderProducts) == 0 {
+		for _, wishlist := range wishlists {
+			form.OrderProducts = append(form.OrderProducts, domain.OrderProduct{ProductID: wishlist.ProductID, Quantity: 1})
+		}
+	}
+
+	if len(form.OrderProducts) == 0 {
+		return nil, errs.NewBadRequestError("Wishlist is empty")
+	}
+
+	wishlisted := make(map[int64]bool)
+	for _, wishlist := range wishlists {
+		wishlisted[wishlist.ProductID] = true
+	}
+
+	order := new(domain.OrderDetail)
+	order.UserID = form.UserID
+	order.PaymentMethodID = form.PaymentMethodID
+	order.ShippingMethodID = &form.ShippingMethodID
+	order.CouponCodes = form.CouponCodes
+	order.TotalPrice = form.TotalPrice
+	order.ShipmentAddress = form.ShipmentAddress
+
+	ordered := make(map[int64]bool)
+	for _, orderProduct := range form.OrderProducts {
+		if !wishlisted[orderProduct.ProductID] {
+			return nil, errs.NewBadRequestError(fmt.Sprintf("Product %d is not in wishlist", orderProduct.ProductID))
+		}
+
+		if ordered[orderProduct.ProductID] {
+			return nil, errs.NewBadRequestError(fmt.Sprintf("Product %d is given more than once", orderProduct.ProductID))
+		}
+		ordered[orderProduct.ProductID] = true
+
+		order.OrderProducts = append(order.OrderProducts, orderProduct)
+	}
+
+	// the ordered products leave the wishlist in the transaction placing the order
+	order.IsFromWishlist = true
+	return s.orderService.Create(order)
+}
+
+func (s WishlistService) GetMostWishlisted(criteria *domain.WishlistReportCriteria) ([]domain.WishlistReport, int64, *errs.AppError) {
+	return s.repo.GetMostWishlistedPaginate(criteria)
+}
+
+func (s WishlistService) getProducts(wishlists []domain.Wishlist) (map[int64]domain.ProductList, *errs.AppError) {
+	productByID := make(map[int64]domain.ProductList)
+	if len(wishlists) == 0 {
+		return productByID, nil
+	}
+
+	productIDs := make([]int64, 0, len(wishlists))
+	for _, wishlist := range wishlists {
+		productIDs = append(productIDs, wishlist.ProductID)
+	}
+
+	products, appErr := s.repoProduct.GetAllByIDs(productIDs)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	for _, product := range products {
+		productByID[product.ProductID] = product
+	}
+
+	return productByID, nil
+}
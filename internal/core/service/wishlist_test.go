@@ -0,0 +1,51 @@
+package service
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var mockWishlistRepo = &mocks.WishlistRepo{Mock: mock.Mock{}}
+var mockOrderService = &mocks.OrderService{Mock: mock.Mock{}}
+var wishlistService = WishlistService{repo: mockWishlistRepo, repoProduct: mockProductRepo, orderService: mockOrderService}
+
+func TestWishlist_Remove_NotInWishlist(t *testing.T) {
+	mockWishlistRepo.Mock.On("CheckByUserIDAndProductID", int64(7), int64(30)).Return(false, nil).Once()
+
+	appErr := wishlistService.Remove(7, 30)
+
+	assert.NotNil(t, appErr)
+	assert.Equal(t, http.StatusNotFound, appErr.Code)
+}
+
+func TestWishlist_MoveToOrder_NotInWishlist(t *testing.T) {
+	form := &domain.WishlistOrder{UserID: 7, OrderProducts: []domain.OrderProduct{{ProductID: 31, Quantity: 1}}}
+
+	mockWishlistRepo.Mock.On("GetAllByUserID", int64(7)).Return([]domain.Wishlist{{UserID: 7, ProductID: 30}}, nil).Once()
+
+	order, appErr := wishlistService.MoveToOrder(form)
+
+	assert.Nil(t, order)
+	assert.NotNil(t, appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.Code)
+}
+
+func TestWishlist_MoveToOrder_Success(t *testing.T) {
+	form := &domain.WishlistOrder{UserID: 8, TotalPrice: 11000, OrderProducts: []domain.OrderProduct{{ProductID: 30, Quantity: 2}}}
+
+	mockWishlistRepo.Mock.On("GetAllByUserID", int64(8)).Return([]domain.Wishlist{{UserID: 8, ProductID: 30}, {UserID: 8, ProductID: 32}}, nil).Once()
+	mockOrderService.Mock.On("Create", mock.MatchedBy(func(order *domain.OrderDetail) bool {
+		return order.UserID == 8 && order.TotalPrice == 11000 && len(order.OrderProducts) == 1 && order.OrderProducts[0].Quantity == 2 && order.IsFromWishlist
+	})).Return(&domain.OrderDetail{Order: domain.Order{OrderID: 90}}, nil).Once()
+
+	order, appErr := wishlistService.MoveToOrder(form)
+
+	assert.Nil(t, appErr)
+	assert.Equal(t, int64(90), order.Order.OrderID)
+	mockWishlistRepo.AssertExpectations(t)
+}
@@ -0,0 +1,97 @@
+package dto
+
+import (
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+type (
+	AttributeRequest struct {
+		Code               string   `json:"code"`
+		Name               string   `json:"name"`
+		DataType           string   `json:"data_type"`
+		Options            []string `json:"options"`
+		IsRequired         bool     `json:"is_required"`
+		ProductCategoryIDs []int64  `json:"product_category_ids"`
+	}
+
+	ProductAttributeRequest struct {
+		AttributeDefinitionID int64  `json:"attribute_definition_id"`
+		Value                 string `json:"value"`
+	}
+
+	AttributeResponse struct {
+		AttributeDefinitionID int64    `json:"attribute_definition_id"`
+		Code                  string   `json:"code"`
+		Name                  string   `json:"name"`
+		DataType              string   `json:"data_type"`
+		Options               []string `json:"options"`
+		IsRequired            bool     `json:"is_required"`
+		ProductCategoryIDs    []int64  `json:"product_category_ids"`
+	}
+
+	ProductAttributeResponse struct {
+		AttributeDefinitionID int64  `json:"attribute_definition_id"`
+		Code                  string `json:"code"`
+		Name                  string `json:"name"`
+		DataType              string `json:"data_type"`
+		Value                 string `json:"value"`
+	}
+)
+
+func NewGetAttributeListResponse(message string, data []domain.AttributeDefinition) *ResponseData {
+	attributes := make([]AttributeResponse, 0)
+	for _, value := range data {
+		attributes = append(attributes, newAttributeResponse(value))
+	}
+	return GenerateResponseData(message, attributes)
+}
+
+func NewGetAttributeDetailResponse(message string, data *domain.AttributeDefinition) *ResponseData {
+	return GenerateResponseData(message, newAttributeResponse(*data))
+}
+
+func newAttributeResponse(data domain.AttributeDefinition) AttributeResponse {
+	var attribute AttributeResponse
+	attribute.AttributeDefinitionID = data.AttributeDefinitionID
+	attribute.Code = data.Code
+	attribute.Name = data.Name
+	attribute.DataType = data.DataType
+	attribute.Options = data.Options
+	attribute.IsRequired = data.IsRequired
+	attribute.ProductCategoryIDs = data.ProductCategoryIDs
+	return attribute
+}
+
+func newProductAttributeResponse(data []domain.ProductAttributeValue) []ProductAttributeResponse {
+	attributes := make([]ProductAttributeResponse, 0)
+	for _, value := range data {
+		var attribute ProductAttributeResponse
+		attribute.AttributeDefinitionID = value.AttributeDefinitionID
+		attribute.Code = value.Code
+		attribute.Name = value.Name
+		attribute.DataType = value.DataType
+		attribute.Value = value.Value
+		attributes = append(attributes, attribute)
+	}
+	return attributes
+}
+
+func (r AttributeRequest) Validate() *errs.AppError {
+	if err := validation.Validate(r.Code, validation.Required); err != nil {
+		return errs.NewBadRequestError("Code is required")
+	} else if err := validation.Validate(r.Code, validation.Length(0, 50)); err != nil {
+		return errs.NewBadRequestError("Code maximum length is 50")
+	} else if err := validation.Validate(r.Name, validation.Required); err != nil {
+		return errs.NewBadRequestError("Name is required")
+	} else if err := validation.Validate(r.Name, validation.Length(0, 100)); err != nil {
+		return errs.NewBadRequestError("Name maximum length is 100")
+	} else if err := validation.Validate(r.DataType, validation.Required); err != nil {
+		return errs.NewBadRequestError("Data type is required")
+	} else if err := validation.Validate(r.ProductCategoryIDs, validation.Required); err != nil {
+		return errs.NewBadRequestError("Product category is required")
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package dto
+
+import (
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+type (
+	BrandRequest struct {
+		Name        string  `json:"name"`
+		Slug        string  `json:"slug"`
+		Logo        *string `json:"logo"`
+		Description *string `json:"description"`
+	}
+
+	BrandResponse struct {
+		BrandID      int64   `json:"brand_id"`
+		Name         string  `json:"name"`
+		Slug         string  `json:"slug"`
+		Logo         *string `json:"logo"`
+		Description  *string `json:"description"`
+		NumbProducts int64   `json:"numb_products"`
+	}
+)
+
+func NewGetBrandListResponse(message string, data []domain.Brand) *ResponseData {
+	brands := make([]BrandResponse, 0)
+	for _, value := range data {
+		brands = append(brands, newBrandResponse(value))
+	}
+	return GenerateResponseData(message, brands)
+}
+
+func NewGetBrandDetailResponse(message string, data *domain.Brand) *ResponseData {
+	return GenerateResponseData(message, newBrandResponse(*data))
+}
+
+func newBrandResponse(data domain.Brand) BrandResponse {
+	var brand BrandResponse
+	brand.BrandID = data.BrandID
+	brand.Name = data.Name
+	brand.Slug = data.Slug
+	brand.Logo = data.Logo
+	brand.Description = data.Description
+	brand.NumbProducts = data.NumbProducts
+	return brand
+}
+
+func (r BrandRequest) Validate() *errs.AppError {
+	if err := validation.Validate(r.Name, validation.Required); err != nil {
+		return errs.NewBadRequestError("Brand name is required")
+	} else if err := validation.Validate(r.Name, validation.Length(0, 100)); err != nil {
+		return errs.NewBadRequestError("Brand name maximum length is 100")
+	} else if err := validation.Validate(r.Slug, validation.Length(0, 120)); err != nil {
+		return errs.NewBadRequestError("Slug maximum length is 120")
+	} else if err := validation.Validate(r.Logo, validation.Length(0, 255)); err != nil {
+		return errs.NewBadRequestError("Logo maximum length is 255")
+	} else if err := validation.Validate(r.Description, validation.Length(0, 500)); err != nil {
+		return errs.NewBadRequestError("Description maximum length is 500")
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package dto
+
+import (
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+type (
+	CartItemRequest struct {
+		ProductID int64 `json:"product_id"`
+		Quantity  int64 `json:"quantity"`
+	}
+
+	CartCheckoutRequest struct {
+		PaymentMethodID    int64           `json:"payment_method_id"`
+		ShippingMethodID   int64           `json:"shipping_method_id"`
+		CouponCodes        []string        `json:"coupon_codes"`
+		TotalPrice         int64           `json:"total_price"`
+		ShippinmentAddress ShipmentAddress `json:"shipment_address"`
+	}
+
+	CartResponse struct {
+		SessionID *string            `json:"session_id"`
+		TotalItem int64              `json:"total_item"`
+		Subtotal  int64              `json:"subtotal"`
+		Lines     []CartLineResponse `json:"lines"`
+	}
+
+	CartLineResponse struct {
+		ProductID      int64   `json:"product_id"`
+		Name           string  `json:"name"`
+		Slug           string  `json:"slug"`
+		Image          *string `json:"image"`
+		Price          int64   `json:"price"`
+		CompareAtPrice *int64  `json:"compare_at_price"`
+		Stock          int64   `json:"stock"`
+		Quantity       int64   `json:"quantity"`
+		Subtotal       int64   `json:"subtotal"`
+		IsAvailable    bool    `json:"is_available"`
+	}
+)
+
+func NewCartResponse(message string, data *domain.CartDetail) *ResponseData {
+	cart := CartResponse{
+		SessionID: data.SessionID,
+		TotalItem: data.TotalItem,
+		Subtotal:  data.Subtotal,
+		Lines:     make([]CartLineResponse, 0),
+	}
+
+	for _, value := range data.Lines {
+		var line CartLineResponse
+		line.ProductID = value.ProductID
+		line.Name = value.Product.Name
+		line.Slug = value.Product.Slug
+		line.Image = value.Product.Image
+		line.Price = value.Product.Price
+		line.CompareAtPrice = value.Product.CompareAtPrice
+		line.Stock = value.Product.Stock
+		line.Quantity = value.Quantity
+		line.Subtotal = value.Subtotal
+		line.IsAvailable = value.IsAvailable
+		cart.Lines = append(cart.Lines, line)
+	}
+
+	return GenerateResponseData(message, cart)
+}
+
+func (r CartItemRequest) Validate() *errs.AppError {
+	if err := validation.Validate(r.ProductID, validation.Required); err != nil {
+		return errs.NewBadRequestError("product id is required")
+	} else if r.Quantity < 1 {
+		return errs.NewBadRequestError("quantity must more than equal 1")
+	}
+	return nil
+}
+
+func (r CartCheckoutRequest) Validate() *errs.AppError {
+	if err := validation.Validate(r.PaymentMethodID, validation.Required); err != nil {
+		return errs.NewBadRequestError("payment method id is required")
+	} else if err := validation.Validate(r.TotalPrice, validation.Min(0)); err != nil {
+		return errs.NewBadRequestError("total price must more than equal 0")
+	} else if err := validation.Validate(r.ShippinmentAddress.Address, validation.Required); err != nil {
+		return errs.NewBadRequestError("address is required")
+	} else if err := validation.Validate(r.ShippinmentAddress.City, validation.Required); err != nil {
+		return errs.NewBadRequestError("city address is required")
+	} else if err := validation.Validate(r.ShippinmentAddress.PostalCode, validation.Required); err != nil {
+		return errs.NewBadRequestError("postal code is required")
+	} else if err := validation.Validate(r.ShippinmentAddress.Country, validation.Required); err != nil {
+		return errs.NewBadRequestError("country is required")
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package dto
+
+import (
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/utils/constants"
+	"github.com/danisbagus/matchoshop/utils/helper"
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+type (
+	CouponRequest struct {
+		Code               string  `json:"code"`
+		Name               string  `json:"name"`
+		Type               string  `json:"type"`
+		Value              int64   `json:"value"`
+		MaxDiscount        int64   `json:"max_discount"`
+		MinSpend           int64   `json:"min_spend"`
+		BuyQuantity        int64   `json:"buy_quantity"`
+		GetQuantity        int64   `json:"get_quantity"`
+		ProductIDs         []int64 `json:"product_ids"`
+		ProductCategoryIDs []int64 `json:"product_category_ids"`
+		UsageLimit         int64   `json:"usage_limit"`
+		UsageLimitPerUser  int64   `json:"usage_limit_per_user"`
+		StartsAt           *string `json:"starts_at"`
+		EndsAt             *string `json:"ends_at"`
+		IsStackable        bool    `json:"is_stackable"`
+		IsAutomatic        bool    `json:"is_automatic"`
+		IsActive           *bool   `json:"is_active"`
+	}
+
+	CouponRedemptionListRequest struct {
+		Page  int64 `query:"page"`
+		Limit int64 `query:"limit"`
+	}
+
+	CouponResponse struct {
+		CouponID           int64   `json:"coupon_id"`
+		Code               string  `json:"code"`
+		Name               string  `json:"name"`
+		Type               string  `json:"type"`
+		Value              int64   `json:"value"`
+		MaxDiscount        int64   `json:"max_discount"`
+		MinSpend           int64   `json:"min_spend"`
+		BuyQuantity        int64   `json:"buy_quantity"`
+		GetQuantity        int64   `json:"get_quantity"`
+		ProductIDs         []int64 `json:"product_ids"`
+		ProductCategoryIDs []int64 `json:"product_category_ids"`
+		UsageLimit         int64   `json:"usage_limit"`
+		UsageLimitPerUser  int64   `json:"usage_limit_per_user"`
+		UsedCount          int64   `json:"used_count"`
+		StartsAt           string  `json:"starts_at"`
+		EndsAt             string  `json:"ends_at"`
+		IsStackable        bool    `json:"is_stackable"`
+		IsAutomatic        bool    `json:"is_automatic"`
+		IsActive           bool    `json:"is_active"`
+		CreatedAt          string  `json:"created_at"`
+	}
+
+	CouponRedemptionResponse struct {
+		CouponRedemptionID int64  `json:"coupon_redemption_id"`
+		OrderID            int64  `json:"order_id"`
+		OrderStatus        string `json:"order_status"`
+		UserID             int64  `json:"user_id"`
+		UserName           string `json:"user_name"`
+		Code               string `json:"code"`
+		DiscountPrice      int64  `json:"discount_price"`
+		CreatedAt          string `json:"created_at"`
+	}
+)
+
+func NewGetCouponListResponse(message string, data []domain.Coupon) *ResponseData {
+	coupons := make([]CouponResponse, 0)
+	for _, value := range data {
+		coupons = append(coupons, newCouponResponse(value))
+	}
+	return GenerateResponseData(message, coupons)
+}
+
+func NewGetCouponDetailResponse(message string, data *domain.Coupon) *ResponseData {
+	return GenerateResponseData(message, newCouponResponse(*data))
+}
+
+func NewGetCouponRedemptionListPaginateResponse(message string, data []domain.CouponRedemption, meta *helper.Meta) *ResponsePaginateData {
+	redemptions := make([]CouponRedemptionResponse, 0)
+	for _, value := range data {
+		var redemption CouponRedemptionResponse
+		redemption.CouponRedemptionID = value.CouponRedemptionID
+		redemption.OrderID = value.OrderID
+		redemption.OrderStatus = value.OrderStatus
+		redemption.UserID = value.UserID
+		redemption.UserName = value.UserName
+		redemption.Code = value.Code
+		redemption.DiscountPrice = value.DiscountPrice
+		redemption.CreatedAt = helper.PointDateToString(&value.CreatedAt, constants.DATE_TIME_FORMAT)
+		redemptions = append(redemptions, redemption)
+	}
+	return GenerateResponsePaginateData(message, redemptions, meta)
+}
+
+func newCouponResponse(data domain.Coupon) CouponResponse {
+	var coupon CouponResponse
+	coupon.CouponID = data.CouponID
+	coupon.Code = data.Code
+	coupon.Name = data.Name
+	coupon.Type = data.Type
+	coupon.Value = data.Value
+	coupon.MaxDiscount = data.MaxDiscount
+	coupon.MinSpend = data.MinSpend
+	coupon.BuyQuantity = data.BuyQuantity
+	coupon.GetQuantity = data.GetQuantity
+	coupon.ProductIDs = data.ProductIDs
+	coupon.ProductCategoryIDs = data.ProductCategoryIDs
+	coupon.UsageLimit = data.UsageLimit
+	coupon.UsageLimitPerUser = data.UsageLimitPerUser
+	coupon.UsedCount = data.UsedCount
+	coupon.StartsAt = helper.PointDateToString(data.StartsAt, constants.DATE_TIME_FORMAT)
+	coupon.EndsAt = helper.PointDateToString(data.EndsAt, constants.DATE_TIME_FORMAT)
+	coupon.IsStackable = data.IsStackable
+	coupon.IsAutomatic = data.IsAutomatic
+	coupon.IsActive = data.IsActive
+	coupon.CreatedAt = helper.PointDateToString(&data.CreatedAt, constants.DATE_TIME_FORMAT)
+	return coupon
+}
+
+func (r CouponRequest) Validate() *errs.AppError {
+	if err := validation.Validate(r.Code, validation.Required); err != nil {
+		return errs.NewBadRequestError("Code is required")
+	} else if err := validation.Validate(r.Code, validation.Length(0, 50)); err != nil {
+		return errs.NewBadRequestError("Code maximum length is 50")
+	} else if err := validation.Validate(r.Name, validation.Required); err != nil {
+		return errs.NewBadRequestError("Name is required")
+	} else if err := validation.Validate(r.Type, validation.Required, validation.In(constants.CouponTypePercentage, constants.CouponTypeFixed, constants.CouponTypeFreeShipping, constants.CouponTypeBuyXGetY)); err != nil {
+		return errs.NewBadRequestError("Type must be percentage, fixed, free_shipping or bxgy")
+	} else if r.Value < 0 || r.MaxDiscount < 0 || r.MinSpend < 0 {
+		return errs.NewBadRequestError("Value, maximum discount and minimum spend must more than equal 0")
+	} else if r.BuyQuantity < 0 || r.GetQuantity < 0 {
+		return errs.NewBadRequestError("Buy and get quantity must more than equal 0")
+	} else if r.UsageLimit < 0 || r.UsageLimitPerUser < 0 {
+		return errs.NewBadRequestError("Usage limit must more than equal 0")
+	} else if err := validation.Validate(r.StartsAt, validation.Date(constants.DATE_TIME_FORMAT)); err != nil {
+		return errs.NewBadRequestError("Start time format must be " + constants.DATE_TIME_FORMAT)
+	} else if err := validation.Validate(r.EndsAt, validation.Date(constants.DATE_TIME_FORMAT)); err != nil {
+		return errs.NewBadRequestError("End time format must be " + constants.DATE_TIME_FORMAT)
+	}
+	return nil
+}
@@ -12,27 +12,62 @@ type (
 	CreateOrder struct {
 		UserID             int64           `json:"-"`
 		PaymentMethodID    int64           `json:"payment_method_id"`
-		ProductPrice       int64           `json:"product_price"`
-		TaxPrice           int64           `json:"tax_price"`
-		ShippingPrice      int64           `json:"shipping_price"`
+		ShippingMethodID   int64           `json:"shipping_method_id"`
+		CouponCodes        []string        `json:"coupon_codes"`
 		TotalPrice         int64           `json:"total_price"`
 		ShippinmentAddress ShipmentAddress `json:"shipment_address"`
 		OrderProduct       []OrderProduct  `json:"order_product"`
 	}
 
 	OrderProduct struct {
-		ProductID int64  `json:"product_id"`
-		Name      string `json:"name"`
-		Image     string `json:"image"`
-		Price     int64  `json:"price"`
-		Quantity  int64  `json:"quantity"`
+		ProductID           int64   `json:"product_id"`
+		Name                string  `json:"name"`
+		Sku                 string  `json:"sku"`
+		Image               string  `json:"image"`
+		RegularPrice        int64   `json:"regular_price"`
+		Price               int64   `json:"price"`
+		Quantity            int64   `json:"quantity"`
+		DiscountPrice       int64   `json:"discount_price"`
+		CouponDiscountPrice int64   `json:"coupon_discount_price"`
+		TaxClass            string  `json:"tax_class"`
+		TaxRate             float64 `json:"tax_rate"`
+		IsTaxInclusive      bool    `json:"is_tax_inclusive"`
+		TaxPrice            int64   `json:"tax_price"`
+		TotalPrice          int64   `json:"total_price"`
 	}
 
 	ShipmentAddress struct {
-		Address    string `json:"address"`
-		City       string `json:"city"`
-		PostalCode string `json:"postal_code"`
-		Country    string `json:"country"`
+		Address    string  `json:"address"`
+		City       string  `json:"city"`
+		Region     *string `json:"region"`
+		PostalCode string  `json:"postal_code"`
+		Country    string  `json:"country"`
+	}
+
+	UpdateOrderStatusRequest struct {
+		Status string `json:"status"`
+		Note   string `json:"note"`
+	}
+
+	CancelOrderRequest struct {
+		Reason string `json:"reason"`
+	}
+
+	OrderListRequest struct {
+		Keyword         string `query:"keyword"`
+		Status          string `query:"status"`
+		IsPaid          *bool  `query:"is_paid"`
+		IsDelivered     *bool  `query:"is_delivered"`
+		DateFrom        string `query:"date_from"`
+		DateTo          string `query:"date_to"`
+		UserID          int64  `query:"user_id"`
+		PaymentMethodID int64  `query:"payment_method_id"`
+		MinTotalPrice   *int64 `query:"min_total_price"`
+		MaxTotalPrice   *int64 `query:"max_total_price"`
+		Sort            string `query:"sort"`
+		Order           string `query:"order"`
+		Page            int64  `query:"page"`
+		Limit           int64  `query:"limit"`
 	}
 
 	UpdateOrderPaid struct {
@@ -44,35 +79,75 @@ type (
 	}
 
 	CreateOrderResponse struct {
-		OrderID int64 `json:"order_id"`
+		OrderID               int64   `json:"order_id"`
+		ProductPrice          int64   `json:"product_price"`
+		DiscountPrice         int64   `json:"discount_price"`
+		CouponDiscountPrice   int64   `json:"coupon_discount_price"`
+		TaxPrice              int64   `json:"tax_price"`
+		ShippingPrice         int64   `json:"shipping_price"`
+		ShippingDiscountPrice int64   `json:"shipping_discount_price"`
+		ShippingMethodName    *string `json:"shipping_method_name"`
+		TotalPrice            int64   `json:"total_price"`
+		ReservationExpiresAt  string  `json:"reservation_expires_at"`
 	}
 
 	OrderDetailResponse struct {
-		OrderID            int64           `json:"order_id"`
-		PaymentMethodID    int64           `json:"payment_method_id"`
-		PaymentMethodName  string          `json:"payment_method_name"`
-		ProductPrice       int64           `json:"product_price"`
-		TaxPrice           int64           `json:"tax_price"`
-		ShippingPrice      int64           `json:"shipping_price"`
-		TotalPrice         int64           `json:"total_price"`
-		UserName           string          `json:"user_name"`
-		UserEmail          string          `json:"user_email"`
-		IsPaid             bool            `json:"is_paid"`
-		PaidAt             string          `json:"paid_at"`
-		IsDelivered        bool            `json:"is_delivered"`
-		DeliveredAt        string          `json:"deliverd_at"`
-		ShippinmentAddress ShipmentAddress `json:"shipment_address"`
-		OrderProduct       []OrderProduct  `json:"order_product"`
+		OrderID               int64           `json:"order_id"`
+		PaymentMethodID       int64           `json:"payment_method_id"`
+		PaymentMethodName     string          `json:"payment_method_name"`
+		ProductPrice          int64           `json:"product_price"`
+		DiscountPrice         int64           `json:"discount_price"`
+		CouponDiscountPrice   int64           `json:"coupon_discount_price"`
+		TaxPrice              int64           `json:"tax_price"`
+		ShippingPrice         int64           `json:"shipping_price"`
+		ShippingDiscountPrice int64           `json:"shipping_discount_price"`
+		ShippingMethodID      *int64          `json:"shipping_method_id"`
+		ShippingMethodName    *string         `json:"shipping_method_name"`
+		ShippingWeight        int64           `json:"shipping_weight"`
+		TotalPrice            int64           `json:"total_price"`
+		RefundedPrice         int64           `json:"refunded_price"`
+		NetPaidPrice          int64           `json:"net_paid_price"`
+		Status                string          `json:"status"`
+		UserName              string          `json:"user_name"`
+		UserEmail             string          `json:"user_email"`
+		IsPaid                bool            `json:"is_paid"`
+		PaidAt                string          `json:"paid_at"`
+		IsDelivered           bool            `json:"is_delivered"`
+		DeliveredAt           string          `json:"deliverd_at"`
+		ReservationExpiresAt  string          `json:"reservation_expires_at"`
+		CancellationReason    *string         `json:"cancellation_reason"`
+		CancelledAt           string          `json:"cancelled_at"`
+		ShippinmentAddress    ShipmentAddress `json:"shipment_address"`
+		OrderProduct          []OrderProduct  `json:"order_product"`
+		Coupons               []OrderCoupon   `json:"coupons"`
+		Timeline              []OrderTimeline `json:"timeline"`
+	}
+
+	OrderCoupon struct {
+		Code          string `json:"code"`
+		Type          string `json:"type"`
+		DiscountPrice int64  `json:"discount_price"`
+	}
+
+	OrderTimeline struct {
+		Status    string  `json:"status"`
+		Note      *string `json:"note"`
+		CreatedAt string  `json:"created_at"`
 	}
 
 	OrderListResponse struct {
 		OrderID         int64  `json:"order_id"`
 		PaymentMethodID int64  `json:"payment_method_id"`
 		ProductPrice    int64  `json:"product_price"`
+		DiscountPrice   int64  `json:"discount_price"`
 		TaxPrice        int64  `json:"tax_price"`
 		ShippingPrice   int64  `json:"shipping_price"`
 		TotalPrice      int64  `json:"total_price"`
+		RefundedPrice   int64  `json:"refunded_price"`
+		NetPaidPrice    int64  `json:"net_paid_price"`
+		Status          string `json:"status"`
 		UserName        string `json:"user_name"`
+		UserEmail       string `json:"user_email"`
 		IsPaid          bool   `json:"is_paid"`
 		PaidAt          string `json:"paid_at"`
 		IsDelivered     bool   `json:"is_delivered"`
@@ -84,16 +159,38 @@ type (
 		OrderID         int64  `json:"order_id"`
 		PaymentResultID string `json:"payment_result_id"`
 	}
+
+	RenewStockReservationResponse struct {
+		OrderID              int64  `json:"order_id"`
+		ReservationExpiresAt string `json:"reservation_expires_at"`
+	}
 )
 
 func NewOrderResponse(message string, data *domain.OrderDetail) *ResponseData {
 	resData := new(CreateOrderResponse)
 	resData.OrderID = data.Order.OrderID
+	resData.ProductPrice = data.ProductPrice
+	resData.DiscountPrice = data.DiscountPrice
+	resData.CouponDiscountPrice = data.CouponDiscountPrice
+	resData.TaxPrice = data.TaxPrice
+	resData.ShippingPrice = data.ShippingPrice
+	resData.ShippingDiscountPrice = data.ShippingDiscountPrice
+	resData.ShippingMethodName = data.ShippingMethodName
+	resData.TotalPrice = data.TotalPrice
+	resData.ReservationExpiresAt = helper.PointDateToString(data.ReservationExpiresAt, constants.DATE_TIME_FORMAT)
 
 	return GenerateResponseData(message, resData)
 }
 
 func NewGetOrderListResponse(message string, data []domain.OrderDetail) *ResponseData {
+	return GenerateResponseData(message, newOrderListResponse(data))
+}
+
+func NewGetOrderListPaginateResponse(message string, data []domain.OrderDetail, meta *helper.Meta) *ResponsePaginateData {
+	return GenerateResponsePaginateData(message, newOrderListResponse(data), meta)
+}
+
+func newOrderListResponse(data []domain.OrderDetail) []OrderListResponse {
 	resData := make([]OrderListResponse, 0)
 
 	for _, orderDetail := range data {
@@ -101,10 +198,15 @@ func NewGetOrderListResponse(message string, data []domain.OrderDetail) *Respons
 		resOrderList.OrderID = orderDetail.Order.OrderID
 		resOrderList.PaymentMethodID = orderDetail.PaymentMethodID
 		resOrderList.ProductPrice = orderDetail.ProductPrice
+		resOrderList.DiscountPrice = orderDetail.DiscountPrice
 		resOrderList.TaxPrice = orderDetail.TaxPrice
 		resOrderList.ShippingPrice = orderDetail.ShippingPrice
 		resOrderList.TotalPrice = orderDetail.TotalPrice
+		resOrderList.RefundedPrice = orderDetail.RefundedPrice
+		resOrderList.NetPaidPrice = orderDetail.NetPaidPrice()
+		resOrderList.Status = orderDetail.Order.Status
 		resOrderList.UserName = orderDetail.UserName
+		resOrderList.UserEmail = orderDetail.UserEmail
 		resOrderList.IsPaid = orderDetail.IsPaid
 		resOrderList.PaidAt = helper.PointDateToString(orderDetail.PaidAt, constants.DATE_FORMAT)
 		resOrderList.IsDelivered = orderDetail.IsDelivered
@@ -112,7 +214,7 @@ func NewGetOrderListResponse(message string, data []domain.OrderDetail) *Respons
 		resOrderList.CreatedAt = helper.PointDateToString(&orderDetail.CreatedAt, constants.DATE_FORMAT)
 		resData = append(resData, resOrderList)
 	}
-	return GenerateResponseData(message, resData)
+	return resData
 }
 
 func NewGetOrderDetailResponse(message string, data *domain.OrderDetail) *ResponseData {
@@ -121,18 +223,31 @@ func NewGetOrderDetailResponse(message string, data *domain.OrderDetail) *Respon
 	resData.PaymentMethodID = data.PaymentMethodID
 	resData.PaymentMethodName = data.PaymentMethodName
 	resData.ProductPrice = data.ProductPrice
+	resData.DiscountPrice = data.DiscountPrice
+	resData.CouponDiscountPrice = data.CouponDiscountPrice
 	resData.TaxPrice = data.TaxPrice
 	resData.ShippingPrice = data.ShippingPrice
+	resData.ShippingDiscountPrice = data.ShippingDiscountPrice
+	resData.ShippingMethodID = data.ShippingMethodID
+	resData.ShippingMethodName = data.ShippingMethodName
+	resData.ShippingWeight = data.ShippingWeight
 	resData.TotalPrice = data.TotalPrice
+	resData.RefundedPrice = data.RefundedPrice
+	resData.NetPaidPrice = data.NetPaidPrice()
+	resData.Status = data.Order.Status
 	resData.UserName = data.UserName
 	resData.UserEmail = data.UserEmail
 	resData.IsPaid = data.IsPaid
 	resData.PaidAt = helper.PointDateToString(data.PaidAt, constants.DATE_FORMAT)
 	resData.IsDelivered = data.IsDelivered
 	resData.DeliveredAt = helper.PointDateToString(data.DeliveredAt, constants.DATE_FORMAT)
+	resData.ReservationExpiresAt = helper.PointDateToString(data.ReservationExpiresAt, constants.DATE_TIME_FORMAT)
+	resData.CancellationReason = data.CancellationReason
+	resData.CancelledAt = helper.PointDateToString(data.CancelledAt, constants.DATE_TIME_FORMAT)
 	resData.ShippinmentAddress = ShipmentAddress{
 		Address:    data.ShipmentAddress.Address,
 		City:       data.ShipmentAddress.City,
+		Region:     data.ShipmentAddress.Region,
 		PostalCode: data.ShipmentAddress.PostalCode,
 		Country:    data.ShipmentAddress.Country,
 	}
@@ -141,14 +256,39 @@ func NewGetOrderDetailResponse(message string, data *domain.OrderDetail) *Respon
 	for _, value := range data.OrderProducts {
 		var orderProduct OrderProduct
 		orderProduct.ProductID = value.ProductID
+		orderProduct.RegularPrice = value.RegularPrice
 		orderProduct.Price = value.Price
 		orderProduct.Name = value.Name
+		orderProduct.Sku = value.Sku
 		orderProduct.Image = value.Image
 		orderProduct.Quantity = value.Quantity
+		orderProduct.DiscountPrice = value.DiscountPrice
+		orderProduct.CouponDiscountPrice = value.CouponDiscountPrice
+		orderProduct.TaxClass = value.TaxClass
+		orderProduct.TaxRate = value.TaxRate
+		orderProduct.IsTaxInclusive = value.IsTaxInclusive
+		orderProduct.TaxPrice = value.TaxPrice
+		orderProduct.TotalPrice = value.TotalPrice
 
 		orderProducts = append(orderProducts, orderProduct)
 	}
 	resData.OrderProduct = orderProducts
+
+	coupons := make([]OrderCoupon, 0)
+	for _, value := range data.CouponRedemptions {
+		coupons = append(coupons, OrderCoupon{Code: value.Code, Type: value.Type, DiscountPrice: value.DiscountPrice})
+	}
+	resData.Coupons = coupons
+
+	timeline := make([]OrderTimeline, 0)
+	for _, value := range data.StatusHistories {
+		var history OrderTimeline
+		history.Status = value.ToStatus
+		history.Note = value.Note
+		history.CreatedAt = helper.PointDateToString(&value.CreatedAt, constants.DATE_TIME_FORMAT)
+		timeline = append(timeline, history)
+	}
+	resData.Timeline = timeline
 	return GenerateResponseData(message, resData)
 }
 
@@ -160,21 +300,19 @@ func NewUpdatePaidResponse(message string, data *domain.PaymentResult) *Response
 	return GenerateResponseData(message, resData)
 }
 
+func NewRenewStockReservationResponse(message string, data *domain.OrderDetail) *ResponseData {
+	resData := new(RenewStockReservationResponse)
+	resData.OrderID = data.Order.OrderID
+	resData.ReservationExpiresAt = helper.PointDateToString(data.ReservationExpiresAt, constants.DATE_TIME_FORMAT)
+
+	return GenerateResponseData(message, resData)
+}
+
 func (r CreateOrder) Validate() *errs.AppError {
 	if err := validation.Validate(r.PaymentMethodID, validation.Required); err != nil {
 		return errs.NewBadRequestError("payment method id is required")
-	} else if err := validation.Validate(r.ProductPrice, validation.Required); err != nil {
-		return errs.NewBadRequestError("product price is required")
-	} else if err := validation.Validate(r.ProductPrice, validation.Min(100)); err != nil {
-		return errs.NewBadRequestError("product price must more than equal 100")
-	} else if err := validation.Validate(r.TaxPrice, validation.Min(0)); err != nil {
-		return errs.NewBadRequestError("tax price must more than equal 0")
-	} else if err := validation.Validate(r.ShippingPrice, validation.Min(0)); err != nil {
-		return errs.NewBadRequestError("shipping price must more than equal 0")
-	} else if err := validation.Validate(r.TotalPrice, validation.Required); err != nil {
-		return errs.NewBadRequestError("total price is required")
-	} else if err := validation.Validate(r.TotalPrice, validation.Min(100)); err != nil {
-		return errs.NewBadRequestError("total price must more than equal 100")
+	} else if err := validation.Validate(r.TotalPrice, validation.Min(0)); err != nil {
+		return errs.NewBadRequestError("total price must more than equal 0")
 	} else if err := validation.Validate(r.ShippinmentAddress, validation.Required); err != nil {
 		return errs.NewBadRequestError("shipping address is required")
 	} else if err := validation.Validate(r.ShippinmentAddress.Address, validation.Required); err != nil {
@@ -190,6 +328,31 @@ func (r CreateOrder) Validate() *errs.AppError {
 	} else if err := validation.Validate(r.OrderProduct, validation.Required); err != nil || len(r.OrderProduct) <= 0 {
 		return errs.NewBadRequestError("order product is required")
 	}
+
+	for _, orderProduct := range r.OrderProduct {
+		if orderProduct.Quantity < 1 {
+			return errs.NewBadRequestError("quantity must more than equal 1")
+		}
+	}
+	return nil
+}
+
+func (r OrderListRequest) Validate() *errs.AppError {
+	statuses := []interface{}{constants.OrderStatusPendingPayment, constants.OrderStatusPaymentFailed, constants.OrderStatusPaid, constants.OrderStatusProcessing,
+		constants.OrderStatusShipped, constants.OrderStatusDelivered, constants.OrderStatusCancelled, constants.OrderStatusRefunded}
+	if err := validation.Validate(r.Status, validation.In(statuses...)); err != nil {
+		return errs.NewBadRequestError("status is not valid")
+	} else if err := validation.Validate(r.DateFrom, validation.Date(constants.DATE_FORMAT)); err != nil {
+		return errs.NewBadRequestError("date from format must be " + constants.DATE_FORMAT)
+	} else if err := validation.Validate(r.DateTo, validation.Date(constants.DATE_FORMAT)); err != nil {
+		return errs.NewBadRequestError("date to format must be " + constants.DATE_FORMAT)
+	} else if err := validation.Validate(r.Sort, validation.In("created_at", "paid_at", "total_price", "status", "order_id")); err != nil {
+		return errs.NewBadRequestError("sort must be created_at, paid_at, total_price, status or order_id")
+	} else if err := validation.Validate(r.Order, validation.In("asc", "desc")); err != nil {
+		return errs.NewBadRequestError("order must be asc or desc")
+	} else if r.MinTotalPrice != nil && r.MaxTotalPrice != nil && *r.MinTotalPrice > *r.MaxTotalPrice {
+		return errs.NewBadRequestError("min total price must not be more than max total price")
+	}
 	return nil
 }
 
@@ -201,3 +364,20 @@ func (r UpdateOrderPaid) Validate() *errs.AppError {
 	}
 	return nil
 }
+
+func (r UpdateOrderStatusRequest) Validate() *errs.AppError {
+	statuses := []interface{}{constants.OrderStatusPaymentFailed, constants.OrderStatusProcessing, constants.OrderStatusShipped, constants.OrderStatusDelivered}
+	if err := validation.Validate(r.Status, validation.Required); err != nil {
+		return errs.NewBadRequestError("status is required")
+	} else if err := validation.Validate(r.Status, validation.In(statuses...)); err != nil {
+		return errs.NewBadRequestError("status is not valid")
+	}
+	return nil
+}
+
+func (r CancelOrderRequest) Validate() *errs.AppError {
+	if err := validation.Validate(r.Reason, validation.Required); err != nil {
+		return errs.NewBadRequestError("reason is required")
+	}
+	return nil
+}
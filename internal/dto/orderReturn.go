@@ -0,0 +1,148 @@
+package dto
+
+import (
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/utils/constants"
+	"github.com/danisbagus/matchoshop/utils/helper"
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+type (
+	CreateOrderReturnRequest struct {
+		Reason string                   `json:"reason"`
+		Photos []string                 `json:"photos"`
+		Items  []OrderReturnItemRequest `json:"items"`
+	}
+
+	OrderReturnItemRequest struct {
+		ProductID int64 `json:"product_id"`
+		Quantity  int64 `json:"quantity"`
+	}
+
+	UpdateOrderReturnStatusRequest struct {
+		Status  string  `json:"status"`
+		Note    *string `json:"note"`
+		Restock bool    `json:"restock"`
+	}
+
+	OrderReturnListRequest struct {
+		Status string `query:"status"`
+		Page   int64  `query:"page"`
+		Limit  int64  `query:"limit"`
+	}
+
+	OrderReturnResponse struct {
+		OrderReturnID int64                     `json:"order_return_id"`
+		OrderID       int64                     `json:"order_id"`
+		UserID        int64                     `json:"user_id"`
+		Reason        string                    `json:"reason"`
+		Photos        []string                  `json:"photos"`
+		Status        string                    `json:"status"`
+		RefundAmount  int64                     `json:"refund_amount"`
+		IsRestocked   bool                      `json:"is_restocked"`
+		CreatedAt     string                    `json:"created_at"`
+		UpdatedAt     string                    `json:"updated_at"`
+		Items         []OrderReturnItemResponse `json:"items,omitempty"`
+		Timeline      []OrderTimeline           `json:"timeline,omitempty"`
+	}
+
+	OrderReturnItemResponse struct {
+		ProductID    int64  `json:"product_id"`
+		Name         string `json:"name"`
+		Quantity     int64  `json:"quantity"`
+		RefundAmount int64  `json:"refund_amount"`
+	}
+)
+
+func NewOrderReturnResponse(message string, data *domain.OrderReturn) *ResponseData {
+	return GenerateResponseData(message, newOrderReturnResponse(*data))
+}
+
+func NewGetOrderReturnListResponse(message string, data []domain.OrderReturn) *ResponseData {
+	orderReturns := make([]OrderReturnResponse, 0)
+	for _, value := range data {
+		orderReturns = append(orderReturns, newOrderReturnResponse(value))
+	}
+	return GenerateResponseData(message, orderReturns)
+}
+
+func NewGetOrderReturnListPaginateResponse(message string, data []domain.OrderReturn, meta *helper.Meta) *ResponsePaginateData {
+	orderReturns := make([]OrderReturnResponse, 0)
+	for _, value := range data {
+		orderReturns = append(orderReturns, newOrderReturnResponse(value))
+	}
+	return GenerateResponsePaginateData(message, orderReturns, meta)
+}
+
+func newOrderReturnResponse(data domain.OrderReturn) OrderReturnResponse {
+	var resData OrderReturnResponse
+	resData.OrderReturnID = data.OrderReturnID
+	resData.OrderID = data.OrderID
+	resData.UserID = data.UserID
+	resData.Reason = data.Reason
+	resData.Photos = data.Photos
+	if resData.Photos == nil {
+		resData.Photos = make([]string, 0)
+	}
+	resData.Status = data.Status
+	resData.RefundAmount = data.RefundAmount
+	resData.IsRestocked = data.IsRestocked
+	resData.CreatedAt = helper.PointDateToString(&data.CreatedAt, constants.DATE_TIME_FORMAT)
+	resData.UpdatedAt = helper.PointDateToString(&data.UpdatedAt, constants.DATE_TIME_FORMAT)
+
+	for _, value := range data.Items {
+		var item OrderReturnItemResponse
+		item.ProductID = value.ProductID
+		item.Name = value.Name
+		item.Quantity = value.Quantity
+		item.RefundAmount = value.RefundAmount
+		resData.Items = append(resData.Items, item)
+	}
+
+	for _, value := range data.StatusHistories {
+		var history OrderTimeline
+		history.Status = value.ToStatus
+		history.Note = value.Note
+		history.CreatedAt = helper.PointDateToString(&value.CreatedAt, constants.DATE_TIME_FORMAT)
+		resData.Timeline = append(resData.Timeline, history)
+	}
+	return resData
+}
+
+func (r CreateOrderReturnRequest) Validate() *errs.AppError {
+	if err := validation.Validate(r.Reason, validation.Required); err != nil {
+		return errs.NewBadRequestError("reason is required")
+	} else if err := validation.Validate(r.Reason, validation.Length(0, 255)); err != nil {
+		return errs.NewBadRequestError("maximal reason length is 255")
+	} else if err := validation.Validate(r.Photos, validation.Length(0, constants.OrderReturnMaxPhotos)); err != nil {
+		return errs.NewBadRequestError("too many photos")
+	} else if err := validation.Validate(r.Items, validation.Required); err != nil {
+		return errs.NewBadRequestError("items is required")
+	}
+
+	for _, photo := range r.Photos {
+		if err := validation.Validate(photo, validation.Required); err != nil {
+			return errs.NewBadRequestError("photo url is required")
+		}
+	}
+
+	for _, item := range r.Items {
+		if err := validation.Validate(item.ProductID, validation.Required); err != nil {
+			return errs.NewBadRequestError("product id is required")
+		} else if item.Quantity < 1 {
+			return errs.NewBadRequestError("quantity must more than equal 1")
+		}
+	}
+	return nil
+}
+
+func (r UpdateOrderReturnStatusRequest) Validate() *errs.AppError {
+	statuses := []interface{}{constants.OrderReturnStatusApproved, constants.OrderReturnStatusRejected, constants.OrderReturnStatusReceived}
+	if err := validation.Validate(r.Status, validation.Required); err != nil {
+		return errs.NewBadRequestError("status is required")
+	} else if err := validation.Validate(r.Status, validation.In(statuses...)); err != nil {
+		return errs.NewBadRequestError("status is not valid")
+	}
+	return nil
+}
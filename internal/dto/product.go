@@ -3,34 +3,49 @@ package dto
 import (
 	"github.com/danisbagus/go-common-packages/errs"
 	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/utils/constants"
 	"github.com/danisbagus/matchoshop/utils/helper"
 	validation "github.com/go-ozzo/ozzo-validation"
 )
 
 type ProductRequest struct {
-	Name               string  `json:"name"`
-	Sku                string  `json:"sku"`
-	Brand              *string `json:"brand"`
-	Image              *string `json:"Image"`
-	Description        *string `json:"description"`
-	ProductCategoryIDs []int64 `json:"product_category_id"`
-	Price              int64   `json:"price"`
-	Stock              int64   `json:"stock"`
+	Name               string                    `json:"name"`
+	Sku                string                    `json:"sku"`
+	Slug               string                    `json:"slug"`
+	BrandID            int64                     `json:"brand_id"`
+	Image              *string                   `json:"Image"`
+	Description        *string                   `json:"description"`
+	MetaTitle          *string                   `json:"meta_title"`
+	MetaDescription    *string                   `json:"meta_description"`
+	ProductCategoryIDs []int64                   `json:"product_category_id"`
+	Attributes         []ProductAttributeRequest `json:"attributes"`
+	Price              int64                     `json:"price"`
+	CompareAtPrice     *int64                    `json:"compare_at_price"`
+	Stock              int64                     `json:"stock"`
+	LowStockThreshold  int64                     `json:"low_stock_threshold"`
+	IsPublished        *bool                     `json:"is_published"`
+	TaxClass           string                    `json:"tax_class"`
+	Weight             int64                     `json:"weight"`
 }
 
 type ProductListRequest struct {
-	Keyword string `query:"keyword"`
-	Page    int64  `query:"page"`
-	Limit   int64  `query:"limit"`
+	Keyword           string `query:"keyword"`
+	BrandID           int64  `query:"brand_id"`
+	ProductCategoryID int64  `query:"product_category_id"`
+	Page              int64  `query:"page"`
+	Limit             int64  `query:"limit"`
 }
 
 type ProductResponse struct {
-	ProductID int64   `json:"product_id"`
-	Name      string  `json:"name"`
-	Sku       string  `json:"sku"`
-	Brand     *string `json:"brand"`
-	Image     *string `json:"image"`
-	Price     int64   `json:"price"`
+	ProductID      int64   `json:"product_id"`
+	Name           string  `json:"name"`
+	Sku            string  `json:"sku"`
+	Slug           string  `json:"slug"`
+	BrandID        *int64  `json:"brand_id"`
+	Brand          *string `json:"brand"`
+	Image          *string `json:"image"`
+	Price          int64   `json:"price"`
+	CompareAtPrice *int64  `json:"compare_at_price"`
 }
 
 type ProductListResponse struct {
@@ -42,12 +57,30 @@ type ProductListResponse struct {
 
 type ProductDetailtResponse struct {
 	ProductResponse
-	Description       *string                   `json:"description"`
-	Stock             int64                     `json:"stock"`
-	Rating            float32                   `json:"rating"`
-	NumbReviews       int64                     `json:"numb_reviews"`
-	ProductCategories []ProductCategoryResponse `json:"product_categories"`
-	Review            []ReviewResponse          `json:"reviews"`
+	RegularPrice      int64                       `json:"regular_price"`
+	Description       *string                     `json:"description"`
+	MetaTitle         *string                     `json:"meta_title"`
+	MetaDescription   *string                     `json:"meta_description"`
+	Stock             int64                       `json:"stock"`
+	LowStockThreshold int64                       `json:"low_stock_threshold"`
+	IsPublished       bool                        `json:"is_published"`
+	TaxClass          string                      `json:"tax_class"`
+	Weight            int64                       `json:"weight"`
+	Rating            float32                     `json:"rating"`
+	NumbReviews       int64                       `json:"numb_reviews"`
+	ProductCategories []ProductCategoryResponse   `json:"product_categories"`
+	Breadcrumbs       [][]ProductCategoryResponse `json:"breadcrumbs"`
+	Attributes        []ProductAttributeResponse  `json:"attributes"`
+	Review            []ReviewResponse            `json:"reviews"`
+}
+
+type LowStockProductResponse struct {
+	ProductID         int64  `json:"product_id"`
+	Name              string `json:"name"`
+	Sku               string `json:"sku"`
+	Slug              string `json:"slug"`
+	Stock             int64  `json:"stock"`
+	LowStockThreshold int64  `json:"low_stock_threshold"`
 }
 
 type ResponsePaginateData struct {
@@ -71,9 +104,12 @@ func NewGetProductListResponse(message string, data []domain.ProductDetail, meta
 		product.ProductID = value.ProductID
 		product.Name = value.Name
 		product.Sku = value.Sku
+		product.Slug = value.Slug
 		product.Image = value.Image
+		product.BrandID = value.BrandID
 		product.Brand = value.Brand
 		product.Price = value.Price
+		product.CompareAtPrice = value.CompareAtPrice
 		product.Rating = value.Rating
 		product.NumbReviews = value.NumbReviews
 
@@ -82,6 +118,7 @@ func NewGetProductListResponse(message string, data []domain.ProductDetail, meta
 			var category ProductCategoryResponse
 			category.ProductCategoryID = valueCategory.ProductCategoryID
 			category.Name = valueCategory.Name
+			category.Slug = valueCategory.Slug
 			productCategories = append(productCategories, category)
 		}
 
@@ -91,29 +128,71 @@ func NewGetProductListResponse(message string, data []domain.ProductDetail, meta
 	return GenerateResponsePaginateData(message, products, meta)
 }
 
+func NewGetLowStockProductListResponse(message string, data []domain.ProductModel, meta *helper.Meta) *ResponsePaginateData {
+	products := make([]LowStockProductResponse, 0)
+	for _, value := range data {
+		var product LowStockProductResponse
+		product.ProductID = value.ProductID
+		product.Name = value.Name
+		product.Sku = value.Sku
+		product.Slug = value.Slug
+		product.Stock = value.Stock
+		product.LowStockThreshold = value.LowStockThreshold
+		products = append(products, product)
+	}
+	return GenerateResponsePaginateData(message, products, meta)
+}
+
 func NewGetProductDetailResponse(message string, data *domain.ProductDetail) *ResponseData {
 	product := new(ProductDetailtResponse)
 	product.ProductID = data.ProductID
 	product.Name = data.Name
 	product.Sku = data.Sku
+	product.Slug = data.Slug
 	product.Image = data.Image
 	product.Description = data.Description
+	product.MetaTitle = data.MetaTitle
+	product.MetaDescription = data.MetaDescription
+	product.BrandID = data.BrandID
 	product.Brand = data.Brand
 	product.Price = data.Price
+	product.CompareAtPrice = data.CompareAtPrice
+	product.RegularPrice = data.RegularPrice
 	product.Rating = data.Rating
 	product.NumbReviews = data.NumbReviews
 	product.Stock = data.Stock
+	product.LowStockThreshold = data.LowStockThreshold
+	product.IsPublished = data.IsPublished
+	product.TaxClass = data.TaxClass
+	product.Weight = data.Weight
 
 	productCategories := make([]ProductCategoryResponse, 0)
 	for _, valData := range data.ProductCategories {
 		productCategory := ProductCategoryResponse{
 			ProductCategoryID: valData.ProductCategoryID,
 			Name:              valData.Name,
+			Slug:              valData.Slug,
 		}
 		productCategories = append(productCategories, productCategory)
 	}
 	product.ProductCategories = productCategories
 
+	breadcrumbs := make([][]ProductCategoryResponse, 0)
+	for _, valData := range data.Breadcrumbs {
+		breadcrumb := make([]ProductCategoryResponse, 0)
+		for _, productCategory := range valData {
+			breadcrumb = append(breadcrumb, ProductCategoryResponse{
+				ProductCategoryID: productCategory.ProductCategoryID,
+				ParentID:          productCategory.ParentID,
+				Name:              productCategory.Name,
+				Slug:              productCategory.Slug,
+			})
+		}
+		breadcrumbs = append(breadcrumbs, breadcrumb)
+	}
+	product.Breadcrumbs = breadcrumbs
+	product.Attributes = newProductAttributeResponse(data.Attributes)
+
 	productReviews := make([]ReviewResponse, 0)
 	for _, valData := range data.Review {
 		productReview := ReviewResponse{
@@ -139,16 +218,42 @@ func (r ProductRequest) Validate() *errs.AppError {
 		return errs.NewBadRequestError("Name is required")
 	} else if err := validation.Validate(r.Sku, validation.Required); err != nil {
 		return errs.NewBadRequestError("SKU is required")
-	} else if err := validation.Validate(r.Brand, validation.Required); err != nil {
+	} else if err := validation.Validate(r.BrandID, validation.Required); err != nil {
 		return errs.NewBadRequestError("Brand is required")
 	} else if err := validation.Validate(r.ProductCategoryIDs, validation.Required); err != nil {
 		return errs.NewBadRequestError("Product category ID is required")
+	} else if err := validation.Validate(r.Slug, validation.Length(0, 120)); err != nil {
+		return errs.NewBadRequestError("Slug maximum length is 120")
+	} else if err := validation.Validate(r.MetaTitle, validation.Length(0, 70)); err != nil {
+		return errs.NewBadRequestError("Meta title maximum length is 70")
+	} else if err := validation.Validate(r.MetaDescription, validation.Length(0, 160)); err != nil {
+		return errs.NewBadRequestError("Meta description maximum length is 160")
 	} else if err := validation.Validate(r.Price, validation.Required); err != nil {
 		return errs.NewBadRequestError("Price is required")
+	} else if r.Stock < 0 {
+		return errs.NewValidationError("Minimum stock is 0")
+	} else if r.LowStockThreshold < 0 {
+		return errs.NewValidationError("Minimum low stock threshold is 0")
 	} else if r.Price < 100 {
 		return errs.NewValidationError("Minimum price is 100")
+	} else if r.CompareAtPrice != nil && *r.CompareAtPrice <= r.Price {
+		return errs.NewValidationError("Compare at price must be higher than the price")
 	} else if len(r.ProductCategoryIDs) < 1 {
 		return errs.NewValidationError("Product category ID required")
+	} else if err := validation.Validate(r.TaxClass, validation.In(constants.TaxClassStandard, constants.TaxClassReduced, constants.TaxClassExempt)); err != nil {
+		return errs.NewValidationError("Tax class must be standard, reduced or exempt")
+	} else if r.Weight < 0 {
+		return errs.NewValidationError("Minimum weight is 0")
+	}
+	return nil
+}
+
+// ValidateUpdate rejects the stock of an existing product, its stock only changes through a stock movement
+func (r ProductRequest) ValidateUpdate() *errs.AppError {
+	if appErr := r.Validate(); appErr != nil {
+		return appErr
+	} else if r.Stock != 0 {
+		return errs.NewValidationError("Stock can not be updated, record a stock movement instead")
 	}
 	return nil
 }
@@ -7,7 +7,11 @@ import (
 )
 
 type CreateProductCategoryRequest struct {
-	Name string `json:"name"`
+	ParentID        *int64  `json:"parent_id"`
+	Name            string  `json:"name"`
+	Slug            string  `json:"slug"`
+	MetaTitle       *string `json:"meta_title"`
+	MetaDescription *string `json:"meta_description"`
 }
 
 type CreateProductCategoryResponse struct {
@@ -16,7 +20,20 @@ type CreateProductCategoryResponse struct {
 
 type ProductCategoryResponse struct {
 	ProductCategoryID int64  `json:"product_category_id"`
+	ParentID          *int64 `json:"parent_id"`
 	Name              string `json:"name"`
+	Slug              string `json:"slug"`
+}
+
+type ProductCategoryTreeResponse struct {
+	ProductCategoryResponse
+	Children []ProductCategoryTreeResponse `json:"children"`
+}
+
+type ProductCategoryDetailResponse struct {
+	ProductCategoryResponse
+	MetaTitle       *string `json:"meta_title"`
+	MetaDescription *string `json:"meta_description"`
 }
 
 type UpdateroductCategoryRequest struct {
@@ -39,16 +56,44 @@ func NewGetProductCategoryListResponse(message string, data []domain.ProductCate
 	for keyData, valData := range data {
 		productCategories[keyData] = ProductCategoryResponse{
 			ProductCategoryID: valData.ProductCategoryID,
+			ParentID:          valData.ParentID,
 			Name:              valData.Name,
+			Slug:              valData.Slug,
 		}
 	}
 	return GenerateResponseData(message, productCategories)
 }
 
+func NewGetProductCategoryTreeResponse(message string, data []domain.ProductCategory) *ResponseData {
+	return GenerateResponseData(message, newProductCategoryTreeResponse(data))
+}
+
+func newProductCategoryTreeResponse(data []domain.ProductCategory) []ProductCategoryTreeResponse {
+	productCategories := make([]ProductCategoryTreeResponse, 0)
+	for _, valData := range data {
+		productCategories = append(productCategories, ProductCategoryTreeResponse{
+			ProductCategoryResponse: ProductCategoryResponse{
+				ProductCategoryID: valData.ProductCategoryID,
+				ParentID:          valData.ParentID,
+				Name:              valData.Name,
+				Slug:              valData.Slug,
+			},
+			Children: newProductCategoryTreeResponse(valData.Children),
+		})
+	}
+	return productCategories
+}
+
 func NewGetProductCategoryDetailResponse(message string, data *domain.ProductCategory) *ResponseData {
-	productCategoryResponse := &ProductCategoryResponse{
-		ProductCategoryID: data.ProductCategoryID,
-		Name:              data.Name,
+	productCategoryResponse := &ProductCategoryDetailResponse{
+		ProductCategoryResponse: ProductCategoryResponse{
+			ProductCategoryID: data.ProductCategoryID,
+			ParentID:          data.ParentID,
+			Name:              data.Name,
+			Slug:              data.Slug,
+		},
+		MetaTitle:       data.MetaTitle,
+		MetaDescription: data.MetaDescription,
 	}
 
 	return GenerateResponseData(message, productCategoryResponse)
@@ -59,6 +104,12 @@ func (r CreateProductCategoryRequest) Validate() *errs.AppError {
 	if err := validation.Validate(r.Name, validation.Required); err != nil {
 		return errs.NewBadRequestError("Product category name is required")
 
+	} else if err := validation.Validate(r.Slug, validation.Length(0, 120)); err != nil {
+		return errs.NewBadRequestError("Slug maximum length is 120")
+	} else if err := validation.Validate(r.MetaTitle, validation.Length(0, 70)); err != nil {
+		return errs.NewBadRequestError("Meta title maximum length is 70")
+	} else if err := validation.Validate(r.MetaDescription, validation.Length(0, 160)); err != nil {
+		return errs.NewBadRequestError("Meta description maximum length is 160")
 	}
 
 	return nil
@@ -0,0 +1,103 @@
+package dto
+
+import (
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/utils/constants"
+	"github.com/danisbagus/matchoshop/utils/helper"
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+type (
+	ProductPriceScheduleRequest struct {
+		Price          int64   `json:"price"`
+		CompareAtPrice *int64  `json:"compare_at_price"`
+		StartsAt       string  `json:"starts_at"`
+		EndsAt         *string `json:"ends_at"`
+	}
+
+	ProductPriceScheduleResponse struct {
+		ProductPriceScheduleID int64  `json:"product_price_schedule_id"`
+		ProductID              int64  `json:"product_id"`
+		Price                  int64  `json:"price"`
+		CompareAtPrice         *int64 `json:"compare_at_price"`
+		StartsAt               string `json:"starts_at"`
+		EndsAt                 string `json:"ends_at"`
+		CancelledAt            string `json:"cancelled_at"`
+		CreatedAt              string `json:"created_at"`
+	}
+
+	ProductPriceHistoryResponse struct {
+		ProductPriceHistoryID int64   `json:"product_price_history_id"`
+		Price                 int64   `json:"price"`
+		CompareAtPrice        *int64  `json:"compare_at_price"`
+		ActorName             *string `json:"actor_name"`
+		CreatedAt             string  `json:"created_at"`
+	}
+
+	ProductPriceResponse struct {
+		ProductID              int64  `json:"product_id"`
+		Price                  int64  `json:"price"`
+		CompareAtPrice         *int64 `json:"compare_at_price"`
+		ProductPriceScheduleID *int64 `json:"product_price_schedule_id"`
+		At                     string `json:"at"`
+	}
+)
+
+func NewGetProductPriceScheduleListResponse(message string, data []domain.ProductPriceSchedule) *ResponseData {
+	schedules := make([]ProductPriceScheduleResponse, 0)
+	for _, value := range data {
+		var schedule ProductPriceScheduleResponse
+		schedule.ProductPriceScheduleID = value.ProductPriceScheduleID
+		schedule.ProductID = value.ProductID
+		schedule.Price = value.Price
+		schedule.CompareAtPrice = value.CompareAtPrice
+		schedule.StartsAt = helper.PointDateToString(&value.StartsAt, constants.DATE_TIME_FORMAT)
+		schedule.EndsAt = helper.PointDateToString(value.EndsAt, constants.DATE_TIME_FORMAT)
+		schedule.CancelledAt = helper.PointDateToString(value.CancelledAt, constants.DATE_TIME_FORMAT)
+		schedule.CreatedAt = helper.PointDateToString(&value.CreatedAt, constants.DATE_TIME_FORMAT)
+		schedules = append(schedules, schedule)
+	}
+	return GenerateResponseData(message, schedules)
+}
+
+func NewGetProductPriceHistoryListResponse(message string, data []domain.ProductPriceHistory) *ResponseData {
+	histories := make([]ProductPriceHistoryResponse, 0)
+	for _, value := range data {
+		var history ProductPriceHistoryResponse
+		history.ProductPriceHistoryID = value.ProductPriceHistoryID
+		history.Price = value.Price
+		history.CompareAtPrice = value.CompareAtPrice
+		history.ActorName = value.ActorName
+		history.CreatedAt = helper.PointDateToString(&value.CreatedAt, constants.DATE_TIME_FORMAT)
+		histories = append(histories, history)
+	}
+	return GenerateResponseData(message, histories)
+}
+
+func NewGetProductPriceResponse(message string, data *domain.ProductPrice) *ResponseData {
+	resData := new(ProductPriceResponse)
+	resData.ProductID = data.ProductID
+	resData.Price = data.Price
+	resData.CompareAtPrice = data.CompareAtPrice
+	resData.ProductPriceScheduleID = data.ProductPriceScheduleID
+	resData.At = helper.PointDateToString(&data.At, constants.DATE_TIME_FORMAT)
+
+	return GenerateResponseData(message, resData)
+}
+
+func (r ProductPriceScheduleRequest) Validate() *errs.AppError {
+
+	if err := validation.Validate(r.Price, validation.Required); err != nil {
+		return errs.NewBadRequestError("Price is required")
+	} else if r.Price < 100 {
+		return errs.NewValidationError("Minimum price is 100")
+	} else if err := validation.Validate(r.StartsAt, validation.Required); err != nil {
+		return errs.NewBadRequestError("Start time is required")
+	} else if err := validation.Validate(r.StartsAt, validation.Date(constants.DATE_TIME_FORMAT)); err != nil {
+		return errs.NewBadRequestError("Start time format must be " + constants.DATE_TIME_FORMAT)
+	} else if err := validation.Validate(r.EndsAt, validation.Date(constants.DATE_TIME_FORMAT)); err != nil {
+		return errs.NewBadRequestError("End time format must be " + constants.DATE_TIME_FORMAT)
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package dto
+
+import "github.com/danisbagus/matchoshop/internal/core/domain"
+
+type ProductRecommendationResponse struct {
+	FrequentlyBoughtTogether []ProductResponse `json:"frequently_bought_together"`
+	SameCategory             []ProductResponse `json:"same_category"`
+	SimilarPrice             []ProductResponse `json:"similar_price"`
+}
+
+func NewGetProductRecommendationResponse(message string, data *domain.ProductRecommendation) *ResponseData {
+	resData := new(ProductRecommendationResponse)
+	resData.FrequentlyBoughtTogether = newProductResponses(data.FrequentlyBoughtTogether)
+	resData.SameCategory = newProductResponses(data.SameCategory)
+	resData.SimilarPrice = newProductResponses(data.SimilarPrice)
+
+	return GenerateResponseData(message, resData)
+}
+
+func newProductResponses(data []domain.ProductModel) []ProductResponse {
+	products := make([]ProductResponse, 0)
+	for _, value := range data {
+		var product ProductResponse
+		product.ProductID = value.ProductID
+		product.Name = value.Name
+		product.Sku = value.Sku
+		product.Slug = value.Slug
+		product.BrandID = value.BrandID
+		product.Brand = value.Brand
+		product.Image = value.Image
+		product.Price = value.Price
+		product.CompareAtPrice = value.CompareAtPrice
+		products = append(products, product)
+	}
+	return products
+}
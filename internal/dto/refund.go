@@ -0,0 +1,105 @@
+package dto
+
+import (
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/utils/constants"
+	"github.com/danisbagus/matchoshop/utils/helper"
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+type (
+	CreateRefundRequest struct {
+		Amount int64               `json:"amount"`
+		Reason string              `json:"reason"`
+		Items  []RefundItemRequest `json:"items"`
+	}
+
+	RefundItemRequest struct {
+		ProductID int64 `json:"product_id"`
+		Quantity  int64 `json:"quantity"`
+	}
+
+	RejectRefundRequest struct {
+		Note *string `json:"note"`
+	}
+
+	RefundResponse struct {
+		RefundID         int64                `json:"refund_id"`
+		OrderID          int64                `json:"order_id"`
+		OrderReturnID    *int64               `json:"order_return_id"`
+		PaymentResultID  *string              `json:"payment_result_id"`
+		Amount           int64                `json:"amount"`
+		Reason           *string              `json:"reason"`
+		Status           string               `json:"status"`
+		ProviderRefundID *string              `json:"provider_refund_id"`
+		FailureReason    *string              `json:"failure_reason"`
+		ProcessedAt      string               `json:"processed_at"`
+		CreatedAt        string               `json:"created_at"`
+		Items            []RefundItemResponse `json:"items"`
+	}
+
+	RefundItemResponse struct {
+		ProductID int64 `json:"product_id"`
+		Quantity  int64 `json:"quantity"`
+		Amount    int64 `json:"amount"`
+	}
+)
+
+func NewRefundResponse(message string, data *domain.Refund) *ResponseData {
+	return GenerateResponseData(message, newRefundResponse(*data))
+}
+
+func NewGetRefundListResponse(message string, data []domain.Refund) *ResponseData {
+	refunds := make([]RefundResponse, 0)
+	for _, value := range data {
+		refunds = append(refunds, newRefundResponse(value))
+	}
+	return GenerateResponseData(message, refunds)
+}
+
+func newRefundResponse(data domain.Refund) RefundResponse {
+	var resData RefundResponse
+	resData.RefundID = data.RefundID
+	resData.OrderID = data.OrderID
+	resData.OrderReturnID = data.OrderReturnID
+	resData.PaymentResultID = data.PaymentResultID
+	resData.Amount = data.Amount
+	resData.Reason = data.Reason
+	resData.Status = data.Status
+	resData.ProviderRefundID = data.ProviderRefundID
+	resData.FailureReason = data.FailureReason
+	resData.ProcessedAt = helper.PointDateToString(data.ProcessedAt, constants.DATE_TIME_FORMAT)
+	resData.CreatedAt = helper.PointDateToString(&data.CreatedAt, constants.DATE_TIME_FORMAT)
+
+	resData.Items = make([]RefundItemResponse, 0)
+	for _, value := range data.Items {
+		var item RefundItemResponse
+		item.ProductID = value.ProductID
+		item.Quantity = value.Quantity
+		item.Amount = value.Amount
+		resData.Items = append(resData.Items, item)
+	}
+	return resData
+}
+
+func (r CreateRefundRequest) Validate() *errs.AppError {
+	if err := validation.Validate(r.Reason, validation.Required); err != nil {
+		return errs.NewBadRequestError("reason is required")
+	} else if err := validation.Validate(r.Reason, validation.Length(0, 255)); err != nil {
+		return errs.NewBadRequestError("maximal reason length is 255")
+	} else if len(r.Items) == 0 && r.Amount < 1 {
+		return errs.NewBadRequestError("amount or items is required")
+	} else if len(r.Items) > 0 && r.Amount != 0 {
+		return errs.NewBadRequestError("amount is calculated from the items")
+	}
+
+	for _, item := range r.Items {
+		if err := validation.Validate(item.ProductID, validation.Required); err != nil {
+			return errs.NewBadRequestError("product id is required")
+		} else if item.Quantity < 1 {
+			return errs.NewBadRequestError("quantity must more than equal 1")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package dto
+
+import (
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/utils/constants"
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+type (
+	ShippingZoneRequest struct {
+		Name               string   `json:"name"`
+		Countries          []string `json:"countries"`
+		PostalCodePatterns []string `json:"postal_code_patterns"`
+	}
+
+	ShippingMethodRequest struct {
+		Name        string             `json:"name"`
+		RateType    string             `json:"rate_type"`
+		Rate        int64              `json:"rate"`
+		WeightUnit  int64              `json:"weight_unit"`
+		MinSubtotal int64              `json:"min_subtotal"`
+		IsActive    *bool              `json:"is_active"`
+		Tiers       []ShippingRateTier `json:"tiers"`
+	}
+
+	ShippingRateTier struct {
+		MinSubtotal int64 `json:"min_subtotal"`
+		Price       int64 `json:"price"`
+	}
+
+	ShippingQuoteRequest struct {
+		ShippinmentAddress ShipmentAddress `json:"shipment_address"`
+		OrderProduct       []OrderProduct  `json:"order_product"`
+	}
+
+	ShippingZoneResponse struct {
+		ShippingZoneID     int64                    `json:"shipping_zone_id"`
+		Name               string                   `json:"name"`
+		Countries          []string                 `json:"countries"`
+		PostalCodePatterns []string                 `json:"postal_code_patterns"`
+		Methods            []ShippingMethodResponse `json:"methods"`
+	}
+
+	ShippingMethodResponse struct {
+		ShippingMethodID int64              `json:"shipping_method_id"`
+		Name             string             `json:"name"`
+		RateType         string             `json:"rate_type"`
+		Rate             int64              `json:"rate"`
+		WeightUnit       int64              `json:"weight_unit"`
+		MinSubtotal      int64              `json:"min_subtotal"`
+		IsActive         bool               `json:"is_active"`
+		Tiers            []ShippingRateTier `json:"tiers"`
+	}
+
+	ShippingQuoteResponse struct {
+		ShippingMethodID int64  `json:"shipping_method_id"`
+		ShippingZoneID   int64  `json:"shipping_zone_id"`
+		ZoneName         string `json:"zone_name"`
+		Name             string `json:"name"`
+		RateType         string `json:"rate_type"`
+		Price            int64  `json:"price"`
+	}
+)
+
+func NewGetShippingZoneListResponse(message string, data []domain.ShippingZone) *ResponseData {
+	zones := make([]ShippingZoneResponse, 0)
+	for _, value := range data {
+		zones = append(zones, newShippingZoneResponse(value))
+	}
+	return GenerateResponseData(message, zones)
+}
+
+func NewGetShippingZoneDetailResponse(message string, data *domain.ShippingZone) *ResponseData {
+	return GenerateResponseData(message, newShippingZoneResponse(*data))
+}
+
+func NewShippingQuoteResponse(message string, data []domain.ShippingQuote) *ResponseData {
+	quotes := make([]ShippingQuoteResponse, 0)
+	for _, value := range data {
+		var quote ShippingQuoteResponse
+		quote.ShippingMethodID = value.ShippingMethodID
+		quote.ShippingZoneID = value.ShippingZoneID
+		quote.ZoneName = value.ZoneName
+		quote.Name = value.Name
+		quote.RateType = value.RateType
+		quote.Price = value.Price
+		quotes = append(quotes, quote)
+	}
+	return GenerateResponseData(message, quotes)
+}
+
+func newShippingZoneResponse(data domain.ShippingZone) ShippingZoneResponse {
+	var zone ShippingZoneResponse
+	zone.ShippingZoneID = data.ShippingZoneID
+	zone.Name = data.Name
+	zone.Countries = data.Countries
+	zone.PostalCodePatterns = data.PostalCodePatterns
+
+	zone.Methods = make([]ShippingMethodResponse, 0)
+	for _, value := range data.Methods {
+		var method ShippingMethodResponse
+		method.ShippingMethodID = value.ShippingMethodID
+		method.Name = value.Name
+		method.RateType = value.RateType
+		method.Rate = value.Rate
+		method.WeightUnit = value.WeightUnit
+		method.MinSubtotal = value.MinSubtotal
+		method.IsActive = value.IsActive
+
+		method.Tiers = make([]ShippingRateTier, 0)
+		for _, tier := range value.Tiers {
+			method.Tiers = append(method.Tiers, ShippingRateTier{MinSubtotal: tier.MinSubtotal, Price: tier.Price})
+		}
+		zone.Methods = append(zone.Methods, method)
+	}
+	return zone
+}
+
+func (r ShippingZoneRequest) Validate() *errs.AppError {
+	if err := validation.Validate(r.Name, validation.Required); err != nil {
+		return errs.NewBadRequestError("Name is required")
+	} else if err := validation.Validate(r.Name, validation.Length(0, 100)); err != nil {
+		return errs.NewBadRequestError("Name maximum length is 100")
+	} else if len(r.Countries) == 0 {
+		return errs.NewBadRequestError("Countries is required")
+	}
+	return nil
+}
+
+func (r ShippingMethodRequest) Validate() *errs.AppError {
+	if err := validation.Validate(r.Name, validation.Required); err != nil {
+		return errs.NewBadRequestError("Name is required")
+	} else if err := validation.Validate(r.Name, validation.Length(0, 100)); err != nil {
+		return errs.NewBadRequestError("Name maximum length is 100")
+	} else if err := validation.Validate(r.RateType, validation.Required, validation.In(constants.ShippingRateTypeFlat, constants.ShippingRateTypeWeight, constants.ShippingRateTypeTiered, constants.ShippingRateTypeFree)); err != nil {
+		return errs.NewBadRequestError("Rate type must be flat, weight, tiered or free")
+	} else if r.Rate < 0 {
+		return errs.NewBadRequestError("Minimum rate is 0")
+	} else if r.WeightUnit < 0 {
+		return errs.NewBadRequestError("Minimum weight unit is 0")
+	} else if r.MinSubtotal < 0 {
+		return errs.NewBadRequestError("Minimum subtotal must more than equal 0")
+	}
+
+	for _, tier := range r.Tiers {
+		if tier.MinSubtotal < 0 || tier.Price < 0 {
+			return errs.NewBadRequestError("Tier subtotal and price must more than equal 0")
+		}
+	}
+	return nil
+}
+
+func (r ShippingQuoteRequest) Validate() *errs.AppError {
+	if err := validation.Validate(r.ShippinmentAddress.PostalCode, validation.Required); err != nil {
+		return errs.NewBadRequestError("postal code is required")
+	} else if err := validation.Validate(r.ShippinmentAddress.Country, validation.Required); err != nil {
+		return errs.NewBadRequestError("country is required")
+	} else if len(r.OrderProduct) == 0 {
+		return errs.NewBadRequestError("order product is required")
+	}
+
+	for _, orderProduct := range r.OrderProduct {
+		if orderProduct.Quantity < 1 {
+			return errs.NewBadRequestError("quantity must more than equal 1")
+		}
+	}
+	return nil
+}
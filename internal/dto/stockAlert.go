@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/utils/helper"
+)
+
+type (
+	StockAlertListRequest struct {
+		Page  int64 `query:"page"`
+		Limit int64 `query:"limit"`
+	}
+
+	StockAlertResponse struct {
+		StockAlertID int64  `json:"stock_alert_id"`
+		AlertType    string `json:"alert_type"`
+		ProductID    int64  `json:"product_id"`
+		ProductName  string `json:"product_name"`
+		Stock        int64  `json:"stock"`
+		CreatedAt    string `json:"created_at"`
+	}
+)
+
+func NewGetStockAlertListResponse(message string, data []domain.StockAlert, meta *helper.Meta) *ResponsePaginateData {
+	stockAlerts := make([]StockAlertResponse, 0)
+	for _, value := range data {
+		var stockAlert StockAlertResponse
+		stockAlert.StockAlertID = value.StockAlertID
+		stockAlert.AlertType = value.AlertType
+		stockAlert.ProductID = value.ProductID
+		stockAlert.ProductName = value.ProductName
+		stockAlert.Stock = value.Stock
+		stockAlert.CreatedAt = value.CreatedAt
+		stockAlerts = append(stockAlerts, stockAlert)
+	}
+	return GenerateResponsePaginateData(message, stockAlerts, meta)
+}
@@ -0,0 +1,70 @@
+package dto
+
+import (
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/utils/constants"
+	"github.com/danisbagus/matchoshop/utils/helper"
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+type (
+	StockMovementRequest struct {
+		MovementType string  `json:"movement_type"`
+		Quantity     int64   `json:"quantity"`
+		Reason       *string `json:"reason"`
+	}
+
+	StockMovementListRequest struct {
+		Page  int64 `query:"page"`
+		Limit int64 `query:"limit"`
+	}
+
+	StockMovementResponse struct {
+		StockMovementID int64   `json:"stock_movement_id"`
+		ProductID       int64   `json:"product_id"`
+		MovementType    string  `json:"movement_type"`
+		Quantity        int64   `json:"quantity"`
+		StockAfter      int64   `json:"stock_after"`
+		Reason          *string `json:"reason"`
+		ActorUserID     *int64  `json:"actor_user_id"`
+		ActorName       *string `json:"actor_name"`
+		OrderID         *int64  `json:"order_id"`
+		CreatedAt       string  `json:"created_at"`
+	}
+)
+
+func NewGetStockMovementListResponse(message string, data []domain.StockMovement, meta *helper.Meta) *ResponsePaginateData {
+	stockMovements := make([]StockMovementResponse, 0)
+	for _, value := range data {
+		var stockMovement StockMovementResponse
+		stockMovement.StockMovementID = value.StockMovementID
+		stockMovement.ProductID = value.ProductID
+		stockMovement.MovementType = value.MovementType
+		stockMovement.Quantity = value.Quantity
+		stockMovement.StockAfter = value.StockAfter
+		stockMovement.Reason = value.Reason
+		stockMovement.ActorUserID = value.ActorUserID
+		stockMovement.ActorName = value.ActorName
+		stockMovement.OrderID = value.OrderID
+		stockMovement.CreatedAt = value.CreatedAt
+		stockMovements = append(stockMovements, stockMovement)
+	}
+	return GenerateResponsePaginateData(message, stockMovements, meta)
+}
+
+func (r StockMovementRequest) Validate() *errs.AppError {
+
+	if err := validation.Validate(r.MovementType, validation.Required); err != nil {
+		return errs.NewBadRequestError("movement type is required")
+	} else if err := validation.Validate(r.MovementType, validation.In(constants.StockMovementRestock, constants.StockMovementAdjustment, constants.StockMovementReturn)); err != nil {
+		return errs.NewBadRequestError("movement type must be restock, adjustment or return")
+	} else if err := validation.Validate(r.Quantity, validation.Required); err != nil {
+		return errs.NewBadRequestError("quantity is required")
+	} else if err := validation.Validate(r.Reason, validation.Required); err != nil {
+		return errs.NewBadRequestError("reason is required")
+	} else if err := validation.Validate(r.Reason, validation.Length(0, 255)); err != nil {
+		return errs.NewBadRequestError("maximal reason length is 255")
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package dto
+
+import (
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/utils/constants"
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+type (
+	TaxRuleRequest struct {
+		Country     string  `json:"country"`
+		Region      string  `json:"region"`
+		TaxClass    string  `json:"tax_class"`
+		Rate        float64 `json:"rate"`
+		IsInclusive bool    `json:"is_inclusive"`
+		Rounding    string  `json:"rounding"`
+	}
+
+	TaxRuleResponse struct {
+		TaxRuleID   int64   `json:"tax_rule_id"`
+		Country     string  `json:"country"`
+		Region      string  `json:"region"`
+		TaxClass    string  `json:"tax_class"`
+		Rate        float64 `json:"rate"`
+		IsInclusive bool    `json:"is_inclusive"`
+		Rounding    string  `json:"rounding"`
+	}
+)
+
+func NewGetTaxRuleListResponse(message string, data []domain.TaxRule) *ResponseData {
+	taxRules := make([]TaxRuleResponse, 0)
+	for _, value := range data {
+		taxRules = append(taxRules, newTaxRuleResponse(value))
+	}
+	return GenerateResponseData(message, taxRules)
+}
+
+func NewGetTaxRuleDetailResponse(message string, data *domain.TaxRule) *ResponseData {
+	return GenerateResponseData(message, newTaxRuleResponse(*data))
+}
+
+func newTaxRuleResponse(data domain.TaxRule) TaxRuleResponse {
+	var taxRule TaxRuleResponse
+	taxRule.TaxRuleID = data.TaxRuleID
+	taxRule.Country = data.Country
+	taxRule.Region = data.Region
+	taxRule.TaxClass = data.TaxClass
+	taxRule.Rate = data.Rate
+	taxRule.IsInclusive = data.IsInclusive
+	taxRule.Rounding = data.Rounding
+	return taxRule
+}
+
+func (r TaxRuleRequest) Validate() *errs.AppError {
+	if err := validation.Validate(r.Country, validation.Required); err != nil {
+		return errs.NewBadRequestError("Country is required")
+	} else if err := validation.Validate(r.Country, validation.Length(0, 100)); err != nil {
+		return errs.NewBadRequestError("Country maximum length is 100")
+	} else if err := validation.Validate(r.Region, validation.Length(0, 100)); err != nil {
+		return errs.NewBadRequestError("Region maximum length is 100")
+	} else if err := validation.Validate(r.TaxClass, validation.Required, validation.In(constants.TaxClassStandard, constants.TaxClassReduced, constants.TaxClassExempt)); err != nil {
+		return errs.NewBadRequestError("Tax class must be standard, reduced or exempt")
+	} else if r.Rate < 0 || r.Rate > 100 {
+		return errs.NewBadRequestError("Rate must be between 0 and 100")
+	} else if err := validation.Validate(r.Rounding, validation.In(constants.TaxRoundingHalfUp, constants.TaxRoundingUp, constants.TaxRoundingDown)); err != nil {
+		return errs.NewBadRequestError("Rounding must be half_up, up or down")
+	}
+	return nil
+}
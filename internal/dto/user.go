@@ -3,6 +3,7 @@ package dto
 import (
 	"github.com/danisbagus/go-common-packages/errs"
 	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/utils/constants"
 	validation "github.com/go-ozzo/ozzo-validation"
 )
 
@@ -26,10 +27,12 @@ type RegisterCustomerRequest struct {
 	Email           string `json:"email"`
 	Password        string `json:"password"`
 	ConfirmPassword string `json:"confirm_password"`
+	Locale          string `json:"locale"`
 }
 
 type UpdateUserRequest struct {
-	Name string `json:"name"`
+	Name   string `json:"name"`
+	Locale string `json:"locale"`
 }
 
 type LoginResponse struct {
@@ -46,6 +49,7 @@ type UserDetailResponse struct {
 	Name   string `json:"name"`
 	Email  string `json:"email"`
 	RoleID int64  `json:"role_id"`
+	Locale string `json:"locale,omitempty"`
 }
 
 type UserListResponse struct {
@@ -116,6 +120,7 @@ func NewGetUserDetailResponse(message string, data *domain.User) *ResponseData {
 		Name:   data.Name,
 		Email:  data.Email,
 		RoleID: data.RoleID,
+		Locale: data.Locale,
 	}
 
 	return GenerateResponseData(message, userDetailResponse)
@@ -161,6 +166,8 @@ func (r RegisterCustomerRequest) Validate() *errs.AppError {
 		return errs.NewBadRequestError("Password is required")
 	} else if r.Password != r.ConfirmPassword {
 		return errs.NewBadRequestError("Invalid confirm password")
+	} else if err := validation.Validate(r.Locale, validation.In(constants.LocaleEnglish, constants.LocaleIndonesian)); err != nil {
+		return errs.NewBadRequestError("Locale is not supported")
 	}
 
 	return nil
@@ -170,6 +177,8 @@ func (r UpdateUserRequest) Validate() *errs.AppError {
 
 	if err := validation.Validate(r.Name, validation.Required); err != nil {
 		return errs.NewBadRequestError("name is required")
+	} else if err := validation.Validate(r.Locale, validation.In(constants.LocaleEnglish, constants.LocaleIndonesian)); err != nil {
+		return errs.NewBadRequestError("locale is not supported")
 	}
 	return nil
 }
@@ -0,0 +1,115 @@
+package dto
+
+import (
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/utils/constants"
+	"github.com/danisbagus/matchoshop/utils/helper"
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+type (
+	WishlistRequest struct {
+		ProductID int64 `json:"product_id"`
+	}
+
+	WishlistOrderRequest struct {
+		PaymentMethodID    int64           `json:"payment_method_id"`
+		ShippingMethodID   int64           `json:"shipping_method_id"`
+		CouponCodes        []string        `json:"coupon_codes"`
+		TotalPrice         int64           `json:"total_price"`
+		ShippinmentAddress ShipmentAddress `json:"shipment_address"`
+		OrderProduct       []OrderProduct  `json:"order_product"`
+	}
+
+	WishlistReportRequest struct {
+		Page  int64 `query:"page"`
+		Limit int64 `query:"limit"`
+	}
+
+	WishlistResponse struct {
+		ProductID      int64   `json:"product_id"`
+		Name           string  `json:"name"`
+		Slug           string  `json:"slug"`
+		Brand          *string `json:"brand"`
+		Image          *string `json:"image"`
+		Price          int64   `json:"price"`
+		CompareAtPrice *int64  `json:"compare_at_price"`
+		Stock          int64   `json:"stock"`
+		Rating         float32 `json:"rating"`
+		NumbReviews    int64   `json:"numb_reviews"`
+		CreatedAt      string  `json:"created_at"`
+	}
+
+	WishlistReportResponse struct {
+		ProductID     int64   `json:"product_id"`
+		Name          string  `json:"name"`
+		Slug          string  `json:"slug"`
+		Image         *string `json:"image"`
+		NumbWishlists int64   `json:"numb_wishlists"`
+	}
+)
+
+func NewGetWishlistListResponse(message string, data []domain.WishlistItem) *ResponseData {
+	wishlists := make([]WishlistResponse, 0)
+	for _, value := range data {
+		var wishlist WishlistResponse
+		wishlist.ProductID = value.ProductID
+		wishlist.Name = value.Product.Name
+		wishlist.Slug = value.Product.Slug
+		wishlist.Brand = value.Product.Brand
+		wishlist.Image = value.Product.Image
+		wishlist.Price = value.Product.Price
+		wishlist.CompareAtPrice = value.Product.CompareAtPrice
+		wishlist.Stock = value.Product.Stock
+		wishlist.Rating = value.Product.Rating
+		wishlist.NumbReviews = value.Product.NumbReviews
+		wishlist.CreatedAt = helper.PointDateToString(&value.CreatedAt, constants.DATE_TIME_FORMAT)
+		wishlists = append(wishlists, wishlist)
+	}
+	return GenerateResponseData(message, wishlists)
+}
+
+func NewGetWishlistReportResponse(message string, data []domain.WishlistReport, meta *helper.Meta) *ResponsePaginateData {
+	reports := make([]WishlistReportResponse, 0)
+	for _, value := range data {
+		var report WishlistReportResponse
+		report.ProductID = value.ProductID
+		report.Name = value.Name
+		report.Slug = value.Slug
+		report.Image = value.Image
+		report.NumbWishlists = value.NumbWishlists
+		reports = append(reports, report)
+	}
+	return GenerateResponsePaginateData(message, reports, meta)
+}
+
+func (r WishlistRequest) Validate() *errs.AppError {
+	if err := validation.Validate(r.ProductID, validation.Required); err != nil {
+		return errs.NewBadRequestError("product id is required")
+	}
+	return nil
+}
+
+func (r WishlistOrderRequest) Validate() *errs.AppError {
+	if err := validation.Validate(r.PaymentMethodID, validation.Required); err != nil {
+		return errs.NewBadRequestError("payment method id is required")
+	} else if err := validation.Validate(r.TotalPrice, validation.Min(0)); err != nil {
+		return errs.NewBadRequestError("total price must more than equal 0")
+	} else if err := validation.Validate(r.ShippinmentAddress.Address, validation.Required); err != nil {
+		return errs.NewBadRequestError("address is required")
+	} else if err := validation.Validate(r.ShippinmentAddress.City, validation.Required); err != nil {
+		return errs.NewBadRequestError("city address is required")
+	} else if err := validation.Validate(r.ShippinmentAddress.PostalCode, validation.Required); err != nil {
+		return errs.NewBadRequestError("postal code is required")
+	} else if err := validation.Validate(r.ShippinmentAddress.Country, validation.Required); err != nil {
+		return errs.NewBadRequestError("country is required")
+	}
+
+	for _, orderProduct := range r.OrderProduct {
+		if orderProduct.Quantity < 1 {
+			return errs.NewBadRequestError("quantity must more than equal 1")
+		}
+	}
+	return nil
+}
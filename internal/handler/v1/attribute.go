@@ -0,0 +1,115 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/danisbagus/go-common-packages/logger"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/danisbagus/matchoshop/internal/dto"
+	"github.com/danisbagus/matchoshop/utils/constants"
+	"github.com/danisbagus/matchoshop/utils/helper"
+	"github.com/labstack/echo/v4"
+)
+
+type AttributeHandler struct {
+	service port.AttributeService
+}
+
+func NewAttributeHandler(service port.AttributeService) *AttributeHandler {
+	return &AttributeHandler{service: service}
+}
+
+func (h AttributeHandler) Create(c echo.Context) error {
+	var req dto.AttributeRequest
+	if err := c.Bind(&req); err != nil {
+		logger.Error("Error while decoding create attribute request: " + err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	appErr := req.Validate()
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	form := newAttributeDefinition(req)
+
+	appErr = h.service.Create(form)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	resData := dto.GenerateResponseData(constants.SuccessCreate, map[string]int64{"attribute_definition_id": form.AttributeDefinitionID})
+	return c.JSON(http.StatusOK, resData)
+}
+
+func (h AttributeHandler) GetList(c echo.Context) error {
+	productCategoryID := helper.StringToInt64(c.QueryParam("product_category_id"), 0)
+
+	attributes, appErr := h.service.GetList(productCategoryID)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	res := dto.NewGetAttributeListResponse(constants.SuccesGet, attributes)
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h AttributeHandler) GetDetail(c echo.Context) error {
+	attributeDefinitionID := helper.StringToInt64(c.Param("attribute_definition_id"), 0)
+
+	attribute, appErr := h.service.GetDetail(attributeDefinitionID)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	res := dto.NewGetAttributeDetailResponse(constants.SuccesGet, attribute)
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h AttributeHandler) Update(c echo.Context) error {
+	var req dto.AttributeRequest
+	if err := c.Bind(&req); err != nil {
+		logger.Error("Error while decoding update attribute request: " + err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	appErr := req.Validate()
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	form := newAttributeDefinition(req)
+	form.AttributeDefinitionID = helper.StringToInt64(c.Param("attribute_definition_id"), 0)
+
+	appErr = h.service.Update(form)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	resData := dto.GenerateResponseData(constants.SuccessUpdate, nil)
+	return c.JSON(http.StatusOK, resData)
+}
+
+func (h AttributeHandler) Delete(c echo.Context) error {
+	attributeDefinitionID := helper.StringToInt64(c.Param("attribute_definition_id"), 0)
+
+	appErr := h.service.Delete(attributeDefinitionID)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	resData := dto.GenerateResponseData(constants.SuccessUpdate, nil)
+	return c.JSON(http.StatusOK, resData)
+}
+
+func newAttributeDefinition(req dto.AttributeRequest) *domain.AttributeDefinition {
+	form := new(domain.AttributeDefinition)
+	form.Code = req.Code
+	form.Name = req.Name
+	form.DataType = req.DataType
+	form.Options = req.Options
+	form.IsRequired = req.IsRequired
+	form.ProductCategoryIDs = req.ProductCategoryIDs
+	return form
+}
@@ -0,0 +1,128 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/danisbagus/go-common-packages/logger"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/danisbagus/matchoshop/internal/dto"
+	"github.com/danisbagus/matchoshop/utils/constants"
+	"github.com/danisbagus/matchoshop/utils/helper"
+	"github.com/labstack/echo/v4"
+)
+
+type BrandHandler struct {
+	service port.BrandService
+}
+
+func NewBrandHandler(service port.BrandService) *BrandHandler {
+	return &BrandHandler{service: service}
+}
+
+func (h BrandHandler) Create(c echo.Context) error {
+	var req dto.BrandRequest
+	if err := c.Bind(&req); err != nil {
+		logger.Error("Error while decoding create brand request: " + err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	appErr := req.Validate()
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	form := newBrand(req)
+
+	appErr = h.service.Create(form)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	resData := dto.GenerateResponseData(constants.SuccessCreate, map[string]int64{"brand_id": form.BrandID})
+	return c.JSON(http.StatusOK, resData)
+}
+
+func (h BrandHandler) GetList(c echo.Context) error {
+	brands, appErr := h.service.GetList()
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	res := dto.NewGetBrandListResponse(constants.SuccesGet, brands)
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h BrandHandler) GetDetail(c echo.Context) error {
+	brandID := helper.StringToInt64(c.Param("brand_id"), 0)
+
+	brand, appErr := h.service.GetDetail(brandID)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	res := dto.NewGetBrandDetailResponse(constants.SuccesGet, brand)
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h BrandHandler) GetDetailBySlug(c echo.Context) error {
+	slug := c.Param("slug")
+
+	brand, appErr := h.service.GetDetailBySlug(slug)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	// old slug of a renamed brand, point the client to the current one
+	if brand.Slug != slug {
+		return c.Redirect(http.StatusMovedPermanently, "/api/v1/brand/slug/"+brand.Slug)
+	}
+
+	res := dto.NewGetBrandDetailResponse(constants.SuccesGet, brand)
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h BrandHandler) Update(c echo.Context) error {
+	var req dto.BrandRequest
+	if err := c.Bind(&req); err != nil {
+		logger.Error("Error while decoding update brand request: " + err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	appErr := req.Validate()
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	form := newBrand(req)
+	form.BrandID = helper.StringToInt64(c.Param("brand_id"), 0)
+
+	appErr = h.service.Update(form)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	resData := dto.GenerateResponseData(constants.SuccessUpdate, nil)
+	return c.JSON(http.StatusOK, resData)
+}
+
+func (h BrandHandler) Delete(c echo.Context) error {
+	brandID := helper.StringToInt64(c.Param("brand_id"), 0)
+
+	appErr := h.service.Delete(brandID)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	resData := dto.GenerateResponseData(constants.SuccessUpdate, nil)
+	return c.JSON(http.StatusOK, resData)
+}
+
+func newBrand(req dto.BrandRequest) *domain.Brand {
+	form := new(domain.Brand)
+	form.Name = req.Name
+	form.Slug = req.Slug
+	form.Logo = req.Logo
+	form.Description = req.Description
+	return form
+}
@@ -0,0 +1,143 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/danisbagus/go-common-packages/logger"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/danisbagus/matchoshop/internal/dto"
+	"github.com/danisbagus/matchoshop/utils/auth"
+	"github.com/danisbagus/matchoshop/utils/constants"
+	"github.com/danisbagus/matchoshop/utils/helper"
+	"github.com/labstack/echo/v4"
+)
+
+type CartHandler struct {
+	service port.CartService
+}
+
+func NewCartHandler(service port.CartService) *CartHandler {
+	return &CartHandler{service: service}
+}
+
+func (h CartHandler) GetDetail(c echo.Context) error {
+	cart, appErr := h.service.GetDetail(cartOwner(c))
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	return cartResponse(c, constants.SuccesGet, cart)
+}
+
+func (h CartHandler) AddItem(c echo.Context) error {
+	var req dto.CartItemRequest
+	if err := c.Bind(&req); err != nil {
+		logger.Error("Error while decoding add cart item request: " + err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	appErr := req.Validate()
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	form := new(domain.CartItem)
+	form.ProductID = req.ProductID
+	form.Quantity = req.Quantity
+
+	cart, appErr := h.service.AddItem(cartOwner(c), form)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	return cartResponse(c, constants.SuccessCreate, cart)
+}
+
+func (h CartHandler) UpdateItem(c echo.Context) error {
+	var req dto.CartItemRequest
+	if err := c.Bind(&req); err != nil {
+		logger.Error("Error while decoding update cart item request: " + err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	req.ProductID = helper.StringToInt64(c.Param("product_id"), 0)
+
+	appErr := req.Validate()
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	form := new(domain.CartItem)
+	form.ProductID = req.ProductID
+	form.Quantity = req.Quantity
+
+	cart, appErr := h.service.UpdateItem(cartOwner(c), form)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	return cartResponse(c, constants.SuccessUpdate, cart)
+}
+
+func (h CartHandler) RemoveItem(c echo.Context) error {
+	productID := helper.StringToInt64(c.Param("product_id"), 0)
+
+	cart, appErr := h.service.RemoveItem(cartOwner(c), productID)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	return cartResponse(c, constants.SuccessUpdate, cart)
+}
+
+func (h CartHandler) Checkout(c echo.Context) error {
+	userInfo := auth.GetClaimData(c)
+	var req dto.CartCheckoutRequest
+	if err := c.Bind(&req); err != nil {
+		logger.Error("Error while decoding cart checkout request: " + err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	appErr := req.Validate()
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	form := new(domain.CartCheckout)
+	form.UserID = userInfo.UserID
+	form.PaymentMethodID = req.PaymentMethodID
+	form.ShippingMethodID = req.ShippingMethodID
+	form.CouponCodes = req.CouponCodes
+	form.TotalPrice = req.TotalPrice
+	form.ShipmentAddress.Address = req.ShippinmentAddress.Address
+	form.ShipmentAddress.City = req.ShippinmentAddress.City
+	form.ShipmentAddress.Region = req.ShippinmentAddress.Region
+	form.ShipmentAddress.PostalCode = req.ShippinmentAddress.PostalCode
+	form.ShipmentAddress.Country = req.ShippinmentAddress.Country
+
+	order, appErr := h.service.Checkout(form)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	resData := dto.NewOrderResponse(constants.SuccessCreate, order)
+	return c.JSON(http.StatusOK, resData)
+}
+
+// cartOwner resolves the cart of the logged in user, or of the guest session when there is no token
+func cartOwner(c echo.Context) domain.CartOwner {
+	owner := domain.CartOwner{SessionID: c.Request().Header.Get(constants.CartSessionHeader)}
+	if userInfo := auth.GetClaimData(c); userInfo != nil {
+		owner.UserID = userInfo.UserID
+	}
+	return owner
+}
+
+func cartResponse(c echo.Context, message string, cart *domain.CartDetail) error {
+	if cart.SessionID != nil {
+		c.Response().Header().Set(constants.CartSessionHeader, *cart.SessionID)
+	}
+
+	res := dto.NewCartResponse(message, cart)
+	return c.JSON(http.StatusOK, res)
+}
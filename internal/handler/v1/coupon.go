@@ -0,0 +1,165 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/danisbagus/go-common-packages/logger"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/danisbagus/matchoshop/internal/dto"
+	"github.com/danisbagus/matchoshop/utils/constants"
+	"github.com/danisbagus/matchoshop/utils/helper"
+	"github.com/labstack/echo/v4"
+)
+
+type CouponHandler struct {
+	service port.CouponService
+}
+
+func NewCouponHandler(service port.CouponService) *CouponHandler {
+	return &CouponHandler{service: service}
+}
+
+func (h CouponHandler) Create(c echo.Context) error {
+	var req dto.CouponRequest
+	if err := c.Bind(&req); err != nil {
+		logger.Error("Error while decoding create coupon request: " + err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	appErr := req.Validate()
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	form := newCoupon(req)
+
+	appErr = h.service.Create(form)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	resData := dto.GenerateResponseData(constants.SuccessCreate, map[string]int64{"coupon_id": form.CouponID})
+	return c.JSON(http.StatusOK, resData)
+}
+
+func (h CouponHandler) GetList(c echo.Context) error {
+	coupons, appErr := h.service.GetList()
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	res := dto.NewGetCouponListResponse(constants.SuccesGet, coupons)
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h CouponHandler) GetDetail(c echo.Context) error {
+	couponID := helper.StringToInt64(c.Param("coupon_id"), 0)
+
+	coupon, appErr := h.service.GetDetail(couponID)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	res := dto.NewGetCouponDetailResponse(constants.SuccesGet, coupon)
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h CouponHandler) GetRedemptionListPaginate(c echo.Context) error {
+	req := new(dto.CouponRedemptionListRequest)
+	if err := c.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	criteria := new(domain.CouponRedemptionListCriteria)
+	criteria.CouponID = helper.StringToInt64(c.Param("coupon_id"), 0)
+	criteria.Page, criteria.Limit = helper.SetPaginationParameter(req.Page, req.Limit)
+
+	redemptions, total, appErr := h.service.GetRedemptionListPaginate(criteria)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	meta := new(helper.Meta)
+	meta.SetPaginationData(criteria.Page, criteria.Limit, total)
+
+	res := dto.NewGetCouponRedemptionListPaginateResponse(constants.SuccesGet, redemptions, meta)
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h CouponHandler) Update(c echo.Context) error {
+	var req dto.CouponRequest
+	if err := c.Bind(&req); err != nil {
+		logger.Error("Error while decoding update coupon request: " + err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	appErr := req.Validate()
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	form := newCoupon(req)
+	form.CouponID = helper.StringToInt64(c.Param("coupon_id"), 0)
+
+	appErr = h.service.Update(form)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	resData := dto.GenerateResponseData(constants.SuccessUpdate, nil)
+	return c.JSON(http.StatusOK, resData)
+}
+
+func (h CouponHandler) Delete(c echo.Context) error {
+	couponID := helper.StringToInt64(c.Param("coupon_id"), 0)
+
+	appErr := h.service.Delete(couponID)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	resData := dto.GenerateResponseData(constants.SuccessUpdate, nil)
+	return c.JSON(http.StatusOK, resData)
+}
+
+func newCoupon(req dto.CouponRequest) *domain.Coupon {
+	form := new(domain.Coupon)
+	form.Code = req.Code
+	form.Name = req.Name
+	form.Type = req.Type
+	form.Value = req.Value
+	form.MaxDiscount = req.MaxDiscount
+	form.MinSpend = req.MinSpend
+	form.BuyQuantity = req.BuyQuantity
+	form.GetQuantity = req.GetQuantity
+	form.ProductIDs = req.ProductIDs
+	form.ProductCategoryIDs = req.ProductCategoryIDs
+	form.UsageLimit = req.UsageLimit
+	form.UsageLimitPerUser = req.UsageLimitPerUser
+	form.IsStackable = req.IsStackable
+	form.IsAutomatic = req.IsAutomatic
+
+	form.IsActive = true
+	if req.IsActive != nil {
+		form.IsActive = *req.IsActive
+	}
+
+	if req.StartsAt != nil && *req.StartsAt != "" {
+		startsAt := helper.StringToLocalDate(*req.StartsAt, constants.DATE_TIME_FORMAT)
+		form.StartsAt = &startsAt
+	}
+
+	if req.EndsAt != nil && *req.EndsAt != "" {
+		endsAt := helper.StringToLocalDate(*req.EndsAt, constants.DATE_TIME_FORMAT)
+		form.EndsAt = &endsAt
+	}
+
+	if form.ProductIDs == nil {
+		form.ProductIDs = []int64{}
+	}
+	if form.ProductCategoryIDs == nil {
+		form.ProductCategoryIDs = []int64{}
+	}
+	return form
+}
@@ -40,12 +40,12 @@ func (h OrderHandler) Create(c echo.Context) error {
 	form := new(domain.OrderDetail)
 	form.UserID = userInfo.UserID
 	form.PaymentMethodID = req.PaymentMethodID
-	form.ProductPrice = req.ProductPrice
-	form.TaxPrice = req.TaxPrice
-	form.ShippingPrice = req.ShippingPrice
+	form.ShippingMethodID = &req.ShippingMethodID
+	form.CouponCodes = req.CouponCodes
 	form.TotalPrice = req.TotalPrice
 	form.ShipmentAddress.Address = req.ShippinmentAddress.Address
 	form.ShipmentAddress.City = req.ShippinmentAddress.City
+	form.ShipmentAddress.Region = req.ShippinmentAddress.Region
 	form.ShipmentAddress.PostalCode = req.ShippinmentAddress.PostalCode
 	form.ShipmentAddress.Country = req.ShippinmentAddress.Country
 
@@ -94,6 +94,19 @@ func (h OrderHandler) GetDetail(c echo.Context) error {
 	return c.JSON(http.StatusOK, resData)
 }
 
+func (h OrderHandler) GetDetailByUser(c echo.Context) error {
+	userInfo := auth.GetClaimData(c)
+	orderID := helper.StringToInt64(c.Param("order_id"), 0)
+
+	order, appErr := h.service.GetDetailByUser(orderID, userInfo.UserID)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	resData := dto.NewGetOrderDetailResponse(constants.SuccesGet, order)
+	return c.JSON(http.StatusOK, resData)
+}
+
 func (h OrderHandler) UpdatePaid(c echo.Context) error {
 	orderID := helper.StringToInt64(c.Param("order_id"), 0)
 	var req dto.UpdateOrderPaid
@@ -115,6 +128,7 @@ func (h OrderHandler) UpdatePaid(c echo.Context) error {
 	form.Status = req.Status
 	form.UpdateTime = helper.StringToDate(req.UpdateTime, time.RFC3339)
 	form.Email = req.Email
+	form.ActorUserID = auth.GetClaimData(c).UserID
 
 	appErr = h.service.UpdatePaid(form)
 	if appErr != nil {
@@ -126,10 +140,26 @@ func (h OrderHandler) UpdatePaid(c echo.Context) error {
 	return c.JSON(http.StatusOK, resData)
 }
 
+// RenewStockReservation is called by the storefront before the payment is captured, a failure means the
+// order can no longer be paid and the payment must not be captured
+func (h OrderHandler) RenewStockReservation(c echo.Context) error {
+	userInfo := auth.GetClaimData(c)
+	orderID := helper.StringToInt64(c.Param("order_id"), 0)
+
+	order, appErr := h.service.RenewStockReservation(orderID, userInfo.UserID)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	resData := dto.NewRenewStockReservationResponse(constants.SuccessUpdate, order)
+	return c.JSON(http.StatusOK, resData)
+}
+
 func (h OrderHandler) UpdateDelivered(c echo.Context) error {
+	userInfo := auth.GetClaimData(c)
 	orderID := helper.StringToInt64(c.Param("order_id"), 0)
 
-	appErr := h.service.UpdateDelivered(orderID)
+	appErr := h.service.UpdateDelivered(orderID, userInfo.UserID)
 	if appErr != nil {
 		return c.JSON(appErr.Code, appErr.AsMessage())
 	}
@@ -141,12 +171,116 @@ func (h OrderHandler) UpdateDelivered(c echo.Context) error {
 	return c.JSON(http.StatusOK, resData)
 }
 
+func (h OrderHandler) UpdateStatus(c echo.Context) error {
+	userInfo := auth.GetClaimData(c)
+	orderID := helper.StringToInt64(c.Param("order_id"), 0)
+	var req dto.UpdateOrderStatusRequest
+	if err := c.Bind(&req); err != nil {
+		logger.Error("Error while decoding update order status request: " + err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	appErr := req.Validate()
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	form := new(domain.OrderStatusHistory)
+	form.OrderID = orderID
+	form.ToStatus = req.Status
+	form.ActorUserID = &userInfo.UserID
+	if req.Note != "" {
+		form.Note = &req.Note
+	}
+
+	appErr = h.service.UpdateStatus(form)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	resData := dto.GenerateResponseData(constants.SuccessUpdate, nil)
+	return c.JSON(http.StatusOK, resData)
+}
+
+func (h OrderHandler) Cancel(c echo.Context) error {
+	return h.cancel(c, false)
+}
+
+func (h OrderHandler) CancelAdmin(c echo.Context) error {
+	return h.cancel(c, true)
+}
+
+func (h OrderHandler) cancel(c echo.Context, isAdmin bool) error {
+	userInfo := auth.GetClaimData(c)
+	var req dto.CancelOrderRequest
+	if err := c.Bind(&req); err != nil {
+		logger.Error("Error while decoding cancel order request: " + err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	appErr := req.Validate()
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	form := new(domain.OrderCancellation)
+	form.OrderID = helper.StringToInt64(c.Param("order_id"), 0)
+	form.ActorUserID = userInfo.UserID
+	form.IsAdmin = isAdmin
+	form.Reason = req.Reason
+
+	appErr = h.service.Cancel(form)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	resData := dto.GenerateResponseData(constants.SuccessUpdate, nil)
+	return c.JSON(http.StatusOK, resData)
+}
+
 func (h OrderHandler) GetListAdmin(c echo.Context) error {
-	orders, appErr := h.service.GetList()
+	req := new(dto.OrderListRequest)
+	if err := c.Bind(req); err != nil {
+		logger.Error("Error while decoding order list request: " + err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	appErr := req.Validate()
 	if appErr != nil {
 		return c.JSON(appErr.Code, appErr.AsMessage())
 	}
 
-	resData := dto.NewGetOrderListResponse(constants.SuccesGet, orders)
+	criteria := new(domain.OrderListCriteria)
+	criteria.Keyword = req.Keyword
+	criteria.Status = req.Status
+	criteria.IsPaid = req.IsPaid
+	criteria.IsDelivered = req.IsDelivered
+	criteria.UserID = req.UserID
+	criteria.PaymentMethodID = req.PaymentMethodID
+	criteria.MinTotalPrice = req.MinTotalPrice
+	criteria.MaxTotalPrice = req.MaxTotalPrice
+	criteria.Sort = req.Sort
+	criteria.Order = req.Order
+	criteria.Page, criteria.Limit = helper.SetPaginationParameter(req.Page, req.Limit)
+
+	if req.DateFrom != "" {
+		dateFrom := helper.StringToLocalDate(req.DateFrom, constants.DATE_FORMAT)
+		criteria.DateFrom = &dateFrom
+	}
+
+	if req.DateTo != "" {
+		dateTo := helper.StringToLocalDate(req.DateTo, constants.DATE_FORMAT)
+		criteria.DateTo = &dateTo
+	}
+
+	orders, total, appErr := h.service.GetListPaginate(criteria)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	meta := new(helper.Meta)
+	meta.SetPaginationData(criteria.Page, criteria.Limit, total)
+
+	resData := dto.NewGetOrderListPaginateResponse(constants.SuccesGet, orders, meta)
 	return c.JSON(http.StatusOK, resData)
 }
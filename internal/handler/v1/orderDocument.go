@@ -0,0 +1,49 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/danisbagus/matchoshop/utils/auth"
+	"github.com/danisbagus/matchoshop/utils/constants"
+	"github.com/danisbagus/matchoshop/utils/helper"
+	"github.com/labstack/echo/v4"
+)
+
+type OrderDocumentHandler struct {
+	service port.OrderDocumentService
+}
+
+func NewOrderDocumentHandler(service port.OrderDocumentService) *OrderDocumentHandler {
+	return &OrderDocumentHandler{service: service}
+}
+
+func (h OrderDocumentHandler) GetInvoice(c echo.Context) error {
+	return h.getDocument(c, constants.OrderDocumentTypeInvoice, false)
+}
+
+func (h OrderDocumentHandler) GetInvoiceAdmin(c echo.Context) error {
+	return h.getDocument(c, constants.OrderDocumentTypeInvoice, true)
+}
+
+func (h OrderDocumentHandler) GetPackingSlip(c echo.Context) error {
+	return h.getDocument(c, constants.OrderDocumentTypePackingSlip, false)
+}
+
+func (h OrderDocumentHandler) GetPackingSlipAdmin(c echo.Context) error {
+	return h.getDocument(c, constants.OrderDocumentTypePackingSlip, true)
+}
+
+func (h OrderDocumentHandler) getDocument(c echo.Context, documentType string, isAdmin bool) error {
+	userInfo := auth.GetClaimData(c)
+	orderID := helper.StringToInt64(c.Param("order_id"), 0)
+
+	document, appErr := h.service.GetDocument(orderID, userInfo.UserID, isAdmin, documentType)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", document.FileName))
+	return c.Blob(http.StatusOK, "application/pdf", document.Content)
+}
@@ -0,0 +1,147 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/danisbagus/go-common-packages/logger"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/danisbagus/matchoshop/internal/dto"
+	"github.com/danisbagus/matchoshop/utils/auth"
+	"github.com/danisbagus/matchoshop/utils/constants"
+	"github.com/danisbagus/matchoshop/utils/helper"
+	"github.com/labstack/echo/v4"
+)
+
+type OrderReturnHandler struct {
+	service port.OrderReturnService
+}
+
+func NewOrderReturnHandler(service port.OrderReturnService) *OrderReturnHandler {
+	return &OrderReturnHandler{service: service}
+}
+
+func (h OrderReturnHandler) Create(c echo.Context) error {
+	userInfo := auth.GetClaimData(c)
+	var req dto.CreateOrderReturnRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		logger.Error("Error while decoding create order return request: " + err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	appErr := req.Validate()
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	form := new(domain.OrderReturn)
+	form.OrderID = helper.StringToInt64(c.Param("order_id"), 0)
+	form.UserID = userInfo.UserID
+	form.Reason = req.Reason
+	form.Photos = req.Photos
+	for _, value := range req.Items {
+		form.Items = append(form.Items, domain.OrderReturnItem{ProductID: value.ProductID, Quantity: value.Quantity})
+	}
+
+	orderReturn, appErr := h.service.Create(form)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	resData := dto.NewOrderReturnResponse(constants.SuccessCreate, orderReturn)
+	return c.JSON(http.StatusOK, resData)
+}
+
+func (h OrderReturnHandler) GetListByOrder(c echo.Context) error {
+	userInfo := auth.GetClaimData(c)
+	orderID := helper.StringToInt64(c.Param("order_id"), 0)
+
+	orderReturns, appErr := h.service.GetListByOrder(orderID, userInfo.UserID)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	resData := dto.NewGetOrderReturnListResponse(constants.SuccesGet, orderReturns)
+	return c.JSON(http.StatusOK, resData)
+}
+
+func (h OrderReturnHandler) GetDetailByOrder(c echo.Context) error {
+	userInfo := auth.GetClaimData(c)
+	orderID := helper.StringToInt64(c.Param("order_id"), 0)
+	orderReturnID := helper.StringToInt64(c.Param("order_return_id"), 0)
+
+	orderReturn, appErr := h.service.GetDetailByUser(orderID, orderReturnID, userInfo.UserID)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	resData := dto.NewOrderReturnResponse(constants.SuccesGet, orderReturn)
+	return c.JSON(http.StatusOK, resData)
+}
+
+func (h OrderReturnHandler) GetListPaginate(c echo.Context) error {
+	req := new(dto.OrderReturnListRequest)
+	if err := c.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	criteria := new(domain.OrderReturnListCriteria)
+	criteria.Status = req.Status
+	criteria.Page, criteria.Limit = helper.SetPaginationParameter(req.Page, req.Limit)
+
+	orderReturns, total, appErr := h.service.GetListPaginate(criteria)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	meta := new(helper.Meta)
+	meta.SetPaginationData(criteria.Page, criteria.Limit, total)
+
+	res := dto.NewGetOrderReturnListPaginateResponse(constants.SuccesGet, orderReturns, meta)
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h OrderReturnHandler) GetDetail(c echo.Context) error {
+	orderReturnID := helper.StringToInt64(c.Param("order_return_id"), 0)
+
+	orderReturn, appErr := h.service.GetDetail(orderReturnID)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	resData := dto.NewOrderReturnResponse(constants.SuccesGet, orderReturn)
+	return c.JSON(http.StatusOK, resData)
+}
+
+func (h OrderReturnHandler) UpdateStatus(c echo.Context) error {
+	userInfo := auth.GetClaimData(c)
+	var req dto.UpdateOrderReturnStatusRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		logger.Error("Error while decoding update order return status request: " + err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	appErr := req.Validate()
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	form := new(domain.OrderReturnStatusUpdate)
+	form.OrderReturnID = helper.StringToInt64(c.Param("order_return_id"), 0)
+	form.ToStatus = req.Status
+	form.ActorUserID = userInfo.UserID
+	form.Note = req.Note
+	form.Restock = req.Restock
+
+	appErr = h.service.UpdateStatus(form)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	resData := dto.GenerateResponseData(constants.SuccessUpdate, nil)
+	return c.JSON(http.StatusOK, resData)
+}
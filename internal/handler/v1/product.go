@@ -1,17 +1,26 @@
 package v1
 
 import (
+	"fmt"
 	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/danisbagus/go-common-packages/logger"
 	"github.com/danisbagus/matchoshop/internal/core/domain"
 	"github.com/danisbagus/matchoshop/internal/core/port"
 	"github.com/danisbagus/matchoshop/internal/dto"
+	"github.com/danisbagus/matchoshop/utils/auth"
 	"github.com/danisbagus/matchoshop/utils/helper"
 	"github.com/labstack/echo/v4"
 )
 
+// attributeFilterPattern matches attr[code], attr[code][min] and attr[code][max] query keys
+var attributeFilterPattern = regexp.MustCompile(`^attr\[([a-z0-9_]+)\](?:\[(min|max)\])?$`)
+
 type ProductHandler struct {
 	service port.ProductService
 }
@@ -35,12 +44,22 @@ func (h ProductHandler) CreateProduct(c echo.Context) error {
 	form := new(domain.Product)
 	form.Name = req.Name
 	form.Sku = req.Sku
-	form.Brand = req.Brand
+	form.Slug = req.Slug
+	form.BrandID = &req.BrandID
 	form.Image = req.Image
 	form.Description = req.Description
+	form.MetaTitle = req.MetaTitle
+	form.MetaDescription = req.MetaDescription
 	form.Price = req.Price
+	form.CompareAtPrice = req.CompareAtPrice
 	form.Stock = req.Stock
+	form.LowStockThreshold = req.LowStockThreshold
+	form.IsPublished = req.IsPublished == nil || *req.IsPublished
+	form.TaxClass = req.TaxClass
+	form.Weight = req.Weight
 	form.ProductCategoryIDs = req.ProductCategoryIDs
+	form.Attributes = newProductAttributeValues(req.Attributes)
+	form.ActorUserID = auth.GetClaimData(c).UserID
 
 	appErr = h.service.Create(form)
 	if appErr != nil {
@@ -56,6 +75,7 @@ func (h ProductHandler) GetTopProduct(c echo.Context) error {
 	criteria.Limit = 3
 	criteria.Sort = "numb_reviews"
 	criteria.Order = "DESC"
+	criteria.PublishedOnly = true
 
 	products, appErr := h.service.GetList(criteria)
 	if appErr != nil {
@@ -67,6 +87,15 @@ func (h ProductHandler) GetTopProduct(c echo.Context) error {
 }
 
 func (h ProductHandler) GetProductListPaginate(c echo.Context) error {
+	return h.getProductListPaginate(c, false)
+}
+
+func (h ProductHandler) GetProductListPaginateAdmin(c echo.Context) error {
+	return h.getProductListPaginate(c, true)
+}
+
+// getProductListPaginate lists published products only on the storefront, the admin sees them all
+func (h ProductHandler) getProductListPaginate(c echo.Context, isAdmin bool) error {
 	req := new(dto.ProductListRequest)
 	if err := c.Bind(&req); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
@@ -74,8 +103,17 @@ func (h ProductHandler) GetProductListPaginate(c echo.Context) error {
 
 	criteria := new(domain.ProductListCriteria)
 	criteria.Keyword = req.Keyword
+	criteria.BrandID = req.BrandID
+	criteria.ProductCategoryID = req.ProductCategoryID
+	criteria.PublishedOnly = !isAdmin
 	criteria.Page, criteria.Limit = helper.SetPaginationParameter(req.Page, req.Limit)
 
+	attributeFilters, err := parseAttributeFilters(c.QueryParams())
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	criteria.AttributeFilters = attributeFilters
+
 	products, total, appErr := h.service.GetListPaginate(criteria)
 	if appErr != nil {
 		return c.JSON(appErr.Code, appErr.AsMessage())
@@ -91,6 +129,18 @@ func (h ProductHandler) GetProductListPaginate(c echo.Context) error {
 func (h ProductHandler) GetProductDetail(c echo.Context) error {
 	productID, _ := strconv.Atoi(c.Param("product_id"))
 
+	product, appErr := h.service.GetPublishedDetail(int64(productID))
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	res := dto.NewGetProductDetailResponse("Successfully get data", product)
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h ProductHandler) GetProductDetailAdmin(c echo.Context) error {
+	productID, _ := strconv.Atoi(c.Param("product_id"))
+
 	product, appErr := h.service.GetDetail(int64(productID))
 	if appErr != nil {
 		return c.JSON(appErr.Code, appErr.AsMessage())
@@ -100,6 +150,23 @@ func (h ProductHandler) GetProductDetail(c echo.Context) error {
 	return c.JSON(http.StatusOK, res)
 }
 
+func (h ProductHandler) GetProductDetailBySlug(c echo.Context) error {
+	slug := c.Param("slug")
+
+	product, appErr := h.service.GetDetailBySlug(slug)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	// old slug of a renamed product, point the client to the current one
+	if product.Slug != slug {
+		return c.Redirect(http.StatusMovedPermanently, "/api/v1/product/slug/"+product.Slug)
+	}
+
+	res := dto.NewGetProductDetailResponse("Successfully get data", product)
+	return c.JSON(http.StatusOK, res)
+}
+
 func (h ProductHandler) UpdateProduct(c echo.Context) error {
 	productID, _ := strconv.Atoi(c.Param("product_id"))
 	var req dto.ProductRequest
@@ -109,7 +176,7 @@ func (h ProductHandler) UpdateProduct(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
 
-	appErr := req.Validate()
+	appErr := req.ValidateUpdate()
 	if appErr != nil {
 		return c.JSON(appErr.Code, appErr.AsMessage())
 	}
@@ -118,11 +185,20 @@ func (h ProductHandler) UpdateProduct(c echo.Context) error {
 	form.Name = req.Name
 	form.Sku = req.Sku
 	form.Price = req.Price
-	form.Brand = req.Brand
+	form.CompareAtPrice = req.CompareAtPrice
+	form.LowStockThreshold = req.LowStockThreshold
+	form.Publish = req.IsPublished
+	form.TaxClass = req.TaxClass
+	form.Weight = req.Weight
+	form.ActorUserID = auth.GetClaimData(c).UserID
+	form.BrandID = &req.BrandID
 	form.Image = req.Image
-	form.Stock = req.Stock
 	form.Description = req.Description
+	form.Slug = req.Slug
+	form.MetaTitle = req.MetaTitle
+	form.MetaDescription = req.MetaDescription
 	form.ProductCategoryIDs = req.ProductCategoryIDs
+	form.Attributes = newProductAttributeValues(req.Attributes)
 
 	appErr = h.service.Update(int64(productID), form)
 	if appErr != nil {
@@ -143,3 +219,83 @@ func (h ProductHandler) Delete(c echo.Context) error {
 	res := dto.GenerateResponseData("Successfully delete data", nil)
 	return c.JSON(http.StatusOK, res)
 }
+
+func (h ProductHandler) GetLowStockProductList(c echo.Context) error {
+	req := new(dto.ProductListRequest)
+	if err := c.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	criteria := new(domain.ProductListCriteria)
+	criteria.Page, criteria.Limit = helper.SetPaginationParameter(req.Page, req.Limit)
+
+	products, total, appErr := h.service.GetLowStockListPaginate(criteria)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	meta := new(helper.Meta)
+	meta.SetPaginationData(criteria.Page, criteria.Limit, total)
+
+	res := dto.NewGetLowStockProductListResponse("Successfully get data", products, meta)
+	return c.JSON(http.StatusOK, res)
+}
+
+func newProductAttributeValues(req []dto.ProductAttributeRequest) []domain.ProductAttributeValue {
+	values := make([]domain.ProductAttributeValue, 0)
+	for _, value := range req {
+		values = append(values, domain.ProductAttributeValue{
+			AttributeDefinitionID: value.AttributeDefinitionID,
+			Value:                 value.Value,
+		})
+	}
+	return values
+}
+
+// parseAttributeFilters reads enum filters as attr[code]=a,b and number filters as attr[code][min]=n&attr[code][max]=n
+func parseAttributeFilters(query url.Values) ([]domain.AttributeFilter, error) {
+	filterByCode := make(map[string]*domain.AttributeFilter)
+	codes := make([]string, 0)
+
+	for key, values := range query {
+		matches := attributeFilterPattern.FindStringSubmatch(key)
+		if matches == nil || len(values) == 0 {
+			continue
+		}
+
+		code := matches[1]
+		filter, ok := filterByCode[code]
+		if !ok {
+			filter = &domain.AttributeFilter{Code: code}
+			filterByCode[code] = filter
+			codes = append(codes, code)
+		}
+
+		if matches[2] == "" {
+			for _, value := range strings.Split(values[0], ",") {
+				if value = strings.TrimSpace(value); value != "" {
+					filter.Values = append(filter.Values, value)
+				}
+			}
+			continue
+		}
+
+		number, err := strconv.ParseFloat(values[0], 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a number", key)
+		}
+
+		if matches[2] == "min" {
+			filter.Min = &number
+		} else {
+			filter.Max = &number
+		}
+	}
+
+	sort.Strings(codes)
+	filters := make([]domain.AttributeFilter, 0, len(codes))
+	for _, code := range codes {
+		filters = append(filters, *filterByCode[code])
+	}
+	return filters, nil
+}
@@ -43,6 +43,16 @@ func (h ProductCategoryHandler) GetProductCategoryList(c echo.Context) error {
 	return c.JSON(http.StatusOK, res)
 }
 
+func (h ProductCategoryHandler) GetProductCategoryTree(c echo.Context) error {
+	productCategories, appErr := h.service.GetTree()
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	res := dto.NewGetProductCategoryTreeResponse("Successfully get data", productCategories)
+	return c.JSON(http.StatusOK, res)
+}
+
 func (h ProductCategoryHandler) GetProductCategoryDetail(c echo.Context) error {
 	productCategoryID, _ := strconv.Atoi(c.Param("product_category_id"))
 
@@ -54,6 +64,23 @@ func (h ProductCategoryHandler) GetProductCategoryDetail(c echo.Context) error {
 	return c.JSON(http.StatusOK, productCategory)
 }
 
+func (h ProductCategoryHandler) GetProductCategoryDetailBySlug(c echo.Context) error {
+	slug := c.Param("slug")
+
+	productCategory, appErr := h.service.GetDetailBySlug(slug)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	// old slug of a renamed category, point the client to the current one
+	if productCategory.Slug != slug {
+		return c.Redirect(http.StatusMovedPermanently, "/api/v1/product-category/slug/"+productCategory.Slug)
+	}
+
+	res := dto.NewGetProductCategoryDetailResponse("Successfully get data", productCategory)
+	return c.JSON(http.StatusOK, res)
+}
+
 func (h ProductCategoryHandler) UpdateProductCategory(c echo.Context) error {
 	productCategoryID, _ := strconv.Atoi(c.Param("product_category_id"))
 	var request dto.CreateProductCategoryRequest
@@ -74,7 +101,10 @@ func (h ProductCategoryHandler) UpdateProductCategory(c echo.Context) error {
 func (h ProductCategoryHandler) Delete(c echo.Context) error {
 	productCategoryID, _ := strconv.Atoi(c.Param("product_category_id"))
 
-	deleteData, appErr := h.service.Delete(int64(productCategoryID))
+	// products and child categories move to reassign_to, without it a non empty category is refused
+	reassignToID, _ := strconv.Atoi(c.QueryParam("reassign_to"))
+
+	deleteData, appErr := h.service.Delete(int64(productCategoryID), int64(reassignToID))
 	if appErr != nil {
 		return c.JSON(appErr.Code, appErr.AsMessage())
 	}
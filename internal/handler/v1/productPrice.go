@@ -0,0 +1,115 @@
+package v1
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/danisbagus/go-common-packages/logger"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/danisbagus/matchoshop/internal/dto"
+	"github.com/danisbagus/matchoshop/utils/auth"
+	"github.com/danisbagus/matchoshop/utils/constants"
+	"github.com/danisbagus/matchoshop/utils/helper"
+	"github.com/labstack/echo/v4"
+)
+
+type ProductPriceHandler struct {
+	service port.ProductPriceService
+}
+
+func NewProductPriceHandler(service port.ProductPriceService) *ProductPriceHandler {
+	return &ProductPriceHandler{service: service}
+}
+
+func (h ProductPriceHandler) CreateSchedule(c echo.Context) error {
+	userInfo := auth.GetClaimData(c)
+	var req dto.ProductPriceScheduleRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		logger.Error("Error while decoding create product price schedule request: " + err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	appErr := req.Validate()
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	form := new(domain.ProductPriceSchedule)
+	form.ProductID = helper.StringToInt64(c.Param("product_id"), 0)
+	form.Price = req.Price
+	form.CompareAtPrice = req.CompareAtPrice
+	form.StartsAt = helper.StringToLocalDate(req.StartsAt, constants.DATE_TIME_FORMAT)
+	if req.EndsAt != nil && *req.EndsAt != "" {
+		endsAt := helper.StringToLocalDate(*req.EndsAt, constants.DATE_TIME_FORMAT)
+		form.EndsAt = &endsAt
+	}
+	form.ActorUserID = &userInfo.UserID
+
+	appErr = h.service.CreateSchedule(form)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	resData := dto.GenerateResponseData(constants.SuccessCreate, nil)
+	return c.JSON(http.StatusOK, resData)
+}
+
+func (h ProductPriceHandler) GetScheduleList(c echo.Context) error {
+	productID := helper.StringToInt64(c.Param("product_id"), 0)
+
+	schedules, appErr := h.service.GetScheduleList(productID)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	res := dto.NewGetProductPriceScheduleListResponse(constants.SuccesGet, schedules)
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h ProductPriceHandler) CancelSchedule(c echo.Context) error {
+	productID := helper.StringToInt64(c.Param("product_id"), 0)
+	productPriceScheduleID := helper.StringToInt64(c.Param("product_price_schedule_id"), 0)
+
+	appErr := h.service.CancelSchedule(productID, productPriceScheduleID)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	resData := dto.GenerateResponseData(constants.SuccessUpdate, nil)
+	return c.JSON(http.StatusOK, resData)
+}
+
+func (h ProductPriceHandler) GetHistoryList(c echo.Context) error {
+	productID := helper.StringToInt64(c.Param("product_id"), 0)
+
+	histories, appErr := h.service.GetHistoryList(productID)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	res := dto.NewGetProductPriceHistoryListResponse(constants.SuccesGet, histories)
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h ProductPriceHandler) GetPriceAt(c echo.Context) error {
+	productID := helper.StringToInt64(c.Param("product_id"), 0)
+
+	at := time.Now()
+	if c.QueryParam("at") != "" {
+		at = helper.StringToLocalDate(c.QueryParam("at"), constants.DATE_TIME_FORMAT)
+		if at.IsZero() {
+			return echo.NewHTTPError(http.StatusBadRequest, "at format must be "+constants.DATE_TIME_FORMAT)
+		}
+	}
+
+	price, appErr := h.service.GetPriceAt(productID, at)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	res := dto.NewGetProductPriceResponse(constants.SuccesGet, price)
+	return c.JSON(http.StatusOK, res)
+}
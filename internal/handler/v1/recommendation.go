@@ -0,0 +1,31 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/danisbagus/matchoshop/internal/dto"
+	"github.com/danisbagus/matchoshop/utils/constants"
+	"github.com/danisbagus/matchoshop/utils/helper"
+	"github.com/labstack/echo/v4"
+)
+
+type RecommendationHandler struct {
+	service port.RecommendationService
+}
+
+func NewRecommendationHandler(service port.RecommendationService) *RecommendationHandler {
+	return &RecommendationHandler{service: service}
+}
+
+func (h RecommendationHandler) GetByProduct(c echo.Context) error {
+	productID := helper.StringToInt64(c.Param("product_id"), 0)
+
+	recommendation, appErr := h.service.GetByProduct(productID)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	res := dto.NewGetProductRecommendationResponse(constants.SuccesGet, recommendation)
+	return c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,101 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/danisbagus/go-common-packages/logger"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/danisbagus/matchoshop/internal/dto"
+	"github.com/danisbagus/matchoshop/utils/auth"
+	"github.com/danisbagus/matchoshop/utils/constants"
+	"github.com/danisbagus/matchoshop/utils/helper"
+	"github.com/labstack/echo/v4"
+)
+
+type RefundHandler struct {
+	service port.RefundService
+}
+
+func NewRefundHandler(service port.RefundService) *RefundHandler {
+	return &RefundHandler{service: service}
+}
+
+func (h RefundHandler) Create(c echo.Context) error {
+	userInfo := auth.GetClaimData(c)
+	var req dto.CreateRefundRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		logger.Error("Error while decoding create refund request: " + err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	appErr := req.Validate()
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	form := new(domain.Refund)
+	form.OrderID = helper.StringToInt64(c.Param("order_id"), 0)
+	form.Amount = req.Amount
+	form.Reason = &req.Reason
+	form.RequestedBy = &userInfo.UserID
+	for _, value := range req.Items {
+		form.Items = append(form.Items, domain.RefundItem{ProductID: value.ProductID, Quantity: value.Quantity})
+	}
+
+	refund, appErr := h.service.Create(form)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	resData := dto.NewRefundResponse(constants.SuccessCreate, refund)
+	return c.JSON(http.StatusOK, resData)
+}
+
+func (h RefundHandler) GetListByOrder(c echo.Context) error {
+	orderID := helper.StringToInt64(c.Param("order_id"), 0)
+
+	refunds, appErr := h.service.GetListByOrder(orderID)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	resData := dto.NewGetRefundListResponse(constants.SuccesGet, refunds)
+	return c.JSON(http.StatusOK, resData)
+}
+
+func (h RefundHandler) Process(c echo.Context) error {
+	userInfo := auth.GetClaimData(c)
+	refundID := helper.StringToInt64(c.Param("refund_id"), 0)
+
+	refund, appErr := h.service.Process(refundID, userInfo.UserID)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	resData := dto.NewRefundResponse(constants.SuccessUpdate, refund)
+	return c.JSON(http.StatusOK, resData)
+}
+
+func (h RefundHandler) Reject(c echo.Context) error {
+	userInfo := auth.GetClaimData(c)
+	var req dto.RejectRefundRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		logger.Error("Error while decoding reject refund request: " + err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	refundID := helper.StringToInt64(c.Param("refund_id"), 0)
+
+	appErr := h.service.Reject(refundID, userInfo.UserID, req.Note)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	resData := dto.GenerateResponseData(constants.SuccessUpdate, nil)
+	return c.JSON(http.StatusOK, resData)
+}
@@ -0,0 +1,216 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/danisbagus/go-common-packages/logger"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/danisbagus/matchoshop/internal/dto"
+	"github.com/danisbagus/matchoshop/utils/constants"
+	"github.com/danisbagus/matchoshop/utils/helper"
+	"github.com/labstack/echo/v4"
+)
+
+type ShippingHandler struct {
+	service      port.ShippingService
+	orderService port.OrderService
+}
+
+func NewShippingHandler(service port.ShippingService, orderService port.OrderService) *ShippingHandler {
+	return &ShippingHandler{service: service, orderService: orderService}
+}
+
+func (h ShippingHandler) Quote(c echo.Context) error {
+	var req dto.ShippingQuoteRequest
+	if err := c.Bind(&req); err != nil {
+		logger.Error("Error while decoding shipping quote request: " + err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	appErr := req.Validate()
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	form := new(domain.OrderDetail)
+	form.ShipmentAddress.Region = req.ShippinmentAddress.Region
+	form.ShipmentAddress.PostalCode = req.ShippinmentAddress.PostalCode
+	form.ShipmentAddress.Country = req.ShippinmentAddress.Country
+	for _, val := range req.OrderProduct {
+		form.OrderProducts = append(form.OrderProducts, domain.OrderProduct{ProductID: val.ProductID, Quantity: val.Quantity})
+	}
+
+	quotes, appErr := h.orderService.QuoteShipping(form)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	res := dto.NewShippingQuoteResponse(constants.SuccesGet, quotes)
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h ShippingHandler) CreateZone(c echo.Context) error {
+	var req dto.ShippingZoneRequest
+	if err := c.Bind(&req); err != nil {
+		logger.Error("Error while decoding create shipping zone request: " + err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	appErr := req.Validate()
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	form := newShippingZone(req)
+
+	appErr = h.service.CreateZone(form)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	resData := dto.GenerateResponseData(constants.SuccessCreate, map[string]int64{"shipping_zone_id": form.ShippingZoneID})
+	return c.JSON(http.StatusOK, resData)
+}
+
+func (h ShippingHandler) GetZoneList(c echo.Context) error {
+	zones, appErr := h.service.GetZoneList()
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	res := dto.NewGetShippingZoneListResponse(constants.SuccesGet, zones)
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h ShippingHandler) GetZoneDetail(c echo.Context) error {
+	shippingZoneID := helper.StringToInt64(c.Param("shipping_zone_id"), 0)
+
+	zone, appErr := h.service.GetZoneDetail(shippingZoneID)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	res := dto.NewGetShippingZoneDetailResponse(constants.SuccesGet, zone)
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h ShippingHandler) UpdateZone(c echo.Context) error {
+	var req dto.ShippingZoneRequest
+	if err := c.Bind(&req); err != nil {
+		logger.Error("Error while decoding update shipping zone request: " + err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	appErr := req.Validate()
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	form := newShippingZone(req)
+	form.ShippingZoneID = helper.StringToInt64(c.Param("shipping_zone_id"), 0)
+
+	appErr = h.service.UpdateZone(form)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	resData := dto.GenerateResponseData(constants.SuccessUpdate, nil)
+	return c.JSON(http.StatusOK, resData)
+}
+
+func (h ShippingHandler) DeleteZone(c echo.Context) error {
+	shippingZoneID := helper.StringToInt64(c.Param("shipping_zone_id"), 0)
+
+	appErr := h.service.DeleteZone(shippingZoneID)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	resData := dto.GenerateResponseData(constants.SuccessUpdate, nil)
+	return c.JSON(http.StatusOK, resData)
+}
+
+func (h ShippingHandler) CreateMethod(c echo.Context) error {
+	var req dto.ShippingMethodRequest
+	if err := c.Bind(&req); err != nil {
+		logger.Error("Error while decoding create shipping method request: " + err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	appErr := req.Validate()
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	form := newShippingMethod(req)
+	form.ShippingZoneID = helper.StringToInt64(c.Param("shipping_zone_id"), 0)
+
+	appErr = h.service.CreateMethod(form)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	resData := dto.GenerateResponseData(constants.SuccessCreate, map[string]int64{"shipping_method_id": form.ShippingMethodID})
+	return c.JSON(http.StatusOK, resData)
+}
+
+func (h ShippingHandler) UpdateMethod(c echo.Context) error {
+	var req dto.ShippingMethodRequest
+	if err := c.Bind(&req); err != nil {
+		logger.Error("Error while decoding update shipping method request: " + err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	appErr := req.Validate()
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	form := newShippingMethod(req)
+	form.ShippingZoneID = helper.StringToInt64(c.Param("shipping_zone_id"), 0)
+	form.ShippingMethodID = helper.StringToInt64(c.Param("shipping_method_id"), 0)
+
+	appErr = h.service.UpdateMethod(form)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	resData := dto.GenerateResponseData(constants.SuccessUpdate, nil)
+	return c.JSON(http.StatusOK, resData)
+}
+
+func (h ShippingHandler) DeleteMethod(c echo.Context) error {
+	shippingZoneID := helper.StringToInt64(c.Param("shipping_zone_id"), 0)
+	shippingMethodID := helper.StringToInt64(c.Param("shipping_method_id"), 0)
+
+	appErr := h.service.DeleteMethod(shippingZoneID, shippingMethodID)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	resData := dto.GenerateResponseData(constants.SuccessUpdate, nil)
+	return c.JSON(http.StatusOK, resData)
+}
+
+func newShippingZone(req dto.ShippingZoneRequest) *domain.ShippingZone {
+	form := new(domain.ShippingZone)
+	form.Name = req.Name
+	form.Countries = req.Countries
+	form.PostalCodePatterns = req.PostalCodePatterns
+	return form
+}
+
+func newShippingMethod(req dto.ShippingMethodRequest) *domain.ShippingMethod {
+	form := new(domain.ShippingMethod)
+	form.Name = req.Name
+	form.RateType = req.RateType
+	form.Rate = req.Rate
+	form.WeightUnit = req.WeightUnit
+	form.MinSubtotal = req.MinSubtotal
+	form.IsActive = req.IsActive == nil || *req.IsActive
+	for _, tier := range req.Tiers {
+		form.Tiers = append(form.Tiers, domain.ShippingRateTier{MinSubtotal: tier.MinSubtotal, Price: tier.Price})
+	}
+	return form
+}
@@ -0,0 +1,81 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/danisbagus/matchoshop/internal/dto"
+	"github.com/danisbagus/matchoshop/utils/auth"
+	"github.com/danisbagus/matchoshop/utils/constants"
+	"github.com/danisbagus/matchoshop/utils/helper"
+	"github.com/labstack/echo/v4"
+)
+
+type StockAlertHandler struct {
+	service port.StockAlertService
+}
+
+func NewStockAlertHandler(service port.StockAlertService) *StockAlertHandler {
+	return &StockAlertHandler{service: service}
+}
+
+func (h StockAlertHandler) Subscribe(c echo.Context) error {
+	userInfo := auth.GetClaimData(c)
+
+	form := new(domain.BackInStockSubscription)
+	form.ProductID = helper.StringToInt64(c.Param("product_id"), 0)
+	form.UserID = userInfo.UserID
+
+	appErr := h.service.Subscribe(form)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	resData := dto.GenerateResponseData(constants.SuccessCreate, nil)
+	return c.JSON(http.StatusOK, resData)
+}
+
+func (h StockAlertHandler) Unsubscribe(c echo.Context) error {
+	userInfo := auth.GetClaimData(c)
+	productID := helper.StringToInt64(c.Param("product_id"), 0)
+
+	appErr := h.service.Unsubscribe(productID, userInfo.UserID)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	resData := dto.GenerateResponseData(constants.SuccessUpdate, nil)
+	return c.JSON(http.StatusOK, resData)
+}
+
+func (h StockAlertHandler) GetList(c echo.Context) error {
+	userInfo := auth.GetClaimData(c)
+	return h.getListPaginate(c, &userInfo.UserID)
+}
+
+func (h StockAlertHandler) GetListAdmin(c echo.Context) error {
+	return h.getListPaginate(c, nil)
+}
+
+func (h StockAlertHandler) getListPaginate(c echo.Context, userID *int64) error {
+	req := new(dto.StockAlertListRequest)
+	if err := c.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	criteria := new(domain.StockAlertListCriteria)
+	criteria.UserID = userID
+	criteria.Page, criteria.Limit = helper.SetPaginationParameter(req.Page, req.Limit)
+
+	stockAlerts, total, appErr := h.service.GetListPaginate(criteria)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	meta := new(helper.Meta)
+	meta.SetPaginationData(criteria.Page, criteria.Limit, total)
+
+	res := dto.NewGetStockAlertListResponse(constants.SuccesGet, stockAlerts, meta)
+	return c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,75 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/danisbagus/go-common-packages/logger"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/danisbagus/matchoshop/internal/dto"
+	"github.com/danisbagus/matchoshop/utils/auth"
+	"github.com/danisbagus/matchoshop/utils/constants"
+	"github.com/danisbagus/matchoshop/utils/helper"
+	"github.com/labstack/echo/v4"
+)
+
+type StockMovementHandler struct {
+	service port.StockMovementService
+}
+
+func NewStockMovementHandler(service port.StockMovementService) *StockMovementHandler {
+	return &StockMovementHandler{service: service}
+}
+
+func (h StockMovementHandler) Create(c echo.Context) error {
+	userInfo := auth.GetClaimData(c)
+	var req dto.StockMovementRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		logger.Error("Error while decoding create stock movement request: " + err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	appErr := req.Validate()
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	form := new(domain.StockMovement)
+	form.ProductID = helper.StringToInt64(c.Param("product_id"), 0)
+	form.MovementType = req.MovementType
+	form.Quantity = req.Quantity
+	form.Reason = req.Reason
+	form.ActorUserID = &userInfo.UserID
+
+	appErr = h.service.Create(form)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	resData := dto.GenerateResponseData(constants.SuccessCreate, nil)
+	return c.JSON(http.StatusOK, resData)
+}
+
+func (h StockMovementHandler) GetListPaginate(c echo.Context) error {
+	req := new(dto.StockMovementListRequest)
+	if err := c.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	criteria := new(domain.StockMovementListCriteria)
+	criteria.ProductID = helper.StringToInt64(c.Param("product_id"), 0)
+	criteria.Page, criteria.Limit = helper.SetPaginationParameter(req.Page, req.Limit)
+
+	stockMovements, total, appErr := h.service.GetListPaginate(criteria)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	meta := new(helper.Meta)
+	meta.SetPaginationData(criteria.Page, criteria.Limit, total)
+
+	res := dto.NewGetStockMovementListResponse(constants.SuccesGet, stockMovements, meta)
+	return c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,116 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/danisbagus/go-common-packages/logger"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/danisbagus/matchoshop/internal/dto"
+	"github.com/danisbagus/matchoshop/utils/constants"
+	"github.com/danisbagus/matchoshop/utils/helper"
+	"github.com/labstack/echo/v4"
+)
+
+type TaxRuleHandler struct {
+	service port.TaxRuleService
+}
+
+func NewTaxRuleHandler(service port.TaxRuleService) *TaxRuleHandler {
+	return &TaxRuleHandler{service: service}
+}
+
+func (h TaxRuleHandler) Create(c echo.Context) error {
+	var req dto.TaxRuleRequest
+	if err := c.Bind(&req); err != nil {
+		logger.Error("Error while decoding create tax rule request: " + err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	appErr := req.Validate()
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	form := newTaxRule(req)
+
+	appErr = h.service.Create(form)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	resData := dto.GenerateResponseData(constants.SuccessCreate, map[string]int64{"tax_rule_id": form.TaxRuleID})
+	return c.JSON(http.StatusOK, resData)
+}
+
+func (h TaxRuleHandler) GetList(c echo.Context) error {
+	taxRules, appErr := h.service.GetList()
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	res := dto.NewGetTaxRuleListResponse(constants.SuccesGet, taxRules)
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h TaxRuleHandler) GetDetail(c echo.Context) error {
+	taxRuleID := helper.StringToInt64(c.Param("tax_rule_id"), 0)
+
+	taxRule, appErr := h.service.GetDetail(taxRuleID)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	res := dto.NewGetTaxRuleDetailResponse(constants.SuccesGet, taxRule)
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h TaxRuleHandler) Update(c echo.Context) error {
+	var req dto.TaxRuleRequest
+	if err := c.Bind(&req); err != nil {
+		logger.Error("Error while decoding update tax rule request: " + err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	appErr := req.Validate()
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	form := newTaxRule(req)
+	form.TaxRuleID = helper.StringToInt64(c.Param("tax_rule_id"), 0)
+
+	appErr = h.service.Update(form)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	resData := dto.GenerateResponseData(constants.SuccessUpdate, nil)
+	return c.JSON(http.StatusOK, resData)
+}
+
+func (h TaxRuleHandler) Delete(c echo.Context) error {
+	taxRuleID := helper.StringToInt64(c.Param("tax_rule_id"), 0)
+
+	appErr := h.service.Delete(taxRuleID)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	resData := dto.GenerateResponseData(constants.SuccessUpdate, nil)
+	return c.JSON(http.StatusOK, resData)
+}
+
+func newTaxRule(req dto.TaxRuleRequest) *domain.TaxRule {
+	form := new(domain.TaxRule)
+	form.Country = req.Country
+	form.Region = req.Region
+	form.TaxClass = req.TaxClass
+	form.Rate = req.Rate
+	form.IsInclusive = req.IsInclusive
+	form.Rounding = req.Rounding
+	if form.Rounding == "" {
+		form.Rounding = constants.TaxRoundingHalfUp
+	}
+	return form
+}
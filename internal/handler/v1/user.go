@@ -9,16 +9,18 @@ import (
 	"github.com/danisbagus/matchoshop/internal/core/port"
 	"github.com/danisbagus/matchoshop/internal/dto"
 	"github.com/danisbagus/matchoshop/utils/auth"
+	"github.com/danisbagus/matchoshop/utils/constants"
 
 	"github.com/labstack/echo/v4"
 )
 
 type UserHandler struct {
-	service port.UserService
+	service     port.UserService
+	cartService port.CartService
 }
 
-func NewUserhandler(service port.UserService) *UserHandler {
-	return &UserHandler{service: service}
+func NewUserhandler(service port.UserService, cartService port.CartService) *UserHandler {
+	return &UserHandler{service: service, cartService: cartService}
 }
 
 func (h UserHandler) Login(c echo.Context) error {
@@ -33,6 +35,10 @@ func (h UserHandler) Login(c echo.Context) error {
 		return c.JSON(appErr.Code, appErr.AsMessage())
 	}
 
+	if login, ok := token.Data.(dto.LoginResponse); ok {
+		h.mergeGuestCart(c, login.UserID)
+	}
+
 	return c.JSON(http.StatusOK, *token)
 }
 
@@ -64,6 +70,10 @@ func (h UserHandler) RegisterCustomer(c echo.Context) error {
 
 	}
 
+	if register, ok := token.Data.(dto.RegisterCustomerResponse); ok {
+		h.mergeGuestCart(c, register.UserID)
+	}
+
 	return c.JSON(http.StatusOK, *token)
 }
 
@@ -95,6 +105,7 @@ func (h UserHandler) UpdateUser(c echo.Context) error {
 	form := new(domain.User)
 	form.UserID = userInfo.UserID
 	form.Name = req.Name
+	form.Locale = req.Locale
 
 	appErr = h.service.Update(form)
 	if appErr != nil {
@@ -152,6 +163,7 @@ func (h UserHandler) UpdateUserAdmin(c echo.Context) error {
 	form := new(domain.User)
 	form.UserID = int64(userID)
 	form.Name = req.Name
+	form.Locale = req.Locale
 
 	appErr = h.service.Update(form)
 	if appErr != nil {
@@ -173,3 +185,16 @@ func (h UserHandler) GetUserDetailAdmin(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, userData)
 }
+
+// mergeGuestCart moves the guest cart into the user cart, a failed merge must not fail the login
+func (h UserHandler) mergeGuestCart(c echo.Context, userID int64) {
+	sessionID := c.Request().Header.Get(constants.CartSessionHeader)
+	if sessionID == "" {
+		return
+	}
+
+	appErr := h.cartService.Merge(sessionID, userID)
+	if appErr != nil {
+		logger.Error("Error while merge guest cart: " + appErr.Message)
+	}
+}
@@ -0,0 +1,135 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/danisbagus/go-common-packages/logger"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/danisbagus/matchoshop/internal/dto"
+	"github.com/danisbagus/matchoshop/utils/auth"
+	"github.com/danisbagus/matchoshop/utils/constants"
+	"github.com/danisbagus/matchoshop/utils/helper"
+	"github.com/labstack/echo/v4"
+)
+
+type WishlistHandler struct {
+	service port.WishlistService
+}
+
+func NewWishlistHandler(service port.WishlistService) *WishlistHandler {
+	return &WishlistHandler{service: service}
+}
+
+func (h WishlistHandler) Add(c echo.Context) error {
+	userInfo := auth.GetClaimData(c)
+	var req dto.WishlistRequest
+	if err := c.Bind(&req); err != nil {
+		logger.Error("Error while decoding add wishlist request: " + err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	appErr := req.Validate()
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	form := new(domain.Wishlist)
+	form.UserID = userInfo.UserID
+	form.ProductID = req.ProductID
+
+	appErr = h.service.Add(form)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	resData := dto.GenerateResponseData(constants.SuccessCreate, nil)
+	return c.JSON(http.StatusOK, resData)
+}
+
+func (h WishlistHandler) Remove(c echo.Context) error {
+	userInfo := auth.GetClaimData(c)
+	productID := helper.StringToInt64(c.Param("product_id"), 0)
+
+	appErr := h.service.Remove(userInfo.UserID, productID)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	resData := dto.GenerateResponseData(constants.SuccessUpdate, nil)
+	return c.JSON(http.StatusOK, resData)
+}
+
+func (h WishlistHandler) GetList(c echo.Context) error {
+	userInfo := auth.GetClaimData(c)
+
+	items, appErr := h.service.GetList(userInfo.UserID)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	res := dto.NewGetWishlistListResponse(constants.SuccesGet, items)
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h WishlistHandler) MoveToOrder(c echo.Context) error {
+	userInfo := auth.GetClaimData(c)
+	var req dto.WishlistOrderRequest
+	if err := c.Bind(&req); err != nil {
+		logger.Error("Error while decoding wishlist order request: " + err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	appErr := req.Validate()
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	form := new(domain.WishlistOrder)
+	form.UserID = userInfo.UserID
+	form.PaymentMethodID = req.PaymentMethodID
+	form.ShippingMethodID = req.ShippingMethodID
+	form.CouponCodes = req.CouponCodes
+	form.TotalPrice = req.TotalPrice
+	form.ShipmentAddress.Address = req.ShippinmentAddress.Address
+	form.ShipmentAddress.City = req.ShippinmentAddress.City
+	form.ShipmentAddress.Region = req.ShippinmentAddress.Region
+	form.ShipmentAddress.PostalCode = req.ShippinmentAddress.PostalCode
+	form.ShipmentAddress.Country = req.ShippinmentAddress.Country
+
+	for _, val := range req.OrderProduct {
+		form.OrderProducts = append(form.OrderProducts, domain.OrderProduct{
+			ProductID: val.ProductID,
+			Quantity:  val.Quantity,
+		})
+	}
+
+	order, appErr := h.service.MoveToOrder(form)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	resData := dto.NewOrderResponse(constants.SuccessCreate, order)
+	return c.JSON(http.StatusOK, resData)
+}
+
+func (h WishlistHandler) GetMostWishlisted(c echo.Context) error {
+	req := new(dto.WishlistReportRequest)
+	if err := c.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	criteria := new(domain.WishlistReportCriteria)
+	criteria.Page, criteria.Limit = helper.SetPaginationParameter(req.Page, req.Limit)
+
+	reports, total, appErr := h.service.GetMostWishlisted(criteria)
+	if appErr != nil {
+		return c.JSON(appErr.Code, appErr.AsMessage())
+	}
+
+	meta := new(helper.Meta)
+	meta.SetPaginationData(criteria.Page, criteria.Limit, total)
+
+	res := dto.NewGetWishlistReportResponse(constants.SuccesGet, reports, meta)
+	return c.JSON(http.StatusOK, res)
+}
@@ -5,6 +5,7 @@ package mocks
 import (
 	errs "github.com/danisbagus/go-common-packages/errs"
 	domain "github.com/danisbagus/matchoshop/internal/core/domain"
+	time "time"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -14,6 +15,22 @@ type OrderRepo struct {
 	mock.Mock
 }
 
+// Cancel provides a mock function with given fields: history, refund, notification
+func (_m *OrderRepo) Cancel(history *domain.OrderStatusHistory, refund *domain.Refund, notification *domain.NotificationEvent) *errs.AppError {
+	ret := _m.Called(history, refund, notification)
+
+	var r0 *errs.AppError
+	if rf, ok := ret.Get(0).(func(*domain.OrderStatusHistory, *domain.Refund, *domain.NotificationEvent) *errs.AppError); ok {
+		r0 = rf(history, refund, notification)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*errs.AppError)
+		}
+	}
+
+	return r0
+}
+
 // GetAllByUserID provides a mock function with given fields: userID
 func (_m *OrderRepo) GetAllByUserID(userID int64) ([]domain.OrderDetail, *errs.AppError) {
 	ret := _m.Called(userID)
@@ -39,6 +56,63 @@ func (_m *OrderRepo) GetAllByUserID(userID int64) ([]domain.OrderDetail, *errs.A
 	return r0, r1
 }
 
+// GetAllPaginate provides a mock function with given fields: criteria
+func (_m *OrderRepo) GetAllPaginate(criteria *domain.OrderListCriteria) ([]domain.OrderDetail, int64, *errs.AppError) {
+	ret := _m.Called(criteria)
+
+	var r0 []domain.OrderDetail
+	if rf, ok := ret.Get(0).(func(*domain.OrderListCriteria) []domain.OrderDetail); ok {
+		r0 = rf(criteria)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.OrderDetail)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(*domain.OrderListCriteria) int64); ok {
+		r1 = rf(criteria)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 *errs.AppError
+	if rf, ok := ret.Get(2).(func(*domain.OrderListCriteria) *errs.AppError); ok {
+		r2 = rf(criteria)
+	} else {
+		if ret.Get(2) != nil {
+			r2 = ret.Get(2).(*errs.AppError)
+		}
+	}
+
+	return r0, r1, r2
+}
+
+// GetAllStatusHistoryByOrderID provides a mock function with given fields: orderID
+func (_m *OrderRepo) GetAllStatusHistoryByOrderID(orderID int64) ([]domain.OrderStatusHistory, *errs.AppError) {
+	ret := _m.Called(orderID)
+
+	var r0 []domain.OrderStatusHistory
+	if rf, ok := ret.Get(0).(func(int64) []domain.OrderStatusHistory); ok {
+		r0 = rf(orderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.OrderStatusHistory)
+		}
+	}
+
+	var r1 *errs.AppError
+	if rf, ok := ret.Get(1).(func(int64) *errs.AppError); ok {
+		r1 = rf(orderID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*errs.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // GetOneByID provides a mock function with given fields: ID
 func (_m *OrderRepo) GetOneByID(ID int64) (*domain.OrderDetail, *errs.AppError) {
 	ret := _m.Called(ID)
@@ -64,20 +138,20 @@ func (_m *OrderRepo) GetOneByID(ID int64) (*domain.OrderDetail, *errs.AppError)
 	return r0, r1
 }
 
-// Insert provides a mock function with given fields: form
-func (_m *OrderRepo) Insert(form *domain.OrderDetail) (int64, *errs.AppError) {
-	ret := _m.Called(form)
+// Insert provides a mock function with given fields: form, notification
+func (_m *OrderRepo) Insert(form *domain.OrderDetail, notification *domain.NotificationEvent) (int64, *errs.AppError) {
+	ret := _m.Called(form, notification)
 
 	var r0 int64
-	if rf, ok := ret.Get(0).(func(*domain.OrderDetail) int64); ok {
-		r0 = rf(form)
+	if rf, ok := ret.Get(0).(func(*domain.OrderDetail, *domain.NotificationEvent) int64); ok {
+		r0 = rf(form, notification)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
 
 	var r1 *errs.AppError
-	if rf, ok := ret.Get(1).(func(*domain.OrderDetail) *errs.AppError); ok {
-		r1 = rf(form)
+	if rf, ok := ret.Get(1).(func(*domain.OrderDetail, *domain.NotificationEvent) *errs.AppError); ok {
+		r1 = rf(form, notification)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*errs.AppError)
@@ -87,13 +161,45 @@ func (_m *OrderRepo) Insert(form *domain.OrderDetail) (int64, *errs.AppError) {
 	return r0, r1
 }
 
-// UpdatePaid provides a mock function with given fields: form
-func (_m *OrderRepo) UpdatePaid(form *domain.PaymentResult) *errs.AppError {
-	ret := _m.Called(form)
+// RenewStockReservation provides a mock function with given fields: orderID, expiresAt
+func (_m *OrderRepo) RenewStockReservation(orderID int64, expiresAt time.Time) *errs.AppError {
+	ret := _m.Called(orderID, expiresAt)
+
+	var r0 *errs.AppError
+	if rf, ok := ret.Get(0).(func(int64, time.Time) *errs.AppError); ok {
+		r0 = rf(orderID, expiresAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*errs.AppError)
+		}
+	}
+
+	return r0
+}
+
+// UpdatePaid provides a mock function with given fields: form, history, notification
+func (_m *OrderRepo) UpdatePaid(form *domain.PaymentResult, history *domain.OrderStatusHistory, notification *domain.NotificationEvent) *errs.AppError {
+	ret := _m.Called(form, history, notification)
+
+	var r0 *errs.AppError
+	if rf, ok := ret.Get(0).(func(*domain.PaymentResult, *domain.OrderStatusHistory, *domain.NotificationEvent) *errs.AppError); ok {
+		r0 = rf(form, history, notification)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*errs.AppError)
+		}
+	}
+
+	return r0
+}
+
+// UpdateStatus provides a mock function with given fields: form, notification
+func (_m *OrderRepo) UpdateStatus(form *domain.OrderStatusHistory, notification *domain.NotificationEvent) *errs.AppError {
+	ret := _m.Called(form, notification)
 
 	var r0 *errs.AppError
-	if rf, ok := ret.Get(0).(func(*domain.PaymentResult) *errs.AppError); ok {
-		r0 = rf(form)
+	if rf, ok := ret.Get(0).(func(*domain.OrderStatusHistory, *domain.NotificationEvent) *errs.AppError); ok {
+		r0 = rf(form, notification)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*errs.AppError)
@@ -14,6 +14,22 @@ type OrderService struct {
 	mock.Mock
 }
 
+// Cancel provides a mock function with given fields: form
+func (_m *OrderService) Cancel(form *domain.OrderCancellation) *errs.AppError {
+	ret := _m.Called(form)
+
+	var r0 *errs.AppError
+	if rf, ok := ret.Get(0).(func(*domain.OrderCancellation) *errs.AppError); ok {
+		r0 = rf(form)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*errs.AppError)
+		}
+	}
+
+	return r0
+}
+
 // Create provides a mock function with given fields: form
 func (_m *OrderService) Create(form *domain.OrderDetail) (*domain.OrderDetail, *errs.AppError) {
 	ret := _m.Called(form)
@@ -64,8 +80,33 @@ func (_m *OrderService) GetDetail(ID int64) (*domain.OrderDetail, *errs.AppError
 	return r0, r1
 }
 
-// GetList provides a mock function with given fields: userID
-func (_m *OrderService) GetList(userID int64) ([]domain.OrderDetail, *errs.AppError) {
+// GetDetailByUser provides a mock function with given fields: ID, userID
+func (_m *OrderService) GetDetailByUser(ID int64, userID int64) (*domain.OrderDetail, *errs.AppError) {
+	ret := _m.Called(ID, userID)
+
+	var r0 *domain.OrderDetail
+	if rf, ok := ret.Get(0).(func(int64, int64) *domain.OrderDetail); ok {
+		r0 = rf(ID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.OrderDetail)
+		}
+	}
+
+	var r1 *errs.AppError
+	if rf, ok := ret.Get(1).(func(int64, int64) *errs.AppError); ok {
+		r1 = rf(ID, userID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*errs.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetListByUser provides a mock function with given fields: userID
+func (_m *OrderService) GetListByUser(userID int64) ([]domain.OrderDetail, *errs.AppError) {
 	ret := _m.Called(userID)
 
 	var r0 []domain.OrderDetail
@@ -89,6 +130,104 @@ func (_m *OrderService) GetList(userID int64) ([]domain.OrderDetail, *errs.AppEr
 	return r0, r1
 }
 
+// GetListPaginate provides a mock function with given fields: criteria
+func (_m *OrderService) GetListPaginate(criteria *domain.OrderListCriteria) ([]domain.OrderDetail, int64, *errs.AppError) {
+	ret := _m.Called(criteria)
+
+	var r0 []domain.OrderDetail
+	if rf, ok := ret.Get(0).(func(*domain.OrderListCriteria) []domain.OrderDetail); ok {
+		r0 = rf(criteria)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.OrderDetail)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(*domain.OrderListCriteria) int64); ok {
+		r1 = rf(criteria)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 *errs.AppError
+	if rf, ok := ret.Get(2).(func(*domain.OrderListCriteria) *errs.AppError); ok {
+		r2 = rf(criteria)
+	} else {
+		if ret.Get(2) != nil {
+			r2 = ret.Get(2).(*errs.AppError)
+		}
+	}
+
+	return r0, r1, r2
+}
+
+// QuoteShipping provides a mock function with given fields: form
+func (_m *OrderService) QuoteShipping(form *domain.OrderDetail) ([]domain.ShippingQuote, *errs.AppError) {
+	ret := _m.Called(form)
+
+	var r0 []domain.ShippingQuote
+	if rf, ok := ret.Get(0).(func(*domain.OrderDetail) []domain.ShippingQuote); ok {
+		r0 = rf(form)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ShippingQuote)
+		}
+	}
+
+	var r1 *errs.AppError
+	if rf, ok := ret.Get(1).(func(*domain.OrderDetail) *errs.AppError); ok {
+		r1 = rf(form)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*errs.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
+// RenewStockReservation provides a mock function with given fields: orderID, userID
+func (_m *OrderService) RenewStockReservation(orderID int64, userID int64) (*domain.OrderDetail, *errs.AppError) {
+	ret := _m.Called(orderID, userID)
+
+	var r0 *domain.OrderDetail
+	if rf, ok := ret.Get(0).(func(int64, int64) *domain.OrderDetail); ok {
+		r0 = rf(orderID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.OrderDetail)
+		}
+	}
+
+	var r1 *errs.AppError
+	if rf, ok := ret.Get(1).(func(int64, int64) *errs.AppError); ok {
+		r1 = rf(orderID, userID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*errs.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
+// UpdateDelivered provides a mock function with given fields: ID, actorUserID
+func (_m *OrderService) UpdateDelivered(ID int64, actorUserID int64) *errs.AppError {
+	ret := _m.Called(ID, actorUserID)
+
+	var r0 *errs.AppError
+	if rf, ok := ret.Get(0).(func(int64, int64) *errs.AppError); ok {
+		r0 = rf(ID, actorUserID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*errs.AppError)
+		}
+	}
+
+	return r0
+}
+
 // UpdatePaid provides a mock function with given fields: form
 func (_m *OrderService) UpdatePaid(form *domain.PaymentResult) *errs.AppError {
 	ret := _m.Called(form)
@@ -104,3 +243,19 @@ func (_m *OrderService) UpdatePaid(form *domain.PaymentResult) *errs.AppError {
 
 	return r0
 }
+
+// UpdateStatus provides a mock function with given fields: form
+func (_m *OrderService) UpdateStatus(form *domain.OrderStatusHistory) *errs.AppError {
+	ret := _m.Called(form)
+
+	var r0 *errs.AppError
+	if rf, ok := ret.Get(0).(func(*domain.OrderStatusHistory) *errs.AppError); ok {
+		r0 = rf(form)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*errs.AppError)
+		}
+	}
+
+	return r0
+}
@@ -60,6 +60,29 @@ func (_m *ProductCategoryRepo) CheckByIDAndName(productCategoryID int64, name st
 	return r0, r1
 }
 
+// CheckByIDAndSlug provides a mock function with given fields: productCategoryID, slug
+func (_m *ProductCategoryRepo) CheckByIDAndSlug(productCategoryID int64, slug string) (bool, *errs.AppError) {
+	ret := _m.Called(productCategoryID, slug)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(int64, string) bool); ok {
+		r0 = rf(productCategoryID, slug)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 *errs.AppError
+	if rf, ok := ret.Get(1).(func(int64, string) *errs.AppError); ok {
+		r1 = rf(productCategoryID, slug)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*errs.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // CheckByName provides a mock function with given fields: name
 func (_m *ProductCategoryRepo) CheckByName(name string) (bool, *errs.AppError) {
 	ret := _m.Called(name)
@@ -83,6 +106,52 @@ func (_m *ProductCategoryRepo) CheckByName(name string) (bool, *errs.AppError) {
 	return r0, r1
 }
 
+// CheckChildByID provides a mock function with given fields: productCategoryID
+func (_m *ProductCategoryRepo) CheckChildByID(productCategoryID int64) (bool, *errs.AppError) {
+	ret := _m.Called(productCategoryID)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(int64) bool); ok {
+		r0 = rf(productCategoryID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 *errs.AppError
+	if rf, ok := ret.Get(1).(func(int64) *errs.AppError); ok {
+		r1 = rf(productCategoryID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*errs.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
+// CheckProductByID provides a mock function with given fields: productCategoryID
+func (_m *ProductCategoryRepo) CheckProductByID(productCategoryID int64) (bool, *errs.AppError) {
+	ret := _m.Called(productCategoryID)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(int64) bool); ok {
+		r0 = rf(productCategoryID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 *errs.AppError
+	if rf, ok := ret.Get(1).(func(int64) *errs.AppError); ok {
+		r1 = rf(productCategoryID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*errs.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // Delete provides a mock function with given fields: productCategoryID
 func (_m *ProductCategoryRepo) Delete(productCategoryID int64) *errs.AppError {
 	ret := _m.Called(productCategoryID)
@@ -124,6 +193,31 @@ func (_m *ProductCategoryRepo) GetAll() ([]domain.ProductCategory, *errs.AppErro
 	return r0, r1
 }
 
+// GetAllAncestors provides a mock function with given fields: productCategoryID
+func (_m *ProductCategoryRepo) GetAllAncestors(productCategoryID int64) ([]domain.ProductCategory, *errs.AppError) {
+	ret := _m.Called(productCategoryID)
+
+	var r0 []domain.ProductCategory
+	if rf, ok := ret.Get(0).(func(int64) []domain.ProductCategory); ok {
+		r0 = rf(productCategoryID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ProductCategory)
+		}
+	}
+
+	var r1 *errs.AppError
+	if rf, ok := ret.Get(1).(func(int64) *errs.AppError); ok {
+		r1 = rf(productCategoryID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*errs.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // GetAllByProductID provides a mock function with given fields: productID
 func (_m *ProductCategoryRepo) GetAllByProductID(productID int64) ([]domain.ProductCategory, *errs.AppError) {
 	ret := _m.Called(productID)
@@ -149,6 +243,31 @@ func (_m *ProductCategoryRepo) GetAllByProductID(productID int64) ([]domain.Prod
 	return r0, r1
 }
 
+// GetAllDescendantIDs provides a mock function with given fields: productCategoryID
+func (_m *ProductCategoryRepo) GetAllDescendantIDs(productCategoryID int64) ([]int64, *errs.AppError) {
+	ret := _m.Called(productCategoryID)
+
+	var r0 []int64
+	if rf, ok := ret.Get(0).(func(int64) []int64); ok {
+		r0 = rf(productCategoryID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int64)
+		}
+	}
+
+	var r1 *errs.AppError
+	if rf, ok := ret.Get(1).(func(int64) *errs.AppError); ok {
+		r1 = rf(productCategoryID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*errs.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // GetOneByID provides a mock function with given fields: productCategoryID
 func (_m *ProductCategoryRepo) GetOneByID(productCategoryID int64) (*domain.ProductCategory, *errs.AppError) {
 	ret := _m.Called(productCategoryID)
@@ -174,6 +293,31 @@ func (_m *ProductCategoryRepo) GetOneByID(productCategoryID int64) (*domain.Prod
 	return r0, r1
 }
 
+// GetOneBySlug provides a mock function with given fields: slug
+func (_m *ProductCategoryRepo) GetOneBySlug(slug string) (*domain.ProductCategory, *errs.AppError) {
+	ret := _m.Called(slug)
+
+	var r0 *domain.ProductCategory
+	if rf, ok := ret.Get(0).(func(string) *domain.ProductCategory); ok {
+		r0 = rf(slug)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ProductCategory)
+		}
+	}
+
+	var r1 *errs.AppError
+	if rf, ok := ret.Get(1).(func(string) *errs.AppError); ok {
+		r1 = rf(slug)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*errs.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // Insert provides a mock function with given fields: data
 func (_m *ProductCategoryRepo) Insert(data *domain.ProductCategory) (*domain.ProductCategory, *errs.AppError) {
 	ret := _m.Called(data)
@@ -199,6 +343,22 @@ func (_m *ProductCategoryRepo) Insert(data *domain.ProductCategory) (*domain.Pro
 	return r0, r1
 }
 
+// Reassign provides a mock function with given fields: productCategoryID, targetProductCategoryID
+func (_m *ProductCategoryRepo) Reassign(productCategoryID int64, targetProductCategoryID int64) *errs.AppError {
+	ret := _m.Called(productCategoryID, targetProductCategoryID)
+
+	var r0 *errs.AppError
+	if rf, ok := ret.Get(0).(func(int64, int64) *errs.AppError); ok {
+		r0 = rf(productCategoryID, targetProductCategoryID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*errs.AppError)
+		}
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: productCategoryID, data
 func (_m *ProductCategoryRepo) Update(productCategoryID int64, data *domain.ProductCategory) *errs.AppError {
 	ret := _m.Called(productCategoryID, data)
@@ -3,9 +3,11 @@
 package mocks
 
 import (
-	errs "github.com/danisbagus/go-common-packages/errs"
+	domain "github.com/danisbagus/matchoshop/internal/core/domain"
 	dto "github.com/danisbagus/matchoshop/internal/dto"
 
+	errs "github.com/danisbagus/go-common-packages/errs"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -39,13 +41,13 @@ func (_m *ProductCategoryService) Create(data *dto.CreateProductCategoryRequest)
 	return r0, r1
 }
 
-// Delete provides a mock function with given fields: productCategoryID
-func (_m *ProductCategoryService) Delete(productCategoryID int64) (*dto.ResponseData, *errs.AppError) {
-	ret := _m.Called(productCategoryID)
+// Delete provides a mock function with given fields: productCategoryID, reassignToID
+func (_m *ProductCategoryService) Delete(productCategoryID int64, reassignToID int64) (*dto.ResponseData, *errs.AppError) {
+	ret := _m.Called(productCategoryID, reassignToID)
 
 	var r0 *dto.ResponseData
-	if rf, ok := ret.Get(0).(func(int64) *dto.ResponseData); ok {
-		r0 = rf(productCategoryID)
+	if rf, ok := ret.Get(0).(func(int64, int64) *dto.ResponseData); ok {
+		r0 = rf(productCategoryID, reassignToID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*dto.ResponseData)
@@ -53,8 +55,8 @@ func (_m *ProductCategoryService) Delete(productCategoryID int64) (*dto.Response
 	}
 
 	var r1 *errs.AppError
-	if rf, ok := ret.Get(1).(func(int64) *errs.AppError); ok {
-		r1 = rf(productCategoryID)
+	if rf, ok := ret.Get(1).(func(int64, int64) *errs.AppError); ok {
+		r1 = rf(productCategoryID, reassignToID)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*errs.AppError)
@@ -89,6 +91,31 @@ func (_m *ProductCategoryService) GetDetail(productCategoryID int64) (*dto.Respo
 	return r0, r1
 }
 
+// GetDetailBySlug provides a mock function with given fields: slug
+func (_m *ProductCategoryService) GetDetailBySlug(slug string) (*domain.ProductCategory, *errs.AppError) {
+	ret := _m.Called(slug)
+
+	var r0 *domain.ProductCategory
+	if rf, ok := ret.Get(0).(func(string) *domain.ProductCategory); ok {
+		r0 = rf(slug)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ProductCategory)
+		}
+	}
+
+	var r1 *errs.AppError
+	if rf, ok := ret.Get(1).(func(string) *errs.AppError); ok {
+		r1 = rf(slug)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*errs.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // GetList provides a mock function with given fields:
 func (_m *ProductCategoryService) GetList() (*dto.ResponseData, *errs.AppError) {
 	ret := _m.Called()
@@ -114,6 +141,31 @@ func (_m *ProductCategoryService) GetList() (*dto.ResponseData, *errs.AppError)
 	return r0, r1
 }
 
+// GetTree provides a mock function with given fields:
+func (_m *ProductCategoryService) GetTree() ([]domain.ProductCategory, *errs.AppError) {
+	ret := _m.Called()
+
+	var r0 []domain.ProductCategory
+	if rf, ok := ret.Get(0).(func() []domain.ProductCategory); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ProductCategory)
+		}
+	}
+
+	var r1 *errs.AppError
+	if rf, ok := ret.Get(1).(func() *errs.AppError); ok {
+		r1 = rf()
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*errs.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: productCategoryID, data
 func (_m *ProductCategoryService) Update(productCategoryID int64, data *dto.CreateProductCategoryRequest) (*dto.ResponseData, *errs.AppError) {
 	ret := _m.Called(productCategoryID, data)
@@ -74,6 +74,29 @@ func (_m *ProductRepo) CheckByIDAndSKU(productID int64, sku string) (bool, *errs
 	return r0, r1
 }
 
+// CheckByIDAndSlug provides a mock function with given fields: productID, slug
+func (_m *ProductRepo) CheckByIDAndSlug(productID int64, slug string) (bool, *errs.AppError) {
+	ret := _m.Called(productID, slug)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(int64, string) bool); ok {
+		r0 = rf(productID, slug)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 *errs.AppError
+	if rf, ok := ret.Get(1).(func(int64, string) *errs.AppError); ok {
+		r1 = rf(productID, slug)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*errs.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // CheckBySKU provides a mock function with given fields: sku
 func (_m *ProductRepo) CheckBySKU(sku string) (bool, *errs.AppError) {
 	ret := _m.Called(sku)
@@ -156,6 +179,63 @@ func (_m *ProductRepo) GetAll(criteria *domain.ProductListCriteria) ([]domain.Pr
 	return r0, r1
 }
 
+// GetAllByIDs provides a mock function with given fields: productIDs
+func (_m *ProductRepo) GetAllByIDs(productIDs []int64) ([]domain.ProductList, *errs.AppError) {
+	ret := _m.Called(productIDs)
+
+	var r0 []domain.ProductList
+	if rf, ok := ret.Get(0).(func([]int64) []domain.ProductList); ok {
+		r0 = rf(productIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ProductList)
+		}
+	}
+
+	var r1 *errs.AppError
+	if rf, ok := ret.Get(1).(func([]int64) *errs.AppError); ok {
+		r1 = rf(productIDs)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*errs.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetAllLowStockPaginate provides a mock function with given fields: criteria
+func (_m *ProductRepo) GetAllLowStockPaginate(criteria *domain.ProductListCriteria) ([]domain.ProductModel, int64, *errs.AppError) {
+	ret := _m.Called(criteria)
+
+	var r0 []domain.ProductModel
+	if rf, ok := ret.Get(0).(func(*domain.ProductListCriteria) []domain.ProductModel); ok {
+		r0 = rf(criteria)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ProductModel)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(*domain.ProductListCriteria) int64); ok {
+		r1 = rf(criteria)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 *errs.AppError
+	if rf, ok := ret.Get(2).(func(*domain.ProductListCriteria) *errs.AppError); ok {
+		r2 = rf(criteria)
+	} else {
+		if ret.Get(2) != nil {
+			r2 = ret.Get(2).(*errs.AppError)
+		}
+	}
+
+	return r0, r1, r2
+}
+
 // GetAllPaginate provides a mock function with given fields: criteria
 func (_m *ProductRepo) GetAllPaginate(criteria *domain.ProductListCriteria) ([]domain.ProductList, int64, *errs.AppError) {
 	ret := _m.Called(criteria)
@@ -227,6 +307,56 @@ func (_m *ProductRepo) GetOneByID(productID int64) (*domain.ProductDetail, *errs
 	return r0, r1
 }
 
+// GetOneBySlug provides a mock function with given fields: slug
+func (_m *ProductRepo) GetOneBySlug(slug string) (*domain.ProductDetail, *errs.AppError) {
+	ret := _m.Called(slug)
+
+	var r0 *domain.ProductDetail
+	if rf, ok := ret.Get(0).(func(string) *domain.ProductDetail); ok {
+		r0 = rf(slug)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ProductDetail)
+		}
+	}
+
+	var r1 *errs.AppError
+	if rf, ok := ret.Get(1).(func(string) *errs.AppError); ok {
+		r1 = rf(slug)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*errs.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetOnePublishedByID provides a mock function with given fields: productID
+func (_m *ProductRepo) GetOnePublishedByID(productID int64) (*domain.ProductDetail, *errs.AppError) {
+	ret := _m.Called(productID)
+
+	var r0 *domain.ProductDetail
+	if rf, ok := ret.Get(0).(func(int64) *domain.ProductDetail); ok {
+		r0 = rf(productID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ProductDetail)
+		}
+	}
+
+	var r1 *errs.AppError
+	if rf, ok := ret.Get(1).(func(int64) *errs.AppError); ok {
+		r1 = rf(productID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*errs.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // Insert provides a mock function with given fields: data
 func (_m *ProductRepo) Insert(data *domain.Product) (*domain.Product, *errs.AppError) {
 	ret := _m.Called(data)
@@ -279,26 +409,6 @@ func (_m *ProductRepo) Update(productID int64, data *domain.Product) *errs.AppEr
 	return r0
 }
 
-// UpdateStock provides a mock function with given fields: productID, quantity
-func (_m *ProductRepo) UpdateStock(productID int64, quantity int64) *errs.AppError {
-	ret := _m.Called(productID, quantity)
-
-	if len(ret) == 0 {
-		panic("no return value specified for UpdateStock")
-	}
-
-	var r0 *errs.AppError
-	if rf, ok := ret.Get(0).(func(int64, int64) *errs.AppError); ok {
-		r0 = rf(productID, quantity)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*errs.AppError)
-		}
-	}
-
-	return r0
-}
-
 // NewProductRepo creates a new instance of ProductRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewProductRepo(t interface {
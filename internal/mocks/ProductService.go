@@ -86,6 +86,31 @@ func (_m *ProductService) GetDetail(productID int64) (*domain.ProductDetail, *er
 	return r0, r1
 }
 
+// GetDetailBySlug provides a mock function with given fields: slug
+func (_m *ProductService) GetDetailBySlug(slug string) (*domain.ProductDetail, *errs.AppError) {
+	ret := _m.Called(slug)
+
+	var r0 *domain.ProductDetail
+	if rf, ok := ret.Get(0).(func(string) *domain.ProductDetail); ok {
+		r0 = rf(slug)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ProductDetail)
+		}
+	}
+
+	var r1 *errs.AppError
+	if rf, ok := ret.Get(1).(func(string) *errs.AppError); ok {
+		r1 = rf(slug)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*errs.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // GetList provides a mock function with given fields: criteria
 func (_m *ProductService) GetList(criteria *domain.ProductListCriteria) ([]domain.ProductDetail, *errs.AppError) {
 	ret := _m.Called(criteria)
@@ -157,6 +182,63 @@ func (_m *ProductService) GetListPaginate(criteria *domain.ProductListCriteria)
 	return r0, r1, r2
 }
 
+// GetLowStockListPaginate provides a mock function with given fields: criteria
+func (_m *ProductService) GetLowStockListPaginate(criteria *domain.ProductListCriteria) ([]domain.ProductModel, int64, *errs.AppError) {
+	ret := _m.Called(criteria)
+
+	var r0 []domain.ProductModel
+	if rf, ok := ret.Get(0).(func(*domain.ProductListCriteria) []domain.ProductModel); ok {
+		r0 = rf(criteria)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ProductModel)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(*domain.ProductListCriteria) int64); ok {
+		r1 = rf(criteria)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 *errs.AppError
+	if rf, ok := ret.Get(2).(func(*domain.ProductListCriteria) *errs.AppError); ok {
+		r2 = rf(criteria)
+	} else {
+		if ret.Get(2) != nil {
+			r2 = ret.Get(2).(*errs.AppError)
+		}
+	}
+
+	return r0, r1, r2
+}
+
+// GetPublishedDetail provides a mock function with given fields: productID
+func (_m *ProductService) GetPublishedDetail(productID int64) (*domain.ProductDetail, *errs.AppError) {
+	ret := _m.Called(productID)
+
+	var r0 *domain.ProductDetail
+	if rf, ok := ret.Get(0).(func(int64) *domain.ProductDetail); ok {
+		r0 = rf(productID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ProductDetail)
+		}
+	}
+
+	var r1 *errs.AppError
+	if rf, ok := ret.Get(1).(func(int64) *errs.AppError); ok {
+		r1 = rf(productID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*errs.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: productID, form
 func (_m *ProductService) Update(productID int64, form *domain.Product) *errs.AppError {
 	ret := _m.Called(productID, form)
@@ -14,13 +14,13 @@ type UserRepo struct {
 	mock.Mock
 }
 
-// CreateUserCustomer provides a mock function with given fields: data
-func (_m *UserRepo) CreateUserCustomer(data *domain.User) (*domain.User, *errs.AppError) {
-	ret := _m.Called(data)
+// CreateUserCustomer provides a mock function with given fields: data, notification
+func (_m *UserRepo) CreateUserCustomer(data *domain.User, notification *domain.NotificationEvent) (*domain.User, *errs.AppError) {
+	ret := _m.Called(data, notification)
 
 	var r0 *domain.User
-	if rf, ok := ret.Get(0).(func(*domain.User) *domain.User); ok {
-		r0 = rf(data)
+	if rf, ok := ret.Get(0).(func(*domain.User, *domain.NotificationEvent) *domain.User); ok {
+		r0 = rf(data, notification)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.User)
@@ -28,8 +28,8 @@ func (_m *UserRepo) CreateUserCustomer(data *domain.User) (*domain.User, *errs.A
 	}
 
 	var r1 *errs.AppError
-	if rf, ok := ret.Get(1).(func(*domain.User) *errs.AppError); ok {
-		r1 = rf(data)
+	if rf, ok := ret.Get(1).(func(*domain.User, *domain.NotificationEvent) *errs.AppError); ok {
+		r1 = rf(data, notification)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*errs.AppError)
@@ -130,13 +130,13 @@ func (_m *UserRepo) GetAll() ([]domain.UserDetail, *errs.AppError) {
 	return r0, r1
 }
 
-// Update provides a mock function with given fields: userID, data
-func (_m *UserRepo) Update(userID int64, data *domain.User) *errs.AppError {
-	ret := _m.Called(userID, data)
+// Update provides a mock function with given fields: userID, data, notification
+func (_m *UserRepo) Update(userID int64, data *domain.User, notification *domain.NotificationEvent) *errs.AppError {
+	ret := _m.Called(userID, data, notification)
 
 	var r0 *errs.AppError
-	if rf, ok := ret.Get(0).(func(int64, *domain.User) *errs.AppError); ok {
-		r0 = rf(userID, data)
+	if rf, ok := ret.Get(0).(func(int64, *domain.User, *domain.NotificationEvent) *errs.AppError); ok {
+		r0 = rf(userID, data, notification)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*errs.AppError)
@@ -0,0 +1,319 @@
+package repo
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/go-common-packages/logger"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type AttributeRepo struct {
+	db *sqlx.DB
+}
+
+func NewAttributeRepo(db *sqlx.DB) port.AttributeRepo {
+	return &AttributeRepo{
+		db: db,
+	}
+}
+
+func (r AttributeRepo) Insert(form *domain.AttributeDefinition) *errs.AppError {
+	tx, err := r.db.Begin()
+	if err != nil {
+		logger.Error("Error when starting insert attribute definition: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	sqlInsert := `INSERT INTO attribute_definitions(code, name, data_type, options, is_required, created_at, updated_at) 
+					  VALUES($1, $2, $3, $4, $5, $6, $7)
+					  RETURNING attribute_definition_id`
+
+	err = tx.QueryRow(sqlInsert, form.Code, form.Name, form.DataType, pq.Array(form.Options), form.IsRequired, form.CreatedAt, form.UpdatedAt).Scan(&form.AttributeDefinitionID)
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while insert attribute definition: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	err = r.bulkInsertCategory(tx, form.AttributeDefinitionID, form.ProductCategoryIDs)
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while insert attribute definition category: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while commiting transaction: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return nil
+}
+
+func (r AttributeRepo) CheckByIDAndCode(attributeDefinitionID int64, code string) (bool, *errs.AppError) {
+
+	sqlCount := `SELECT COUNT(attribute_definition_id) 
+	FROM attribute_definitions 
+	WHERE attribute_definition_id != $1
+	AND code = $2`
+
+	var totalData int64
+	err := r.db.QueryRow(sqlCount, attributeDefinitionID, code).Scan(&totalData)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error("Error while count attribute definition from database: " + err.Error())
+		return false, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return totalData > 0, nil
+}
+
+func (r AttributeRepo) GetAll() ([]domain.AttributeDefinition, *errs.AppError) {
+	return r.getAll("")
+}
+
+func (r AttributeRepo) GetAllByProductCategoryIDs(productCategoryIDs []int64) ([]domain.AttributeDefinition, *errs.AppError) {
+	condition := `WHERE ad.attribute_definition_id IN (
+		SELECT attribute_definition_id FROM attribute_definition_categories WHERE product_category_id = ANY($1)
+	)`
+	return r.getAll(condition, pq.Array(productCategoryIDs))
+}
+
+func (r AttributeRepo) GetAllByCodes(codes []string) ([]domain.AttributeDefinition, *errs.AppError) {
+	return r.getAll("WHERE ad.code = ANY($1)", pq.Array(codes))
+}
+
+func (r AttributeRepo) GetOneByID(attributeDefinitionID int64) (*domain.AttributeDefinition, *errs.AppError) {
+	attributes, appErr := r.getAll("WHERE ad.attribute_definition_id = $1", attributeDefinitionID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if len(attributes) == 0 {
+		return nil, errs.NewNotFoundError("Attribute not found!")
+	}
+
+	return &attributes[0], nil
+}
+
+func (r AttributeRepo) Update(form *domain.AttributeDefinition) *errs.AppError {
+	tx, err := r.db.Begin()
+	if err != nil {
+		logger.Error("Error when starting update attribute definition: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	sqlUpdate := `
+	UPDATE attribute_definitions 
+	SET code = $2,
+		name = $3,
+		data_type = $4,
+		options = $5,
+		is_required = $6,
+		updated_at = $7
+	WHERE attribute_definition_id = $1`
+
+	_, err = tx.Exec(sqlUpdate, form.AttributeDefinitionID, form.Code, form.Name, form.DataType, pq.Array(form.Options), form.IsRequired, form.UpdatedAt)
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while update attribute definition: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	_, err = tx.Exec(`DELETE FROM attribute_definition_categories WHERE attribute_definition_id = $1`, form.AttributeDefinitionID)
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while delete attribute definition category: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	err = r.bulkInsertCategory(tx, form.AttributeDefinitionID, form.ProductCategoryIDs)
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while insert attribute definition category: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while commiting transaction: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return nil
+}
+
+func (r AttributeRepo) Delete(attributeDefinitionID int64) *errs.AppError {
+	tx, err := r.db.Begin()
+	if err != nil {
+		logger.Error("Error when starting delete attribute definition: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	sqlDeletes := []string{
+		`DELETE FROM product_attribute_values WHERE attribute_definition_id = $1`,
+		`DELETE FROM attribute_definition_categories WHERE attribute_definition_id = $1`,
+		`DELETE FROM attribute_definitions WHERE attribute_definition_id = $1`,
+	}
+
+	for _, sqlDelete := range sqlDeletes {
+		_, err = tx.Exec(sqlDelete, attributeDefinitionID)
+		if err != nil {
+			tx.Rollback()
+			logger.Error("Error while delete attribute definition: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while commiting transaction: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return nil
+}
+
+func (r AttributeRepo) ReplaceProductValues(productID int64, values []domain.ProductAttributeValue) *errs.AppError {
+	tx, err := r.db.Begin()
+	if err != nil {
+		logger.Error("Error when starting replace product attribute value: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	_, err = tx.Exec(`DELETE FROM product_attribute_values WHERE product_id = $1`, productID)
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while delete product attribute value: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	sqlInsert := `INSERT INTO product_attribute_values(product_id, attribute_definition_id, value, value_number) 
+					  VALUES($1, $2, $3, $4)`
+
+	for _, value := range values {
+		_, err = tx.Exec(sqlInsert, productID, value.AttributeDefinitionID, value.Value, value.ValueNumber)
+		if err != nil {
+			tx.Rollback()
+			logger.Error("Error while insert product attribute value: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while commiting transaction: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return nil
+}
+
+func (r AttributeRepo) GetAllProductValues(productID int64) ([]domain.ProductAttributeValue, *errs.AppError) {
+	sqlGet := `
+	SELECT 
+		pav.product_id,
+		pav.attribute_definition_id,
+		ad.code,
+		ad.name,
+		ad.data_type,
+		pav.value,
+		pav.value_number
+	FROM product_attribute_values pav
+	INNER JOIN attribute_definitions ad ON ad.attribute_definition_id = pav.attribute_definition_id
+	WHERE pav.product_id = $1
+	ORDER BY ad.name ASC`
+
+	rows, err := r.db.Query(sqlGet, productID)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error("Error while get all product attribute value from database: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	defer rows.Close()
+
+	values := make([]domain.ProductAttributeValue, 0)
+	for rows.Next() {
+		var value domain.ProductAttributeValue
+		if err := rows.Scan(&value.ProductID, &value.AttributeDefinitionID, &value.Code, &value.Name, &value.DataType, &value.Value, &value.ValueNumber); err != nil {
+			logger.Error("Error while scanning product attribute value from database: " + err.Error())
+			return nil, errs.NewUnexpectedError("Unexpected database error")
+		}
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+func (r AttributeRepo) getAll(condition string, args ...interface{}) ([]domain.AttributeDefinition, *errs.AppError) {
+	sqlGet := fmt.Sprintf(`
+	SELECT 
+		ad.attribute_definition_id,
+		ad.code,
+		ad.name,
+		ad.data_type,
+		ad.options,
+		ad.is_required,
+		COALESCE(ARRAY_AGG(adc.product_category_id) FILTER (WHERE adc.product_category_id IS NOT NULL), '{}') AS product_category_ids,
+		ad.created_at,
+		ad.updated_at
+	FROM attribute_definitions ad
+	LEFT JOIN attribute_definition_categories adc ON adc.attribute_definition_id = ad.attribute_definition_id
+	%s
+	GROUP BY ad.attribute_definition_id
+	ORDER BY ad.name ASC`, condition)
+
+	rows, err := r.db.Query(sqlGet, args...)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error("Error while get all attribute definition from database: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	defer rows.Close()
+
+	attributes := make([]domain.AttributeDefinition, 0)
+	for rows.Next() {
+		var attribute domain.AttributeDefinition
+		if err := rows.Scan(&attribute.AttributeDefinitionID, &attribute.Code, &attribute.Name, &attribute.DataType, pq.Array(&attribute.Options), &attribute.IsRequired,
+			pq.Array(&attribute.ProductCategoryIDs), &attribute.CreatedAt, &attribute.UpdatedAt); err != nil {
+			logger.Error("Error while scanning attribute definition from database: " + err.Error())
+			return nil, errs.NewUnexpectedError("Unexpected database error")
+		}
+		attributes = append(attributes, attribute)
+	}
+
+	return attributes, nil
+}
+
+func (r AttributeRepo) bulkInsertCategory(tx *sql.Tx, attributeDefinitionID int64, productCategoryIDs []int64) error {
+	if len(productCategoryIDs) == 0 {
+		return nil
+	}
+
+	valueStrings := make([]string, 0, len(productCategoryIDs))
+	valueArgs := make([]interface{}, 0, len(productCategoryIDs)*2)
+
+	for i, productCategoryID := range productCategoryIDs {
+		valueStrings = append(valueStrings, fmt.Sprintf("($%d, $%d)", i*2+1, i*2+2))
+		valueArgs = append(valueArgs, attributeDefinitionID, productCategoryID)
+	}
+
+	sqlInsert := fmt.Sprintf("INSERT INTO attribute_definition_categories (attribute_definition_id, product_category_id) VALUES %s",
+		strings.Join(valueStrings, ","))
+
+	_, err := tx.Exec(sqlInsert, valueArgs...)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+package repo
+
+import (
+	"database/sql"
+
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/go-common-packages/logger"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/jmoiron/sqlx"
+)
+
+type BrandRepo struct {
+	db *sqlx.DB
+}
+
+func NewBrandRepo(db *sqlx.DB) port.BrandRepo {
+	return &BrandRepo{
+		db: db,
+	}
+}
+
+func (r BrandRepo) Insert(form *domain.Brand) *errs.AppError {
+	sqlInsert := `INSERT INTO brands(name, slug, logo, description, created_at, updated_at)
+					  VALUES($1, $2, $3, $4, $5, $6)
+					  RETURNING brand_id`
+
+	err := r.db.QueryRow(sqlInsert, form.Name, form.Slug, form.Logo, form.Description, form.CreatedAt, form.UpdatedAt).Scan(&form.BrandID)
+	if err != nil {
+		logger.Error("Error while insert brand: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return nil
+}
+
+func (r BrandRepo) CheckByID(brandID int64) (bool, *errs.AppError) {
+	sqlCountBrand := `SELECT COUNT(brand_id) 
+	FROM brands 
+	WHERE brand_id = $1`
+
+	var totalData int64
+	err := r.db.QueryRow(sqlCountBrand, brandID).Scan(&totalData)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error("Error while count brand from database: " + err.Error())
+		return false, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return totalData > 0, nil
+}
+
+func (r BrandRepo) CheckByIDAndName(brandID int64, name string) (bool, *errs.AppError) {
+	sqlCountBrand := `SELECT COUNT(brand_id) 
+	FROM brands 
+	WHERE brand_id != $1
+	AND lower(name) = lower(TRIM($2))`
+
+	var totalData int64
+	err := r.db.QueryRow(sqlCountBrand, brandID, name).Scan(&totalData)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error("Error while count brand from database: " + err.Error())
+		return false, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return totalData > 0, nil
+}
+
+func (r BrandRepo) CheckByIDAndSlug(brandID int64, slug string) (bool, *errs.AppError) {
+	sqlCountBrand := `SELECT COUNT(brand_id) 
+	FROM brands 
+	WHERE brand_id != $1
+	AND slug = $2`
+
+	var totalData int64
+	err := r.db.QueryRow(sqlCountBrand, brandID, slug).Scan(&totalData)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error("Error while count brand from database: " + err.Error())
+		return false, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return totalData > 0, nil
+}
+
+func (r BrandRepo) CheckProductByID(brandID int64) (bool, *errs.AppError) {
+	sqlCountProduct := `SELECT COUNT(product_id) 
+	FROM products 
+	WHERE brand_id = $1`
+
+	var totalData int64
+	err := r.db.QueryRow(sqlCountProduct, brandID).Scan(&totalData)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error("Error while count product of brand from database: " + err.Error())
+		return false, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return totalData > 0, nil
+}
+
+func (r BrandRepo) GetAll() ([]domain.Brand, *errs.AppError) {
+	sqlGetBrand := `
+	SELECT 
+		b.brand_id,
+		b.name,
+		b.slug,
+		b.logo,
+		b.description,
+		COUNT(p.product_id) AS numb_products
+	FROM brands b
+	LEFT JOIN products p ON p.brand_id = b.brand_id AND p.is_published = TRUE
+	GROUP BY b.brand_id
+	ORDER BY b.name ASC`
+
+	rows, err := r.db.Query(sqlGetBrand)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error("Error while get all brand from database: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	defer rows.Close()
+
+	brands := make([]domain.Brand, 0)
+	for rows.Next() {
+		var brand domain.Brand
+		if err := rows.Scan(&brand.BrandID, &brand.Name, &brand.Slug, &brand.Logo, &brand.Description, &brand.NumbProducts); err != nil {
+			logger.Error("Error while scanning brand from database: " + err.Error())
+			return nil, errs.NewUnexpectedError("Unexpected database error")
+		}
+		brands = append(brands, brand)
+	}
+
+	return brands, nil
+}
+
+func (r BrandRepo) GetOneByID(brandID int64) (*domain.Brand, *errs.AppError) {
+	return r.getOne("b.brand_id = $1", brandID)
+}
+
+func (r BrandRepo) GetOneBySlug(slug string) (*domain.Brand, *errs.AppError) {
+	return r.getOne("b.slug = $1", slug)
+}
+
+func (r BrandRepo) Update(form *domain.Brand) *errs.AppError {
+	sqlUpdate := `
+	UPDATE brands 
+	SET name = $2, slug = $3, logo = $4, description = $5, updated_at = $6
+	WHERE brand_id = $1`
+
+	_, err := r.db.Exec(sqlUpdate, form.BrandID, form.Name, form.Slug, form.Logo, form.Description, form.UpdatedAt)
+	if err != nil {
+		logger.Error("Error while update brand: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return nil
+}
+
+func (r BrandRepo) Delete(brandID int64) *errs.AppError {
+	_, err := r.db.Exec(`DELETE FROM brands WHERE brand_id = $1`, brandID)
+	if err != nil {
+		logger.Error("Error while delete brand: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return nil
+}
+
+func (r BrandRepo) getOne(condition string, arg interface{}) (*domain.Brand, *errs.AppError) {
+	sqlGetBrand := `
+	SELECT 
+		b.brand_id,
+		b.name,
+		b.slug,
+		b.logo,
+		b.description,
+		(SELECT COUNT(p.product_id) FROM products p WHERE p.brand_id = b.brand_id AND p.is_published = TRUE) AS numb_products,
+		b.created_at,
+		b.updated_at
+	FROM brands b
+	WHERE ` + condition + `
+	LIMIT 1`
+
+	var brand domain.Brand
+	err := r.db.QueryRow(sqlGetBrand, arg).Scan(&brand.BrandID, &brand.Name, &brand.Slug, &brand.Logo, &brand.Description, &brand.NumbProducts, &brand.CreatedAt, &brand.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errs.NewNotFoundError("Brand not found!")
+		}
+		logger.Error("Error while get brand from database: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return &brand, nil
+}
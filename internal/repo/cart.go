@@ -0,0 +1,216 @@
+package repo
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/go-common-packages/logger"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/jmoiron/sqlx"
+)
+
+type CartRepo struct {
+	db *sqlx.DB
+}
+
+func NewCartRepo(db *sqlx.DB) port.CartRepo {
+	return &CartRepo{
+		db: db,
+	}
+}
+
+func (r CartRepo) Insert(form *domain.Cart) (int64, *errs.AppError) {
+	sqlInsert := `INSERT INTO carts(user_id, session_id, created_at, updated_at)
+		VALUES($1, $2, $3, $4)
+		RETURNING cart_id`
+
+	var cartID int64
+	err := r.db.QueryRow(sqlInsert, form.UserID, form.SessionID, form.CreatedAt, form.UpdatedAt).Scan(&cartID)
+	if err != nil {
+		logger.Error("Error while insert cart: " + err.Error())
+		return 0, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return cartID, nil
+}
+
+func (r CartRepo) GetOneByUserID(userID int64) (*domain.Cart, *errs.AppError) {
+	return r.getOne("user_id = $1", userID)
+}
+
+func (r CartRepo) GetOneBySessionID(sessionID string) (*domain.Cart, *errs.AppError) {
+	return r.getOne("session_id = $1", sessionID)
+}
+
+func (r CartRepo) GetAllItemByCartID(cartID int64) ([]domain.CartItem, *errs.AppError) {
+	sqlGet := `
+	SELECT
+		cart_item_id,
+		cart_id,
+		product_id,
+		quantity,
+		created_at,
+		updated_at
+	FROM cart_items
+	WHERE cart_id = $1
+	ORDER BY cart_item_id ASC`
+
+	rows, err := r.db.Query(sqlGet, cartID)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error("Error while get all cart item from database: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	defer rows.Close()
+
+	items := make([]domain.CartItem, 0)
+	for rows.Next() {
+		var item domain.CartItem
+		if err := rows.Scan(&item.CartItemID, &item.CartID, &item.ProductID, &item.Quantity, &item.CreatedAt, &item.UpdatedAt); err != nil {
+			logger.Error("Error while scanning cart item from database: " + err.Error())
+			return nil, errs.NewUnexpectedError("Unexpected database error")
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// UpsertItem sets the quantity of the cart line, the line is created when the product is not in the cart yet
+func (r CartRepo) UpsertItem(form *domain.CartItem) *errs.AppError {
+	tx, err := r.db.Begin()
+	if err != nil {
+		logger.Error("Error when starting upsert cart item: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	sqlUpsert := `INSERT INTO cart_items(cart_id, product_id, quantity, created_at, updated_at)
+		VALUES($1, $2, $3, $4, $4)
+		ON CONFLICT (cart_id, product_id) DO UPDATE SET quantity = EXCLUDED.quantity, updated_at = EXCLUDED.updated_at`
+
+	_, err = tx.Exec(sqlUpsert, form.CartID, form.ProductID, form.Quantity, form.UpdatedAt)
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while upsert cart item: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	appErr := touchCart(tx, form.CartID, form.UpdatedAt)
+	if appErr != nil {
+		tx.Rollback()
+		return appErr
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while commiting transaction: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return nil
+}
+
+func (r CartRepo) DeleteItem(cartID, productID int64) *errs.AppError {
+	_, err := r.db.Exec(`DELETE FROM cart_items WHERE cart_id = $1 AND product_id = $2`, cartID, productID)
+	if err != nil {
+		logger.Error("Error while delete cart item: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return nil
+}
+
+// Merge moves the lines of the guest cart into the user cart, quantities of the same product are added up
+// and the guest cart is removed
+func (r CartRepo) Merge(fromCartID, toCartID int64) *errs.AppError {
+	tx, err := r.db.Begin()
+	if err != nil {
+		logger.Error("Error when starting merge cart: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	now := time.Now()
+	sqlMerge := `INSERT INTO cart_items(cart_id, product_id, quantity, created_at, updated_at)
+		SELECT $2, product_id, quantity, created_at, $3
+		FROM cart_items
+		WHERE cart_id = $1
+		ON CONFLICT (cart_id, product_id) DO UPDATE SET quantity = cart_items.quantity + EXCLUDED.quantity, updated_at = EXCLUDED.updated_at`
+
+	_, err = tx.Exec(sqlMerge, fromCartID, toCartID, now)
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while merge cart item: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	_, err = tx.Exec(`DELETE FROM carts WHERE cart_id = $1`, fromCartID)
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while delete merged cart: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	appErr := touchCart(tx, toCartID, now)
+	if appErr != nil {
+		tx.Rollback()
+		return appErr
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while commiting transaction: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return nil
+}
+
+// AssignUser hands a guest cart over to the user, it is used when the user has no cart yet
+func (r CartRepo) AssignUser(cartID, userID int64) *errs.AppError {
+	_, err := r.db.Exec(`UPDATE carts SET user_id = $2, session_id = NULL, updated_at = $3 WHERE cart_id = $1`, cartID, userID, time.Now())
+	if err != nil {
+		logger.Error("Error while assign cart user: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return nil
+}
+
+func (r CartRepo) getOne(condition string, arg interface{}) (*domain.Cart, *errs.AppError) {
+	sqlGet := `
+	SELECT
+		cart_id,
+		user_id,
+		session_id,
+		created_at,
+		updated_at
+	FROM carts
+	WHERE ` + condition + `
+	LIMIT 1`
+
+	var cart domain.Cart
+	err := r.db.QueryRow(sqlGet, arg).Scan(&cart.CartID, &cart.UserID, &cart.SessionID, &cart.CreatedAt, &cart.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errs.NewNotFoundError("Cart not found!")
+		}
+		logger.Error("Error while get cart from database: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return &cart, nil
+}
+
+func touchCart(tx *sql.Tx, cartID int64, updatedAt time.Time) *errs.AppError {
+	_, err := tx.Exec(`UPDATE carts SET updated_at = $2 WHERE cart_id = $1`, cartID, updatedAt)
+	if err != nil {
+		logger.Error("Error while update cart: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return nil
+}
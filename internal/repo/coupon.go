@@ -0,0 +1,340 @@
+package repo
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/go-common-packages/logger"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/danisbagus/matchoshop/utils/constants"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+var errCouponLimitReached = errors.New("coupon usage limit reached")
+
+// couponUsedCount counts the redemptions of orders that were not cancelled, a cancelled order gives the
+// coupon back
+const couponUsedCount = `(SELECT COUNT(cr.coupon_redemption_id) FROM coupon_redemptions cr
+		INNER JOIN orders o ON o.order_id = cr.order_id
+		WHERE cr.coupon_id = c.coupon_id AND o.status != '` + constants.OrderStatusCancelled + `')`
+
+type CouponRepo struct {
+	db *sqlx.DB
+}
+
+func NewCouponRepo(db *sqlx.DB) port.CouponRepo {
+	return &CouponRepo{
+		db: db,
+	}
+}
+
+func (r CouponRepo) Insert(form *domain.Coupon) *errs.AppError {
+	sqlInsert := `INSERT INTO coupons(code, name, type, value, max_discount, min_spend, buy_quantity, get_quantity, product_ids, product_category_ids,
+					  usage_limit, usage_limit_per_user, starts_at, ends_at, is_stackable, is_automatic, is_active, created_at, updated_at)
+					  VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
+					  RETURNING coupon_id`
+
+	err := r.db.QueryRow(sqlInsert, form.Code, form.Name, form.Type, form.Value, form.MaxDiscount, form.MinSpend, form.BuyQuantity, form.GetQuantity,
+		pq.Array(form.ProductIDs), pq.Array(form.ProductCategoryIDs), form.UsageLimit, form.UsageLimitPerUser, form.StartsAt, form.EndsAt,
+		form.IsStackable, form.IsAutomatic, form.IsActive, form.CreatedAt, form.UpdatedAt).Scan(&form.CouponID)
+	if err != nil {
+		logger.Error("Error while insert coupon: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return nil
+}
+
+func (r CouponRepo) CheckByIDAndCode(couponID int64, code string) (bool, *errs.AppError) {
+	sqlCountCoupon := `SELECT COUNT(coupon_id)
+	FROM coupons
+	WHERE coupon_id != $1
+	AND lower(code) = lower($2)`
+
+	var totalData int64
+	err := r.db.QueryRow(sqlCountCoupon, couponID, code).Scan(&totalData)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error("Error while count coupon from database: " + err.Error())
+		return false, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return totalData > 0, nil
+}
+
+func (r CouponRepo) GetAll() ([]domain.Coupon, *errs.AppError) {
+	return r.getAll("TRUE")
+}
+
+// GetAllByCodes returns the coupons of the codes, an automatic coupon is never redeemed by its code
+func (r CouponRepo) GetAllByCodes(codes []string) ([]domain.Coupon, *errs.AppError) {
+	return r.getAll("lower(c.code) = ANY(SELECT lower(code) FROM unnest($1::TEXT[]) code) AND NOT c.is_automatic", pq.Array(codes))
+}
+
+// GetAllAutomatic returns the active automatic coupons, newest first
+func (r CouponRepo) GetAllAutomatic() ([]domain.Coupon, *errs.AppError) {
+	return r.getAll("c.is_automatic AND c.is_active")
+}
+
+func (r CouponRepo) GetOneByID(couponID int64) (*domain.Coupon, *errs.AppError) {
+	coupons, appErr := r.getAll("c.coupon_id = $1", couponID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if len(coupons) == 0 {
+		return nil, errs.NewNotFoundError("Coupon not found!")
+	}
+
+	return &coupons[0], nil
+}
+
+// GetAllScopedProductID returns the given products the coupon applies to, a product is in scope when it is
+// one of the coupon products or belongs to one of the coupon categories or their subcategories
+func (r CouponRepo) GetAllScopedProductID(couponID int64, productIDs []int64) ([]int64, *errs.AppError) {
+	sqlGet := `
+	WITH RECURSIVE scoped_categories AS (
+		SELECT pc.product_category_id
+		FROM product_categories pc
+		INNER JOIN coupons c ON pc.product_category_id = ANY(c.product_category_ids)
+		WHERE c.coupon_id = $1
+		UNION
+		SELECT pc.product_category_id
+		FROM product_categories pc
+		JOIN scoped_categories sc ON pc.parent_id = sc.product_category_id
+	)
+	SELECT p.product_id
+	FROM products p
+	INNER JOIN coupons c ON c.coupon_id = $1
+	WHERE p.product_id = ANY($2)
+	AND (p.product_id = ANY(c.product_ids) OR EXISTS (
+		SELECT 1 FROM product_product_categories ppc
+		WHERE ppc.product_id = p.product_id
+		AND ppc.product_category_id IN (SELECT product_category_id FROM scoped_categories)
+	))`
+
+	rows, err := r.db.Query(sqlGet, couponID, pq.Array(productIDs))
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error("Error while get coupon product from database: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	defer rows.Close()
+
+	scopedProductIDs := make([]int64, 0)
+	for rows.Next() {
+		var productID int64
+		if err := rows.Scan(&productID); err != nil {
+			logger.Error("Error while scanning coupon product from database: " + err.Error())
+			return nil, errs.NewUnexpectedError("Unexpected database error")
+		}
+		scopedProductIDs = append(scopedProductIDs, productID)
+	}
+
+	return scopedProductIDs, nil
+}
+
+// CountRedemption returns how often the coupon was redeemed in total and by the user
+func (r CouponRepo) CountRedemption(couponID, userID int64) (int64, int64, *errs.AppError) {
+	sqlCount := `
+	SELECT
+		COUNT(cr.coupon_redemption_id),
+		COUNT(cr.coupon_redemption_id) FILTER (WHERE cr.user_id = $2)
+	FROM coupon_redemptions cr
+	INNER JOIN orders o ON o.order_id = cr.order_id
+	WHERE cr.coupon_id = $1
+	AND o.status != $3`
+
+	var total, totalByUser int64
+	err := r.db.QueryRow(sqlCount, couponID, userID, constants.OrderStatusCancelled).Scan(&total, &totalByUser)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error("Error while count coupon redemption from database: " + err.Error())
+		return 0, 0, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return total, totalByUser, nil
+}
+
+func (r CouponRepo) GetAllRedemptionPaginate(criteria *domain.CouponRedemptionListCriteria) ([]domain.CouponRedemption, int64, *errs.AppError) {
+	var totalData int64
+	var offset int64
+	if criteria.Page > 0 {
+		offset = (criteria.Page - 1) * criteria.Limit
+	}
+
+	err := r.db.QueryRow(`SELECT COUNT(coupon_redemption_id) FROM coupon_redemptions WHERE coupon_id = $1`, criteria.CouponID).Scan(&totalData)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error("Error while count coupon redemption from database: " + err.Error())
+		return nil, 0, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	redemptions, appErr := r.getAllRedemption("cr.coupon_id = $1 ORDER BY cr.coupon_redemption_id DESC LIMIT $2 OFFSET $3", criteria.CouponID, criteria.Limit, offset)
+	if appErr != nil {
+		return nil, 0, appErr
+	}
+
+	return redemptions, totalData, nil
+}
+
+func (r CouponRepo) GetAllRedemptionByOrderID(orderID int64) ([]domain.CouponRedemption, *errs.AppError) {
+	return r.getAllRedemption("cr.order_id = $1 ORDER BY cr.coupon_redemption_id ASC", orderID)
+}
+
+func (r CouponRepo) Update(form *domain.Coupon) *errs.AppError {
+	sqlUpdate := `
+	UPDATE coupons
+	SET code = $2, name = $3, type = $4, value = $5, max_discount = $6, min_spend = $7, buy_quantity = $8, get_quantity = $9, product_ids = $10,
+		product_category_ids = $11, usage_limit = $12, usage_limit_per_user = $13, starts_at = $14, ends_at = $15, is_stackable = $16, is_automatic = $17,
+		is_active = $18, updated_at = $19
+	WHERE coupon_id = $1`
+
+	_, err := r.db.Exec(sqlUpdate, form.CouponID, form.Code, form.Name, form.Type, form.Value, form.MaxDiscount, form.MinSpend, form.BuyQuantity, form.GetQuantity,
+		pq.Array(form.ProductIDs), pq.Array(form.ProductCategoryIDs), form.UsageLimit, form.UsageLimitPerUser, form.StartsAt, form.EndsAt,
+		form.IsStackable, form.IsAutomatic, form.IsActive, form.UpdatedAt)
+	if err != nil {
+		logger.Error("Error while update coupon: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return nil
+}
+
+func (r CouponRepo) Delete(couponID int64) *errs.AppError {
+	_, err := r.db.Exec(`DELETE FROM coupons WHERE coupon_id = $1`, couponID)
+	if err != nil {
+		logger.Error("Error while delete coupon: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return nil
+}
+
+func (r CouponRepo) getAll(condition string, args ...interface{}) ([]domain.Coupon, *errs.AppError) {
+	sqlGetCoupon := `
+	SELECT
+		c.coupon_id,
+		c.code,
+		c.name,
+		c.type,
+		c.value,
+		c.max_discount,
+		c.min_spend,
+		c.buy_quantity,
+		c.get_quantity,
+		c.product_ids,
+		c.product_category_ids,
+		c.usage_limit,
+		c.usage_limit_per_user,
+		c.starts_at,
+		c.ends_at,
+		c.is_stackable,
+		c.is_automatic,
+		c.is_active,
+		` + couponUsedCount + ` AS used_count,
+		c.created_at,
+		c.updated_at
+	FROM coupons c
+	WHERE ` + condition + `
+	ORDER BY c.coupon_id DESC`
+
+	rows, err := r.db.Query(sqlGetCoupon, args...)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error("Error while get all coupon from database: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	defer rows.Close()
+
+	coupons := make([]domain.Coupon, 0)
+	for rows.Next() {
+		var coupon domain.Coupon
+		if err := rows.Scan(&coupon.CouponID, &coupon.Code, &coupon.Name, &coupon.Type, &coupon.Value, &coupon.MaxDiscount, &coupon.MinSpend,
+			&coupon.BuyQuantity, &coupon.GetQuantity, pq.Array(&coupon.ProductIDs), pq.Array(&coupon.ProductCategoryIDs), &coupon.UsageLimit,
+			&coupon.UsageLimitPerUser, &coupon.StartsAt, &coupon.EndsAt, &coupon.IsStackable, &coupon.IsAutomatic, &coupon.IsActive, &coupon.UsedCount,
+			&coupon.CreatedAt, &coupon.UpdatedAt); err != nil {
+			logger.Error("Error while scanning coupon from database: " + err.Error())
+			return nil, errs.NewUnexpectedError("Unexpected database error")
+		}
+		coupons = append(coupons, coupon)
+	}
+
+	return coupons, nil
+}
+
+func (r CouponRepo) getAllRedemption(condition string, args ...interface{}) ([]domain.CouponRedemption, *errs.AppError) {
+	sqlGetRedemption := `
+	SELECT
+		cr.coupon_redemption_id,
+		cr.coupon_id,
+		cr.order_id,
+		cr.user_id,
+		cr.code,
+		c.type,
+		cr.discount_price,
+		cr.created_at,
+		u.name AS user_name,
+		o.status
+	FROM coupon_redemptions cr
+	INNER JOIN coupons c ON c.coupon_id = cr.coupon_id
+	INNER JOIN orders o ON o.order_id = cr.order_id
+	INNER JOIN users u ON u.user_id = cr.user_id
+	WHERE ` + condition
+
+	rows, err := r.db.Query(sqlGetRedemption, args...)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error("Error while get all coupon redemption from database: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	defer rows.Close()
+
+	redemptions := make([]domain.CouponRedemption, 0)
+	for rows.Next() {
+		var redemption domain.CouponRedemption
+		if err := rows.Scan(&redemption.CouponRedemptionID, &redemption.CouponID, &redemption.OrderID, &redemption.UserID, &redemption.Code,
+			&redemption.Type, &redemption.DiscountPrice, &redemption.CreatedAt, &redemption.UserName, &redemption.OrderStatus); err != nil {
+			logger.Error("Error while scanning coupon redemption from database: " + err.Error())
+			return nil, errs.NewUnexpectedError("Unexpected database error")
+		}
+		redemptions = append(redemptions, redemption)
+	}
+
+	return redemptions, nil
+}
+
+// insertCouponRedemption redeems the coupon for the order. The coupon row is locked so concurrent orders
+// cannot go past the global or the per customer limit.
+func insertCouponRedemption(tx *sql.Tx, form *domain.CouponRedemption) error {
+	var usageLimit, usageLimitPerUser int64
+	err := tx.QueryRow(`SELECT usage_limit, usage_limit_per_user FROM coupons WHERE coupon_id = $1 FOR UPDATE`, form.CouponID).Scan(&usageLimit, &usageLimitPerUser)
+	if err != nil {
+		return err
+	}
+
+	sqlCount := `
+	SELECT
+		COUNT(cr.coupon_redemption_id),
+		COUNT(cr.coupon_redemption_id) FILTER (WHERE cr.user_id = $2)
+	FROM coupon_redemptions cr
+	INNER JOIN orders o ON o.order_id = cr.order_id
+	WHERE cr.coupon_id = $1
+	AND o.status != $3`
+
+	var total, totalByUser int64
+	err = tx.QueryRow(sqlCount, form.CouponID, form.UserID, constants.OrderStatusCancelled).Scan(&total, &totalByUser)
+	if err != nil {
+		return err
+	}
+
+	if (usageLimit > 0 && total >= usageLimit) || (usageLimitPerUser > 0 && totalByUser >= usageLimitPerUser) {
+		return errCouponLimitReached
+	}
+
+	sqlInsert := `INSERT INTO coupon_redemptions(coupon_id, order_id, user_id, code, discount_price, created_at)
+					  VALUES($1, $2, $3, $4, $5, $6)
+					  RETURNING coupon_redemption_id`
+
+	return tx.QueryRow(sqlInsert, form.CouponID, form.OrderID, form.UserID, form.Code, form.DiscountPrice, form.CreatedAt).Scan(&form.CouponRedemptionID)
+}
@@ -0,0 +1,120 @@
+package repo
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/go-common-packages/logger"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/jmoiron/sqlx"
+)
+
+type IdempotencyKeyRepo struct {
+	db *sqlx.DB
+}
+
+func NewIdempotencyKeyRepo(db *sqlx.DB) port.IdempotencyKeyRepo {
+	return &IdempotencyKeyRepo{
+		db: db,
+	}
+}
+
+// Insert claims the key for the request and reports whether it was claimed. A key already in use is left
+// untouched, an expired one is taken over as if it was new.
+func (r IdempotencyKeyRepo) Insert(form *domain.IdempotencyKey) (bool, *errs.AppError) {
+	sqlInsert := `
+	INSERT INTO idempotency_keys(user_id, key, method, path, request_hash, created_at, expires_at)
+	VALUES($1, $2, $3, $4, $5, $6, $7)
+	ON CONFLICT (user_id, key) DO UPDATE
+	SET method = EXCLUDED.method,
+		path = EXCLUDED.path,
+		request_hash = EXCLUDED.request_hash,
+		status_code = 0,
+		content_type = NULL,
+		response_body = NULL,
+		created_at = EXCLUDED.created_at,
+		completed_at = NULL,
+		expires_at = EXCLUDED.expires_at
+	WHERE idempotency_keys.expires_at <= EXCLUDED.created_at
+	RETURNING idempotency_key_id`
+
+	err := r.db.QueryRow(sqlInsert, form.UserID, form.Key, form.Method, form.Path, form.RequestHash, form.CreatedAt, form.ExpiresAt).Scan(&form.IdempotencyKeyID)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+
+	if err != nil {
+		logger.Error("Error while insert idempotency key: " + err.Error())
+		return false, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return true, nil
+}
+
+func (r IdempotencyKeyRepo) GetOneByKey(userID int64, key string) (*domain.IdempotencyKey, *errs.AppError) {
+	sqlGet := `
+	SELECT idempotency_key_id, user_id, key, method, path, request_hash, status_code, content_type, response_body, created_at, completed_at, expires_at
+	FROM idempotency_keys
+	WHERE user_id = $1
+	AND key = $2`
+
+	var idempotencyKey domain.IdempotencyKey
+	err := r.db.QueryRow(sqlGet, userID, key).Scan(&idempotencyKey.IdempotencyKeyID, &idempotencyKey.UserID, &idempotencyKey.Key, &idempotencyKey.Method,
+		&idempotencyKey.Path, &idempotencyKey.RequestHash, &idempotencyKey.StatusCode, &idempotencyKey.ContentType, &idempotencyKey.ResponseBody,
+		&idempotencyKey.CreatedAt, &idempotencyKey.CompletedAt, &idempotencyKey.ExpiresAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errs.NewNotFoundError("Idempotency key not found!")
+		}
+		logger.Error("Error while get idempotency key from database: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return &idempotencyKey, nil
+}
+
+func (r IdempotencyKeyRepo) Complete(form *domain.IdempotencyKey) *errs.AppError {
+	sqlUpdate := `
+	UPDATE idempotency_keys
+	SET status_code = $2,
+		content_type = $3,
+		response_body = $4,
+		completed_at = $5
+	WHERE idempotency_key_id = $1`
+
+	_, err := r.db.Exec(sqlUpdate, form.IdempotencyKeyID, form.StatusCode, form.ContentType, form.ResponseBody, form.CompletedAt)
+	if err != nil {
+		logger.Error("Error while complete idempotency key: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return nil
+}
+
+func (r IdempotencyKeyRepo) Delete(idempotencyKeyID int64) *errs.AppError {
+	_, err := r.db.Exec(`DELETE FROM idempotency_keys WHERE idempotency_key_id = $1`, idempotencyKeyID)
+	if err != nil {
+		logger.Error("Error while delete idempotency key: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return nil
+}
+
+func (r IdempotencyKeyRepo) DeleteExpired(now time.Time) (int64, *errs.AppError) {
+	result, err := r.db.Exec(`DELETE FROM idempotency_keys WHERE expires_at <= $1`, now)
+	if err != nil {
+		logger.Error("Error while delete expired idempotency key: " + err.Error())
+		return 0, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		logger.Error("Error while count deleted idempotency key: " + err.Error())
+		return 0, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,139 @@
+package repo
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/go-common-packages/logger"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/danisbagus/matchoshop/utils/constants"
+	"github.com/jmoiron/sqlx"
+)
+
+type NotificationEventRepo struct {
+	db *sqlx.DB
+}
+
+func NewNotificationEventRepo(db *sqlx.DB) port.NotificationEventRepo {
+	return &NotificationEventRepo{
+		db: db,
+	}
+}
+
+// ClaimDue returns the pending events due at now and moves them out of reach of other workers until
+// claimedUntil, an event whose worker stopped before updating it is due again after that
+func (r NotificationEventRepo) ClaimDue(now, claimedUntil time.Time, limit int64) ([]domain.NotificationEvent, *errs.AppError) {
+	sqlUpdate := `
+	UPDATE notification_events
+	SET next_attempt_at = $2,
+		updated_at = $1
+	WHERE notification_event_id IN (
+		SELECT notification_event_id
+		FROM notification_events
+		WHERE status = $3
+		AND next_attempt_at <= $1
+		ORDER BY next_attempt_at, notification_event_id
+		LIMIT $4
+		FOR UPDATE SKIP LOCKED
+	)
+	RETURNING notification_event_id, user_id, template, data, status, attempts, next_attempt_at, created_at`
+
+	rows, err := r.db.Query(sqlUpdate, now, claimedUntil, constants.NotificationEventStatusPending, limit)
+	if err != nil {
+		logger.Error("Error while claim notification event: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	defer rows.Close()
+
+	events := make([]domain.NotificationEvent, 0)
+	for rows.Next() {
+		var event domain.NotificationEvent
+		var data []byte
+		if err := rows.Scan(&event.NotificationEventID, &event.UserID, &event.Template, &data, &event.Status, &event.Attempts,
+			&event.NextAttemptAt, &event.CreatedAt); err != nil {
+			logger.Error("Error while scanning notification event from database: " + err.Error())
+			return nil, errs.NewUnexpectedError("Unexpected database error")
+		}
+
+		event.Data, err = decodeNotificationData(data)
+		if err != nil {
+			logger.Error("Error while decode notification event data: " + err.Error())
+			return nil, errs.NewUnexpectedError("Unexpected database error")
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+func (r NotificationEventRepo) UpdateStatus(form *domain.NotificationEvent) *errs.AppError {
+	sqlUpdate := `
+	UPDATE notification_events
+	SET status = $2,
+		attempts = $3,
+		next_attempt_at = $4,
+		last_error = $5,
+		sent_at = $6,
+		updated_at = $7
+	WHERE notification_event_id = $1`
+
+	_, err := r.db.Exec(sqlUpdate, form.NotificationEventID, form.Status, form.Attempts, form.NextAttemptAt, form.LastError, form.SentAt, form.UpdatedAt)
+	if err != nil {
+		logger.Error("Error while update notification event: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return nil
+}
+
+// insertNotificationEvent stores the event with the change that raised it, it is due from its creation
+func insertNotificationEvent(tx *sql.Tx, form *domain.NotificationEvent) error {
+	data := []byte("{}")
+	if len(form.Data) > 0 {
+		var err error
+		data, err = json.Marshal(form.Data)
+		if err != nil {
+			return err
+		}
+	}
+
+	form.Status = constants.NotificationEventStatusPending
+	form.NextAttemptAt = form.CreatedAt
+
+	sqlInsert := `INSERT INTO notification_events(user_id, template, data, status, next_attempt_at, created_at, updated_at)
+					  VALUES($1, $2, $3, $4, $5, $5, $5)
+					  RETURNING notification_event_id`
+
+	return tx.QueryRow(sqlInsert, form.UserID, form.Template, data, form.Status, form.CreatedAt).Scan(&form.NotificationEventID)
+}
+
+// decodeNotificationData keeps whole numbers as int64 so the templates format them like before they were
+// stored
+func decodeNotificationData(data []byte) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	values := make(map[string]interface{})
+	if err := decoder.Decode(&values); err != nil {
+		return nil, err
+	}
+
+	for key, value := range values {
+		number, ok := value.(json.Number)
+		if !ok {
+			continue
+		}
+
+		if integer, err := number.Int64(); err == nil {
+			values[key] = integer
+		} else if float, err := number.Float64(); err == nil {
+			values[key] = float
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,96 @@
+package repo
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/danisbagus/matchoshop/utils/constants"
+	"github.com/danisbagus/matchoshop/utils/helper"
+)
+
+// NewNotifier returns the email notifier picked by NOTIFIER_DRIVER, emails go to the local outbox unless
+// smtp is picked so a development setup never mails real customers
+func NewNotifier() port.Notifier {
+	if helper.EnvNotifierDriver() == constants.NotifierDriverSMTP {
+		return NewSMTPNotifier()
+	}
+
+	return NewOutboxNotifier()
+}
+
+// buildEmail writes the notification as a multipart/alternative email with the text and the HTML body
+func buildEmail(from mail.Address, message *domain.Notification, sentAt time.Time) ([]byte, error) {
+	to := mail.Address{Name: message.ToName, Address: message.To}
+
+	buf := new(bytes.Buffer)
+	body := multipart.NewWriter(buf)
+
+	headers := []string{
+		"From: " + from.String(),
+		"To: " + to.String(),
+		"Subject: " + mime.QEncoding.Encode("utf-8", stripNewline(message.Subject)),
+		"Date: " + sentAt.Format(time.RFC1123Z),
+		"Message-ID: " + messageID(from.Address),
+		"Content-Language: " + message.Locale,
+		"MIME-Version: 1.0",
+		"Content-Type: multipart/alternative; boundary=" + body.Boundary(),
+	}
+	buf.WriteString(strings.Join(headers, "\r\n") + "\r\n\r\n")
+
+	parts := []struct {
+		contentType string
+		content     string
+	}{
+		{"text/plain; charset=utf-8", message.TextBody},
+		{"text/html; charset=utf-8", message.HTMLBody},
+	}
+	for _, part := range parts {
+		writer, err := body.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		encoder := quotedprintable.NewWriter(writer)
+		if _, err := encoder.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+		if err := encoder.Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := body.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func messageID(fromAddress string) string {
+	domainName := "localhost"
+	if at := strings.LastIndex(fromAddress, "@"); at >= 0 {
+		domainName = fromAddress[at+1:]
+	}
+
+	random := make([]byte, 12)
+	rand.Read(random)
+	return fmt.Sprintf("<%d.%s@%s>", time.Now().UnixNano(), hex.EncodeToString(random), domainName)
+}
+
+func stripNewline(s string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(s)
+}
@@ -2,7 +2,10 @@ package repo
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
+	"net/http"
+	"sort"
 	"strings"
 	"time"
 
@@ -10,7 +13,16 @@ import (
 	"github.com/danisbagus/go-common-packages/logger"
 	"github.com/danisbagus/matchoshop/internal/core/domain"
 	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/danisbagus/matchoshop/utils/constants"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+var (
+	errOrderStatusChanged = errors.New("order status has changed")
+	errOrderAlreadyPaid   = errors.New("order already paid")
+	errCartChanged        = errors.New("cart has changed")
+	errWishlistChanged    = errors.New("wishlist has changed")
 )
 
 type OrderRepo struct {
@@ -23,31 +35,60 @@ func NewOrderRepo(db *sqlx.DB) port.OrderRepo {
 	}
 }
 
-func (r OrderRepo) GetAll() ([]domain.OrderDetail, *errs.AppError) {
-	sqlGet := `
+func (r OrderRepo) GetAllPaginate(criteria *domain.OrderListCriteria) ([]domain.OrderDetail, int64, *errs.AppError) {
+	var totalData int64
+	var offset int64
+	if criteria.Page > 0 {
+		offset = (criteria.Page - 1) * criteria.Limit
+	}
+
+	condition, args := orderListCondition(criteria)
+
+	sqlCount := fmt.Sprintf(`
+	SELECT
+		COUNT(o.order_id)
+	FROM orders o
+	INNER JOIN users u ON u.user_id = o.user_id
+	WHERE %s`, condition)
+
+	err := r.db.QueryRow(sqlCount, args...).Scan(&totalData)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error("Error while count all order from database: " + err.Error())
+		return nil, 0, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	sqlGet := fmt.Sprintf(`
 	SELECT 
 		o.order_id, 
 		o.user_id, 
 		o.payment_method_id, 
 		o.product_price, 
+		o.discount_price, 
 		o.tax_price, 
 		o.shipping_price, 
 		o.total_price, 
+		o.refunded_price, 
+		o.status, 
 		o.is_paid, 
 		o.paid_at, 
 		o.is_delivered,
 		o.delivered_at,
 		o.created_at,
-		u.name AS user_name
+		u.name AS user_name,
+		u.email AS user_email
 	FROM 
 		orders o
 	INNER JOIN users u ON u.user_id = o.user_id
-	`
+	WHERE %s
+	ORDER BY %s, o.order_id DESC
+	LIMIT $%d
+	OFFSET $%d`, condition, getSortOrder(criteria.Sort, criteria.Order), len(args)+1, len(args)+2)
 
-	rows, err := r.db.Query(sqlGet)
+	args = append(args, criteria.Limit, offset)
+	rows, err := r.db.Query(sqlGet, args...)
 	if err != nil && err != sql.ErrNoRows {
 		logger.Error("Error while get all order from database: " + err.Error())
-		return nil, errs.NewUnexpectedError("Unexpected database error")
+		return nil, 0, errs.NewUnexpectedError("Unexpected database error")
 	}
 
 	defer rows.Close()
@@ -55,16 +96,102 @@ func (r OrderRepo) GetAll() ([]domain.OrderDetail, *errs.AppError) {
 	orders := make([]domain.OrderDetail, 0)
 	for rows.Next() {
 		var order domain.OrderDetail
-		err := rows.Scan(&order.Order.OrderID, &order.UserID, &order.PaymentMethodID, &order.ProductPrice, &order.TaxPrice, &order.ShippingPrice,
-			&order.TotalPrice, &order.IsPaid, &order.PaidAt, &order.IsDelivered, &order.DeliveredAt, &order.CreatedAt, &order.UserName)
+		err := rows.Scan(&order.Order.OrderID, &order.UserID, &order.PaymentMethodID, &order.ProductPrice, &order.DiscountPrice, &order.TaxPrice, &order.ShippingPrice,
+			&order.TotalPrice, &order.RefundedPrice, &order.Order.Status, &order.IsPaid, &order.PaidAt, &order.IsDelivered, &order.DeliveredAt, &order.CreatedAt, &order.UserName,
+			&order.UserEmail)
 		if err != nil && err != sql.ErrNoRows {
 			logger.Error("Error while get all order from database: " + err.Error())
-			return nil, errs.NewUnexpectedError("Unexpected database error")
+			return nil, 0, errs.NewUnexpectedError("Unexpected database error")
 		}
 		orders = append(orders, order)
 	}
 
-	return orders, nil
+	return orders, totalData, nil
+}
+
+func orderListCondition(criteria *domain.OrderListCriteria) (string, []interface{}) {
+	args := make([]interface{}, 0)
+	conditions := []string{"TRUE"}
+
+	if keyword := strings.TrimSpace(criteria.Keyword); keyword != "" {
+		args = append(args, fmt.Sprintf("%%%s%%", keyword), keyword)
+		conditions = append(conditions, fmt.Sprintf("(u.email ILIKE $%d OR u.name ILIKE $%d OR o.order_id::TEXT = $%d)", len(args)-1, len(args)-1, len(args)))
+	}
+
+	if criteria.Status != "" {
+		args = append(args, criteria.Status)
+		conditions = append(conditions, fmt.Sprintf("o.status = $%d", len(args)))
+	}
+
+	if criteria.IsPaid != nil {
+		args = append(args, *criteria.IsPaid)
+		conditions = append(conditions, fmt.Sprintf("(o.is_paid = 1) = $%d", len(args)))
+	}
+
+	if criteria.IsDelivered != nil {
+		args = append(args, *criteria.IsDelivered)
+		conditions = append(conditions, fmt.Sprintf("(o.is_delivered = 1) = $%d", len(args)))
+	}
+
+	if criteria.DateFrom != nil {
+		args = append(args, *criteria.DateFrom)
+		conditions = append(conditions, fmt.Sprintf("o.created_at >= $%d", len(args)))
+	}
+
+	if criteria.DateTo != nil {
+		args = append(args, criteria.DateTo.AddDate(0, 0, 1))
+		conditions = append(conditions, fmt.Sprintf("o.created_at < $%d", len(args)))
+	}
+
+	if criteria.UserID != 0 {
+		args = append(args, criteria.UserID)
+		conditions = append(conditions, fmt.Sprintf("o.user_id = $%d", len(args)))
+	}
+
+	if criteria.PaymentMethodID != 0 {
+		args = append(args, criteria.PaymentMethodID)
+		conditions = append(conditions, fmt.Sprintf("o.payment_method_id = $%d", len(args)))
+	}
+
+	if criteria.MinTotalPrice != nil {
+		args = append(args, *criteria.MinTotalPrice)
+		conditions = append(conditions, fmt.Sprintf("o.total_price >= $%d", len(args)))
+	}
+
+	if criteria.MaxTotalPrice != nil {
+		args = append(args, *criteria.MaxTotalPrice)
+		conditions = append(conditions, fmt.Sprintf("o.total_price <= $%d", len(args)))
+	}
+
+	return strings.Join(conditions, "\n\tAND "), args
+}
+
+func getSortOrder(sort string, order string) string {
+	var sortResult, orderResult string
+	sort = strings.ToLower(sort)
+	order = strings.ToLower(order)
+
+	switch sort {
+	case "total_price":
+		sortResult = "o.total_price"
+	case "paid_at":
+		sortResult = "o.paid_at"
+	case "status":
+		sortResult = "o.status"
+	case "order_id":
+		sortResult = "o.order_id"
+	default:
+		sortResult = "o.created_at"
+	}
+
+	switch order {
+	case "asc":
+		orderResult = "asc"
+	default:
+		orderResult = "desc"
+	}
+
+	return fmt.Sprintf("%s %s NULLS LAST", sortResult, orderResult)
 }
 
 func (r OrderRepo) GetAllByUserID(userID int64) ([]domain.OrderDetail, *errs.AppError) {
@@ -74,9 +201,12 @@ func (r OrderRepo) GetAllByUserID(userID int64) ([]domain.OrderDetail, *errs.App
 		o.user_id, 
 		o.payment_method_id, 
 		o.product_price, 
+		o.discount_price, 
 		o.tax_price, 
 		o.shipping_price, 
 		o.total_price, 
+		o.refunded_price, 
+		o.status, 
 		o.is_paid, 
 		o.paid_at, 
 		o.is_delivered,
@@ -98,8 +228,8 @@ func (r OrderRepo) GetAllByUserID(userID int64) ([]domain.OrderDetail, *errs.App
 	orders := make([]domain.OrderDetail, 0)
 	for rows.Next() {
 		var order domain.OrderDetail
-		err := rows.Scan(&order.Order.OrderID, &order.UserID, &order.PaymentMethodID, &order.ProductPrice, &order.TaxPrice, &order.ShippingPrice,
-			&order.TotalPrice, &order.IsPaid, &order.PaidAt, &order.IsDelivered, &order.DeliveredAt, &order.CreatedAt)
+		err := rows.Scan(&order.Order.OrderID, &order.UserID, &order.PaymentMethodID, &order.ProductPrice, &order.DiscountPrice, &order.TaxPrice, &order.ShippingPrice,
+			&order.TotalPrice, &order.RefundedPrice, &order.Order.Status, &order.IsPaid, &order.PaidAt, &order.IsDelivered, &order.DeliveredAt, &order.CreatedAt)
 		if err != nil && err != sql.ErrNoRows {
 			logger.Error("Error while get all order from database: " + err.Error())
 			return nil, errs.NewUnexpectedError("Unexpected database error")
@@ -117,15 +247,27 @@ func (r OrderRepo) GetOneByID(OrderID int64) (*domain.OrderDetail, *errs.AppErro
 		o.user_id, 
 		o.payment_method_id, 
 		o.product_price, 
+		o.discount_price, 
+		o.coupon_discount_price, 
 		o.tax_price, 
 		o.shipping_price, 
+		o.shipping_discount_price, 
 		o.total_price, 
+		o.refunded_price, 
+		o.status, 
 		o.is_paid, 
 		o.paid_at, 
 		o.is_delivered, 
 		o.delivered_at, 
+		o.reservation_expires_at,
+		o.cancellation_reason,
+		o.cancelled_at,
+		o.shipping_method_id,
+		o.shipping_method_name,
+		o.shipping_weight,
 		sa.address, 
 		sa.city, 
+		sa.region, 
 		sa.postal_code, 
 		sa.country,
 		pm.name,
@@ -140,8 +282,9 @@ func (r OrderRepo) GetOneByID(OrderID int64) (*domain.OrderDetail, *errs.AppErro
   		o.order_id=$1`
 
 	var order domain.OrderDetail
-	err := r.db.QueryRow(sqlGet, OrderID).Scan(&order.Order.OrderID, &order.UserID, &order.PaymentMethodID, &order.ProductPrice, &order.TaxPrice, &order.ShippingPrice,
-		&order.TotalPrice, &order.IsPaid, &order.PaidAt, &order.IsDelivered, &order.DeliveredAt, &order.Address, &order.City, &order.PostalCode, &order.Country, &order.PaymentMethodName, &order.UserName, &order.UserEmail)
+	err := r.db.QueryRow(sqlGet, OrderID).Scan(&order.Order.OrderID, &order.UserID, &order.PaymentMethodID, &order.ProductPrice, &order.DiscountPrice, &order.CouponDiscountPrice, &order.TaxPrice, &order.ShippingPrice, &order.ShippingDiscountPrice,
+		&order.TotalPrice, &order.RefundedPrice, &order.Order.Status, &order.IsPaid, &order.PaidAt, &order.IsDelivered, &order.DeliveredAt, &order.ReservationExpiresAt, &order.CancellationReason, &order.CancelledAt,
+		&order.ShippingMethodID, &order.ShippingMethodName, &order.ShippingWeight, &order.Address, &order.City, &order.Region, &order.PostalCode, &order.Country, &order.PaymentMethodName, &order.UserName, &order.UserEmail)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, errs.NewNotFoundError("Order not found!")
@@ -154,19 +297,19 @@ func (r OrderRepo) GetOneByID(OrderID int64) (*domain.OrderDetail, *errs.AppErro
 	return &order, nil
 }
 
-func (r OrderRepo) Insert(form *domain.OrderDetail) (int64, *errs.AppError) {
+func (r OrderRepo) Insert(form *domain.OrderDetail, notification *domain.NotificationEvent) (int64, *errs.AppError) {
 	tx, err := r.db.Begin()
 	if err != nil {
 		logger.Error("Error when starting insert order: " + err.Error())
 		return 0, errs.NewUnexpectedError("Unexpected database error")
 	}
 
-	sqlInsert := `INSERT INTO orders(user_id, payment_method_id, product_price, tax_price, shipping_price, total_price, created_at, updated_at) 
-					  VALUES($1, $2,$3, $4, $5, $6, $7, $8)
+	sqlInsert := `INSERT INTO orders(user_id, payment_method_id, product_price, discount_price, tax_price, coupon_discount_price, shipping_price, shipping_discount_price, shipping_method_id, shipping_method_name, shipping_weight, total_price, status, reservation_expires_at, created_at, updated_at) 
+					  VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
 					  RETURNING order_id`
 
 	var orderID int64
-	err = tx.QueryRow(sqlInsert, form.UserID, form.PaymentMethodID, form.ProductPrice, form.TaxPrice, form.ShippingPrice, form.TotalPrice, form.CreatedAt, form.UpdatedAt).Scan(&orderID)
+	err = tx.QueryRow(sqlInsert, form.UserID, form.PaymentMethodID, form.ProductPrice, form.DiscountPrice, form.TaxPrice, form.CouponDiscountPrice, form.ShippingPrice, form.ShippingDiscountPrice, form.ShippingMethodID, form.ShippingMethodName, form.ShippingWeight, form.TotalPrice, form.Order.Status, form.ReservationExpiresAt, form.CreatedAt, form.UpdatedAt).Scan(&orderID)
 	if err != nil {
 		tx.Rollback()
 		logger.Error("Error while insert order: " + err.Error())
@@ -187,6 +330,72 @@ func (r OrderRepo) Insert(form *domain.OrderDetail) (int64, *errs.AppError) {
 		return 0, errs.NewUnexpectedError("Unexpected database error")
 	}
 
+	err = insertOrderStatusHistory(tx, &domain.OrderStatusHistory{OrderID: orderID, ToStatus: form.Order.Status, ActorUserID: &form.UserID, CreatedAt: form.CreatedAt})
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while insert order status history: " + err.Error())
+		return 0, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	for i := range form.CouponRedemptions {
+		redemption := &form.CouponRedemptions[i]
+		redemption.OrderID = orderID
+		err = insertCouponRedemption(tx, redemption)
+		if err != nil {
+			tx.Rollback()
+			if err == errCouponLimitReached {
+				return 0, errs.NewBadRequestError(fmt.Sprintf("Coupon %s has reached its usage limit", redemption.Code))
+			}
+			logger.Error("Error while insert coupon redemption: " + err.Error())
+			return 0, errs.NewUnexpectedError("Unexpected database error")
+		}
+	}
+
+	for _, orderProduct := range sortOrderProductByProductID(form.OrderProducts) {
+		err = r.reserveStock(tx, orderID, form.UserID, orderProduct, constants.StockReservationReserved, form.CreatedAt)
+		if err != nil {
+			tx.Rollback()
+			if err == errInsufficientStock {
+				return 0, errs.NewBadRequestError("Insufficient product stock")
+			}
+			logger.Error("Error while reserve product stock: " + err.Error())
+			return 0, errs.NewUnexpectedError("Unexpected database error")
+		}
+	}
+
+	if form.CartID != nil {
+		err = deleteCheckedOutCartItem(tx, *form.CartID, form.OrderProducts)
+		if err != nil {
+			tx.Rollback()
+			if err == errCartChanged {
+				return 0, &errs.AppError{Code: http.StatusConflict, Message: "Cart has changed or was already checked out, please review the cart"}
+			}
+			logger.Error("Error while delete checked out cart item: " + err.Error())
+			return 0, errs.NewUnexpectedError("Unexpected database error")
+		}
+	}
+
+	if form.IsFromWishlist {
+		err = deleteOrderedWishlist(tx, form.UserID, form.OrderProducts)
+		if err != nil {
+			tx.Rollback()
+			if err == errWishlistChanged {
+				return 0, &errs.AppError{Code: http.StatusConflict, Message: "Wishlist has changed or was already ordered, please review the wishlist"}
+			}
+			logger.Error("Error while delete ordered wishlist: " + err.Error())
+			return 0, errs.NewUnexpectedError("Unexpected database error")
+		}
+	}
+
+	// the order id is only known once the order is stored
+	notification.Data["OrderID"] = orderID
+	err = insertNotificationEvent(tx, notification)
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while insert notification event: " + err.Error())
+		return 0, errs.NewUnexpectedError("Unexpected database error")
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		tx.Rollback()
@@ -197,13 +406,41 @@ func (r OrderRepo) Insert(form *domain.OrderDetail) (int64, *errs.AppError) {
 	return orderID, nil
 }
 
-func (r OrderRepo) UpdatePaid(form *domain.PaymentResult) *errs.AppError {
+// UpdatePaid stores the payment result and moves the order to paid, the reserved stock is settled in the
+// same transaction. Stock is taken with conditional updates that never go below zero, so a line that has to
+// take its stock again fails the whole confirmation instead of overselling.
+func (r OrderRepo) UpdatePaid(form *domain.PaymentResult, history *domain.OrderStatusHistory, notification *domain.NotificationEvent) *errs.AppError {
 	tx, err := r.db.Begin()
 	if err != nil {
 		logger.Error("Error when starting update order paid: " + err.Error())
 		return errs.NewUnexpectedError("Unexpected database error")
 	}
 
+	// the order row stays locked until commit so a concurrent confirmation waits and then sees it paid
+	var isPaid bool
+	err = tx.QueryRow(`SELECT is_paid = 1 FROM orders WHERE order_id = $1 FOR UPDATE`, form.OrderID).Scan(&isPaid)
+	if err == nil && isPaid {
+		err = errOrderAlreadyPaid
+	}
+	if err != nil {
+		tx.Rollback()
+		if err == errOrderAlreadyPaid {
+			return errs.NewBadRequestError("order already paid")
+		}
+		logger.Error("Error while lock order: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	err = changeOrderStatus(tx, history)
+	if err != nil {
+		tx.Rollback()
+		if err == errOrderStatusChanged {
+			return errs.NewBadRequestError("Order status has changed, please try again")
+		}
+		logger.Error("Error while update order status: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
 	sqlUpdate := `
 	UPDATE orders 
 	SET is_paid = $2,
@@ -211,7 +448,7 @@ func (r OrderRepo) UpdatePaid(form *domain.PaymentResult) *errs.AppError {
 		updated_at = $3
 	WHERE order_id = $1`
 
-	_, err = tx.Exec(sqlUpdate, form.OrderID, 1, time.Now())
+	_, err = tx.Exec(sqlUpdate, form.OrderID, 1, history.CreatedAt)
 	if err != nil {
 		tx.Rollback()
 		logger.Error("Error while update product: " + err.Error())
@@ -221,7 +458,27 @@ func (r OrderRepo) UpdatePaid(form *domain.PaymentResult) *errs.AppError {
 	err = r.insertPaymentResult(tx, form)
 	if err != nil {
 		tx.Rollback()
-		logger.Error("Error while insert order product: " + err.Error())
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return errs.NewBadRequestError("payment result id already exist")
+		}
+		logger.Error("Error while insert payment result: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	err = r.consumeStockReservation(tx, form.OrderID)
+	if err != nil {
+		tx.Rollback()
+		if err == errInsufficientStock {
+			return errs.NewBadRequestError("Insufficient product stock")
+		}
+		logger.Error("Error while consume stock reservation: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	err = insertNotificationEvent(tx, notification)
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while insert notification event: " + err.Error())
 		return errs.NewUnexpectedError("Unexpected database error")
 	}
 
@@ -233,27 +490,91 @@ func (r OrderRepo) UpdatePaid(form *domain.PaymentResult) *errs.AppError {
 	}
 
 	return nil
+}
+
+// RenewStockReservation reserves the stock of an unpaid order again and moves its payment deadline, lines
+// whose reservation was released once the order expired take their stock again and fail the renewal when
+// it is gone
+func (r OrderRepo) RenewStockReservation(orderID int64, expiresAt time.Time) *errs.AppError {
+	tx, err := r.db.Begin()
+	if err != nil {
+		logger.Error("Error when starting renew stock reservation: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	// the order row stays locked until commit so a payment confirmation waits for the renewal
+	var isPaid bool
+	err = tx.QueryRow(`SELECT is_paid = 1 FROM orders WHERE order_id = $1 FOR UPDATE`, orderID).Scan(&isPaid)
+	if err == nil && isPaid {
+		err = errOrderAlreadyPaid
+	}
+	if err != nil {
+		tx.Rollback()
+		if err == errOrderAlreadyPaid {
+			return errs.NewBadRequestError("order already paid")
+		}
+		logger.Error("Error while lock order: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	now := time.Now()
+	userID, orderProducts, err := getUnreservedOrderProduct(tx, orderID, constants.StockReservationReserved, constants.StockReservationConsumed)
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while get unreserved order product: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
 
+	for _, orderProduct := range orderProducts {
+		err = r.reserveStock(tx, orderID, userID, orderProduct, constants.StockReservationReserved, now)
+		if err != nil {
+			tx.Rollback()
+			if err == errInsufficientStock {
+				return errs.NewBadRequestError("Insufficient product stock, the order can no longer be paid")
+			}
+			logger.Error("Error while reserve product stock: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+	}
+
+	_, err = tx.Exec(`UPDATE orders SET reservation_expires_at = $2, updated_at = $3 WHERE order_id = $1`, orderID, expiresAt, now)
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while update order reservation: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while commiting transaction: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return nil
 }
 
-func (r OrderRepo) UpdateDelivered(ID int64) *errs.AppError {
+func (r OrderRepo) UpdateStatus(form *domain.OrderStatusHistory, notification *domain.NotificationEvent) *errs.AppError {
 	tx, err := r.db.Begin()
 	if err != nil {
-		logger.Error("Error when starting update order paid: " + err.Error())
+		logger.Error("Error when starting update order status: " + err.Error())
 		return errs.NewUnexpectedError("Unexpected database error")
 	}
 
-	sqlUpdate := `
-	UPDATE orders 
-	SET is_delivered = $2,
-		delivered_at = $3,
-		updated_at = $3
-	WHERE order_id = $1`
+	err = changeOrderStatus(tx, form)
+	if err != nil {
+		tx.Rollback()
+		if err == errOrderStatusChanged {
+			return errs.NewBadRequestError("Order status has changed, please try again")
+		}
+		logger.Error("Error while update order status: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
 
-	_, err = tx.Exec(sqlUpdate, ID, 1, time.Now())
+	err = insertNotificationEvent(tx, notification)
 	if err != nil {
 		tx.Rollback()
-		logger.Error("Error while update product: " + err.Error())
+		logger.Error("Error while insert notification event: " + err.Error())
 		return errs.NewUnexpectedError("Unexpected database error")
 	}
 
@@ -267,20 +588,127 @@ func (r OrderRepo) UpdateDelivered(ID int64) *errs.AppError {
 	return nil
 }
 
+// Cancel moves the order to cancelled and gives its stock back, the refund request of a paid order and the
+// cancellation notification are stored in the same transaction
+func (r OrderRepo) Cancel(history *domain.OrderStatusHistory, refund *domain.Refund, notification *domain.NotificationEvent) *errs.AppError {
+	tx, err := r.db.Begin()
+	if err != nil {
+		logger.Error("Error when starting cancel order: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	err = changeOrderStatus(tx, history)
+	if err != nil {
+		tx.Rollback()
+		if err == errOrderStatusChanged {
+			return errs.NewBadRequestError("Order status has changed, please try again")
+		}
+		logger.Error("Error while update order status: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	_, err = tx.Exec(`UPDATE orders SET cancellation_reason = $2, cancelled_at = $3 WHERE order_id = $1`, history.OrderID, history.Note, history.CreatedAt)
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while update order cancellation: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	// a shipped parcel is still on its way, its stock comes back through the return once it is received
+	if history.FromStatus != nil && *history.FromStatus == constants.OrderStatusShipped {
+		err = insertShippedOrderReturn(tx, history)
+	} else {
+		err = r.restoreStock(tx, history)
+	}
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while restore order stock: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	if refund != nil {
+		// earlier partial refunds are taken off, nothing is requested once the order is refunded in full
+		err = insertRefund(tx, refund, true)
+		if err != nil && err != errRefundExceeded {
+			tx.Rollback()
+			logger.Error("Error while insert refund: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+
+		// the customer is told the refund as capped, not the order total
+		if refund.RefundID != 0 {
+			notification.Data["RefundPrice"] = refund.Amount
+		}
+	}
+
+	err = insertNotificationEvent(tx, notification)
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while insert notification event: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while commiting transaction: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return nil
+}
+
+func (r OrderRepo) GetAllStatusHistoryByOrderID(orderID int64) ([]domain.OrderStatusHistory, *errs.AppError) {
+	sqlGet := `
+	SELECT
+		order_status_history_id,
+		order_id,
+		from_status,
+		to_status,
+		actor_user_id,
+		note,
+		created_at
+	FROM order_status_histories
+	WHERE order_id = $1
+	ORDER BY created_at ASC, order_status_history_id ASC`
+
+	rows, err := r.db.Query(sqlGet, orderID)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error("Error while get all order status history from database: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	defer rows.Close()
+
+	histories := make([]domain.OrderStatusHistory, 0)
+	for rows.Next() {
+		var history domain.OrderStatusHistory
+		if err := rows.Scan(&history.OrderStatusHistoryID, &history.OrderID, &history.FromStatus, &history.ToStatus, &history.ActorUserID, &history.Note, &history.CreatedAt); err != nil {
+			logger.Error("Error while scanning order status history from database: " + err.Error())
+			return nil, errs.NewUnexpectedError("Unexpected database error")
+		}
+		histories = append(histories, history)
+	}
+
+	return histories, nil
+}
+
 func (r OrderRepo) bulkInsertOrderProduct(tx *sql.Tx, orderID int64, form []domain.OrderProduct) error {
+	const numbColumns = 15
 	valueStrings := make([]string, 0, len(form))
-	valueArgs := make([]interface{}, 0, len(form)*2)
+	valueArgs := make([]interface{}, 0, len(form)*numbColumns)
 
-	i := 0
-	for _, post := range form {
-		valueStrings = append(valueStrings, fmt.Sprintf("($%d, $%d, $%d)", i*3+1, i*3+2, i*3+3))
-		valueArgs = append(valueArgs, orderID)
-		valueArgs = append(valueArgs, post.ProductID)
-		valueArgs = append(valueArgs, post.Quantity)
-		i++
+	for i, post := range form {
+		placeholders := make([]string, 0, numbColumns)
+		for j := 1; j <= numbColumns; j++ {
+			placeholders = append(placeholders, fmt.Sprintf("$%d", i*numbColumns+j))
+		}
+		valueStrings = append(valueStrings, "("+strings.Join(placeholders, ", ")+")")
+		valueArgs = append(valueArgs, orderID, post.ProductID, post.Quantity, post.Name, post.Sku, post.Image,
+			post.RegularPrice, post.Price, post.DiscountPrice, post.CouponDiscountPrice, post.TaxClass, post.TaxRate, post.IsTaxInclusive, post.TaxPrice, post.TotalPrice)
 	}
 
-	sqlInsert := fmt.Sprintf("INSERT INTO order_products (order_id, product_id, quantity) VALUES %s",
+	sqlInsert := fmt.Sprintf("INSERT INTO order_products (order_id, product_id, quantity, name, sku, image, regular_price, price, discount_price, coupon_discount_price, tax_class, tax_rate, is_tax_inclusive, tax_price, total_price) VALUES %s",
 		strings.Join(valueStrings, ","))
 
 	_, err := tx.Exec(sqlInsert, valueArgs...)
@@ -292,10 +720,10 @@ func (r OrderRepo) bulkInsertOrderProduct(tx *sql.Tx, orderID int64, form []doma
 
 func (r OrderRepo) insertShipmentAddress(tx *sql.Tx, orderID int64, form *domain.ShipmentAddress) error {
 
-	sqlInsert := `INSERT INTO shipment_address(order_id, address, city, postal_code, country) 
-					  VALUES($1, $2,$3, $4, $5)`
+	sqlInsert := `INSERT INTO shipment_address(order_id, address, city, region, postal_code, country) 
+					  VALUES($1, $2, $3, $4, $5, $6)`
 
-	_, err := tx.Exec(sqlInsert, orderID, form.Address, form.City, form.PostalCode, form.Country)
+	_, err := tx.Exec(sqlInsert, orderID, form.Address, form.City, form.Region, form.PostalCode, form.Country)
 	if err != nil {
 		return err
 	}
@@ -313,3 +741,230 @@ func (r OrderRepo) insertPaymentResult(tx *sql.Tx, form *domain.PaymentResult) e
 	}
 	return nil
 }
+
+// deleteCheckedOutCartItem removes the cart lines placed in the order. A line already gone means the cart was
+// checked out by a concurrent request, which holds the lines until it commits, so the order is not placed twice.
+func deleteCheckedOutCartItem(tx *sql.Tx, cartID int64, orderProducts []domain.OrderProduct) error {
+	productIDs := make([]int64, 0, len(orderProducts))
+	for _, orderProduct := range orderProducts {
+		productIDs = append(productIDs, orderProduct.ProductID)
+	}
+
+	result, err := tx.Exec(`DELETE FROM cart_items WHERE cart_id = $1 AND product_id = ANY($2)`, cartID, pq.Array(productIDs))
+	if err != nil {
+		return err
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if deleted != int64(len(productIDs)) {
+		return errCartChanged
+	}
+	return nil
+}
+
+func deleteOrderedWishlist(tx *sql.Tx, userID int64, orderProducts []domain.OrderProduct) error {
+	productIDs := make([]int64, 0, len(orderProducts))
+	for _, orderProduct := range orderProducts {
+		productIDs = append(productIDs, orderProduct.ProductID)
+	}
+
+	result, err := tx.Exec(`DELETE FROM wishlists WHERE user_id = $1 AND product_id = ANY($2)`, userID, pq.Array(productIDs))
+	if err != nil {
+		return err
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if deleted != int64(len(productIDs)) {
+		return errWishlistChanged
+	}
+	return nil
+}
+
+// sortOrderProductByProductID returns the lines ordered by product, stock rows are always locked in product
+// order so two orders sharing products cannot deadlock each other
+func sortOrderProductByProductID(orderProducts []domain.OrderProduct) []domain.OrderProduct {
+	sorted := make([]domain.OrderProduct, len(orderProducts))
+	copy(sorted, orderProducts)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].ProductID < sorted[j].ProductID
+	})
+	return sorted
+}
+
+// reserveStock takes the line quantity off the product stock as a sale movement and
+// records the reservation holding it, failing when the stock is not enough.
+func (r OrderRepo) reserveStock(tx *sql.Tx, orderID, userID int64, orderProduct domain.OrderProduct, status string, createdAt time.Time) error {
+	err := applyStockMovement(tx, &domain.StockMovement{
+		ProductID:    orderProduct.ProductID,
+		MovementType: constants.StockMovementSale,
+		Quantity:     -orderProduct.Quantity,
+		ActorUserID:  &userID,
+		OrderID:      &orderID,
+		CreatedAt:    createdAt.Format(dbTSLayout),
+	})
+	if err != nil {
+		return err
+	}
+
+	sqlInsert := `INSERT INTO stock_reservations(order_id, product_id, quantity, status, created_at, updated_at) 
+					  VALUES($1, $2, $3, $4, $5, $5)`
+
+	_, err = tx.Exec(sqlInsert, orderID, orderProduct.ProductID, orderProduct.Quantity, status, createdAt)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// consumeStockReservation settles the reserved stock of a paid order. Lines whose
+// reservation was released by the sweeper, or orders placed before reservations
+// existed, take their stock again here.
+func (r OrderRepo) consumeStockReservation(tx *sql.Tx, orderID int64) error {
+	now := time.Now()
+
+	sqlUpdate := `
+	UPDATE stock_reservations
+	SET status = $2,
+		updated_at = $3
+	WHERE order_id = $1
+	AND status = $4`
+
+	_, err := tx.Exec(sqlUpdate, orderID, constants.StockReservationConsumed, now, constants.StockReservationReserved)
+	if err != nil {
+		return err
+	}
+
+	userID, orderProducts, err := getUnreservedOrderProduct(tx, orderID, constants.StockReservationConsumed)
+	if err != nil {
+		return err
+	}
+
+	for _, orderProduct := range orderProducts {
+		err = r.reserveStock(tx, orderID, userID, orderProduct, constants.StockReservationConsumed, now)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getUnreservedOrderProduct returns the user of the order and its lines holding no reservation in one of the
+// statuses, ordered by product
+func getUnreservedOrderProduct(tx *sql.Tx, orderID int64, statuses ...string) (int64, []domain.OrderProduct, error) {
+	sqlGet := `
+	SELECT
+		op.product_id,
+		op.quantity,
+		o.user_id
+	FROM order_products op
+	INNER JOIN orders o ON o.order_id = op.order_id
+	WHERE op.order_id = $1
+	AND NOT EXISTS (
+		SELECT 1 FROM stock_reservations sr
+		WHERE sr.order_id = op.order_id
+		AND sr.product_id = op.product_id
+		AND sr.status = ANY($2)
+	)
+	ORDER BY op.product_id`
+
+	rows, err := tx.Query(sqlGet, orderID, pq.Array(statuses))
+	if err != nil {
+		return 0, nil, err
+	}
+	defer rows.Close()
+
+	var userID int64
+	orderProducts := make([]domain.OrderProduct, 0)
+	for rows.Next() {
+		var orderProduct domain.OrderProduct
+		if err := rows.Scan(&orderProduct.ProductID, &orderProduct.Quantity, &userID); err != nil {
+			return 0, nil, err
+		}
+		orderProducts = append(orderProducts, orderProduct)
+	}
+	return userID, orderProducts, nil
+}
+
+// restoreStock releases the reserved or consumed stock of a cancelled order back to the products, a
+// reservation already released by the sweeper has been given back before
+func (r OrderRepo) restoreStock(tx *sql.Tx, history *domain.OrderStatusHistory) error {
+	sqlUpdate := `
+	UPDATE stock_reservations
+	SET status = $2,
+		updated_at = $3
+	WHERE order_id = $1
+	AND status IN ($4, $5)
+	RETURNING product_id, quantity`
+
+	rows, err := tx.Query(sqlUpdate, history.OrderID, constants.StockReservationReleased, history.CreatedAt, constants.StockReservationReserved, constants.StockReservationConsumed)
+	if err != nil {
+		return err
+	}
+
+	stockMovements := make([]domain.StockMovement, 0)
+	for rows.Next() {
+		var stockMovement domain.StockMovement
+		if err := rows.Scan(&stockMovement.ProductID, &stockMovement.Quantity); err != nil {
+			rows.Close()
+			return err
+		}
+		stockMovements = append(stockMovements, stockMovement)
+	}
+	rows.Close()
+
+	for _, stockMovement := range stockMovements {
+		orderID := history.OrderID
+		stockMovement.MovementType = constants.StockMovementCancellation
+		stockMovement.Reason = history.Note
+		stockMovement.ActorUserID = history.ActorUserID
+		stockMovement.OrderID = &orderID
+		stockMovement.CreatedAt = history.CreatedAt.Format(dbTSLayout)
+		err = applyStockMovement(tx, &stockMovement)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// changeOrderStatus moves the order from the expected status to the new one and records the history, it
+// fails with errOrderStatusChanged when another request changed the status first
+func changeOrderStatus(tx *sql.Tx, form *domain.OrderStatusHistory) error {
+	sqlUpdate := `UPDATE orders SET status = $2, updated_at = $4`
+	if form.ToStatus == constants.OrderStatusDelivered {
+		sqlUpdate += `, is_delivered = 1, delivered_at = $4`
+	}
+	sqlUpdate += ` WHERE order_id = $1 AND status = $3`
+
+	result, err := tx.Exec(sqlUpdate, form.OrderID, form.ToStatus, form.FromStatus, form.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return errOrderStatusChanged
+	}
+
+	return insertOrderStatusHistory(tx, form)
+}
+
+func insertOrderStatusHistory(tx *sql.Tx, form *domain.OrderStatusHistory) error {
+	sqlInsert := `INSERT INTO order_status_histories(order_id, from_status, to_status, actor_user_id, note, created_at) 
+					  VALUES($1, $2, $3, $4, $5, $6)`
+
+	_, err := tx.Exec(sqlInsert, form.OrderID, form.FromStatus, form.ToStatus, form.ActorUserID, form.Note, form.CreatedAt)
+	return err
+}
@@ -0,0 +1,144 @@
+package repo
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/go-common-packages/logger"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/danisbagus/matchoshop/utils/constants"
+	"github.com/jmoiron/sqlx"
+)
+
+type OrderDocumentRepo struct {
+	db *sqlx.DB
+}
+
+func NewOrderDocumentRepo(db *sqlx.DB) port.OrderDocumentRepo {
+	return &OrderDocumentRepo{
+		db: db,
+	}
+}
+
+// Insert stores the document and reports whether it was stored, a document of the order generated
+// meanwhile by another request is kept instead
+func (r OrderDocumentRepo) Insert(form *domain.OrderDocument) (bool, *errs.AppError) {
+	sqlInsert := `
+	INSERT INTO order_documents(order_id, type, file_name, content, created_at)
+	VALUES($1, $2, $3, $4, $5)
+	ON CONFLICT (order_id, type) DO NOTHING
+	RETURNING order_document_id`
+
+	err := r.db.QueryRow(sqlInsert, form.OrderID, form.Type, form.FileName, form.Content, form.CreatedAt).Scan(&form.OrderDocumentID)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+
+	if err != nil {
+		logger.Error("Error while insert order document: " + err.Error())
+		return false, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return true, nil
+}
+
+func (r OrderDocumentRepo) GetOneByOrderID(orderID int64, documentType string) (*domain.OrderDocument, *errs.AppError) {
+	sqlGet := `
+	SELECT order_document_id, order_id, type, file_name, content, created_at
+	FROM order_documents
+	WHERE order_id = $1
+	AND type = $2`
+
+	var document domain.OrderDocument
+	err := r.db.QueryRow(sqlGet, orderID, documentType).Scan(&document.OrderDocumentID, &document.OrderID, &document.Type, &document.FileName,
+		&document.Content, &document.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errs.NewNotFoundError("Order document not found!")
+		}
+		logger.Error("Error while get order document from database: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return &document, nil
+}
+
+// GetOrCreateInvoice returns the invoice of the order, numbering it on first use. The yearly sequence is
+// bumped in the same transaction as the invoice is inserted, so a failed or concurrent insert rolls the
+// number back and invoice numbers have no gaps.
+func (r OrderDocumentRepo) GetOrCreateInvoice(orderID int64, issuedAt time.Time) (*domain.Invoice, *errs.AppError) {
+	invoice, appErr := r.getInvoice(orderID)
+	if appErr == nil || appErr.Code != http.StatusNotFound {
+		return invoice, appErr
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		logger.Error("Error when starting create invoice: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	sqlNextNumber := `
+	INSERT INTO invoice_sequences(year, last_number)
+	VALUES($1, 1)
+	ON CONFLICT (year) DO UPDATE
+	SET last_number = invoice_sequences.last_number + 1
+	RETURNING last_number`
+
+	var number int64
+	err = tx.QueryRow(sqlNextNumber, issuedAt.Year()).Scan(&number)
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while get next invoice number: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	sqlInsert := `
+	INSERT INTO invoices(order_id, invoice_number, issued_at)
+	VALUES($1, $2, $3)
+	ON CONFLICT (order_id) DO NOTHING
+	RETURNING order_id`
+
+	invoice = &domain.Invoice{OrderID: orderID, InvoiceNumber: fmt.Sprintf(constants.InvoiceNumberFormat, issuedAt.Year(), number), IssuedAt: issuedAt}
+	err = tx.QueryRow(sqlInsert, invoice.OrderID, invoice.InvoiceNumber, invoice.IssuedAt).Scan(&invoice.OrderID)
+	if err == sql.ErrNoRows {
+		// another request numbered the invoice first, give the number back and use theirs
+		tx.Rollback()
+		return r.getInvoice(orderID)
+	}
+
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while insert invoice: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while commiting transaction: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return invoice, nil
+}
+
+func (r OrderDocumentRepo) getInvoice(orderID int64) (*domain.Invoice, *errs.AppError) {
+	sqlGet := `SELECT order_id, invoice_number, issued_at FROM invoices WHERE order_id = $1`
+
+	var invoice domain.Invoice
+	err := r.db.QueryRow(sqlGet, orderID).Scan(&invoice.OrderID, &invoice.InvoiceNumber, &invoice.IssuedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errs.NewNotFoundError("Invoice not found!")
+		}
+		logger.Error("Error while get invoice from database: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return &invoice, nil
+}
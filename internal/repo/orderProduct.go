@@ -26,15 +26,24 @@ func (r *OrderProductRepo) GetAllByOrderID(orderID int64) ([]domain.OrderProduct
 	SELECT 
 		op.order_id, 
 		op.product_id, 
-		p.name, 
-		p.price, 
-		p.image,
-		op.quantity 
+		op.name, 
+		op.sku, 
+		op.regular_price, 
+		op.price, 
+		op.image,
+		op.quantity,
+		op.discount_price,
+		op.coupon_discount_price,
+		op.tax_class,
+		op.tax_rate,
+		op.is_tax_inclusive,
+		op.tax_price,
+		op.total_price
 	FROM 
 		order_products op 
-		INNER JOIN products p ON p.product_id = op.product_id 
 	WHERE 
 		op.order_id=$1
+	ORDER BY op.product_id ASC
   `
 	rows, err := r.db.Query(sqlGet, orderID)
 	if err != nil && err != sql.ErrNoRows {
@@ -47,7 +56,8 @@ func (r *OrderProductRepo) GetAllByOrderID(orderID int64) ([]domain.OrderProduct
 	orderProducts := make([]domain.OrderProduct, 0)
 	for rows.Next() {
 		var orderProduct domain.OrderProduct
-		if err := rows.Scan(&orderProduct.OrderID, &orderProduct.ProductID, &orderProduct.Name, &orderProduct.Price, &orderProduct.Image, &orderProduct.Quantity); err != nil {
+		if err := rows.Scan(&orderProduct.OrderID, &orderProduct.ProductID, &orderProduct.Name, &orderProduct.Sku, &orderProduct.RegularPrice, &orderProduct.Price, &orderProduct.Image,
+			&orderProduct.Quantity, &orderProduct.DiscountPrice, &orderProduct.CouponDiscountPrice, &orderProduct.TaxClass, &orderProduct.TaxRate, &orderProduct.IsTaxInclusive, &orderProduct.TaxPrice, &orderProduct.TotalPrice); err != nil {
 			logger.Error("Error while scanning porder productfrom database: " + err.Error())
 			return nil, errs.NewUnexpectedError("Unexpected database error")
 		}
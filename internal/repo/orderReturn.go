@@ -0,0 +1,531 @@
+package repo
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/go-common-packages/logger"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/danisbagus/matchoshop/utils/constants"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+var (
+	errOrderReturnQuantityExceeded = errors.New("order return quantity exceeded")
+	errOrderReturnStatusChanged    = errors.New("order return status has changed")
+)
+
+type OrderReturnRepo struct {
+	db *sqlx.DB
+}
+
+func NewOrderReturnRepo(db *sqlx.DB) port.OrderReturnRepo {
+	return &OrderReturnRepo{
+		db: db,
+	}
+}
+
+func (r OrderReturnRepo) Insert(form *domain.OrderReturn) (int64, *errs.AppError) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		logger.Error("Error when starting insert order return: " + err.Error())
+		return 0, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	// concurrent returns and refunds of the same order wait here, the status and the returnable quantity the
+	// service read are checked again against committed rows
+	var status string
+	err = tx.QueryRow(`SELECT status FROM orders WHERE order_id = $1 FOR UPDATE`, form.OrderID).Scan(&status)
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while lock order for return: " + err.Error())
+		return 0, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	if status != constants.OrderStatusDelivered {
+		tx.Rollback()
+		return 0, errs.NewBadRequestError(fmt.Sprintf("Order is %s and cannot be returned", status))
+	}
+
+	sqlInsert := `INSERT INTO order_returns(order_id, user_id, reason, photos, status, refund_amount, created_at, updated_at)
+					  VALUES($1, $2, $3, $4, $5, $6, $7, $7)
+					  RETURNING order_return_id`
+
+	err = tx.QueryRow(sqlInsert, form.OrderID, form.UserID, form.Reason, pq.Array(form.Photos), form.Status, form.RefundAmount, form.CreatedAt).Scan(&form.OrderReturnID)
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while insert order return: " + err.Error())
+		return 0, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	for i := range form.Items {
+		form.Items[i].OrderReturnID = form.OrderReturnID
+		err = insertOrderReturnItem(tx, form.OrderID, &form.Items[i])
+		if err != nil {
+			tx.Rollback()
+			if err == errOrderReturnQuantityExceeded {
+				return 0, errs.NewBadRequestError("Return quantity exceeds the returnable quantity")
+			}
+			logger.Error("Error while insert order return item: " + err.Error())
+			return 0, errs.NewUnexpectedError("Unexpected database error")
+		}
+	}
+
+	err = insertOrderReturnStatusHistory(tx, &domain.OrderReturnStatusHistory{
+		OrderReturnID: form.OrderReturnID,
+		ToStatus:      form.Status,
+		ActorUserID:   &form.UserID,
+		Note:          &form.Reason,
+		CreatedAt:     form.CreatedAt,
+	})
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while insert order return status history: " + err.Error())
+		return 0, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while commiting transaction: " + err.Error())
+		return 0, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return form.OrderReturnID, nil
+}
+
+func (r OrderReturnRepo) GetAllPaginate(criteria *domain.OrderReturnListCriteria) ([]domain.OrderReturn, int64, *errs.AppError) {
+	var totalData int64
+	var offset int64
+	if criteria.Page > 0 {
+		offset = (criteria.Page - 1) * criteria.Limit
+	}
+
+	sqlCount := `
+	SELECT
+		COUNT(r.order_return_id)
+	FROM order_returns r
+	WHERE ($1 = '' OR r.status = $1)`
+
+	err := r.db.QueryRow(sqlCount, criteria.Status).Scan(&totalData)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error("Error while count order return from database: " + err.Error())
+		return nil, 0, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	sqlGet := `
+	SELECT
+		r.order_return_id,
+		r.order_id,
+		r.user_id,
+		r.reason,
+		r.photos,
+		r.status,
+		r.refund_amount,
+		r.is_restocked,
+		r.created_at,
+		r.updated_at
+	FROM order_returns r
+	WHERE ($1 = '' OR r.status = $1)
+	ORDER BY r.order_return_id DESC
+	LIMIT $2
+	OFFSET $3`
+
+	rows, err := r.db.Query(sqlGet, criteria.Status, criteria.Limit, offset)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error("Error while get all order return from database: " + err.Error())
+		return nil, 0, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	orderReturns, err := scanOrderReturns(rows)
+	if err != nil {
+		logger.Error("Error while scanning order return from database: " + err.Error())
+		return nil, 0, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return orderReturns, totalData, nil
+}
+
+func (r OrderReturnRepo) GetAllByOrderID(orderID int64) ([]domain.OrderReturn, *errs.AppError) {
+	sqlGet := `
+	SELECT
+		r.order_return_id,
+		r.order_id,
+		r.user_id,
+		r.reason,
+		r.photos,
+		r.status,
+		r.refund_amount,
+		r.is_restocked,
+		r.created_at,
+		r.updated_at
+	FROM order_returns r
+	WHERE r.order_id = $1
+	ORDER BY r.order_return_id DESC`
+
+	rows, err := r.db.Query(sqlGet, orderID)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error("Error while get all order return by order id from database: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	orderReturns, err := scanOrderReturns(rows)
+	if err != nil {
+		logger.Error("Error while scanning order return from database: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	for i := range orderReturns {
+		orderReturns[i].Items, err = r.getAllItem(orderReturns[i].OrderReturnID)
+		if err != nil {
+			logger.Error("Error while get order return item from database: " + err.Error())
+			return nil, errs.NewUnexpectedError("Unexpected database error")
+		}
+	}
+
+	return orderReturns, nil
+}
+
+func (r OrderReturnRepo) GetOneByID(ID int64) (*domain.OrderReturn, *errs.AppError) {
+	sqlGet := `
+	SELECT
+		r.order_return_id,
+		r.order_id,
+		r.user_id,
+		r.reason,
+		r.photos,
+		r.status,
+		r.refund_amount,
+		r.is_restocked,
+		r.created_at,
+		r.updated_at
+	FROM order_returns r
+	WHERE r.order_return_id = $1
+	LIMIT 1`
+
+	var orderReturn domain.OrderReturn
+	err := r.db.QueryRow(sqlGet, ID).Scan(&orderReturn.OrderReturnID, &orderReturn.OrderID, &orderReturn.UserID, &orderReturn.Reason, pq.Array(&orderReturn.Photos),
+		&orderReturn.Status, &orderReturn.RefundAmount, &orderReturn.IsRestocked, &orderReturn.CreatedAt, &orderReturn.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errs.NewNotFoundError("Order return not found!")
+		}
+		logger.Error("Error while get order return from database: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	orderReturn.Items, err = r.getAllItem(orderReturn.OrderReturnID)
+	if err != nil {
+		logger.Error("Error while get order return item from database: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	orderReturn.StatusHistories, err = r.getAllStatusHistory(orderReturn.OrderReturnID)
+	if err != nil {
+		logger.Error("Error while get order return status history from database: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return &orderReturn, nil
+}
+
+func (r OrderReturnRepo) GetReturnedQuantityByOrderID(orderID int64) (map[int64]int64, *errs.AppError) {
+	sqlGet := `
+	SELECT
+		ri.product_id,
+		SUM(ri.quantity)
+	FROM order_return_items ri
+	INNER JOIN order_returns r ON r.order_return_id = ri.order_return_id
+	WHERE r.order_id = $1
+	AND r.status <> $2
+	GROUP BY ri.product_id`
+
+	rows, err := r.db.Query(sqlGet, orderID, constants.OrderReturnStatusRejected)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error("Error while get returned quantity from database: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	defer rows.Close()
+
+	quantities := make(map[int64]int64)
+	for rows.Next() {
+		var productID, quantity int64
+		if err := rows.Scan(&productID, &quantity); err != nil {
+			logger.Error("Error while scanning returned quantity from database: " + err.Error())
+			return nil, errs.NewUnexpectedError("Unexpected database error")
+		}
+		quantities[productID] = quantity
+	}
+
+	return quantities, nil
+}
+
+func (r OrderReturnRepo) UpdateStatus(history *domain.OrderReturnStatusHistory, refund *domain.Refund, restock bool) *errs.AppError {
+	tx, err := r.db.Begin()
+	if err != nil {
+		logger.Error("Error when starting update order return status: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	err = changeOrderReturnStatus(tx, history, restock)
+	if err != nil {
+		tx.Rollback()
+		if err == errOrderReturnStatusChanged {
+			return errs.NewBadRequestError("Order return status has changed, please try again")
+		}
+		logger.Error("Error while update order return status: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	if restock {
+		err = restockOrderReturn(tx, history)
+		if err != nil {
+			tx.Rollback()
+			logger.Error("Error while restock order return: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+	}
+
+	if refund != nil {
+		err = insertRefund(tx, refund, false)
+		if err != nil {
+			tx.Rollback()
+			if err == errRefundExceeded {
+				return errs.NewBadRequestError("Refund exceeds the refundable amount of the order")
+			}
+			logger.Error("Error while insert refund: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while commiting transaction: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return nil
+}
+
+func (r OrderReturnRepo) getAllItem(orderReturnID int64) ([]domain.OrderReturnItem, error) {
+	sqlGet := `
+	SELECT
+		ri.order_return_item_id,
+		ri.order_return_id,
+		ri.product_id,
+		COALESCE(op.name, ''),
+		ri.quantity,
+		ri.refund_amount
+	FROM order_return_items ri
+	INNER JOIN order_returns r ON r.order_return_id = ri.order_return_id
+	LEFT JOIN order_products op ON op.order_id = r.order_id AND op.product_id = ri.product_id
+	WHERE ri.order_return_id = $1
+	ORDER BY ri.order_return_item_id`
+
+	rows, err := r.db.Query(sqlGet, orderReturnID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	items := make([]domain.OrderReturnItem, 0)
+	for rows.Next() {
+		var item domain.OrderReturnItem
+		if err := rows.Scan(&item.OrderReturnItemID, &item.OrderReturnID, &item.ProductID, &item.Name, &item.Quantity, &item.RefundAmount); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+func (r OrderReturnRepo) getAllStatusHistory(orderReturnID int64) ([]domain.OrderReturnStatusHistory, error) {
+	sqlGet := `
+	SELECT
+		h.order_return_status_history_id,
+		h.order_return_id,
+		h.from_status,
+		h.to_status,
+		h.actor_user_id,
+		h.note,
+		h.created_at
+	FROM order_return_status_histories h
+	WHERE h.order_return_id = $1
+	ORDER BY h.order_return_status_history_id`
+
+	rows, err := r.db.Query(sqlGet, orderReturnID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	histories := make([]domain.OrderReturnStatusHistory, 0)
+	for rows.Next() {
+		var history domain.OrderReturnStatusHistory
+		if err := rows.Scan(&history.OrderReturnStatusHistoryID, &history.OrderReturnID, &history.FromStatus, &history.ToStatus, &history.ActorUserID,
+			&history.Note, &history.CreatedAt); err != nil {
+			return nil, err
+		}
+		histories = append(histories, history)
+	}
+
+	return histories, nil
+}
+
+func scanOrderReturns(rows *sql.Rows) ([]domain.OrderReturn, error) {
+	defer rows.Close()
+
+	orderReturns := make([]domain.OrderReturn, 0)
+	for rows.Next() {
+		var orderReturn domain.OrderReturn
+		if err := rows.Scan(&orderReturn.OrderReturnID, &orderReturn.OrderID, &orderReturn.UserID, &orderReturn.Reason, pq.Array(&orderReturn.Photos),
+			&orderReturn.Status, &orderReturn.RefundAmount, &orderReturn.IsRestocked, &orderReturn.CreatedAt, &orderReturn.UpdatedAt); err != nil {
+			return nil, err
+		}
+		orderReturns = append(orderReturns, orderReturn)
+	}
+
+	return orderReturns, nil
+}
+
+// insertOrderReturnItem stores the item only while the ordered quantity, less what other non-rejected returns
+// of the order already claim, still covers it
+func insertOrderReturnItem(tx *sql.Tx, orderID int64, form *domain.OrderReturnItem) error {
+	sqlInsert := `
+	INSERT INTO order_return_items(order_return_id, product_id, quantity, refund_amount)
+	SELECT $1, $2, $3, $4
+	WHERE $3 <= (
+		SELECT op.quantity FROM order_products op WHERE op.order_id = $5 AND op.product_id = $2
+	) - (
+		SELECT COALESCE(SUM(ri.quantity), 0)
+		FROM order_return_items ri
+		INNER JOIN order_returns r ON r.order_return_id = ri.order_return_id
+		WHERE r.order_id = $5
+		AND ri.product_id = $2
+		AND r.status <> $6
+		AND r.order_return_id <> $1
+	)
+	RETURNING order_return_item_id`
+
+	err := tx.QueryRow(sqlInsert, form.OrderReturnID, form.ProductID, form.Quantity, form.RefundAmount, orderID, constants.OrderReturnStatusRejected).Scan(&form.OrderReturnItemID)
+	if err == sql.ErrNoRows {
+		return errOrderReturnQuantityExceeded
+	}
+	return err
+}
+
+// insertShippedOrderReturn opens an approved return of every line of a cancelled shipped order, marking it
+// received with restock puts the items back to stock. The cancellation refunds the order so the return does not
+func insertShippedOrderReturn(tx *sql.Tx, history *domain.OrderStatusHistory) error {
+	sqlInsert := `INSERT INTO order_returns(order_id, user_id, reason, status, refund_amount, created_at, updated_at)
+					  SELECT order_id, user_id, COALESCE($2, ''), $3, 0, $4, $4 FROM orders WHERE order_id = $1
+					  RETURNING order_return_id`
+
+	var orderReturnID int64
+	err := tx.QueryRow(sqlInsert, history.OrderID, history.Note, constants.OrderReturnStatusApproved, history.CreatedAt).Scan(&orderReturnID)
+	if err != nil {
+		return err
+	}
+
+	sqlInsertItem := `INSERT INTO order_return_items(order_return_id, product_id, quantity, refund_amount)
+					  SELECT $1, product_id, quantity, 0 FROM order_products WHERE order_id = $2`
+
+	_, err = tx.Exec(sqlInsertItem, orderReturnID, history.OrderID)
+	if err != nil {
+		return err
+	}
+
+	return insertOrderReturnStatusHistory(tx, &domain.OrderReturnStatusHistory{
+		OrderReturnID: orderReturnID,
+		ToStatus:      constants.OrderReturnStatusApproved,
+		ActorUserID:   history.ActorUserID,
+		Note:          history.Note,
+		CreatedAt:     history.CreatedAt,
+	})
+}
+
+// changeOrderReturnStatus moves the return from the expected status to the new one and records the history, it
+// fails with errOrderReturnStatusChanged when another request changed the status first
+func changeOrderReturnStatus(tx *sql.Tx, form *domain.OrderReturnStatusHistory, restock bool) error {
+	sqlUpdate := `UPDATE order_returns SET status = $2, updated_at = $4`
+	if restock {
+		sqlUpdate += `, is_restocked = TRUE`
+	}
+	sqlUpdate += ` WHERE order_return_id = $1 AND status = $3`
+
+	result, err := tx.Exec(sqlUpdate, form.OrderReturnID, form.ToStatus, form.FromStatus, form.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return errOrderReturnStatusChanged
+	}
+
+	return insertOrderReturnStatusHistory(tx, form)
+}
+
+func insertOrderReturnStatusHistory(tx *sql.Tx, form *domain.OrderReturnStatusHistory) error {
+	sqlInsert := `INSERT INTO order_return_status_histories(order_return_id, from_status, to_status, actor_user_id, note, created_at)
+					  VALUES($1, $2, $3, $4, $5, $6)`
+
+	_, err := tx.Exec(sqlInsert, form.OrderReturnID, form.FromStatus, form.ToStatus, form.ActorUserID, form.Note, form.CreatedAt)
+	return err
+}
+
+// restockOrderReturn puts the received items of the return back to the product stock
+func restockOrderReturn(tx *sql.Tx, history *domain.OrderReturnStatusHistory) error {
+	sqlGet := `
+	SELECT
+		ri.product_id,
+		ri.quantity,
+		r.order_id
+	FROM order_return_items ri
+	INNER JOIN order_returns r ON r.order_return_id = ri.order_return_id
+	INNER JOIN products p ON p.product_id = ri.product_id
+	WHERE ri.order_return_id = $1`
+
+	rows, err := tx.Query(sqlGet, history.OrderReturnID)
+	if err != nil {
+		return err
+	}
+
+	stockMovements := make([]domain.StockMovement, 0)
+	for rows.Next() {
+		var stockMovement domain.StockMovement
+		var orderID int64
+		if err := rows.Scan(&stockMovement.ProductID, &stockMovement.Quantity, &orderID); err != nil {
+			rows.Close()
+			return err
+		}
+		stockMovement.OrderID = &orderID
+		stockMovements = append(stockMovements, stockMovement)
+	}
+	rows.Close()
+
+	for _, stockMovement := range stockMovements {
+		stockMovement.MovementType = constants.StockMovementReturn
+		stockMovement.Reason = history.Note
+		stockMovement.ActorUserID = history.ActorUserID
+		stockMovement.CreatedAt = history.CreatedAt.Format(dbTSLayout)
+		err = applyStockMovement(tx, &stockMovement)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package repo
+
+import (
+	"fmt"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/go-common-packages/logger"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/danisbagus/matchoshop/utils/helper"
+)
+
+// OutboxNotifier writes each email to a .eml file in a local directory instead of sending it, meant for
+// development where the files can be opened with any mail client
+type OutboxNotifier struct {
+	dir  string
+	from mail.Address
+}
+
+func NewOutboxNotifier() port.Notifier {
+	return &OutboxNotifier{
+		dir:  helper.EnvNotifierOutboxDir(),
+		from: mail.Address{Name: helper.EnvMailFromName(), Address: helper.EnvMailFromAddress()},
+	}
+}
+
+func (n OutboxNotifier) Send(message *domain.Notification) *errs.AppError {
+	sentAt := time.Now()
+	email, err := buildEmail(n.from, message, sentAt)
+	if err != nil {
+		logger.Error("Error while build email: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected email error")
+	}
+
+	err = os.MkdirAll(n.dir, 0755)
+	if err != nil {
+		logger.Error("Error while create outbox directory: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected outbox error")
+	}
+
+	fileName := fmt.Sprintf("%s-%s-%d.eml", sentAt.Format("20060102T150405"), message.Template, sentAt.Nanosecond())
+	err = os.WriteFile(filepath.Join(n.dir, fileName), email, 0644)
+	if err != nil {
+		logger.Error("Error while write email to outbox: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected outbox error")
+	}
+
+	logger.Info(fmt.Sprintf("Email %s to %s written to outbox %s", message.Template, message.To, fileName))
+	return nil
+}
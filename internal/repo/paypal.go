@@ -0,0 +1,168 @@
+package repo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/go-common-packages/logger"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/danisbagus/matchoshop/utils/helper"
+)
+
+type PaypalPaymentProvider struct {
+	client       *http.Client
+	baseURL      string
+	clientID     string
+	clientSecret string
+	currency     string
+}
+
+func NewPaypalPaymentProvider() port.PaymentProvider {
+	return &PaypalPaymentProvider{
+		client:       &http.Client{Timeout: 30 * time.Second},
+		baseURL:      helper.EnvPaypalAPIURL(),
+		clientID:     helper.EnvPaypalClientID(),
+		clientSecret: helper.EnvPaypalClientSecret(),
+		currency:     helper.EnvPaymentCurrency(),
+	}
+}
+
+// Refund refunds the capture of the PayPal order stored as the payment result. The refund id is sent as the
+// request id so a retried refund is not paid out twice
+func (p PaypalPaymentProvider) Refund(form *domain.Refund) (string, *errs.AppError) {
+	if p.clientID == "" || p.clientSecret == "" {
+		return "", errs.NewUnexpectedError("paypal credential not found")
+	}
+
+	if form.PaymentResultID == nil {
+		return "", errs.NewBadRequestError("Refund has no payment to refund")
+	}
+
+	accessToken, err := p.getAccessToken()
+	if err != nil {
+		logger.Error("Error while get paypal access token: " + err.Error())
+		return "", errs.NewUnexpectedError("Unexpected payment provider error")
+	}
+
+	captureID, err := p.getCaptureID(accessToken, *form.PaymentResultID)
+	if err != nil {
+		logger.Error("Error while get paypal capture: " + err.Error())
+		return "", errs.NewUnexpectedError("Unexpected payment provider error")
+	}
+
+	reqBody := map[string]interface{}{
+		"amount": map[string]string{
+			"value":         strconv.FormatInt(form.Amount, 10),
+			"currency_code": p.currency,
+		},
+	}
+	if form.Reason != nil {
+		reqBody["note_to_payer"] = *form.Reason
+	}
+
+	var resBody struct {
+		ID     string `json:"id"`
+		Status string `json:"status"`
+	}
+	headers := map[string]string{"PayPal-Request-Id": fmt.Sprintf("matchoshop-refund-%d", form.RefundID)}
+	err = p.do(http.MethodPost, "/v2/payments/captures/"+captureID+"/refund", accessToken, headers, reqBody, &resBody)
+	if err != nil {
+		logger.Error("Error while refund paypal capture: " + err.Error())
+		return "", errs.NewUnexpectedError("Unexpected payment provider error")
+	}
+
+	if resBody.Status != "COMPLETED" && resBody.Status != "PENDING" {
+		return "", errs.NewBadRequestError("Payment provider refused the refund with status " + resBody.Status)
+	}
+
+	return resBody.ID, nil
+}
+
+func (p PaypalPaymentProvider) getAccessToken() (string, error) {
+	req, err := http.NewRequest(http.MethodPost, p.baseURL+"/v1/oauth2/token", strings.NewReader(url.Values{"grant_type": {"client_credentials"}}.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.SetBasicAuth(p.clientID, p.clientSecret)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var resBody struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := p.send(req, &resBody); err != nil {
+		return "", err
+	}
+	return resBody.AccessToken, nil
+}
+
+// getCaptureID looks up the capture of a PayPal order, the checkout stores the order id as the payment result
+func (p PaypalPaymentProvider) getCaptureID(accessToken, paypalOrderID string) (string, error) {
+	var resBody struct {
+		PurchaseUnits []struct {
+			Payments struct {
+				Captures []struct {
+					ID string `json:"id"`
+				} `json:"captures"`
+			} `json:"payments"`
+		} `json:"purchase_units"`
+	}
+	err := p.do(http.MethodGet, "/v2/checkout/orders/"+url.PathEscape(paypalOrderID), accessToken, nil, nil, &resBody)
+	if err != nil {
+		return "", err
+	}
+
+	for _, purchaseUnit := range resBody.PurchaseUnits {
+		for _, capture := range purchaseUnit.Payments.Captures {
+			return capture.ID, nil
+		}
+	}
+	return "", fmt.Errorf("paypal order %s has no capture", paypalOrderID)
+}
+
+func (p PaypalPaymentProvider) do(method, path, accessToken string, headers map[string]string, reqBody, resBody interface{}) error {
+	var body bytes.Buffer
+	if reqBody != nil {
+		if err := json.NewEncoder(&body).Encode(reqBody); err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequest(method, p.baseURL+path, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	return p.send(req, resBody)
+}
+
+func (p PaypalPaymentProvider) send(req *http.Request, resBody interface{}) error {
+	res, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= http.StatusMultipleChoices {
+		var errBody struct {
+			Name    string `json:"name"`
+			Message string `json:"message"`
+		}
+		json.NewDecoder(res.Body).Decode(&errBody)
+		return fmt.Errorf("paypal responded %d %s: %s", res.StatusCode, errBody.Name, errBody.Message)
+	}
+
+	return json.NewDecoder(res.Body).Decode(resBody)
+}
@@ -10,7 +10,9 @@ import (
 	"github.com/danisbagus/go-common-packages/logger"
 	"github.com/danisbagus/matchoshop/internal/core/domain"
 	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/danisbagus/matchoshop/utils/constants"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 type ProductRepo struct {
@@ -31,12 +33,13 @@ func (r ProductRepo) Insert(data *domain.Product) (*domain.Product, *errs.AppErr
 		return nil, errs.NewUnexpectedError("Unexpected database error")
 	}
 
-	sqlInsert := `INSERT INTO products(name, sku, brand, image, description, price, stock, created_at, updated_at) 
-					  VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	sqlInsert := `INSERT INTO products(name, sku, slug, brand_id, image, description, meta_title, meta_description, price, compare_at_price, stock, low_stock_threshold, is_published, tax_class, weight, created_at, updated_at) 
+					  VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
 					  RETURNING product_id`
 
+	// stock only enters through the ledger, the product starts empty and the initial stock is recorded as a restock
 	var productID int64
-	err = tx.QueryRow(sqlInsert, data.Name, data.Sku, data.Brand, data.Image, data.Description, data.Price, data.Stock, data.CreatedAt, data.UpdatedAt).Scan(&productID)
+	err = tx.QueryRow(sqlInsert, data.Name, data.Sku, data.Slug, data.BrandID, data.Image, data.Description, data.MetaTitle, data.MetaDescription, data.Price, data.CompareAtPrice, 0, data.LowStockThreshold, data.IsPublished, data.TaxClass, data.Weight, data.CreatedAt, data.UpdatedAt).Scan(&productID)
 
 	if err != nil {
 		tx.Rollback()
@@ -44,6 +47,30 @@ func (r ProductRepo) Insert(data *domain.Product) (*domain.Product, *errs.AppErr
 		return nil, errs.NewUnexpectedError("Unexpected database error")
 	}
 
+	err = r.insertPriceHistory(tx, productID, data, data.CreatedAt)
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while insert product price history: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	if data.Stock > 0 {
+		reason := "Initial stock"
+		err = applyStockMovement(tx, &domain.StockMovement{
+			ProductID:    productID,
+			MovementType: constants.StockMovementRestock,
+			Quantity:     data.Stock,
+			Reason:       &reason,
+			ActorUserID:  &data.ActorUserID,
+			CreatedAt:    data.CreatedAt,
+		})
+		if err != nil {
+			tx.Rollback()
+			logger.Error("Error while insert initial stock movement: " + err.Error())
+			return nil, errs.NewUnexpectedError("Unexpected database error")
+		}
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		tx.Rollback()
@@ -105,22 +132,47 @@ func (r ProductRepo) CheckByIDAndSKU(productID int64, sku string) (bool, *errs.A
 	return totalData > 0, nil
 }
 
+func (r ProductRepo) CheckByIDAndSlug(productID int64, slug string) (bool, *errs.AppError) {
+
+	sqlCountProduct := `SELECT COUNT(product_Id) 
+	FROM products 
+	WHERE product_id != $1
+	AND slug = $2`
+
+	var totalData int64
+	err := r.db.QueryRow(sqlCountProduct, productID, slug).Scan(&totalData)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error("Error while count product from database: " + err.Error())
+		return false, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return totalData > 0, nil
+}
+
 func (r ProductRepo) GetAll(criteria *domain.ProductListCriteria) ([]domain.ProductList, *errs.AppError) {
 	sort := getSortProduct(criteria.Sort, criteria.Order)
 
+	publishedCondition := ""
+	if criteria.PublishedOnly {
+		publishedCondition = "WHERE p.is_published = TRUE"
+	}
+
 	sqlGetProduct := fmt.Sprintf(`
 	SELECT 
 		p.product_id, 
 		p.name, 
 		p.sku, 
-		p.brand, 
+		p.slug, 
+		p.brand_id, 
+		b.name AS brand, 
 		p.image, 
-		p.price, 
+		%s,
 		pc.product_category_id,
 		pc.name as product_category_name,
 		COALESCE(r.numb_reviews, 0) AS numb_reviews,
 		COALESCE(r.rating, 0) AS rating
 	FROM products p
+	LEFT JOIN brands b ON b.brand_id = p.brand_id
 	JOIN product_product_categories ppc ON ppc.product_id = p.product_id
 	JOIN product_categories pc ON pc.product_category_id = ppc.product_category_id
 	LEFT JOIN (
@@ -131,10 +183,12 @@ func (r ProductRepo) GetAll(criteria *domain.ProductListCriteria) ([]domain.Prod
 		FROM reviews 
 		GROUP BY product_id
 	) r ON r.product_id = p.product_id
+	%s
+	%s
 	ORDER BY %s
-	LIMIT $1`, sort)
+	LIMIT $1`, effectivePriceColumns, effectivePriceJoin(2), publishedCondition, sort)
 
-	rows, err := r.db.Query(sqlGetProduct, criteria.Limit)
+	rows, err := r.db.Query(sqlGetProduct, criteria.Limit, time.Now())
 
 	if err != nil && err != sql.ErrNoRows {
 		logger.Error("Error while get all product from database: " + err.Error())
@@ -146,7 +200,7 @@ func (r ProductRepo) GetAll(criteria *domain.ProductListCriteria) ([]domain.Prod
 	products := make([]domain.ProductList, 0)
 	for rows.Next() {
 		var product domain.ProductList
-		if err := rows.Scan(&product.ProductID, &product.Name, &product.Sku, &product.Brand, &product.Image, &product.Price, &product.ProductCategoryID,
+		if err := rows.Scan(&product.ProductID, &product.Name, &product.Sku, &product.Slug, &product.BrandID, &product.Brand, &product.Image, &product.Price, &product.CompareAtPrice, &product.ProductCategoryID,
 			&product.ProductCategoryName, &product.NumbReviews, &product.Rating); err != nil {
 			logger.Error("Error while scanning product category from database: " + err.Error())
 			return nil, errs.NewUnexpectedError("Unexpected database error")
@@ -164,31 +218,34 @@ func (r ProductRepo) GetAllPaginate(criteria *domain.ProductListCriteria) ([]dom
 		offset = (criteria.Page - 1) * criteria.Limit
 	}
 
-	searchName := fmt.Sprintf("%%%s%%", criteria.Keyword)
+	condition, args := productListCondition(criteria)
 
-	sqlCountProduct := `
+	sqlCountProduct := fmt.Sprintf(`
 	SELECT 
 		COUNT(p.product_id)
 	FROM products p
-	WHERE p.name ILIKE $1`
+	WHERE %s`, condition)
 
-	err := r.db.QueryRow(sqlCountProduct, searchName).Scan(&totalData)
+	err := r.db.QueryRow(sqlCountProduct, args...).Scan(&totalData)
 	if err != nil && err != sql.ErrNoRows {
 		logger.Error("Error while count all product from database: " + err.Error())
 		return nil, 0, errs.NewUnexpectedError("Unexpected database error")
 	}
 
-	sqlGetProduct := `
+	sqlGetProduct := fmt.Sprintf(`
 	SELECT 
 		p.product_id, 
 		p.name, 
 		p.sku, 
-		p.brand, 
+		p.slug, 
+		p.brand_id, 
+		b.name AS brand, 
 		p.image, 
-		p.price, 
+		%s,
 		COALESCE(r.numb_reviews, 0) AS numb_reviews,
 		COALESCE(r.rating, 0) AS rating
 	FROM products p
+	LEFT JOIN brands b ON b.brand_id = p.brand_id
 	LEFT JOIN (
 		SELECT 
 			product_id,
@@ -197,12 +254,14 @@ func (r ProductRepo) GetAllPaginate(criteria *domain.ProductListCriteria) ([]dom
 		FROM reviews 
 		GROUP BY product_id
 	) r ON r.product_id = p.product_id
-	WHERE p.name ILIKE $1
+	%s
+	WHERE %s
 	ORDER BY p.product_id ASC
-	LIMIT $2
-	OFFSET $3`
+	LIMIT $%d
+	OFFSET $%d`, effectivePriceColumns, effectivePriceJoin(len(args)+3), condition, len(args)+1, len(args)+2)
 
-	rows, err := r.db.Query(sqlGetProduct, searchName, criteria.Limit, offset)
+	args = append(args, criteria.Limit, offset, time.Now())
+	rows, err := r.db.Query(sqlGetProduct, args...)
 	if err != nil && err != sql.ErrNoRows {
 		logger.Error("Error while get all product from database: " + err.Error())
 		return nil, 0, errs.NewUnexpectedError("Unexpected database error")
@@ -214,7 +273,7 @@ func (r ProductRepo) GetAllPaginate(criteria *domain.ProductListCriteria) ([]dom
 
 	for rows.Next() {
 		var product domain.ProductList
-		if err := rows.Scan(&product.ProductID, &product.Name, &product.Sku, &product.Brand, &product.Image, &product.Price, &product.NumbReviews, &product.Rating); err != nil {
+		if err := rows.Scan(&product.ProductID, &product.Name, &product.Sku, &product.Slug, &product.BrandID, &product.Brand, &product.Image, &product.Price, &product.CompareAtPrice, &product.NumbReviews, &product.Rating); err != nil {
 			logger.Error("Error while scanning get product from database: " + err.Error())
 			return nil, 0, errs.NewUnexpectedError("Unexpected database error")
 		}
@@ -224,26 +283,201 @@ func (r ProductRepo) GetAllPaginate(criteria *domain.ProductListCriteria) ([]dom
 	return products, totalData, nil
 }
 
+// GetAllByIDs returns the products with their effective price, stock and rating
+func (r ProductRepo) GetAllByIDs(productIDs []int64) ([]domain.ProductList, *errs.AppError) {
+	sqlGetProduct := fmt.Sprintf(`
+	SELECT 
+		p.product_id, 
+		p.name, 
+		p.sku, 
+		p.slug, 
+		p.brand_id, 
+		b.name AS brand, 
+		p.image, 
+		%s,
+		p.stock,
+		p.is_published,
+		COALESCE(r.numb_reviews, 0) AS numb_reviews,
+		COALESCE(r.rating, 0) AS rating
+	FROM products p
+	LEFT JOIN brands b ON b.brand_id = p.brand_id
+	LEFT JOIN (
+		SELECT 
+			product_id,
+			COUNT(review_id) AS numb_reviews, 
+			AVG(rating)  AS rating
+		FROM reviews 
+		GROUP BY product_id
+	) r ON r.product_id = p.product_id
+	%s
+	WHERE p.product_id = ANY($1)
+	ORDER BY p.product_id ASC`, effectivePriceColumns, effectivePriceJoin(2))
+
+	rows, err := r.db.Query(sqlGetProduct, pq.Array(productIDs), time.Now())
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error("Error while get product by ids from database: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	defer rows.Close()
+
+	products := make([]domain.ProductList, 0)
+	for rows.Next() {
+		var product domain.ProductList
+		if err := rows.Scan(&product.ProductID, &product.Name, &product.Sku, &product.Slug, &product.BrandID, &product.Brand, &product.Image, &product.Price, &product.CompareAtPrice,
+			&product.Stock, &product.IsPublished, &product.NumbReviews, &product.Rating); err != nil {
+			logger.Error("Error while scanning product from database: " + err.Error())
+			return nil, errs.NewUnexpectedError("Unexpected database error")
+		}
+		products = append(products, product)
+	}
+
+	return products, nil
+}
+
+// productListCondition builds the WHERE clause of the paginated product list with its positional args
+func productListCondition(criteria *domain.ProductListCriteria) (string, []interface{}) {
+	args := []interface{}{fmt.Sprintf("%%%s%%", criteria.Keyword)}
+	conditions := []string{"p.name ILIKE $1"}
+
+	if criteria.PublishedOnly {
+		conditions = append(conditions, "p.is_published = TRUE")
+	}
+
+	if criteria.BrandID != 0 {
+		args = append(args, criteria.BrandID)
+		conditions = append(conditions, fmt.Sprintf("p.brand_id = $%d", len(args)))
+	}
+
+	if len(criteria.ProductCategoryIDs) > 0 {
+		args = append(args, pq.Array(criteria.ProductCategoryIDs))
+		conditions = append(conditions, fmt.Sprintf("EXISTS (SELECT 1 FROM product_product_categories ppc WHERE ppc.product_id = p.product_id AND ppc.product_category_id = ANY($%d))", len(args)))
+	}
+
+	for _, filter := range criteria.AttributeFilters {
+		args = append(args, filter.AttributeDefinitionID)
+		attributeCondition := fmt.Sprintf("pav.attribute_definition_id = $%d", len(args))
+
+		if len(filter.Values) > 0 {
+			args = append(args, pq.Array(filter.Values))
+			attributeCondition += fmt.Sprintf(" AND pav.value = ANY($%d)", len(args))
+		}
+		if filter.Min != nil {
+			args = append(args, *filter.Min)
+			attributeCondition += fmt.Sprintf(" AND pav.value_number >= $%d", len(args))
+		}
+		if filter.Max != nil {
+			args = append(args, *filter.Max)
+			attributeCondition += fmt.Sprintf(" AND pav.value_number <= $%d", len(args))
+		}
+
+		conditions = append(conditions, fmt.Sprintf("EXISTS (SELECT 1 FROM product_attribute_values pav WHERE pav.product_id = p.product_id AND %s)", attributeCondition))
+	}
+
+	return strings.Join(conditions, "\n\tAND "), args
+}
+
+func (r ProductRepo) GetAllLowStockPaginate(criteria *domain.ProductListCriteria) ([]domain.ProductModel, int64, *errs.AppError) {
+	var totalData int64
+	var offset int64
+	if criteria.Page > 0 {
+		offset = (criteria.Page - 1) * criteria.Limit
+	}
+
+	sqlCountProduct := `
+	SELECT 
+		COUNT(p.product_id)
+	FROM products p
+	WHERE p.low_stock_threshold > 0
+	AND p.stock <= p.low_stock_threshold`
+
+	err := r.db.QueryRow(sqlCountProduct).Scan(&totalData)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error("Error while count low stock product from database: " + err.Error())
+		return nil, 0, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	sqlGetProduct := `
+	SELECT 
+		p.product_id, 
+		p.name, 
+		p.sku, 
+		p.slug, 
+		p.stock,
+		p.low_stock_threshold
+	FROM products p
+	WHERE p.low_stock_threshold > 0
+	AND p.stock <= p.low_stock_threshold
+	ORDER BY p.stock ASC, p.product_id ASC
+	LIMIT $1
+	OFFSET $2`
+
+	rows, err := r.db.Query(sqlGetProduct, criteria.Limit, offset)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error("Error while get low stock product from database: " + err.Error())
+		return nil, 0, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	defer rows.Close()
+
+	products := make([]domain.ProductModel, 0)
+	for rows.Next() {
+		var product domain.ProductModel
+		if err := rows.Scan(&product.ProductID, &product.Name, &product.Sku, &product.Slug, &product.Stock, &product.LowStockThreshold); err != nil {
+			logger.Error("Error while scanning low stock product from database: " + err.Error())
+			return nil, 0, errs.NewUnexpectedError("Unexpected database error")
+		}
+		products = append(products, product)
+	}
+
+	return products, totalData, nil
+}
+
 func (r ProductRepo) GetOneByID(productID int64) (*domain.ProductDetail, *errs.AppError) {
+	return r.getOne("p.product_id = $1", productID)
+}
+
+// GetOnePublishedByID is the storefront read of GetOneByID, an unpublished product is not found
+func (r ProductRepo) GetOnePublishedByID(productID int64) (*domain.ProductDetail, *errs.AppError) {
+	return r.getOne("p.product_id = $1 AND p.is_published = TRUE", productID)
+}
+
+// GetOneBySlug serves the storefront only, an unpublished product is not found
+func (r ProductRepo) GetOneBySlug(slug string) (*domain.ProductDetail, *errs.AppError) {
+	return r.getOne("p.slug = $1 AND p.is_published = TRUE", slug)
+}
+
+func (r ProductRepo) getOne(condition string, arg interface{}) (*domain.ProductDetail, *errs.AppError) {
 
 	var product domain.ProductDetail
 
-	sqlGetProduct := `
+	sqlGetProduct := fmt.Sprintf(`
 	SELECT 
 		p.product_id, 
 		p.name, 
 		p.sku, 
-		p.brand, 
+		p.slug, 
+		p.brand_id, 
+		b.name AS brand, 
 		p.image, 
-		p.price, 
+		%s,
+		p.price AS regular_price,
 		p.description,
-		p.stock
+		p.meta_title,
+		p.meta_description,
+		p.stock,
+		p.low_stock_threshold,
+		p.is_published,
+		p.tax_class,
+		p.weight
 	FROM products p
-	WHERE p.product_id = $1
-	LIMIT 1`
+	LEFT JOIN brands b ON b.brand_id = p.brand_id
+	%s
+	WHERE %s
+	LIMIT 1`, effectivePriceColumns, effectivePriceJoin(2), condition)
 
-	err := r.db.QueryRow(sqlGetProduct, productID).Scan(&product.ProductID, &product.Name, &product.Sku, &product.Brand, &product.Image, &product.Price,
-		&product.Description, &product.Stock)
+	err := r.db.QueryRow(sqlGetProduct, arg, time.Now()).Scan(&product.ProductID, &product.Name, &product.Sku, &product.Slug, &product.BrandID, &product.Brand, &product.Image, &product.Price, &product.CompareAtPrice, &product.RegularPrice,
+		&product.Description, &product.MetaTitle, &product.MetaDescription, &product.Stock, &product.LowStockThreshold, &product.IsPublished, &product.TaxClass, &product.Weight)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, errs.NewNotFoundError("Product not found!")
@@ -264,55 +498,50 @@ func (r ProductRepo) Update(productID int64, data *domain.Product) *errs.AppErro
 		return errs.NewUnexpectedError("Unexpected database error")
 	}
 
-	sqlUpdate := `
-	UPDATE products 
-	SET name = $2, 
-		sku = $3,
-		brand = $4,
-		image = $5,
-		price = $6,
-		description = $7,
-		stock = $8,
-		updated_at = $9
-	WHERE product_id = $1`
-
-	_, err = tx.Exec(sqlUpdate, productID, data.Name, data.Sku, data.Brand, data.Image, data.Price, data.Description, data.Stock, data.UpdatedAt)
+	var currentPrice int64
+	var currentCompareAtPrice *int64
+	err = tx.QueryRow(`SELECT price, compare_at_price FROM products WHERE product_id = $1 FOR UPDATE`, productID).Scan(&currentPrice, &currentCompareAtPrice)
 	if err != nil {
 		tx.Rollback()
-		logger.Error("Error while update product: " + err.Error())
-		return errs.NewUnexpectedError("Unexpected database error")
-	}
-
-	err = tx.Commit()
-	if err != nil {
-		tx.Rollback()
-		logger.Error("Error while commiting transaction: " + err.Error())
-		return errs.NewUnexpectedError("Unexpected database error")
-	}
-
-	return nil
-}
-
-func (r ProductRepo) UpdateStock(productID, quantity int64) *errs.AppError {
-	tx, err := r.db.Begin()
-	if err != nil {
-		logger.Error("Error when starting update stock: " + err.Error())
+		logger.Error("Error while get product price: " + err.Error())
 		return errs.NewUnexpectedError("Unexpected database error")
 	}
 
 	sqlUpdate := `
 	UPDATE products 
-	SET stock = stock - $2,
-		updated_at = $3
+	SET name = $2, 
+		sku = $3,
+		slug = $4,
+		brand_id = $5,
+		image = $6,
+		price = $7,
+		compare_at_price = $8,
+		description = $9,
+		meta_title = $10,
+		meta_description = $11,
+		low_stock_threshold = $12,
+		is_published = $13,
+		tax_class = $14,
+		weight = $15,
+		updated_at = $16
 	WHERE product_id = $1`
 
-	_, err = tx.Exec(sqlUpdate, productID, quantity, time.Now())
+	_, err = tx.Exec(sqlUpdate, productID, data.Name, data.Sku, data.Slug, data.BrandID, data.Image, data.Price, data.CompareAtPrice, data.Description, data.MetaTitle, data.MetaDescription, data.LowStockThreshold, data.IsPublished, data.TaxClass, data.Weight, data.UpdatedAt)
 	if err != nil {
 		tx.Rollback()
 		logger.Error("Error while update product: " + err.Error())
 		return errs.NewUnexpectedError("Unexpected database error")
 	}
 
+	if currentPrice != data.Price || !equalInt64Pointer(currentCompareAtPrice, data.CompareAtPrice) {
+		err = r.insertPriceHistory(tx, productID, data, data.UpdatedAt)
+		if err != nil {
+			tx.Rollback()
+			logger.Error("Error while insert product price history: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		tx.Rollback()
@@ -352,6 +581,25 @@ func (r ProductRepo) Delete(productID int64) *errs.AppError {
 	return nil
 }
 
+func (r ProductRepo) insertPriceHistory(tx *sql.Tx, productID int64, data *domain.Product, createdAt string) error {
+
+	sqlInsert := `INSERT INTO product_price_histories(product_id, price, compare_at_price, actor_user_id, created_at) 
+					  VALUES($1, $2, $3, $4, $5)`
+
+	_, err := tx.Exec(sqlInsert, productID, data.Price, data.CompareAtPrice, data.ActorUserID, createdAt)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func equalInt64Pointer(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
 func getSortProduct(sort string, order string) string {
 	var sortResult, orderResult string
 	sort = strings.ToLower(sort)
@@ -373,3 +621,25 @@ func getSortProduct(sort string, order string) string {
 
 	return fmt.Sprintf("%s %s", sortResult, orderResult)
 }
+
+// effectivePriceColumns selects the price a product sells for, with the regular price
+// shown as the compare at price while a scheduled price is active.
+const effectivePriceColumns = `COALESCE(sp.price, p.price) AS price,
+		CASE WHEN sp.price IS NULL THEN p.compare_at_price ELSE COALESCE(sp.compare_at_price, p.price) END AS compare_at_price`
+
+// effectivePriceJoin joins the scheduled price of product p active at the time bound
+// to the given parameter number.
+func effectivePriceJoin(timeParam int) string {
+	return fmt.Sprintf(`LEFT JOIN LATERAL (
+		SELECT 
+			pps.price,
+			pps.compare_at_price
+		FROM product_price_schedules pps
+		WHERE pps.product_id = p.product_id
+		AND pps.cancelled_at IS NULL
+		AND pps.starts_at <= $%d
+		AND (pps.ends_at IS NULL OR pps.ends_at > $%d)
+		ORDER BY pps.starts_at DESC, pps.product_price_schedule_id DESC
+		LIMIT 1
+	) sp ON TRUE`, timeParam, timeParam)
+}
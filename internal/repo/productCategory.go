@@ -28,12 +28,12 @@ func (r ProductCategoryRepo) Insert(data *domain.ProductCategory) (*domain.Produ
 		return nil, errs.NewUnexpectedError("Unexpected database error")
 	}
 
-	sqlInsert := `INSERT INTO product_categories(name, created_at, updated_at) 
-					  VALUES($1, $2, $3)
+	sqlInsert := `INSERT INTO product_categories(parent_id, name, slug, meta_title, meta_description, created_at, updated_at) 
+					  VALUES($1, $2, $3, $4, $5, $6, $7)
 					  RETURNING product_category_id`
 
 	var productCategoryID int64
-	err = tx.QueryRow(sqlInsert, data.Name, data.CreatedAt, data.UpdatedAt).Scan(&productCategoryID)
+	err = tx.QueryRow(sqlInsert, data.ParentID, data.Name, data.Slug, data.MetaTitle, data.MetaDescription, data.CreatedAt, data.UpdatedAt).Scan(&productCategoryID)
 
 	if err != nil {
 		tx.Rollback()
@@ -102,12 +102,31 @@ func (r ProductCategoryRepo) CheckByID(productCategoryID int64) (bool, *errs.App
 	return totalData > 0, nil
 }
 
+func (r ProductCategoryRepo) CheckByIDAndSlug(productCategoryID int64, slug string) (bool, *errs.AppError) {
+
+	sqlCountProductCategory := `SELECT COUNT(product_category_Id) 
+	FROM product_categories 
+	WHERE product_category_Id != $1
+	AND slug = $2`
+
+	var totalData int64
+	err := r.db.QueryRow(sqlCountProductCategory, productCategoryID, slug).Scan(&totalData)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error("Error while count product category from database: " + err.Error())
+		return false, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return totalData > 0, nil
+}
+
 func (r ProductCategoryRepo) GetAll() ([]domain.ProductCategory, *errs.AppError) {
 
 	sqlGetProductCategory := `
 	SELECT 
 		product_category_id, 
-		name
+		parent_id,
+		name,
+		slug
 	FROM product_categories
 	ORDER BY name ASC`
 
@@ -123,7 +142,7 @@ func (r ProductCategoryRepo) GetAll() ([]domain.ProductCategory, *errs.AppError)
 
 	for rows.Next() {
 		var productCategory domain.ProductCategory
-		if err := rows.Scan(&productCategory.ProductCategoryID, &productCategory.Name); err != nil {
+		if err := rows.Scan(&productCategory.ProductCategoryID, &productCategory.ParentID, &productCategory.Name, &productCategory.Slug); err != nil {
 			logger.Error("Error while scanning product category from database: " + err.Error())
 			return nil, errs.NewUnexpectedError("Unexpected database error")
 		}
@@ -139,7 +158,9 @@ func (r ProductCategoryRepo) GetAllByProductID(productID int64) ([]domain.Produc
 	sqlGetProductCategory := `
 	SELECT 
 		pc.product_category_id, 
-		pc.name
+		pc.parent_id,
+		pc.name,
+		pc.slug
 	FROM product_categories pc
 	JOIN product_product_categories ppc ON ppc.product_category_id = pc.product_category_id
 	WHERE ppc.product_id = $1`
@@ -156,7 +177,51 @@ func (r ProductCategoryRepo) GetAllByProductID(productID int64) ([]domain.Produc
 
 	for rows.Next() {
 		var productCategory domain.ProductCategory
-		if err := rows.Scan(&productCategory.ProductCategoryID, &productCategory.Name); err != nil {
+		if err := rows.Scan(&productCategory.ProductCategoryID, &productCategory.ParentID, &productCategory.Name, &productCategory.Slug); err != nil {
+			logger.Error("Error while scanning product category from database: " + err.Error())
+			return nil, errs.NewUnexpectedError("Unexpected database error")
+		}
+
+		productCategories = append(productCategories, productCategory)
+	}
+
+	return productCategories, nil
+}
+
+// GetAllAncestors returns the path from the root category down to the given category
+func (r ProductCategoryRepo) GetAllAncestors(productCategoryID int64) ([]domain.ProductCategory, *errs.AppError) {
+
+	sqlGetProductCategory := `
+	WITH RECURSIVE ancestors AS (
+		SELECT product_category_id, parent_id, name, slug, 0 AS depth
+		FROM product_categories
+		WHERE product_category_id = $1
+		UNION ALL
+		SELECT pc.product_category_id, pc.parent_id, pc.name, pc.slug, a.depth + 1
+		FROM product_categories pc
+		JOIN ancestors a ON pc.product_category_id = a.parent_id
+	)
+	SELECT 
+		product_category_id, 
+		parent_id,
+		name,
+		slug
+	FROM ancestors
+	ORDER BY depth DESC`
+
+	rows, err := r.db.Query(sqlGetProductCategory, productCategoryID)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error("Error while get product category ancestors from database: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	defer rows.Close()
+
+	productCategories := make([]domain.ProductCategory, 0)
+
+	for rows.Next() {
+		var productCategory domain.ProductCategory
+		if err := rows.Scan(&productCategory.ProductCategoryID, &productCategory.ParentID, &productCategory.Name, &productCategory.Slug); err != nil {
 			logger.Error("Error while scanning product category from database: " + err.Error())
 			return nil, errs.NewUnexpectedError("Unexpected database error")
 		}
@@ -167,6 +232,76 @@ func (r ProductCategoryRepo) GetAllByProductID(productID int64) ([]domain.Produc
 	return productCategories, nil
 }
 
+// GetAllDescendantIDs returns the given category id with the ids of all categories below it
+func (r ProductCategoryRepo) GetAllDescendantIDs(productCategoryID int64) ([]int64, *errs.AppError) {
+
+	sqlGetProductCategory := `
+	WITH RECURSIVE descendants AS (
+		SELECT product_category_id
+		FROM product_categories
+		WHERE product_category_id = $1
+		UNION ALL
+		SELECT pc.product_category_id
+		FROM product_categories pc
+		JOIN descendants d ON pc.parent_id = d.product_category_id
+	)
+	SELECT product_category_id FROM descendants`
+
+	rows, err := r.db.Query(sqlGetProductCategory, productCategoryID)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error("Error while get product category descendants from database: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	defer rows.Close()
+
+	productCategoryIDs := make([]int64, 0)
+
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			logger.Error("Error while scanning product category from database: " + err.Error())
+			return nil, errs.NewUnexpectedError("Unexpected database error")
+		}
+
+		productCategoryIDs = append(productCategoryIDs, id)
+	}
+
+	return productCategoryIDs, nil
+}
+
+func (r ProductCategoryRepo) CheckChildByID(productCategoryID int64) (bool, *errs.AppError) {
+
+	sqlCountProductCategory := `SELECT COUNT(product_category_id) 
+	FROM product_categories 
+	WHERE parent_id = $1`
+
+	var totalData int64
+	err := r.db.QueryRow(sqlCountProductCategory, productCategoryID).Scan(&totalData)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error("Error while count child product category from database: " + err.Error())
+		return false, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return totalData > 0, nil
+}
+
+func (r ProductCategoryRepo) CheckProductByID(productCategoryID int64) (bool, *errs.AppError) {
+
+	sqlCountProduct := `SELECT COUNT(product_id) 
+	FROM product_product_categories 
+	WHERE product_category_id = $1`
+
+	var totalData int64
+	err := r.db.QueryRow(sqlCountProduct, productCategoryID).Scan(&totalData)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error("Error while count product of product category from database: " + err.Error())
+		return false, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return totalData > 0, nil
+}
+
 func (r ProductCategoryRepo) GetOneByID(productCategoryID int64) (*domain.ProductCategory, *errs.AppError) {
 
 	var productCategory domain.ProductCategory
@@ -174,12 +309,48 @@ func (r ProductCategoryRepo) GetOneByID(productCategoryID int64) (*domain.Produc
 	sqlGetProductCategory := `
 	SELECT 
 		product_category_id, 
-		name
+		parent_id,
+		name,
+		slug,
+		meta_title,
+		meta_description
 	FROM product_categories
 	WHERE product_category_id = $1 
 	LIMIT 1`
 
-	err := r.db.QueryRow(sqlGetProductCategory, productCategoryID).Scan(&productCategory.ProductCategoryID, &productCategory.Name)
+	err := r.db.QueryRow(sqlGetProductCategory, productCategoryID).Scan(&productCategory.ProductCategoryID, &productCategory.ParentID, &productCategory.Name, &productCategory.Slug,
+		&productCategory.MetaTitle, &productCategory.MetaDescription)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errs.NewNotFoundError("Product category not found!")
+		} else {
+			logger.Error("Error while get product category from database: " + err.Error())
+			return nil, errs.NewUnexpectedError("Unexpected database error")
+		}
+	}
+
+	return &productCategory, nil
+
+}
+
+func (r ProductCategoryRepo) GetOneBySlug(slug string) (*domain.ProductCategory, *errs.AppError) {
+
+	var productCategory domain.ProductCategory
+
+	sqlGetProductCategory := `
+	SELECT 
+		product_category_id, 
+		parent_id,
+		name,
+		slug,
+		meta_title,
+		meta_description
+	FROM product_categories
+	WHERE slug = $1 
+	LIMIT 1`
+
+	err := r.db.QueryRow(sqlGetProductCategory, slug).Scan(&productCategory.ProductCategoryID, &productCategory.ParentID, &productCategory.Name, &productCategory.Slug,
+		&productCategory.MetaTitle, &productCategory.MetaDescription)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, errs.NewNotFoundError("Product category not found!")
@@ -203,10 +374,10 @@ func (r ProductCategoryRepo) Update(productCategoryID int64, data *domain.Produc
 
 	sqlUpdate := `
 	UPDATE product_categories 
-	SET name = $2, created_at = $3, updated_at = $4
+	SET parent_id = $2, name = $3, slug = $4, meta_title = $5, meta_description = $6, created_at = $7, updated_at = $8
 	WHERE product_category_id = $1`
 
-	_, err = tx.Exec(sqlUpdate, productCategoryID, data.Name, data.CreatedAt, data.UpdatedAt)
+	_, err = tx.Exec(sqlUpdate, productCategoryID, data.ParentID, data.Name, data.Slug, data.MetaTitle, data.MetaDescription, data.CreatedAt, data.UpdatedAt)
 	if err != nil {
 		tx.Rollback()
 		logger.Error("Error while update product category: " + err.Error())
@@ -223,6 +394,47 @@ func (r ProductCategoryRepo) Update(productCategoryID int64, data *domain.Produc
 	return nil
 }
 
+// Reassign moves the child categories, products and attribute scopes of a category to another category
+func (r ProductCategoryRepo) Reassign(productCategoryID int64, targetProductCategoryID int64) *errs.AppError {
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		logger.Error("Error when starting reassign product category: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	sqlReassigns := []string{
+		`UPDATE product_categories SET parent_id = $2 WHERE parent_id = $1`,
+		`INSERT INTO product_product_categories (product_id, product_category_id)
+		SELECT ppc.product_id, $2 FROM product_product_categories ppc
+		WHERE ppc.product_category_id = $1
+		AND NOT EXISTS (SELECT 1 FROM product_product_categories x WHERE x.product_id = ppc.product_id AND x.product_category_id = $2)`,
+		`DELETE FROM product_product_categories WHERE product_category_id = $1`,
+		`INSERT INTO attribute_definition_categories (attribute_definition_id, product_category_id)
+		SELECT attribute_definition_id, $2 FROM attribute_definition_categories WHERE product_category_id = $1
+		ON CONFLICT DO NOTHING`,
+		`DELETE FROM attribute_definition_categories WHERE product_category_id = $1`,
+	}
+
+	for _, sqlReassign := range sqlReassigns {
+		_, err = tx.Exec(sqlReassign, productCategoryID, targetProductCategoryID)
+		if err != nil {
+			tx.Rollback()
+			logger.Error("Error while reassign product category: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while commiting transaction: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return nil
+}
+
 func (r ProductCategoryRepo) Delete(productCategoryID int64) *errs.AppError {
 
 	tx, err := r.db.Begin()
@@ -231,6 +443,13 @@ func (r ProductCategoryRepo) Delete(productCategoryID int64) *errs.AppError {
 		return errs.NewUnexpectedError("Unexpected database error")
 	}
 
+	_, err = tx.Exec(`DELETE FROM attribute_definition_categories WHERE product_category_id = $1`, productCategoryID)
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while delete attribute scope of product category: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
 	sqlDelete := `
 	DELETE FROM product_categories 
 	WHERE product_category_id = $1`
@@ -0,0 +1,218 @@
+package repo
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/go-common-packages/logger"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/jmoiron/sqlx"
+)
+
+type ProductPriceRepo struct {
+	db *sqlx.DB
+}
+
+func NewProductPriceRepo(db *sqlx.DB) port.ProductPriceRepo {
+	return &ProductPriceRepo{
+		db: db,
+	}
+}
+
+func (r ProductPriceRepo) InsertSchedule(form *domain.ProductPriceSchedule) *errs.AppError {
+
+	sqlInsert := `INSERT INTO product_price_schedules(product_id, price, compare_at_price, starts_at, ends_at, actor_user_id, created_at) 
+					  VALUES($1, $2, $3, $4, $5, $6, $7)
+					  RETURNING product_price_schedule_id`
+
+	err := r.db.QueryRow(sqlInsert, form.ProductID, form.Price, form.CompareAtPrice, form.StartsAt, form.EndsAt, form.ActorUserID, form.CreatedAt).Scan(&form.ProductPriceScheduleID)
+	if err != nil {
+		logger.Error("Error while insert product price schedule: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return nil
+}
+
+func (r ProductPriceRepo) GetAllScheduleByProductID(productID int64) ([]domain.ProductPriceSchedule, *errs.AppError) {
+	sqlGet := `
+	SELECT 
+		product_price_schedule_id,
+		product_id,
+		price,
+		compare_at_price,
+		starts_at,
+		ends_at,
+		actor_user_id,
+		cancelled_at,
+		created_at
+	FROM product_price_schedules
+	WHERE product_id = $1
+	ORDER BY starts_at DESC, product_price_schedule_id DESC`
+
+	rows, err := r.db.Query(sqlGet, productID)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error("Error while get all product price schedule from database: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	defer rows.Close()
+
+	schedules := make([]domain.ProductPriceSchedule, 0)
+	for rows.Next() {
+		var schedule domain.ProductPriceSchedule
+		if err := rows.Scan(&schedule.ProductPriceScheduleID, &schedule.ProductID, &schedule.Price, &schedule.CompareAtPrice, &schedule.StartsAt, &schedule.EndsAt,
+			&schedule.ActorUserID, &schedule.CancelledAt, &schedule.CreatedAt); err != nil {
+			logger.Error("Error while scanning product price schedule from database: " + err.Error())
+			return nil, errs.NewUnexpectedError("Unexpected database error")
+		}
+		schedules = append(schedules, schedule)
+	}
+
+	return schedules, nil
+}
+
+func (r ProductPriceRepo) GetOneScheduleByID(productPriceScheduleID int64) (*domain.ProductPriceSchedule, *errs.AppError) {
+	sqlGet := `
+	SELECT 
+		product_price_schedule_id,
+		product_id,
+		price,
+		compare_at_price,
+		starts_at,
+		ends_at,
+		actor_user_id,
+		cancelled_at,
+		created_at
+	FROM product_price_schedules
+	WHERE product_price_schedule_id = $1`
+
+	var schedule domain.ProductPriceSchedule
+	err := r.db.QueryRow(sqlGet, productPriceScheduleID).Scan(&schedule.ProductPriceScheduleID, &schedule.ProductID, &schedule.Price, &schedule.CompareAtPrice, &schedule.StartsAt,
+		&schedule.EndsAt, &schedule.ActorUserID, &schedule.CancelledAt, &schedule.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errs.NewNotFoundError("Product price schedule not found!")
+		} else {
+			logger.Error("Error while get product price schedule from database: " + err.Error())
+			return nil, errs.NewUnexpectedError("Unexpected database error")
+		}
+	}
+
+	return &schedule, nil
+}
+
+func (r ProductPriceRepo) CancelSchedule(productPriceScheduleID int64, cancelledAt time.Time) *errs.AppError {
+
+	sqlUpdate := `
+	UPDATE product_price_schedules 
+	SET cancelled_at = $2
+	WHERE product_price_schedule_id = $1`
+
+	_, err := r.db.Exec(sqlUpdate, productPriceScheduleID, cancelledAt)
+	if err != nil {
+		logger.Error("Error while cancel product price schedule: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return nil
+}
+
+func (r ProductPriceRepo) GetAllHistoryByProductID(productID int64) ([]domain.ProductPriceHistory, *errs.AppError) {
+	sqlGet := `
+	SELECT 
+		pph.product_price_history_id,
+		pph.product_id,
+		pph.price,
+		pph.compare_at_price,
+		pph.actor_user_id,
+		u.name AS actor_name,
+		pph.created_at
+	FROM product_price_histories pph
+	LEFT JOIN users u ON u.user_id = pph.actor_user_id
+	WHERE pph.product_id = $1
+	ORDER BY pph.created_at DESC, pph.product_price_history_id DESC`
+
+	rows, err := r.db.Query(sqlGet, productID)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error("Error while get all product price history from database: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	defer rows.Close()
+
+	histories := make([]domain.ProductPriceHistory, 0)
+	for rows.Next() {
+		var history domain.ProductPriceHistory
+		if err := rows.Scan(&history.ProductPriceHistoryID, &history.ProductID, &history.Price, &history.CompareAtPrice, &history.ActorUserID,
+			&history.ActorName, &history.CreatedAt); err != nil {
+			logger.Error("Error while scanning product price history from database: " + err.Error())
+			return nil, errs.NewUnexpectedError("Unexpected database error")
+		}
+		histories = append(histories, history)
+	}
+
+	return histories, nil
+}
+
+func (r ProductPriceRepo) GetOneByProductIDAndTime(productID int64, at time.Time) (*domain.ProductPrice, *errs.AppError) {
+	price := domain.ProductPrice{ProductID: productID, At: at}
+
+	// regular price in effect at that time
+	sqlGetHistory := `
+	SELECT 
+		price,
+		compare_at_price
+	FROM product_price_histories
+	WHERE product_id = $1
+	AND created_at <= $2
+	ORDER BY created_at DESC, product_price_history_id DESC
+	LIMIT 1`
+
+	err := r.db.QueryRow(sqlGetHistory, productID, at).Scan(&price.Price, &price.CompareAtPrice)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errs.NewNotFoundError("No price recorded at the given time")
+		} else {
+			logger.Error("Error while get product price history from database: " + err.Error())
+			return nil, errs.NewUnexpectedError("Unexpected database error")
+		}
+	}
+
+	// a schedule running at that time overrides it, even if it was cancelled later
+	sqlGetSchedule := `
+	SELECT 
+		product_price_schedule_id,
+		price,
+		compare_at_price
+	FROM product_price_schedules
+	WHERE product_id = $1
+	AND starts_at <= $2
+	AND (ends_at IS NULL OR ends_at > $2)
+	AND (cancelled_at IS NULL OR cancelled_at > $2)
+	ORDER BY starts_at DESC, product_price_schedule_id DESC
+	LIMIT 1`
+
+	var scheduleID int64
+	var schedulePrice int64
+	var scheduleCompareAtPrice *int64
+	err = r.db.QueryRow(sqlGetSchedule, productID, at).Scan(&scheduleID, &schedulePrice, &scheduleCompareAtPrice)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error("Error while get product price schedule from database: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	if err == nil {
+		regularPrice := price.Price
+		price.ProductPriceScheduleID = &scheduleID
+		price.Price = schedulePrice
+		price.CompareAtPrice = scheduleCompareAtPrice
+		if price.CompareAtPrice == nil {
+			price.CompareAtPrice = &regularPrice
+		}
+	}
+
+	return &price, nil
+}
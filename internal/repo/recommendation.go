@@ -0,0 +1,171 @@
+package repo
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/go-common-packages/logger"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/danisbagus/matchoshop/utils/constants"
+	"github.com/jmoiron/sqlx"
+)
+
+type RecommendationRepo struct {
+	db *sqlx.DB
+}
+
+func NewRecommendationRepo(db *sqlx.DB) port.RecommendationRepo {
+	return &RecommendationRepo{
+		db: db,
+	}
+}
+
+func (r RecommendationRepo) RecomputeCoPurchases(now time.Time) *errs.AppError {
+	tx, err := r.db.Begin()
+	if err != nil {
+		logger.Error("Error when starting recompute co purchase: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	_, err = tx.Exec(`DELETE FROM product_co_purchases`)
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while delete co purchase: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	sqlInsert := `
+	INSERT INTO product_co_purchases(product_id, related_product_id, purchase_count, updated_at)
+	SELECT
+		a.product_id,
+		b.product_id,
+		COUNT(DISTINCT a.order_id),
+		$1
+	FROM order_products a
+	INNER JOIN order_products b ON b.order_id = a.order_id AND b.product_id <> a.product_id
+	INNER JOIN orders o ON o.order_id = a.order_id
+	WHERE o.is_paid = 1
+	AND o.status NOT IN ($2, $3)
+	GROUP BY a.product_id, b.product_id`
+
+	_, err = tx.Exec(sqlInsert, now, constants.OrderStatusCancelled, constants.OrderStatusRefunded)
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while insert co purchase: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while commiting transaction: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return nil
+}
+
+func (r RecommendationRepo) GetAllCoPurchased(productID, limit int64) ([]domain.ProductModel, *errs.AppError) {
+	sqlGet := fmt.Sprintf(`
+	SELECT 
+		p.product_id, 
+		p.name, 
+		p.sku, 
+		p.slug, 
+		p.brand_id, 
+		b.name AS brand, 
+		p.image, 
+		%s
+	FROM product_co_purchases cp
+	INNER JOIN products p ON p.product_id = cp.related_product_id
+	LEFT JOIN brands b ON b.brand_id = p.brand_id
+	%s
+	WHERE cp.product_id = $1
+	AND p.stock > 0
+	AND p.is_published = TRUE
+	ORDER BY cp.purchase_count DESC, p.product_id ASC
+	LIMIT $2`, effectivePriceColumns, effectivePriceJoin(3))
+
+	return r.getAll("co purchased", sqlGet, productID, limit, time.Now())
+}
+
+func (r RecommendationRepo) GetAllSameCategory(productID, limit int64) ([]domain.ProductModel, *errs.AppError) {
+	sqlGet := fmt.Sprintf(`
+	SELECT 
+		p.product_id, 
+		p.name, 
+		p.sku, 
+		p.slug, 
+		p.brand_id, 
+		b.name AS brand, 
+		p.image, 
+		%s
+	FROM products p
+	LEFT JOIN brands b ON b.brand_id = p.brand_id
+	INNER JOIN (
+		SELECT 
+			ppc.product_id,
+			COUNT(ppc.product_category_id) AS shared_categories
+		FROM product_product_categories ppc
+		INNER JOIN product_product_categories cur ON cur.product_category_id = ppc.product_category_id
+		WHERE cur.product_id = $1
+		GROUP BY ppc.product_id
+	) c ON c.product_id = p.product_id
+	%s
+	WHERE p.product_id <> $1
+	AND p.stock > 0
+	AND p.is_published = TRUE
+	ORDER BY c.shared_categories DESC, p.product_id DESC
+	LIMIT $2`, effectivePriceColumns, effectivePriceJoin(3))
+
+	return r.getAll("same category", sqlGet, productID, limit, time.Now())
+}
+
+func (r RecommendationRepo) GetAllByPriceRange(productID, minPrice, maxPrice, limit int64) ([]domain.ProductModel, *errs.AppError) {
+	sqlGet := fmt.Sprintf(`
+	SELECT 
+		p.product_id, 
+		p.name, 
+		p.sku, 
+		p.slug, 
+		p.brand_id, 
+		b.name AS brand, 
+		p.image, 
+		%s
+	FROM products p
+	LEFT JOIN brands b ON b.brand_id = p.brand_id
+	%s
+	WHERE p.product_id <> $1
+	AND COALESCE(sp.price, p.price) BETWEEN $2 AND $3
+	AND p.stock > 0
+	AND p.is_published = TRUE
+	ORDER BY ABS(COALESCE(sp.price, p.price) - ($2 + $3) / 2) ASC, p.product_id ASC
+	LIMIT $4`, effectivePriceColumns, effectivePriceJoin(5))
+
+	return r.getAll("similar price", sqlGet, productID, minPrice, maxPrice, limit, time.Now())
+}
+
+func (r RecommendationRepo) getAll(kind string, sqlGet string, args ...interface{}) ([]domain.ProductModel, *errs.AppError) {
+	rows, err := r.db.Query(sqlGet, args...)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error("Error while get " + kind + " product from database: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	defer rows.Close()
+
+	products := make([]domain.ProductModel, 0)
+	for rows.Next() {
+		var product domain.ProductModel
+		if err := rows.Scan(&product.ProductID, &product.Name, &product.Sku, &product.Slug, &product.BrandID, &product.Brand, &product.Image, &product.Price, &product.CompareAtPrice); err != nil {
+			logger.Error("Error while scanning " + kind + " product from database: " + err.Error())
+			return nil, errs.NewUnexpectedError("Unexpected database error")
+		}
+		products = append(products, product)
+	}
+
+	return products, nil
+}
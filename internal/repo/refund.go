@@ -0,0 +1,384 @@
+package repo
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/go-common-packages/logger"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/danisbagus/matchoshop/utils/constants"
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	errRefundExceeded      = errors.New("refund exceeds the refundable amount")
+	errRefundStatusChanged = errors.New("refund status has changed")
+)
+
+type RefundRepo struct {
+	db *sqlx.DB
+}
+
+func NewRefundRepo(db *sqlx.DB) port.RefundRepo {
+	return &RefundRepo{
+		db: db,
+	}
+}
+
+func (r RefundRepo) Insert(form *domain.Refund) (int64, *errs.AppError) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		logger.Error("Error when starting insert refund: " + err.Error())
+		return 0, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	err = insertRefund(tx, form, false)
+	if err != nil {
+		tx.Rollback()
+		if err == errRefundExceeded {
+			return 0, errs.NewBadRequestError("Refund exceeds the refundable amount of the order")
+		}
+		logger.Error("Error while insert refund: " + err.Error())
+		return 0, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while commiting transaction: " + err.Error())
+		return 0, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return form.RefundID, nil
+}
+
+func (r RefundRepo) GetAllByOrderID(orderID int64) ([]domain.Refund, *errs.AppError) {
+	sqlGet := `
+	SELECT
+		rf.refund_id,
+		rf.order_id,
+		rf.order_return_id,
+		rf.payment_result_id,
+		rf.amount,
+		rf.reason,
+		rf.status,
+		rf.requested_by,
+		rf.provider_refund_id,
+		rf.failure_reason,
+		rf.processed_by,
+		rf.processed_at,
+		rf.created_at,
+		rf.updated_at
+	FROM refunds rf
+	WHERE rf.order_id = $1
+	ORDER BY rf.refund_id`
+
+	rows, err := r.db.Query(sqlGet, orderID)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error("Error while get all refund by order id from database: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	defer rows.Close()
+
+	refunds := make([]domain.Refund, 0)
+	for rows.Next() {
+		var refund domain.Refund
+		if err := rows.Scan(&refund.RefundID, &refund.OrderID, &refund.OrderReturnID, &refund.PaymentResultID, &refund.Amount, &refund.Reason, &refund.Status,
+			&refund.RequestedBy, &refund.ProviderRefundID, &refund.FailureReason, &refund.ProcessedBy, &refund.ProcessedAt, &refund.CreatedAt, &refund.UpdatedAt); err != nil {
+			logger.Error("Error while scanning refund from database: " + err.Error())
+			return nil, errs.NewUnexpectedError("Unexpected database error")
+		}
+		refunds = append(refunds, refund)
+	}
+	rows.Close()
+
+	for i := range refunds {
+		refunds[i].Items, err = r.getAllItem(refunds[i].RefundID)
+		if err != nil {
+			logger.Error("Error while get refund item from database: " + err.Error())
+			return nil, errs.NewUnexpectedError("Unexpected database error")
+		}
+	}
+
+	return refunds, nil
+}
+
+func (r RefundRepo) GetOneByID(ID int64) (*domain.Refund, *errs.AppError) {
+	sqlGet := `
+	SELECT
+		rf.refund_id,
+		rf.order_id,
+		rf.order_return_id,
+		rf.payment_result_id,
+		rf.amount,
+		rf.reason,
+		rf.status,
+		rf.requested_by,
+		rf.provider_refund_id,
+		rf.failure_reason,
+		rf.processed_by,
+		rf.processed_at,
+		rf.created_at,
+		rf.updated_at
+	FROM refunds rf
+	WHERE rf.refund_id = $1
+	LIMIT 1`
+
+	var refund domain.Refund
+	err := r.db.QueryRow(sqlGet, ID).Scan(&refund.RefundID, &refund.OrderID, &refund.OrderReturnID, &refund.PaymentResultID, &refund.Amount, &refund.Reason, &refund.Status,
+		&refund.RequestedBy, &refund.ProviderRefundID, &refund.FailureReason, &refund.ProcessedBy, &refund.ProcessedAt, &refund.CreatedAt, &refund.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errs.NewNotFoundError("Refund not found!")
+		}
+		logger.Error("Error while get refund from database: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	refund.Items, err = r.getAllItem(refund.RefundID)
+	if err != nil {
+		logger.Error("Error while get refund item from database: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return &refund, nil
+}
+
+func (r RefundRepo) GetRefundedQuantityByOrderID(orderID int64) (map[int64]int64, *errs.AppError) {
+	sqlGet := `
+	SELECT
+		ri.product_id,
+		SUM(ri.quantity)
+	FROM refund_items ri
+	INNER JOIN refunds rf ON rf.refund_id = ri.refund_id
+	WHERE rf.order_id = $1
+	AND rf.status <> $2
+	GROUP BY ri.product_id`
+
+	rows, err := r.db.Query(sqlGet, orderID, constants.RefundStatusRejected)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error("Error while get refunded quantity from database: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	defer rows.Close()
+
+	quantities := make(map[int64]int64)
+	for rows.Next() {
+		var productID, quantity int64
+		if err := rows.Scan(&productID, &quantity); err != nil {
+			logger.Error("Error while scanning refunded quantity from database: " + err.Error())
+			return nil, errs.NewUnexpectedError("Unexpected database error")
+		}
+		quantities[productID] = quantity
+	}
+
+	return quantities, nil
+}
+
+// Complete records the provider refund and adds it to the refunded price of the order. The order moves to
+// refunded with the given history, a status changed meanwhile is left alone as the money is already returned
+func (r RefundRepo) Complete(form *domain.Refund, history *domain.OrderStatusHistory) *errs.AppError {
+	tx, err := r.db.Begin()
+	if err != nil {
+		logger.Error("Error when starting complete refund: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	err = changeRefundStatus(tx, form)
+	if err != nil {
+		tx.Rollback()
+		if err == errRefundStatusChanged {
+			return errs.NewBadRequestError("Refund status has changed, please try again")
+		}
+		logger.Error("Error while update refund status: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	_, err = tx.Exec(`UPDATE orders SET refunded_price = refunded_price + $2, updated_at = $3 WHERE order_id = $1`, form.OrderID, form.Amount, form.ProcessedAt)
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while update order refunded price: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	if history != nil {
+		err = changeOrderStatus(tx, history)
+		if err != nil && err != errOrderStatusChanged {
+			tx.Rollback()
+			logger.Error("Error while update order status: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while commiting transaction: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return nil
+}
+
+func (r RefundRepo) UpdateStatus(form *domain.Refund) *errs.AppError {
+	tx, err := r.db.Begin()
+	if err != nil {
+		logger.Error("Error when starting update refund status: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	err = changeRefundStatus(tx, form)
+	if err != nil {
+		tx.Rollback()
+		if err == errRefundStatusChanged {
+			return errs.NewBadRequestError("Refund status has changed, please try again")
+		}
+		logger.Error("Error while update refund status: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while commiting transaction: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return nil
+}
+
+func (r RefundRepo) getAllItem(refundID int64) ([]domain.RefundItem, error) {
+	sqlGet := `
+	SELECT
+		ri.refund_item_id,
+		ri.refund_id,
+		ri.product_id,
+		ri.quantity,
+		ri.amount
+	FROM refund_items ri
+	WHERE ri.refund_id = $1
+	ORDER BY ri.refund_item_id`
+
+	rows, err := r.db.Query(sqlGet, refundID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	items := make([]domain.RefundItem, 0)
+	for rows.Next() {
+		var item domain.RefundItem
+		if err := rows.Scan(&item.RefundItemID, &item.RefundID, &item.ProductID, &item.Quantity, &item.Amount); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// insertRefund stores the refund against the completed payment of the order. Refunds not yet rejected may
+// not add up to more than the order total, with capAmount the refund is lowered to what is left instead of
+// failing with errRefundExceeded, which is still returned when nothing is left at all
+func insertRefund(tx *sql.Tx, form *domain.Refund, capAmount bool) error {
+	var refundable int64
+	sqlGet := `
+	SELECT
+		o.total_price - COALESCE((SELECT SUM(rf.amount) FROM refunds rf WHERE rf.order_id = o.order_id AND rf.status <> $2), 0)
+	FROM orders o
+	WHERE o.order_id = $1
+	FOR UPDATE`
+
+	err := tx.QueryRow(sqlGet, form.OrderID, constants.RefundStatusRejected).Scan(&refundable)
+	if err != nil {
+		return err
+	}
+
+	if refundable <= 0 || (form.Amount > refundable && !capAmount) {
+		return errRefundExceeded
+	}
+
+	if form.Amount > refundable {
+		form.Amount = refundable
+	}
+
+	sqlInsert := `INSERT INTO refunds(order_id, order_return_id, payment_result_id, amount, reason, status, requested_by, created_at, updated_at)
+					  VALUES($1, $2, (SELECT payment_result_id FROM payment_results WHERE order_id = $1 AND status = $3 LIMIT 1), $4, $5, $6, $7, $8, $8)
+					  RETURNING refund_id, payment_result_id`
+
+	err = tx.QueryRow(sqlInsert, form.OrderID, form.OrderReturnID, constants.PaymentStatusCompleted, form.Amount, form.Reason, form.Status, form.RequestedBy, form.CreatedAt).Scan(&form.RefundID, &form.PaymentResultID)
+	if err != nil {
+		return err
+	}
+
+	for i := range form.Items {
+		form.Items[i].RefundID = form.RefundID
+		err = insertRefundItem(tx, form.OrderID, &form.Items[i])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// insertRefundItem stores the item only while the ordered quantity, less what other refunds not yet rejected
+// already cover, still covers it
+func insertRefundItem(tx *sql.Tx, orderID int64, form *domain.RefundItem) error {
+	sqlInsert := `
+	INSERT INTO refund_items(refund_id, product_id, quantity, amount)
+	SELECT $1, $2, $3, $4
+	WHERE $3 <= (
+		SELECT op.quantity FROM order_products op WHERE op.order_id = $5 AND op.product_id = $2
+	) - (
+		SELECT COALESCE(SUM(ri.quantity), 0)
+		FROM refund_items ri
+		INNER JOIN refunds rf ON rf.refund_id = ri.refund_id
+		WHERE rf.order_id = $5
+		AND ri.product_id = $2
+		AND rf.status <> $6
+		AND rf.refund_id <> $1
+	)
+	RETURNING refund_item_id`
+
+	err := tx.QueryRow(sqlInsert, form.RefundID, form.ProductID, form.Quantity, form.Amount, orderID, constants.RefundStatusRejected).Scan(&form.RefundItemID)
+	if err == sql.ErrNoRows {
+		return errRefundExceeded
+	}
+	return err
+}
+
+// changeRefundStatus settles a requested or failed refund, it fails with errRefundStatusChanged when another
+// request settled it first
+func changeRefundStatus(tx *sql.Tx, form *domain.Refund) error {
+	sqlUpdate := `
+	UPDATE refunds
+	SET status = $2,
+		provider_refund_id = $3,
+		failure_reason = $4,
+		processed_by = $5,
+		processed_at = $6,
+		updated_at = $6
+	WHERE refund_id = $1
+	AND status IN ($7, $8)`
+
+	result, err := tx.Exec(sqlUpdate, form.RefundID, form.Status, form.ProviderRefundID, form.FailureReason, form.ProcessedBy, form.ProcessedAt,
+		constants.RefundStatusRequested, constants.RefundStatusFailed)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return errRefundStatusChanged
+	}
+	return nil
+}
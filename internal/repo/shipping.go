@@ -0,0 +1,335 @@
+package repo
+
+import (
+	"database/sql"
+
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/go-common-packages/logger"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type ShippingRepo struct {
+	db *sqlx.DB
+}
+
+func NewShippingRepo(db *sqlx.DB) port.ShippingRepo {
+	return &ShippingRepo{
+		db: db,
+	}
+}
+
+func (r ShippingRepo) InsertZone(form *domain.ShippingZone) *errs.AppError {
+	sqlInsert := `INSERT INTO shipping_zones(name, countries, postal_code_patterns, created_at, updated_at)
+					  VALUES($1, $2, $3, $4, $5)
+					  RETURNING shipping_zone_id`
+
+	err := r.db.QueryRow(sqlInsert, form.Name, pq.Array(form.Countries), pq.Array(form.PostalCodePatterns), form.CreatedAt, form.UpdatedAt).Scan(&form.ShippingZoneID)
+	if err != nil {
+		logger.Error("Error while insert shipping zone: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return nil
+}
+
+func (r ShippingRepo) CheckAnyZone() (bool, *errs.AppError) {
+	var exists bool
+	err := r.db.QueryRow(`SELECT EXISTS (SELECT 1 FROM shipping_zones)`).Scan(&exists)
+	if err != nil {
+		logger.Error("Error while check shipping zone from database: " + err.Error())
+		return false, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return exists, nil
+}
+
+func (r ShippingRepo) GetAllZone() ([]domain.ShippingZone, *errs.AppError) {
+	return r.getAllZone("TRUE")
+}
+
+// GetAllZoneByCountry returns the zones covering the country together with their methods
+func (r ShippingRepo) GetAllZoneByCountry(country string) ([]domain.ShippingZone, *errs.AppError) {
+	return r.getAllZone("EXISTS (SELECT 1 FROM unnest(sz.countries) c WHERE lower(c) = lower($1))", country)
+}
+
+func (r ShippingRepo) GetOneZoneByID(shippingZoneID int64) (*domain.ShippingZone, *errs.AppError) {
+	zones, appErr := r.getAllZone("sz.shipping_zone_id = $1", shippingZoneID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if len(zones) == 0 {
+		return nil, errs.NewNotFoundError("Shipping zone not found!")
+	}
+
+	return &zones[0], nil
+}
+
+func (r ShippingRepo) UpdateZone(form *domain.ShippingZone) *errs.AppError {
+	sqlUpdate := `
+	UPDATE shipping_zones
+	SET name = $2, countries = $3, postal_code_patterns = $4, updated_at = $5
+	WHERE shipping_zone_id = $1`
+
+	_, err := r.db.Exec(sqlUpdate, form.ShippingZoneID, form.Name, pq.Array(form.Countries), pq.Array(form.PostalCodePatterns), form.UpdatedAt)
+	if err != nil {
+		logger.Error("Error while update shipping zone: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return nil
+}
+
+func (r ShippingRepo) DeleteZone(shippingZoneID int64) *errs.AppError {
+	_, err := r.db.Exec(`DELETE FROM shipping_zones WHERE shipping_zone_id = $1`, shippingZoneID)
+	if err != nil {
+		logger.Error("Error while delete shipping zone: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return nil
+}
+
+func (r ShippingRepo) InsertMethod(form *domain.ShippingMethod) *errs.AppError {
+	tx, err := r.db.Begin()
+	if err != nil {
+		logger.Error("Error when starting insert shipping method: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	sqlInsert := `INSERT INTO shipping_methods(shipping_zone_id, name, rate_type, rate, weight_unit, min_subtotal, is_active, created_at, updated_at)
+					  VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9)
+					  RETURNING shipping_method_id`
+
+	err = tx.QueryRow(sqlInsert, form.ShippingZoneID, form.Name, form.RateType, form.Rate, form.WeightUnit, form.MinSubtotal, form.IsActive, form.CreatedAt, form.UpdatedAt).Scan(&form.ShippingMethodID)
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while insert shipping method: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	err = r.insertRateTiers(tx, form.ShippingMethodID, form.Tiers)
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while insert shipping rate tier: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while commiting transaction: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return nil
+}
+
+func (r ShippingRepo) GetOneMethodByID(shippingMethodID int64) (*domain.ShippingMethod, *errs.AppError) {
+	methods, appErr := r.getAllMethod("sm.shipping_method_id = $1", shippingMethodID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if len(methods) == 0 {
+		return nil, errs.NewNotFoundError("Shipping method not found!")
+	}
+
+	return &methods[0], nil
+}
+
+// UpdateMethod updates the method and replaces its rate tiers
+func (r ShippingRepo) UpdateMethod(form *domain.ShippingMethod) *errs.AppError {
+	tx, err := r.db.Begin()
+	if err != nil {
+		logger.Error("Error when starting update shipping method: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	sqlUpdate := `
+	UPDATE shipping_methods
+	SET name = $2, rate_type = $3, rate = $4, weight_unit = $5, min_subtotal = $6, is_active = $7, updated_at = $8
+	WHERE shipping_method_id = $1`
+
+	_, err = tx.Exec(sqlUpdate, form.ShippingMethodID, form.Name, form.RateType, form.Rate, form.WeightUnit, form.MinSubtotal, form.IsActive, form.UpdatedAt)
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while update shipping method: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	_, err = tx.Exec(`DELETE FROM shipping_rate_tiers WHERE shipping_method_id = $1`, form.ShippingMethodID)
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while delete shipping rate tier: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	err = r.insertRateTiers(tx, form.ShippingMethodID, form.Tiers)
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while insert shipping rate tier: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while commiting transaction: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return nil
+}
+
+func (r ShippingRepo) DeleteMethod(shippingMethodID int64) *errs.AppError {
+	_, err := r.db.Exec(`DELETE FROM shipping_methods WHERE shipping_method_id = $1`, shippingMethodID)
+	if err != nil {
+		logger.Error("Error while delete shipping method: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return nil
+}
+
+func (r ShippingRepo) insertRateTiers(tx *sql.Tx, shippingMethodID int64, tiers []domain.ShippingRateTier) error {
+	for _, tier := range tiers {
+		_, err := tx.Exec(`INSERT INTO shipping_rate_tiers(shipping_method_id, min_subtotal, price) VALUES($1, $2, $3)`, shippingMethodID, tier.MinSubtotal, tier.Price)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r ShippingRepo) getAllZone(condition string, args ...interface{}) ([]domain.ShippingZone, *errs.AppError) {
+	sqlGetZone := `
+	SELECT
+		sz.shipping_zone_id,
+		sz.name,
+		sz.countries,
+		sz.postal_code_patterns,
+		sz.created_at,
+		sz.updated_at
+	FROM shipping_zones sz
+	WHERE ` + condition + `
+	ORDER BY sz.name ASC`
+
+	rows, err := r.db.Query(sqlGetZone, args...)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error("Error while get all shipping zone from database: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	defer rows.Close()
+
+	zones := make([]domain.ShippingZone, 0)
+	zoneIDs := make([]int64, 0)
+	for rows.Next() {
+		var zone domain.ShippingZone
+		if err := rows.Scan(&zone.ShippingZoneID, &zone.Name, pq.Array(&zone.Countries), pq.Array(&zone.PostalCodePatterns), &zone.CreatedAt, &zone.UpdatedAt); err != nil {
+			logger.Error("Error while scanning shipping zone from database: " + err.Error())
+			return nil, errs.NewUnexpectedError("Unexpected database error")
+		}
+		zone.Methods = make([]domain.ShippingMethod, 0)
+		zones = append(zones, zone)
+		zoneIDs = append(zoneIDs, zone.ShippingZoneID)
+	}
+
+	if len(zones) == 0 {
+		return zones, nil
+	}
+
+	methods, appErr := r.getAllMethod("sm.shipping_zone_id = ANY($1)", pq.Array(zoneIDs))
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	for i := range zones {
+		for _, method := range methods {
+			if method.ShippingZoneID == zones[i].ShippingZoneID {
+				zones[i].Methods = append(zones[i].Methods, method)
+			}
+		}
+	}
+
+	return zones, nil
+}
+
+func (r ShippingRepo) getAllMethod(condition string, args ...interface{}) ([]domain.ShippingMethod, *errs.AppError) {
+	sqlGetMethod := `
+	SELECT
+		sm.shipping_method_id,
+		sm.shipping_zone_id,
+		sm.name,
+		sm.rate_type,
+		sm.rate,
+		sm.weight_unit,
+		sm.min_subtotal,
+		sm.is_active,
+		sm.created_at,
+		sm.updated_at
+	FROM shipping_methods sm
+	WHERE ` + condition + `
+	ORDER BY sm.shipping_method_id ASC`
+
+	rows, err := r.db.Query(sqlGetMethod, args...)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error("Error while get all shipping method from database: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	defer rows.Close()
+
+	methods := make([]domain.ShippingMethod, 0)
+	methodIDs := make([]int64, 0)
+	for rows.Next() {
+		var method domain.ShippingMethod
+		if err := rows.Scan(&method.ShippingMethodID, &method.ShippingZoneID, &method.Name, &method.RateType, &method.Rate, &method.WeightUnit, &method.MinSubtotal,
+			&method.IsActive, &method.CreatedAt, &method.UpdatedAt); err != nil {
+			logger.Error("Error while scanning shipping method from database: " + err.Error())
+			return nil, errs.NewUnexpectedError("Unexpected database error")
+		}
+		method.Tiers = make([]domain.ShippingRateTier, 0)
+		methods = append(methods, method)
+		methodIDs = append(methodIDs, method.ShippingMethodID)
+	}
+
+	if len(methods) == 0 {
+		return methods, nil
+	}
+
+	sqlGetTier := `
+	SELECT shipping_method_id, min_subtotal, price
+	FROM shipping_rate_tiers
+	WHERE shipping_method_id = ANY($1)
+	ORDER BY min_subtotal ASC`
+
+	tierRows, err := r.db.Query(sqlGetTier, pq.Array(methodIDs))
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error("Error while get all shipping rate tier from database: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	defer tierRows.Close()
+
+	for tierRows.Next() {
+		var shippingMethodID int64
+		var tier domain.ShippingRateTier
+		if err := tierRows.Scan(&shippingMethodID, &tier.MinSubtotal, &tier.Price); err != nil {
+			logger.Error("Error while scanning shipping rate tier from database: " + err.Error())
+			return nil, errs.NewUnexpectedError("Unexpected database error")
+		}
+
+		for i := range methods {
+			if methods[i].ShippingMethodID == shippingMethodID {
+				methods[i].Tiers = append(methods[i].Tiers, tier)
+			}
+		}
+	}
+
+	return methods, nil
+}
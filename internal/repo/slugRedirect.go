@@ -0,0 +1,60 @@
+package repo
+
+import (
+	"database/sql"
+
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/go-common-packages/logger"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/jmoiron/sqlx"
+)
+
+type SlugRedirectRepo struct {
+	db *sqlx.DB
+}
+
+func NewSlugRedirectRepo(db *sqlx.DB) port.SlugRedirectRepo {
+	return &SlugRedirectRepo{
+		db: db,
+	}
+}
+
+func (r SlugRedirectRepo) Upsert(data *domain.SlugRedirect) *errs.AppError {
+
+	sqlUpsert := `INSERT INTO slug_redirects(entity_type, old_slug, entity_id, created_at)
+		VALUES($1, $2, $3, $4)
+		ON CONFLICT (entity_type, old_slug)
+		DO UPDATE SET entity_id = EXCLUDED.entity_id, created_at = EXCLUDED.created_at`
+
+	_, err := r.db.Exec(sqlUpsert, data.EntityType, data.OldSlug, data.EntityID, data.CreatedAt)
+	if err != nil {
+		logger.Error("Error while upsert slug redirect: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return nil
+}
+
+func (r SlugRedirectRepo) GetOneBySlug(entityType, slug string) (*domain.SlugRedirect, *errs.AppError) {
+
+	sqlGet := `
+	SELECT
+		slug_redirect_id,
+		entity_type,
+		old_slug,
+		entity_id
+	FROM slug_redirects
+	WHERE entity_type = $1
+	AND old_slug = $2
+	LIMIT 1`
+
+	var slugRedirect domain.SlugRedirect
+	err := r.db.QueryRow(sqlGet, entityType, slug).Scan(&slugRedirect.SlugRedirectID, &slugRedirect.EntityType, &slugRedirect.OldSlug, &slugRedirect.EntityID)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error("Error while get slug redirect from database: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return &slugRedirect, nil
+}
@@ -0,0 +1,60 @@
+package repo
+
+import (
+	"errors"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"time"
+
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/go-common-packages/logger"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/danisbagus/matchoshop/utils/helper"
+)
+
+type SMTPNotifier struct {
+	addr string
+	auth smtp.Auth
+	from mail.Address
+}
+
+func NewSMTPNotifier() port.Notifier {
+	host := helper.EnvSMTPHost()
+
+	var auth smtp.Auth
+	if username := helper.EnvSMTPUsername(); username != "" {
+		auth = smtp.PlainAuth("", username, helper.EnvSMTPPassword(), host)
+	}
+
+	return &SMTPNotifier{
+		addr: net.JoinHostPort(host, helper.EnvSMTPPort()),
+		auth: auth,
+		from: mail.Address{Name: helper.EnvMailFromName(), Address: helper.EnvMailFromAddress()},
+	}
+}
+
+// Send mails the notification, STARTTLS is used whenever the server offers it. A permanent rejection by the
+// server is returned as a bad request so it is not retried.
+func (n SMTPNotifier) Send(message *domain.Notification) *errs.AppError {
+	email, err := buildEmail(n.from, message, time.Now())
+	if err != nil {
+		logger.Error("Error while build email: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected email error")
+	}
+
+	err = smtp.SendMail(n.addr, n.auth, n.from.Address, []string{message.To}, email)
+	if err != nil {
+		logger.Error("Error while send email through smtp: " + err.Error())
+
+		var smtpErr *textproto.Error
+		if errors.As(err, &smtpErr) && smtpErr.Code >= 500 {
+			return errs.NewBadRequestError("Email was rejected: " + smtpErr.Msg)
+		}
+		return errs.NewUnexpectedError("Unexpected email error")
+	}
+
+	return nil
+}
@@ -0,0 +1,276 @@
+package repo
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/go-common-packages/logger"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/danisbagus/matchoshop/utils/constants"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type StockAlertRepo struct {
+	db *sqlx.DB
+}
+
+func NewStockAlertRepo(db *sqlx.DB) port.StockAlertRepo {
+	return &StockAlertRepo{
+		db: db,
+	}
+}
+
+// InsertLowStockAlerts claims the products low on stock and stores an email to every staff user for each of
+// them in the same transaction, so a claimed product is never left without its staff emails
+func (r StockAlertRepo) InsertLowStockAlerts(now time.Time) ([]domain.StockAlert, *errs.AppError) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		logger.Error("Error when starting insert low stock alert: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	// a product alerts once per drop, it is re-armed when restocked above its threshold
+	sqlUpdate := `
+	UPDATE products
+	SET low_stock_notified_at = $1
+	WHERE low_stock_threshold > 0
+	AND stock <= low_stock_threshold
+	AND low_stock_notified_at IS NULL
+	RETURNING product_id, name, stock, NULL::INT`
+
+	stockAlerts, err := r.insertStockAlerts(tx, sqlUpdate, constants.StockAlertLowStock, now)
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while insert low stock alert: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	if len(stockAlerts) > 0 {
+		staffUserIDs := make([]int64, 0)
+		err = tx.QueryRow(`SELECT COALESCE(ARRAY_AGG(user_id), '{}') FROM users WHERE role_id IN ($1, $2)`, constants.SuperAdminRoleID,
+			constants.AdminRoleID).Scan(pq.Array(&staffUserIDs))
+		if err != nil {
+			tx.Rollback()
+			logger.Error("Error while get staff user: " + err.Error())
+			return nil, errs.NewUnexpectedError("Unexpected database error")
+		}
+
+		for _, stockAlert := range stockAlerts {
+			for _, userID := range staffUserIDs {
+				err = insertNotificationEvent(tx, &domain.NotificationEvent{UserID: userID, Template: constants.NotificationLowStock, CreatedAt: now, Data: map[string]interface{}{
+					"ProductID":   stockAlert.ProductID,
+					"ProductName": stockAlert.ProductName,
+					"Stock":       stockAlert.Stock,
+				}})
+				if err != nil {
+					tx.Rollback()
+					logger.Error("Error while insert notification event: " + err.Error())
+					return nil, errs.NewUnexpectedError("Unexpected database error")
+				}
+			}
+		}
+	}
+
+	sqlRearm := `
+	UPDATE products
+	SET low_stock_notified_at = NULL
+	WHERE low_stock_notified_at IS NOT NULL
+	AND stock > low_stock_threshold`
+
+	_, err = tx.Exec(sqlRearm)
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while rearm low stock alert: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while commiting transaction: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return stockAlerts, nil
+}
+
+// InsertBackInStockAlerts claims the subscriptions of products back in stock and stores the email of each
+// subscriber in the same transaction, so a claimed subscription is never left without its email
+func (r StockAlertRepo) InsertBackInStockAlerts(now time.Time) ([]domain.StockAlert, *errs.AppError) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		logger.Error("Error when starting insert back in stock alert: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	sqlUpdate := `
+	UPDATE back_in_stock_subscriptions s
+	SET notified_at = $1
+	FROM products p
+	WHERE p.product_id = s.product_id
+	AND s.notified_at IS NULL
+	AND p.stock > 0
+	RETURNING s.product_id, p.name, p.stock, s.user_id`
+
+	stockAlerts, err := r.insertStockAlerts(tx, sqlUpdate, constants.StockAlertBackInStock, now)
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while insert back in stock alert: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	for _, stockAlert := range stockAlerts {
+		err = insertNotificationEvent(tx, &domain.NotificationEvent{UserID: *stockAlert.UserID, Template: constants.NotificationBackInStock, CreatedAt: now, Data: map[string]interface{}{
+			"ProductID":   stockAlert.ProductID,
+			"ProductName": stockAlert.ProductName,
+		}})
+		if err != nil {
+			tx.Rollback()
+			logger.Error("Error while insert notification event: " + err.Error())
+			return nil, errs.NewUnexpectedError("Unexpected database error")
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while commiting transaction: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return stockAlerts, nil
+}
+
+func (r StockAlertRepo) GetAllPaginate(criteria *domain.StockAlertListCriteria) ([]domain.StockAlert, int64, *errs.AppError) {
+	var totalData int64
+	var offset int64
+	if criteria.Page > 0 {
+		offset = (criteria.Page - 1) * criteria.Limit
+	}
+
+	// staff alerts have no recipient user
+	condition := "sa.user_id IS NULL"
+	args := make([]interface{}, 0)
+	if criteria.UserID != nil {
+		condition = "sa.user_id = $1"
+		args = append(args, *criteria.UserID)
+	}
+
+	sqlCount := `
+	SELECT
+		COUNT(sa.stock_alert_id)
+	FROM stock_alerts sa
+	WHERE ` + condition
+
+	err := r.db.QueryRow(sqlCount, args...).Scan(&totalData)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error("Error while count stock alert from database: " + err.Error())
+		return nil, 0, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	sqlGet := fmt.Sprintf(`
+	SELECT
+		sa.stock_alert_id,
+		sa.alert_type,
+		sa.product_id,
+		p.name,
+		sa.user_id,
+		sa.stock,
+		sa.created_at
+	FROM stock_alerts sa
+	INNER JOIN products p ON p.product_id = sa.product_id
+	WHERE %s
+	ORDER BY sa.stock_alert_id DESC
+	LIMIT $%d
+	OFFSET $%d`, condition, len(args)+1, len(args)+2)
+
+	args = append(args, criteria.Limit, offset)
+	rows, err := r.db.Query(sqlGet, args...)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error("Error while get all stock alert from database: " + err.Error())
+		return nil, 0, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	defer rows.Close()
+
+	stockAlerts := make([]domain.StockAlert, 0)
+	for rows.Next() {
+		var stockAlert domain.StockAlert
+		if err := rows.Scan(&stockAlert.StockAlertID, &stockAlert.AlertType, &stockAlert.ProductID, &stockAlert.ProductName, &stockAlert.UserID,
+			&stockAlert.Stock, &stockAlert.CreatedAt); err != nil {
+			logger.Error("Error while scanning stock alert from database: " + err.Error())
+			return nil, 0, errs.NewUnexpectedError("Unexpected database error")
+		}
+		stockAlerts = append(stockAlerts, stockAlert)
+	}
+
+	return stockAlerts, totalData, nil
+}
+
+func (r StockAlertRepo) UpsertSubscription(form *domain.BackInStockSubscription) *errs.AppError {
+
+	sqlUpsert := `INSERT INTO back_in_stock_subscriptions(product_id, user_id, created_at)
+		VALUES($1, $2, $3)
+		ON CONFLICT (product_id, user_id)
+		DO UPDATE SET notified_at = NULL, created_at = EXCLUDED.created_at`
+
+	_, err := r.db.Exec(sqlUpsert, form.ProductID, form.UserID, form.CreatedAt)
+	if err != nil {
+		logger.Error("Error while upsert back in stock subscription: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return nil
+}
+
+func (r StockAlertRepo) DeleteSubscription(productID, userID int64) *errs.AppError {
+
+	sqlDelete := `DELETE FROM back_in_stock_subscriptions 
+	WHERE product_id = $1 
+	AND user_id = $2`
+
+	_, err := r.db.Exec(sqlDelete, productID, userID)
+	if err != nil {
+		logger.Error("Error while delete back in stock subscription: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return nil
+}
+
+// insertStockAlerts runs the claiming query, which returns product id, name, stock and
+// recipient user id, and records an alert for every claimed row.
+func (r StockAlertRepo) insertStockAlerts(tx *sql.Tx, sqlClaim string, alertType string, now time.Time) ([]domain.StockAlert, error) {
+	rows, err := tx.Query(sqlClaim, now)
+	if err != nil {
+		return nil, err
+	}
+
+	stockAlerts := make([]domain.StockAlert, 0)
+	for rows.Next() {
+		stockAlert := domain.StockAlert{AlertType: alertType, CreatedAt: now.Format(dbTSLayout)}
+		if err := rows.Scan(&stockAlert.ProductID, &stockAlert.ProductName, &stockAlert.Stock, &stockAlert.UserID); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		stockAlerts = append(stockAlerts, stockAlert)
+	}
+	rows.Close()
+
+	sqlInsert := `INSERT INTO stock_alerts(alert_type, product_id, user_id, stock, created_at)
+					  VALUES($1, $2, $3, $4, $5)
+					  RETURNING stock_alert_id`
+
+	for i := range stockAlerts {
+		err = tx.QueryRow(sqlInsert, stockAlerts[i].AlertType, stockAlerts[i].ProductID, stockAlerts[i].UserID, stockAlerts[i].Stock, now).Scan(&stockAlerts[i].StockAlertID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return stockAlerts, nil
+}
@@ -0,0 +1,137 @@
+package repo
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/go-common-packages/logger"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/jmoiron/sqlx"
+)
+
+var errInsufficientStock = errors.New("insufficient product stock")
+
+type StockMovementRepo struct {
+	db *sqlx.DB
+}
+
+func NewStockMovementRepo(db *sqlx.DB) port.StockMovementRepo {
+	return &StockMovementRepo{
+		db: db,
+	}
+}
+
+func (r StockMovementRepo) Insert(form *domain.StockMovement) *errs.AppError {
+	tx, err := r.db.Begin()
+	if err != nil {
+		logger.Error("Error when starting insert stock movement: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	err = applyStockMovement(tx, form)
+	if err != nil {
+		tx.Rollback()
+		if err == errInsufficientStock {
+			return errs.NewBadRequestError("Insufficient product stock")
+		}
+		logger.Error("Error while insert stock movement: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while commiting transaction: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return nil
+}
+
+func (r StockMovementRepo) GetAllPaginate(criteria *domain.StockMovementListCriteria) ([]domain.StockMovement, int64, *errs.AppError) {
+	var totalData int64
+	var offset int64
+	if criteria.Page > 0 {
+		offset = (criteria.Page - 1) * criteria.Limit
+	}
+
+	sqlCount := `
+	SELECT
+		COUNT(sm.stock_movement_id)
+	FROM stock_movements sm
+	WHERE sm.product_id = $1`
+
+	err := r.db.QueryRow(sqlCount, criteria.ProductID).Scan(&totalData)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error("Error while count stock movement from database: " + err.Error())
+		return nil, 0, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	sqlGet := `
+	SELECT
+		sm.stock_movement_id,
+		sm.product_id,
+		sm.movement_type,
+		sm.quantity,
+		sm.stock_after,
+		sm.reason,
+		sm.actor_user_id,
+		u.name AS actor_name,
+		sm.order_id,
+		sm.created_at
+	FROM stock_movements sm
+	LEFT JOIN users u ON u.user_id = sm.actor_user_id
+	WHERE sm.product_id = $1
+	ORDER BY sm.stock_movement_id DESC
+	LIMIT $2
+	OFFSET $3`
+
+	rows, err := r.db.Query(sqlGet, criteria.ProductID, criteria.Limit, offset)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error("Error while get all stock movement from database: " + err.Error())
+		return nil, 0, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	defer rows.Close()
+
+	stockMovements := make([]domain.StockMovement, 0)
+	for rows.Next() {
+		var stockMovement domain.StockMovement
+		if err := rows.Scan(&stockMovement.StockMovementID, &stockMovement.ProductID, &stockMovement.MovementType, &stockMovement.Quantity, &stockMovement.StockAfter,
+			&stockMovement.Reason, &stockMovement.ActorUserID, &stockMovement.ActorName, &stockMovement.OrderID, &stockMovement.CreatedAt); err != nil {
+			logger.Error("Error while scanning stock movement from database: " + err.Error())
+			return nil, 0, errs.NewUnexpectedError("Unexpected database error")
+		}
+		stockMovements = append(stockMovements, stockMovement)
+	}
+
+	return stockMovements, totalData, nil
+}
+
+// applyStockMovement moves products.stock by the movement quantity and appends
+// the movement to the ledger, refusing to take stock below zero.
+func applyStockMovement(tx *sql.Tx, form *domain.StockMovement) error {
+	sqlUpdate := `
+	UPDATE products
+	SET stock = stock + $2,
+		updated_at = $3
+	WHERE product_id = $1
+	AND stock + $2 >= 0
+	RETURNING stock`
+
+	err := tx.QueryRow(sqlUpdate, form.ProductID, form.Quantity, form.CreatedAt).Scan(&form.StockAfter)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errInsufficientStock
+		}
+		return err
+	}
+
+	sqlInsert := `INSERT INTO stock_movements(product_id, movement_type, quantity, stock_after, reason, actor_user_id, order_id, created_at)
+					  VALUES($1, $2, $3, $4, $5, $6, $7, $8)
+					  RETURNING stock_movement_id`
+
+	return tx.QueryRow(sqlInsert, form.ProductID, form.MovementType, form.Quantity, form.StockAfter, form.Reason, form.ActorUserID, form.OrderID, form.CreatedAt).Scan(&form.StockMovementID)
+}
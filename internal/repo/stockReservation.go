@@ -0,0 +1,102 @@
+package repo
+
+import (
+	"database/sql"
+	"sort"
+	"time"
+
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/go-common-packages/logger"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/danisbagus/matchoshop/utils/constants"
+	"github.com/jmoiron/sqlx"
+)
+
+type StockReservationRepo struct {
+	db *sqlx.DB
+}
+
+func NewStockReservationRepo(db *sqlx.DB) port.StockReservationRepo {
+	return &StockReservationRepo{
+		db: db,
+	}
+}
+
+func (r StockReservationRepo) ReleaseExpired(now time.Time) (int64, *errs.AppError) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		logger.Error("Error when starting release expired stock reservation: " + err.Error())
+		return 0, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	released, err := releaseExpiredStockReservation(tx, now)
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while release expired stock reservation: " + err.Error())
+		return 0, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while commiting transaction: " + err.Error())
+		return 0, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return released, nil
+}
+
+// releaseExpiredStockReservation gives the stock of reservations whose order passed its payment deadline back
+// to the products. Products are restocked in product order like orders take them, so a release and an order
+// sharing products do not deadlock.
+func releaseExpiredStockReservation(tx *sql.Tx, now time.Time) (int64, error) {
+	// rows locked by a payment in progress are skipped by the status check once it commits
+	sqlUpdate := `
+	UPDATE stock_reservations sr
+	SET status = $1,
+		updated_at = $2
+	FROM orders o
+	WHERE o.order_id = sr.order_id
+	AND sr.status = $3
+	AND o.reservation_expires_at <= $2
+	RETURNING sr.stock_reservation_id, sr.order_id, sr.product_id, sr.quantity`
+
+	rows, err := tx.Query(sqlUpdate, constants.StockReservationReleased, now, constants.StockReservationReserved)
+	if err != nil {
+		return 0, err
+	}
+
+	stockReservations := make([]domain.StockReservation, 0)
+	for rows.Next() {
+		var stockReservation domain.StockReservation
+		if err := rows.Scan(&stockReservation.StockReservationID, &stockReservation.OrderID, &stockReservation.ProductID, &stockReservation.Quantity); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		stockReservations = append(stockReservations, stockReservation)
+	}
+	rows.Close()
+
+	sort.SliceStable(stockReservations, func(i, j int) bool {
+		return stockReservations[i].ProductID < stockReservations[j].ProductID
+	})
+
+	reason := "Reservation expired"
+	for _, stockReservation := range stockReservations {
+		orderID := stockReservation.OrderID
+		err = applyStockMovement(tx, &domain.StockMovement{
+			ProductID:    stockReservation.ProductID,
+			MovementType: constants.StockMovementCancellation,
+			Quantity:     stockReservation.Quantity,
+			Reason:       &reason,
+			OrderID:      &orderID,
+			CreatedAt:    now.Format(dbTSLayout),
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return int64(len(stockReservations)), nil
+}
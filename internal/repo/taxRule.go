@@ -0,0 +1,155 @@
+package repo
+
+import (
+	"database/sql"
+
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/go-common-packages/logger"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/jmoiron/sqlx"
+)
+
+type TaxRuleRepo struct {
+	db *sqlx.DB
+}
+
+func NewTaxRuleRepo(db *sqlx.DB) port.TaxRuleRepo {
+	return &TaxRuleRepo{
+		db: db,
+	}
+}
+
+func (r TaxRuleRepo) Insert(form *domain.TaxRule) *errs.AppError {
+	sqlInsert := `INSERT INTO tax_rules(country, region, tax_class, rate, is_inclusive, rounding, created_at, updated_at)
+					  VALUES($1, $2, $3, $4, $5, $6, $7, $8)
+					  RETURNING tax_rule_id`
+
+	err := r.db.QueryRow(sqlInsert, form.Country, form.Region, form.TaxClass, form.Rate, form.IsInclusive, form.Rounding, form.CreatedAt, form.UpdatedAt).Scan(&form.TaxRuleID)
+	if err != nil {
+		logger.Error("Error while insert tax rule: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return nil
+}
+
+func (r TaxRuleRepo) CheckByIDAndLocation(taxRuleID int64, country, region, taxClass string) (bool, *errs.AppError) {
+	sqlCountTaxRule := `SELECT COUNT(tax_rule_id) 
+	FROM tax_rules 
+	WHERE tax_rule_id != $1
+	AND lower(country) = lower($2)
+	AND lower(region) = lower($3)
+	AND tax_class = $4`
+
+	var totalData int64
+	err := r.db.QueryRow(sqlCountTaxRule, taxRuleID, country, region, taxClass).Scan(&totalData)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error("Error while count tax rule from database: " + err.Error())
+		return false, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return totalData > 0, nil
+}
+
+func (r TaxRuleRepo) GetAll() ([]domain.TaxRule, *errs.AppError) {
+	return r.getAll("TRUE")
+}
+
+// GetAllByLocation returns the rules of the country that cover the whole country or the given region
+func (r TaxRuleRepo) GetAllByLocation(country, region string) ([]domain.TaxRule, *errs.AppError) {
+	return r.getAll("lower(tr.country) = lower($1) AND (tr.region = '' OR lower(tr.region) = lower($2))", country, region)
+}
+
+func (r TaxRuleRepo) GetOneByID(taxRuleID int64) (*domain.TaxRule, *errs.AppError) {
+	sqlGetTaxRule := `
+	SELECT 
+		tr.tax_rule_id,
+		tr.country,
+		tr.region,
+		tr.tax_class,
+		tr.rate,
+		tr.is_inclusive,
+		tr.rounding,
+		tr.created_at,
+		tr.updated_at
+	FROM tax_rules tr
+	WHERE tr.tax_rule_id = $1
+	LIMIT 1`
+
+	var taxRule domain.TaxRule
+	err := r.db.QueryRow(sqlGetTaxRule, taxRuleID).Scan(&taxRule.TaxRuleID, &taxRule.Country, &taxRule.Region, &taxRule.TaxClass, &taxRule.Rate, &taxRule.IsInclusive,
+		&taxRule.Rounding, &taxRule.CreatedAt, &taxRule.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errs.NewNotFoundError("Tax rule not found!")
+		}
+		logger.Error("Error while get tax rule from database: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return &taxRule, nil
+}
+
+func (r TaxRuleRepo) Update(form *domain.TaxRule) *errs.AppError {
+	sqlUpdate := `
+	UPDATE tax_rules 
+	SET country = $2, region = $3, tax_class = $4, rate = $5, is_inclusive = $6, rounding = $7, updated_at = $8
+	WHERE tax_rule_id = $1`
+
+	_, err := r.db.Exec(sqlUpdate, form.TaxRuleID, form.Country, form.Region, form.TaxClass, form.Rate, form.IsInclusive, form.Rounding, form.UpdatedAt)
+	if err != nil {
+		logger.Error("Error while update tax rule: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return nil
+}
+
+func (r TaxRuleRepo) Delete(taxRuleID int64) *errs.AppError {
+	_, err := r.db.Exec(`DELETE FROM tax_rules WHERE tax_rule_id = $1`, taxRuleID)
+	if err != nil {
+		logger.Error("Error while delete tax rule: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return nil
+}
+
+func (r TaxRuleRepo) getAll(condition string, args ...interface{}) ([]domain.TaxRule, *errs.AppError) {
+	sqlGetTaxRule := `
+	SELECT 
+		tr.tax_rule_id,
+		tr.country,
+		tr.region,
+		tr.tax_class,
+		tr.rate,
+		tr.is_inclusive,
+		tr.rounding,
+		tr.created_at,
+		tr.updated_at
+	FROM tax_rules tr
+	WHERE ` + condition + `
+	ORDER BY tr.country ASC, tr.region ASC, tr.tax_class ASC`
+
+	rows, err := r.db.Query(sqlGetTaxRule, args...)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error("Error while get all tax rule from database: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	defer rows.Close()
+
+	taxRules := make([]domain.TaxRule, 0)
+	for rows.Next() {
+		var taxRule domain.TaxRule
+		if err := rows.Scan(&taxRule.TaxRuleID, &taxRule.Country, &taxRule.Region, &taxRule.TaxClass, &taxRule.Rate, &taxRule.IsInclusive,
+			&taxRule.Rounding, &taxRule.CreatedAt, &taxRule.UpdatedAt); err != nil {
+			logger.Error("Error while scanning tax rule from database: " + err.Error())
+			return nil, errs.NewUnexpectedError("Unexpected database error")
+		}
+		taxRules = append(taxRules, taxRule)
+	}
+
+	return taxRules, nil
+}
@@ -53,9 +53,9 @@ func (r UserRepo) GetAll() ([]domain.UserDetail, *errs.AppError) {
 
 func (r UserRepo) FindOne(email string) (*domain.User, *errs.AppError) {
 	var login domain.User
-	sqlVerify := `SELECT user_id, email, password, name, role_id FROM users WHERE email = $1`
+	sqlVerify := `SELECT user_id, email, password, name, role_id, locale FROM users WHERE email = $1`
 
-	err := r.db.QueryRow(sqlVerify, email).Scan(&login.UserID, &login.Email, &login.Password, &login.Name, &login.RoleID)
+	err := r.db.QueryRow(sqlVerify, email).Scan(&login.UserID, &login.Email, &login.Password, &login.Name, &login.RoleID, &login.Locale)
 	if err != nil && err != sql.ErrNoRows {
 		logger.Error("Error while verifying login request from database: " + err.Error())
 		return nil, errs.NewUnexpectedError("Unexpected database error")
@@ -66,9 +66,9 @@ func (r UserRepo) FindOne(email string) (*domain.User, *errs.AppError) {
 
 func (r UserRepo) FindOneById(userID int64) (*domain.User, *errs.AppError) {
 	var login domain.User
-	sqlVerify := `SELECT user_id, email, password, name, role_id FROM users WHERE user_id = $1`
+	sqlVerify := `SELECT user_id, email, password, name, role_id, locale FROM users WHERE user_id = $1`
 
-	err := r.db.QueryRow(sqlVerify, userID).Scan(&login.UserID, &login.Email, &login.Password, &login.Name, &login.RoleID)
+	err := r.db.QueryRow(sqlVerify, userID).Scan(&login.UserID, &login.Email, &login.Password, &login.Name, &login.RoleID, &login.Locale)
 	if err != nil && err != sql.ErrNoRows {
 		logger.Error("Error while verifying login request from database: " + err.Error())
 		return nil, errs.NewUnexpectedError("Unexpected database error")
@@ -77,7 +77,7 @@ func (r UserRepo) FindOneById(userID int64) (*domain.User, *errs.AppError) {
 	return &login, nil
 }
 
-func (r UserRepo) CreateUserCustomer(data *domain.User) (*domain.User, *errs.AppError) {
+func (r UserRepo) CreateUserCustomer(data *domain.User, notification *domain.NotificationEvent) (*domain.User, *errs.AppError) {
 
 	tx, err := r.db.Begin()
 	if err != nil {
@@ -85,18 +85,27 @@ func (r UserRepo) CreateUserCustomer(data *domain.User) (*domain.User, *errs.App
 		return nil, errs.NewUnexpectedError("Unexpected database error")
 	}
 
-	sqlInsert := `INSERT INTO users(email, password, name, role_id,  created_at, updated_at)
-				  VALUES($1, $2, $3, $4, $5, $6)
+	sqlInsert := `INSERT INTO users(email, password, name, role_id, locale, created_at, updated_at)
+				  VALUES($1, $2, $3, $4, $5, $6, $7)
 				  RETURNING user_id`
 
 	var userID int64
-	err = tx.QueryRow(sqlInsert, data.Email, data.Password, data.Name, data.RoleID, data.CreatedAt, data.UpdatedAt).Scan(&userID)
+	err = tx.QueryRow(sqlInsert, data.Email, data.Password, data.Name, data.RoleID, data.Locale, data.CreatedAt, data.UpdatedAt).Scan(&userID)
 	if err != nil {
 		tx.Rollback()
 		logger.Error("Error while create new user: " + err.Error())
 		return nil, errs.NewUnexpectedError("Unexpected database error")
 	}
 
+	// the user id is only known once the user is stored
+	notification.UserID = userID
+	err = insertNotificationEvent(tx, notification)
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while insert notification event: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		tx.Rollback()
@@ -109,7 +118,7 @@ func (r UserRepo) CreateUserCustomer(data *domain.User) (*domain.User, *errs.App
 	return data, nil
 }
 
-func (r UserRepo) Update(userID int64, data *domain.User) *errs.AppError {
+func (r UserRepo) Update(userID int64, data *domain.User, notification *domain.NotificationEvent) *errs.AppError {
 
 	tx, err := r.db.Begin()
 	if err != nil {
@@ -120,16 +129,24 @@ func (r UserRepo) Update(userID int64, data *domain.User) *errs.AppError {
 	sqlUpdate := `
 	UPDATE users 
 	SET name = $2, 
-		updated_at = $3
+		locale = $3,
+		updated_at = $4
 	WHERE user_id = $1`
 
-	_, err = tx.Exec(sqlUpdate, userID, data.Name, data.UpdatedAt)
+	_, err = tx.Exec(sqlUpdate, userID, data.Name, data.Locale, data.UpdatedAt)
 	if err != nil {
 		tx.Rollback()
 		logger.Error("Error while update user: " + err.Error())
 		return errs.NewUnexpectedError("Unexpected database error")
 	}
 
+	err = insertNotificationEvent(tx, notification)
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while insert notification event: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		tx.Rollback()
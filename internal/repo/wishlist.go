@@ -0,0 +1,148 @@
+package repo
+
+import (
+	"database/sql"
+
+	"github.com/danisbagus/go-common-packages/errs"
+	"github.com/danisbagus/go-common-packages/logger"
+	"github.com/danisbagus/matchoshop/internal/core/domain"
+	"github.com/danisbagus/matchoshop/internal/core/port"
+	"github.com/jmoiron/sqlx"
+)
+
+type WishlistRepo struct {
+	db *sqlx.DB
+}
+
+func NewWishlistRepo(db *sqlx.DB) port.WishlistRepo {
+	return &WishlistRepo{
+		db: db,
+	}
+}
+
+func (r WishlistRepo) Insert(form *domain.Wishlist) *errs.AppError {
+	sqlInsert := `INSERT INTO wishlists(user_id, product_id, created_at)
+		VALUES($1, $2, $3)
+		ON CONFLICT (user_id, product_id) DO NOTHING`
+
+	_, err := r.db.Exec(sqlInsert, form.UserID, form.ProductID, form.CreatedAt)
+	if err != nil {
+		logger.Error("Error while insert wishlist: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return nil
+}
+
+func (r WishlistRepo) CheckByUserIDAndProductID(userID, productID int64) (bool, *errs.AppError) {
+	sqlCountWishlist := `SELECT COUNT(wishlist_id) 
+	FROM wishlists 
+	WHERE user_id = $1
+	AND product_id = $2`
+
+	var totalData int64
+	err := r.db.QueryRow(sqlCountWishlist, userID, productID).Scan(&totalData)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error("Error while count wishlist from database: " + err.Error())
+		return false, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return totalData > 0, nil
+}
+
+func (r WishlistRepo) GetAllByUserID(userID int64) ([]domain.Wishlist, *errs.AppError) {
+	sqlGet := `
+	SELECT
+		wishlist_id,
+		user_id,
+		product_id,
+		created_at
+	FROM wishlists
+	WHERE user_id = $1
+	ORDER BY wishlist_id DESC`
+
+	rows, err := r.db.Query(sqlGet, userID)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error("Error while get all wishlist from database: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	defer rows.Close()
+
+	wishlists := make([]domain.Wishlist, 0)
+	for rows.Next() {
+		var wishlist domain.Wishlist
+		if err := rows.Scan(&wishlist.WishlistID, &wishlist.UserID, &wishlist.ProductID, &wishlist.CreatedAt); err != nil {
+			logger.Error("Error while scanning wishlist from database: " + err.Error())
+			return nil, errs.NewUnexpectedError("Unexpected database error")
+		}
+		wishlists = append(wishlists, wishlist)
+	}
+
+	return wishlists, nil
+}
+
+func (r WishlistRepo) GetMostWishlistedPaginate(criteria *domain.WishlistReportCriteria) ([]domain.WishlistReport, int64, *errs.AppError) {
+	var totalData int64
+	var offset int64
+	if criteria.Page > 0 {
+		offset = (criteria.Page - 1) * criteria.Limit
+	}
+
+	sqlCount := `SELECT COUNT(DISTINCT product_id) FROM wishlists`
+
+	err := r.db.QueryRow(sqlCount).Scan(&totalData)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error("Error while count wishlisted product from database: " + err.Error())
+		return nil, 0, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	sqlGet := `
+	SELECT
+		p.product_id,
+		p.name,
+		p.slug,
+		p.image,
+		w.numb_wishlists
+	FROM (
+		SELECT
+			product_id,
+			COUNT(wishlist_id) AS numb_wishlists
+		FROM wishlists
+		GROUP BY product_id
+	) w
+	INNER JOIN products p ON p.product_id = w.product_id
+	ORDER BY w.numb_wishlists DESC, p.product_id ASC
+	LIMIT $1
+	OFFSET $2`
+
+	rows, err := r.db.Query(sqlGet, criteria.Limit, offset)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error("Error while get most wishlisted product from database: " + err.Error())
+		return nil, 0, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	defer rows.Close()
+
+	reports := make([]domain.WishlistReport, 0)
+	for rows.Next() {
+		var report domain.WishlistReport
+		if err := rows.Scan(&report.ProductID, &report.Name, &report.Slug, &report.Image, &report.NumbWishlists); err != nil {
+			logger.Error("Error while scanning most wishlisted product from database: " + err.Error())
+			return nil, 0, errs.NewUnexpectedError("Unexpected database error")
+		}
+		reports = append(reports, report)
+	}
+
+	return reports, totalData, nil
+}
+
+func (r WishlistRepo) Delete(userID, productID int64) *errs.AppError {
+	_, err := r.db.Exec(`DELETE FROM wishlists WHERE user_id = $1 AND product_id = $2`, userID, productID)
+	if err != nil {
+		logger.Error("Error while delete wishlist: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return nil
+}
@@ -4,11 +4,13 @@ import (
 	"os"
 
 	app "github.com/danisbagus/matchoshop/app/api"
+	"github.com/danisbagus/matchoshop/app/worker"
 )
 
 func main() {
 	// app.StartApp()
 	e := app.Init()
+	worker.Start()
 	appPort := os.Getenv("PORT") // todo: move to config
 	e.Logger.Fatal(e.Start(":" + appPort))
 }
@@ -0,0 +1,4 @@
+package constants
+
+// CartSessionHeader carries the guest cart session, a new session is returned in the same header
+const CartSessionHeader = "X-Cart-Session"
@@ -0,0 +1,8 @@
+package constants
+
+const (
+	CouponTypePercentage   = "percentage"
+	CouponTypeFixed        = "fixed"
+	CouponTypeFreeShipping = "free_shipping"
+	CouponTypeBuyXGetY     = "bxgy"
+)
@@ -0,0 +1,15 @@
+package constants
+
+import "time"
+
+const (
+	// IdempotencyKeyHeader lets a client retry a mutating request, a retry with the same key gets the stored
+	// response back instead of running the request again
+	IdempotencyKeyHeader = "Idempotency-Key"
+
+	// IdempotencyReplayedHeader is set on a response replayed from a stored idempotency key
+	IdempotencyReplayedHeader = "Idempotent-Replayed"
+
+	IdempotencyKeyDuration  = 24 * time.Hour
+	IdempotencyKeyMaxLength = 255
+)
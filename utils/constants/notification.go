@@ -0,0 +1,44 @@
+package constants
+
+import "time"
+
+const (
+	LocaleEnglish    = "en"
+	LocaleIndonesian = "id"
+
+	// DefaultLocale is used for users without a locale and for templates missing in the user locale
+	DefaultLocale = LocaleEnglish
+)
+
+// Notification templates, each has a text and an HTML variant per locale
+const (
+	NotificationUserRegistered     = "user_registered"
+	NotificationUserUpdated        = "user_updated"
+	NotificationOrderCreated       = "order_created"
+	NotificationOrderPaid          = "order_paid"
+	NotificationOrderStatusUpdated = "order_status_updated"
+	NotificationOrderCancelled     = "order_cancelled"
+	NotificationBackInStock        = "back_in_stock"
+	NotificationLowStock           = "low_stock"
+)
+
+const (
+	NotifierDriverSMTP   = "smtp"
+	NotifierDriverOutbox = "outbox"
+)
+
+const (
+	NotificationEventStatusPending = "pending"
+	NotificationEventStatusSent    = "sent"
+	NotificationEventStatusFailed  = "failed"
+)
+
+const (
+	// NotificationRetryDelay is the wait before the first retry of a failed send, it doubles on every retry
+	NotificationRetryDelay = time.Minute
+	// NotificationClaimDuration is how long a claimed event is left to its worker before another one may
+	// pick it up again
+	NotificationClaimDuration = 5 * time.Minute
+	// NotificationBatchSize is the number of events a worker run sends at most
+	NotificationBatchSize = 100
+)
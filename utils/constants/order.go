@@ -0,0 +1,22 @@
+package constants
+
+const (
+	OrderStatusPendingPayment = "pending_payment"
+	OrderStatusPaymentFailed  = "payment_failed"
+	OrderStatusPaid           = "paid"
+	OrderStatusProcessing     = "processing"
+	OrderStatusShipped        = "shipped"
+	OrderStatusDelivered      = "delivered"
+	OrderStatusCancelled      = "cancelled"
+	OrderStatusRefunded       = "refunded"
+)
+
+// PaymentStatusCompleted is the payment provider status of a successful payment
+const PaymentStatusCompleted = "COMPLETED"
+
+const (
+	RefundStatusRequested = "requested"
+	RefundStatusCompleted = "completed"
+	RefundStatusFailed    = "failed"
+	RefundStatusRejected  = "rejected"
+)
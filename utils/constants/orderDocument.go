@@ -0,0 +1,9 @@
+package constants
+
+const (
+	OrderDocumentTypeInvoice     = "invoice"
+	OrderDocumentTypePackingSlip = "packing_slip"
+)
+
+// InvoiceNumberFormat numbers invoices by year, e.g. INV-2026-000001
+const InvoiceNumberFormat = "INV-%d-%06d"
@@ -0,0 +1,11 @@
+package constants
+
+const (
+	OrderReturnStatusRequested = "requested"
+	OrderReturnStatusApproved  = "approved"
+	OrderReturnStatusRejected  = "rejected"
+	OrderReturnStatusReceived  = "received"
+)
+
+// OrderReturnMaxPhotos is the number of photos a customer can attach to a return
+const OrderReturnMaxPhotos = 5
@@ -0,0 +1,9 @@
+package constants
+
+const (
+	// RecommendationLimit is the number of products returned per recommendation kind
+	RecommendationLimit = 6
+
+	// RecommendationPriceBand is the percentage around the product price counted as a similar price
+	RecommendationPriceBand = 20
+)
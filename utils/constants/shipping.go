@@ -0,0 +1,11 @@
+package constants
+
+const (
+	ShippingRateTypeFlat   = "flat"
+	ShippingRateTypeWeight = "weight"
+	ShippingRateTypeTiered = "tiered"
+	ShippingRateTypeFree   = "free"
+)
+
+// ShippingMethodDefaultName names the shipping charged from the order config when no shipping zone is set up
+const ShippingMethodDefaultName = "Standard shipping"
@@ -0,0 +1,7 @@
+package constants
+
+const (
+	SlugEntityProduct         = "product"
+	SlugEntityProductCategory = "product_category"
+	SlugEntityBrand           = "brand"
+)
@@ -0,0 +1,25 @@
+package constants
+
+import "time"
+
+const (
+	StockMovementSale         = "sale"
+	StockMovementRestock      = "restock"
+	StockMovementAdjustment   = "adjustment"
+	StockMovementReturn       = "return"
+	StockMovementCancellation = "cancellation"
+)
+
+const (
+	StockReservationReserved = "reserved"
+	StockReservationConsumed = "consumed"
+	StockReservationReleased = "released"
+
+	// StockReservationDuration is how long an unpaid order holds its stock
+	StockReservationDuration = 30 * time.Minute
+)
+
+const (
+	StockAlertLowStock    = "low_stock"
+	StockAlertBackInStock = "back_in_stock"
+)
@@ -0,0 +1,13 @@
+package constants
+
+const (
+	TaxClassStandard = "standard"
+	TaxClassReduced  = "reduced"
+	TaxClassExempt   = "exempt"
+)
+
+const (
+	TaxRoundingHalfUp = "half_up"
+	TaxRoundingUp     = "up"
+	TaxRoundingDown   = "down"
+)
@@ -2,6 +2,7 @@ package helper
 
 import (
 	"os"
+	"strconv"
 )
 
 func EnvCloudURL() string {
@@ -16,3 +17,163 @@ func EnvCloudUploadFolder() string {
 
 	return folder
 }
+
+// EnvOrderTaxRate is the tax percentage charged on top of the price of the standard tax class without a tax rule
+func EnvOrderTaxRate() float64 {
+	rate, _ := strconv.ParseFloat(os.Getenv("ORDER_TAX_RATE"), 64)
+	return rate
+}
+
+// EnvOrderReducedTaxRate is the tax percentage charged on top of the price of the reduced tax class without a
+// tax rule
+func EnvOrderReducedTaxRate() float64 {
+	rate, _ := strconv.ParseFloat(os.Getenv("ORDER_REDUCED_TAX_RATE"), 64)
+	return rate
+}
+
+// EnvOrderShippingPrice is the shipping charged while no shipping zone is set up
+func EnvOrderShippingPrice() int64 {
+	price, _ := strconv.ParseInt(os.Getenv("ORDER_SHIPPING_PRICE"), 10, 64)
+	return price
+}
+
+// EnvOrderFreeShippingMinimum is the product price from which shipping is free, zero disables free shipping
+func EnvOrderFreeShippingMinimum() int64 {
+	price, _ := strconv.ParseInt(os.Getenv("ORDER_FREE_SHIPPING_MINIMUM"), 10, 64)
+	return price
+}
+
+// EnvOrderReturnWindowDays is the number of days after delivery a customer can still open a return
+func EnvOrderReturnWindowDays() int64 {
+	days, err := strconv.ParseInt(os.Getenv("ORDER_RETURN_WINDOW_DAYS"), 10, 64)
+	if err != nil {
+		days = 14
+	}
+
+	return days
+}
+
+func EnvPaypalClientID() string {
+	return os.Getenv("PAYPAL_CLIENT_ID")
+}
+
+func EnvPaypalClientSecret() string {
+	return os.Getenv("PAYPAL_CLIENT_SECRET")
+}
+
+// EnvPaypalAPIURL is the PayPal REST API base url, the sandbox is used when it is not set
+func EnvPaypalAPIURL() string {
+	url := os.Getenv("PAYPAL_API_URL")
+	if url == "" {
+		url = "https://api-m.sandbox.paypal.com"
+	}
+
+	return url
+}
+
+// EnvPaymentCurrency is the currency code prices are charged and refunded in
+func EnvPaymentCurrency() string {
+	currency := os.Getenv("PAYMENT_CURRENCY")
+	if currency == "" {
+		currency = "USD"
+	}
+
+	return currency
+}
+
+// EnvSellerName is the seller printed on invoices, the app name is used when it is not set
+func EnvSellerName() string {
+	name := os.Getenv("SELLER_NAME")
+	if name == "" {
+		name = os.Getenv("APP_NAME")
+	}
+
+	return name
+}
+
+func EnvSellerAddress() string {
+	return os.Getenv("SELLER_ADDRESS")
+}
+
+func EnvSellerEmail() string {
+	return os.Getenv("SELLER_EMAIL")
+}
+
+func EnvSellerPhone() string {
+	return os.Getenv("SELLER_PHONE")
+}
+
+func EnvSellerTaxID() string {
+	return os.Getenv("SELLER_TAX_ID")
+}
+
+// EnvNotifierDriver picks the email adapter, smtp sends for real and outbox writes the emails to files
+func EnvNotifierDriver() string {
+	driver := os.Getenv("NOTIFIER_DRIVER")
+	if driver == "" {
+		driver = "outbox"
+	}
+
+	return driver
+}
+
+// EnvNotifierOutboxDir is the directory the outbox adapter writes emails to
+func EnvNotifierOutboxDir() string {
+	dir := os.Getenv("NOTIFIER_OUTBOX_DIR")
+	if dir == "" {
+		dir = "outbox"
+	}
+
+	return dir
+}
+
+// EnvNotificationMaxAttempts is how many times a notification is tried before it is given up
+func EnvNotificationMaxAttempts() int {
+	attempts, err := strconv.Atoi(os.Getenv("NOTIFICATION_MAX_ATTEMPTS"))
+	if err != nil || attempts < 1 {
+		attempts = 3
+	}
+
+	return attempts
+}
+
+func EnvSMTPHost() string {
+	return os.Getenv("SMTP_HOST")
+}
+
+func EnvSMTPPort() string {
+	port := os.Getenv("SMTP_PORT")
+	if port == "" {
+		port = "587"
+	}
+
+	return port
+}
+
+func EnvSMTPUsername() string {
+	return os.Getenv("SMTP_USERNAME")
+}
+
+func EnvSMTPPassword() string {
+	return os.Getenv("SMTP_PASSWORD")
+}
+
+// EnvMailFromAddress is the sender address of emails, a placeholder is used when it is not set
+func EnvMailFromAddress() string {
+	address := os.Getenv("MAIL_FROM_ADDRESS")
+	if address == "" {
+		address = "no-reply@localhost"
+	}
+
+	return address
+}
+
+// EnvMailFromName is the sender name of emails, the app name is used when it is not set
+func EnvMailFromName() string {
+	name := os.Getenv("MAIL_FROM_NAME")
+	if name == "" {
+		name = os.Getenv("APP_NAME")
+	}
+
+	return name
+}
@@ -38,3 +38,12 @@ func StringToDate(sValue string, timelayout string) time.Time {
 	}
 	return t
 }
+
+func StringToLocalDate(sValue string, timelayout string) time.Time {
+	var value time.Time
+	t, err := time.ParseInLocation(timelayout, sValue, time.Local)
+	if err != nil {
+		return value
+	}
+	return t
+}
@@ -0,0 +1,21 @@
+package helper
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var nonSlugChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+func Slugify(value string) string {
+	slug := nonSlugChars.ReplaceAllString(strings.ToLower(strings.TrimSpace(value)), "-")
+	return strings.Trim(slug, "-")
+}
+
+func SlugWithSuffix(slug string, suffix int) string {
+	if suffix <= 1 {
+		return slug
+	}
+	return fmt.Sprintf("%s-%d", slug, suffix)
+}
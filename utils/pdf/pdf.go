@@ -0,0 +1,143 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// A4 page size and margin in points
+const (
+	PageWidth  = 595.28
+	PageHeight = 841.89
+	Margin     = 50.0
+)
+
+// Document writes a plain text PDF on A4 pages using the standard Helvetica fonts, which every reader
+// ships with so no font is embedded. Text is encoded as Latin-1, other characters are printed as '?'.
+// Text is laid out top down from a cursor, a new page is started once the bottom margin is reached.
+type Document struct {
+	pages []*bytes.Buffer
+	y     float64
+}
+
+func New() *Document {
+	d := new(Document)
+	d.AddPage()
+	return d
+}
+
+func (d *Document) AddPage() {
+	d.pages = append(d.pages, new(bytes.Buffer))
+	d.y = PageHeight - Margin
+}
+
+// Ln moves the cursor down by height, starting a new page when it would pass the bottom margin
+func (d *Document) Ln(height float64) {
+	d.y -= height
+	if d.y < Margin {
+		d.AddPage()
+	}
+}
+
+// Text writes s with its left edge at x on the cursor line
+func (d *Document) Text(x, size float64, bold bool, s string) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+
+	fmt.Fprintf(d.page(), "BT /%s %.2f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, d.y, escape(s))
+}
+
+// TextRight writes s with its right edge at x on the cursor line, used for amount columns
+func (d *Document) TextRight(x, size float64, bold bool, s string) {
+	d.Text(x-TextWidth(s, size), size, bold, s)
+}
+
+// HLine draws a thin rule across the page just below the cursor line
+func (d *Document) HLine() {
+	fmt.Fprintf(d.page(), "0.5 w %.2f %.2f m %.2f %.2f l S\n", Margin, d.y-4, PageWidth-Margin, d.y-4)
+}
+
+// Bytes renders the document
+func (d *Document) Bytes() []byte {
+	buf := new(bytes.Buffer)
+	offsets := []int{}
+	object := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	buf.WriteString("%PDF-1.4\n")
+
+	// catalog, page tree and fonts come first, each page then takes a page and a content object
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+i*2)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	for i, page := range d.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			PageWidth, PageHeight, 6+i*2))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.String()))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return buf.Bytes()
+}
+
+func (d *Document) page() *bytes.Buffer {
+	return d.pages[len(d.pages)-1]
+}
+
+// TextWidth approximates the width of s in Helvetica, digits and punctuation are exact so amounts line up
+func TextWidth(s string, size float64) float64 {
+	width := 0
+	for _, r := range s {
+		switch {
+		case r == ' ' || r == ',' || r == '.' || r == ':' || r == '/' || r == 'i' || r == 'l' || r == 'j':
+			width += 278
+		case r == '-' || r == '(' || r == ')':
+			width += 333
+		case r == '%':
+			width += 889
+		case r == 'm' || r == 'M' || r == 'W' || r == 'w':
+			width += 833
+		default:
+			width += 556
+		}
+	}
+
+	return float64(width) * size / 1000
+}
+
+func escape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 32:
+			b.WriteByte(' ')
+		case r > 255:
+			b.WriteByte('?')
+		case r > 126:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}